@@ -17,7 +17,7 @@ func blockToBlockEvent(block *Block) *event.Block {
 		RoundRandomSeed:       block.RoundRandomSeed,
 		MerkleTreeRoot:        block.GetMerkleTree().GetRoot(),
 		StateHash:             util.ToHex(block.ClientStateHash),
-		ReceiptMerkleTreeRoot: block.GetReceiptsMerkleTree().GetRoot(),
+		ReceiptMerkleTreeRoot: block.GetReceiptMerkleTreeRoot(),
 		NumTxns:               len(block.Txns),
 		MagicBlockHash:        block.LatestFinalizedMagicBlockHash,
 		PrevHash:              block.PrevHash,
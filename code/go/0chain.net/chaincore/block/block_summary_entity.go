@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strconv"
 
+	"0chain.net/chaincore/proof"
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
 	"0chain.net/core/ememorystore"
@@ -29,6 +30,12 @@ type BlockSummary struct {
 	ReceiptMerkleTreeRoot string        `json:"receipt_merkle_tree_root"`
 	NumTxns               int           `json:"num_txns"`
 	*MagicBlock           `json:"maigc_block,omitempty" msgpack:"mb,omitempty"`
+
+	// PrevHash, StateCommitted and VerificationTickets allow serving the
+	// notarized block headers to light clients from the summaries
+	PrevHash            string                `json:"prev_hash,omitempty"`
+	StateCommitted      bool                  `json:"state_committed,omitempty"`
+	VerificationTickets []*VerificationTicket `json:"verification_tickets,omitempty"`
 }
 
 var blockSummaryEntityMetadata *datastore.EntityMetadataImpl
@@ -101,6 +108,28 @@ func (b *BlockSummary) Decode(input []byte) error {
 	return json.Unmarshal(input, b)
 }
 
+// GetHeader - get the fields of the block committed by the block hash
+func (b *BlockSummary) GetHeader() *proof.BlockHeader {
+	h := &proof.BlockHeader{
+		Hash:                  b.Hash,
+		MinerID:               b.MinerID,
+		PrevHash:              b.PrevHash,
+		CreationDate:          b.CreationDate,
+		Round:                 b.Round,
+		RoundRandomSeed:       b.RoundRandomSeed,
+		StateChangesCount:     b.StateChangesCount,
+		MerkleTreeRoot:        b.MerkleTreeRoot,
+		ReceiptMerkleTreeRoot: b.ReceiptMerkleTreeRoot,
+	}
+	if b.StateCommitted {
+		h.StateHash = util.ToHex(b.ClientStateHash)
+	}
+	if b.MagicBlock != nil {
+		h.MagicBlockHash = b.MagicBlock.Hash
+	}
+	return h
+}
+
 /*GetMagicBlockMap - get the magic block map of this block */
 func (b *BlockSummary) GetMagicBlockMap() *MagicBlockMap {
 	if b.MagicBlock != nil {
@@ -6,8 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
-	"strconv"
-	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -19,6 +17,7 @@ import (
 
 	"0chain.net/chaincore/client"
 	"0chain.net/chaincore/node"
+	"0chain.net/chaincore/proof"
 	"0chain.net/chaincore/transaction"
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
@@ -35,6 +34,9 @@ const (
 	PreviousBlockUnavailable = "previous_block_unavailable"
 	//StateMismatch - indicate if there is a mismatch between computed state and received state of a block
 	StateMismatch = "state_mismatch"
+	//ReceiptsHardFork - the hard fork from which blocks commit to the structured
+	// transaction receipts and to their client state
+	ReceiptsHardFork = "hermes"
 )
 
 var (
@@ -59,6 +61,8 @@ var (
 		"Previous block is not available")
 
 	ErrStateMismatch = common.NewError(StateMismatch, "Computed state hash doesn't match with the state hash of the block")
+
+	ErrReceiptsMismatch = common.NewError("receipts_mismatch", "Computed receipts merkle root doesn't match with the receipts merkle root of the block")
 )
 
 const (
@@ -110,6 +114,10 @@ type UnverifiedBlockBody struct {
 
 	ClientStateHash util.Key `json:"state_hash"`
 
+	// ReceiptMerkleTreeRoot is the root of the merkle tree of the structured
+	// transaction receipts. Empty for blocks without the receipts.
+	ReceiptMerkleTreeRoot string `json:"receipt_merkle_tree_root,omitempty"`
+
 	// The entire transaction payload to represent full block
 	Txns []*transaction.Transaction `json:"transactions,omitempty"`
 }
@@ -452,38 +460,38 @@ func (b *Block) GetMerkleTree() *util.MerkleTree {
 }
 
 func (b *Block) getHashData() string {
-	mt := b.GetMerkleTree()
-	merkleRoot := mt.GetRoot()
-	rmt := b.GetReceiptsMerkleTree()
-	rMerkleRoot := rmt.GetRoot()
-
-	hashBuilder := strings.Builder{}
-	hashBuilder.WriteString(b.MinerID)
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(b.PrevHash)
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(common.TimeToString(b.CreationDate))
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(strconv.FormatInt(b.Round, 10))
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(strconv.FormatInt(b.GetRoundRandomSeed(), 10))
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(strconv.Itoa(b.StateChangesCount))
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(merkleRoot)
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(rMerkleRoot)
+	return b.GetHeader().HashData()
+}
+
+// GetHeader - get the fields of the block committed by the block hash. Blocks
+// after the receipts hard fork, which have the receipts merkle root, also
+// commit to their client state, so that values of the state can be proven by
+// the block. The hash of the earlier blocks is not changed.
+func (b *Block) GetHeader() *proof.BlockHeader {
+	h := &proof.BlockHeader{
+		Hash:                  b.Hash,
+		MinerID:               b.MinerID,
+		PrevHash:              b.PrevHash,
+		CreationDate:          b.CreationDate,
+		Round:                 b.Round,
+		RoundRandomSeed:       b.GetRoundRandomSeed(),
+		StateChangesCount:     b.StateChangesCount,
+		MerkleTreeRoot:        b.GetMerkleTree().GetRoot(),
+		ReceiptMerkleTreeRoot: b.GetReceiptMerkleTreeRoot(),
+	}
+
+	if b.ReceiptMerkleTreeRoot != "" {
+		h.StateHash = util.ToHex(b.ClientStateHash)
+	}
 
 	if b.MagicBlock != nil {
 		if b.MagicBlock.Hash == "" {
 			b.MagicBlock.Hash = b.MagicBlock.GetHash()
 		}
-
-		hashBuilder.WriteString(":")
-		hashBuilder.WriteString(b.MagicBlock.Hash)
+		h.MagicBlockHash = b.MagicBlock.Hash
 	}
 
-	return hashBuilder.String()
+	return h
 }
 
 /*ComputeHash - compute the hash of the block */
@@ -528,9 +536,12 @@ func (b *Block) GetSummary() *BlockSummary {
 	bs.CreationDate = b.CreationDate
 	bs.MerkleTreeRoot = b.GetMerkleTree().GetRoot()
 	bs.ClientStateHash = b.ClientStateHash
-	bs.ReceiptMerkleTreeRoot = b.GetReceiptsMerkleTree().GetRoot()
+	bs.ReceiptMerkleTreeRoot = b.GetReceiptMerkleTreeRoot()
 	bs.NumTxns = len(b.Txns)
 	bs.MagicBlock = b.MagicBlock
+	bs.PrevHash = b.PrevHash
+	bs.StateCommitted = b.ReceiptMerkleTreeRoot != ""
+	bs.VerificationTickets = b.GetVerificationTickets()
 	return bs
 }
 
@@ -619,6 +630,41 @@ func (b *Block) GetReceiptsMerkleTree() *util.MerkleTree {
 	return &mt
 }
 
+// GetReceiptMerkleTreeRoot - return the receipts merkle root of the block,
+// computed from the transaction outputs for blocks without structured receipts
+func (b *Block) GetReceiptMerkleTreeRoot() string {
+	if b.ReceiptMerkleTreeRoot != "" {
+		return b.ReceiptMerkleTreeRoot
+	}
+	return b.GetReceiptsMerkleTree().GetRoot()
+}
+
+// verifyReceipts - check the receipts merkle root of the block. Before the
+// receipts hard fork a block has neither the root nor structured receipts,
+// after it the root must match the receipts computed for the block.
+func (b *Block) verifyReceipts(receiptsActive bool) error {
+	if !receiptsActive {
+		if b.ReceiptMerkleTreeRoot != "" || b.hasAnyReceipt() {
+			return ErrReceiptsMismatch
+		}
+		return nil
+	}
+
+	if b.GetReceiptsMerkleTree().GetRoot() != b.ReceiptMerkleTreeRoot {
+		return ErrReceiptsMismatch
+	}
+	return nil
+}
+
+func (b *Block) hasAnyReceipt() bool {
+	for _, txn := range b.Txns {
+		if txn.Receipt != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // GetTransaction - get the transaction from the block
 func (b *Block) GetTransaction(hash string) *transaction.Transaction {
 	for _, txn := range b.Txns {
@@ -797,6 +843,8 @@ type Chainer interface {
 		waitC ...chan struct{}) ([]event.Event, error)
 	GetEventDb() *event.EventDb
 	GetStateCache() *statecache.StateCache
+	IsHardForkActive(b *Block, bState util.MerklePatriciaTrieI,
+		blockStateCache *statecache.BlockCache, name string) (bool, error)
 }
 
 // CreateStateWithPreviousBlock creates block client state with previous block
@@ -1000,6 +1048,23 @@ func (b *Block) ComputeState(ctx context.Context, c Chainer, waitC ...chan struc
 		b.Events = append(b.Events, events...)
 	}
 
+	receiptsActive, err := c.IsHardForkActive(b, bState, blockStateCache, ReceiptsHardFork)
+	if err != nil {
+		b.SetStateStatus(StateFailed)
+		return common.NewError("state_update_error", err.Error())
+	}
+	if err := b.verifyReceipts(receiptsActive); err != nil {
+		b.SetStateStatus(StateFailed)
+		logging.Logger.Error("compute state - receipts merkle root mismatch",
+			zap.String("minerID", b.MinerID),
+			zap.Int64("round", b.Round),
+			zap.String("block", b.Hash),
+			zap.Bool("receipts_active", receiptsActive),
+			zap.String("block_receipts_root", b.ReceiptMerkleTreeRoot),
+			zap.Error(err))
+		return err
+	}
+
 	if !bytes.Equal(b.ClientStateHash, bState.GetRoot()) {
 		b.SetStateStatus(StateFailed)
 		logging.Logger.Error("compute state - state hash mismatch",
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 
 	"0chain.net/core/config"
@@ -2935,3 +2936,116 @@ func TestBlock_DoReadLock(t *testing.T) {
 		})
 	}
 }
+
+func TestBlock_verifyReceipts(t *testing.T) {
+	txns := func(withReceipts bool) []*transaction.Transaction {
+		txn := &transaction.Transaction{HashIDField: datastore.HashIDField{Hash: "txn"}}
+		if withReceipts {
+			txn.Receipt = &transaction.TxnReceipt{TxnHash: txn.Hash}
+		}
+		return []*transaction.Transaction{txn}
+	}
+	root := func(txns []*transaction.Transaction) string {
+		b := &Block{UnverifiedBlockBody: UnverifiedBlockBody{Txns: txns}}
+		return b.GetReceiptsMerkleTree().GetRoot()
+	}
+
+	tests := []struct {
+		name           string
+		receiptsActive bool
+		txns           []*transaction.Transaction
+		root           func(txns []*transaction.Transaction) string
+		wantErr        bool
+	}{
+		{
+			name: "before the hard fork",
+			txns: txns(false),
+		},
+		{
+			name:    "receipts root before the hard fork",
+			txns:    txns(false),
+			root:    root,
+			wantErr: true,
+		},
+		{
+			name:    "receipts before the hard fork",
+			txns:    txns(true),
+			wantErr: true,
+		},
+		{
+			name:           "after the hard fork",
+			receiptsActive: true,
+			txns:           txns(true),
+			root:           root,
+		},
+		{
+			name:           "empty block after the hard fork",
+			receiptsActive: true,
+			root:           root,
+		},
+		{
+			name:           "missing receipts root after the hard fork",
+			receiptsActive: true,
+			txns:           txns(true),
+			wantErr:        true,
+		},
+		{
+			name:           "receipts root mismatch",
+			receiptsActive: true,
+			txns:           txns(true),
+			root:           func([]*transaction.Transaction) string { return root(txns(false)) },
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Block{UnverifiedBlockBody: UnverifiedBlockBody{Txns: tt.txns}}
+			if tt.root != nil {
+				b.ReceiptMerkleTreeRoot = tt.root(tt.txns)
+			}
+			err := b.verifyReceipts(tt.receiptsActive)
+			if tt.wantErr {
+				require.Equal(t, ErrReceiptsMismatch, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestBlock_GetHeader(t *testing.T) {
+	tests := []struct {
+		name          string
+		receiptsRoot  string
+		wantStateHash string
+	}{
+		{
+			name: "before the receipts hard fork",
+		},
+		{
+			name:          "after the receipts hard fork",
+			receiptsRoot:  "receipts_root",
+			wantStateHash: "0102",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBlock("", 1)
+			b.MinerID = "miner"
+			b.ClientStateHash = util.Key{1, 2}
+			b.ReceiptMerkleTreeRoot = tt.receiptsRoot
+
+			h := b.GetHeader()
+			require.Equal(t, tt.wantStateHash, h.StateHash)
+			require.Equal(t, b.GetReceiptMerkleTreeRoot(), h.ReceiptMerkleTreeRoot)
+
+			hashData := strings.Join([]string{b.MinerID, b.PrevHash,
+				common.TimeToString(b.CreationDate), "1", "0", "0",
+				b.GetMerkleTree().GetRoot(), b.GetReceiptMerkleTreeRoot()}, ":")
+			if tt.wantStateHash != "" {
+				hashData += ":" + tt.wantStateHash
+			}
+			require.Equal(t, hashData, b.getHashData())
+		})
+	}
+}
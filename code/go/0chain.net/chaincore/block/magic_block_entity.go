@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"0chain.net/chaincore/node"
+	"0chain.net/chaincore/proof"
 	"0chain.net/core/datastore"
 	"0chain.net/core/encryption"
 	"github.com/0chain/common/core/util"
@@ -93,6 +94,33 @@ func (mb *MagicBlock) GetHashBytes() []byte {
 	return encryption.RawHash(data)
 }
 
+// GetMagicBlockHeader - get the fields of the magic block committed by the
+// magic block hash, along with the miners public keys
+func (mb *MagicBlock) GetMagicBlockHeader() *proof.MagicBlockHeader {
+	mh := &proof.MagicBlockHeader{
+		Hash:                   mb.Hash,
+		PreviousMagicBlockHash: mb.PreviousMagicBlockHash,
+		MagicBlockNumber:       mb.MagicBlockNumber,
+		StartingRound:          mb.StartingRound,
+		Miners:                 make(map[string]string),
+		Sharders:               mb.Sharders.Keys(),
+		ShareOrSignsHash:       mb.GetShareOrSigns().GetHash(),
+		T:                      mb.T,
+		N:                      mb.N,
+	}
+	if mh.Hash == "" {
+		mh.Hash = mb.GetHash()
+	}
+	for _, n := range mb.Miners.CopyNodes() {
+		mh.Miners[n.GetKey()] = n.PublicKey
+	}
+	for k := range mb.Mpks.Mpks {
+		mh.Mpks = append(mh.Mpks, k)
+	}
+	sort.Strings(mh.Mpks)
+	return mh
+}
+
 func (mb *MagicBlock) IsActiveNode(id string, round int64) bool {
 	if mb == nil || mb.Miners == nil || mb.Sharders == nil {
 		return false
@@ -334,6 +334,34 @@ func TestMagicBlock_GetHash(t *testing.T) {
 	}
 }
 
+func TestMagicBlock_GetMagicBlockHeader(t *testing.T) {
+	client.SetClientSignatureScheme("ed25519")
+	pbK, _, err := encryption.GenerateKeys()
+	require.NoError(t, err)
+	n, err := makeTestNode(pbK)
+	require.NoError(t, err)
+
+	mb := NewMagicBlock()
+	mb.MagicBlockNumber = 10
+	mb.PreviousMagicBlockHash = encryption.Hash("prev mb")
+	mb.StartingRound = 1
+	mb.Miners = node.NewPool(1)
+	mb.Miners.AddNode(n)
+	mb.Sharders = node.NewPool(1)
+	mb.Sharders.AddNode(n)
+	mb.Mpks.Mpks = map[string]*MPK{
+		"key": nil,
+	}
+	mb.T = 1
+	mb.N = 1
+
+	mh := mb.GetMagicBlockHeader()
+	require.Equal(t, mb.GetHash(), mh.Hash)
+	require.Equal(t, mh.Hash, mh.ComputeHash())
+	require.Equal(t, map[string]string{n.GetKey(): pbK}, mh.Miners)
+	require.NoError(t, mh.Validate())
+}
+
 func TestMagicBlock_IsActiveNode(t *testing.T) {
 	mb := NewMagicBlock()
 	mb.Miners = node.NewPool(1)
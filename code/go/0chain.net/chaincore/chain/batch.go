@@ -0,0 +1,126 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	bcstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	sci "0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"github.com/0chain/common/core/util"
+)
+
+// BatchHardFork is the hard fork from which the batch transactions are valid,
+// they're of an invalid transaction type before it
+const BatchHardFork = "hermes"
+
+// checkBatchActive fails the batch transactions before BatchHardFork the way
+// the transactions of an unknown type are failed
+func checkBatchActive(txn *transaction.Transaction, sctx bcstate.StateContextI) error {
+	return bcstate.WithActivation(sctx, BatchHardFork, func() error {
+		return fmt.Errorf("invalid transaction type: %v", txn.TransactionType)
+	}, func() error {
+		return nil
+	})
+}
+
+// ExecuteBatch - executes the calls of a batch transaction in order, within
+// the transaction state context. An error of any call fails the transaction,
+// so all the calls are reverted. The output is the list of the call outputs.
+// The whole batch runs within the timeout of a single smart contract call.
+func (c *Chain) ExecuteBatch(
+	ctx context.Context,
+	txn *transaction.Transaction,
+	balances bcstate.StateContextI) (string, error) {
+
+	return c.executeWithTimeout(ctx, txn, balances, func() (string, error) {
+		return executeBatchCalls(txn, balances)
+	})
+}
+
+func executeBatchCalls(
+	txn *transaction.Transaction,
+	balances bcstate.StateContextI) (string, error) {
+
+	bd, err := txn.DecodeBatchData()
+	if err != nil {
+		return "", common.NewError("invalid_batch", err.Error())
+	}
+
+	outputs := make([]transaction.BatchCallOutput, 0, len(bd.Calls))
+	for i := range bd.Calls {
+		call := &bd.Calls[i]
+		out := transaction.BatchCallOutput{
+			ToClientID:   call.ToClientID,
+			FunctionName: call.FunctionName,
+		}
+
+		if call.IsTransfer() {
+			err = balances.AddTransfer(state.NewTransfer(txn.ClientID, call.ToClientID, call.Value))
+			if err != nil {
+				return "", common.NewErrorf("batch_call_failed", "call %d: %v", i, err)
+			}
+			outputs = append(outputs, out)
+			continue
+		}
+
+		out.Output, err = smartcontract.ExecuteSmartContract(txn.BatchCallTxn(i, call), balances)
+		if err != nil {
+			if isInternalExecutionError(err) {
+				return "", err
+			}
+			return "", common.NewErrorf("batch_call_failed", "call %d: %v", i, err)
+		}
+		outputs = append(outputs, out)
+	}
+
+	output, err := json.Marshal(outputs)
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
+
+// isInternalExecutionError reports whether the error is not caused by the
+// transaction itself, so that it must not be charged for
+func isInternalExecutionError(err error) bool {
+	return err == util.ErrNodeNotFound || bcstate.ErrInvalidState(err)
+}
+
+// estimateBatchCost sums up costs of the calls of a batch transaction from the
+// cost tables of the smart contracts.
+func (c *Chain) estimateBatchCost(txn *transaction.Transaction,
+	sctx bcstate.StateContextI) (int, error) {
+
+	bd, err := txn.DecodeBatchData()
+	if err != nil {
+		return math.MaxInt32, err
+	}
+
+	var total int
+	for i := range bd.Calls {
+		call := &bd.Calls[i]
+		var cost int
+		if call.IsTransfer() {
+			cost = c.ChainConfig.TxnTransferCost()
+		} else {
+			cost, err = smartcontract.EstimateTransactionCost(txn.BatchCallTxn(i, call),
+				sci.SmartContractTransactionData{FunctionName: call.FunctionName}, sctx)
+			if err != nil {
+				return math.MaxInt32, err
+			}
+		}
+
+		if cost > math.MaxInt32-total {
+			return math.MaxInt32, nil
+		}
+		total += cost
+	}
+
+	return total, nil
+}
@@ -28,6 +28,7 @@ import (
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
 	"0chain.net/smartcontract/minersc"
+	"0chain.net/smartcontract/schedulersc"
 	"github.com/0chain/common/core/logging"
 	"github.com/0chain/common/core/util"
 )
@@ -124,6 +125,19 @@ func (c *Chain) ExecuteSmartContract(
 	txn *transaction.Transaction,
 	balances bcstate.StateContextI) (string, error) {
 
+	return c.executeWithTimeout(ctx, txn, balances, func() (string, error) {
+		return smartcontract.ExecuteSmartContract(txn, balances)
+	})
+}
+
+// executeWithTimeout runs the execution of the transaction within the smart
+// contract timeout
+func (c *Chain) executeWithTimeout(
+	ctx context.Context,
+	txn *transaction.Transaction,
+	balances bcstate.StateContextI,
+	execute func() (string, error)) (string, error) {
+
 	type result struct {
 		output string
 		err    error
@@ -140,9 +154,10 @@ func (c *Chain) ExecuteSmartContract(
 		// as full node, so each block should not be executed failed due to timeout
 		sct = time.NewTimer(3 * time.Minute)
 	}
+	defer sct.Stop()
 
 	go func() {
-		output, err := smartcontract.ExecuteSmartContract(txn, balances)
+		output, err := execute()
 		resultC <- result{output: output, err: err}
 	}()
 	select {
@@ -256,6 +271,31 @@ func (c *Chain) EstimateTransactionCost(ctx context.Context,
 
 		return cost, err
 
+	case transaction.TxnTypeBatch:
+		if err := checkBatchActive(txn, sctx); err != nil {
+			logging.Logger.Error("Invalid transaction type", zap.Int("txn type", txn.TransactionType))
+			return math.MaxInt32, err
+		}
+
+		cost, err := c.estimateBatchCost(txn, sctx)
+		if missingKeys := sctx.GetMissingNodeKeys(); len(missingKeys) > 0 {
+			syncOpts := &SyncReplyC{}
+			for _, opt := range opts {
+				opt(syncOpts)
+			}
+
+			logging.Logger.Error("Internal error while estimate batch transaction cost",
+				zap.Error(util.ErrNodeNotFound),
+				zap.Int64("round", b.Round),
+				zap.String("block", b.Hash))
+			if syncOpts.sync {
+				c.SyncMissingNodes(b.Round, missingKeys, syncOpts.replyC...)
+			}
+			return math.MaxInt32, util.ErrNodeNotFound
+		}
+
+		return cost, err
+
 	case transaction.TxnTypeSend:
 		return c.ChainConfig.TxnTransferCost(), nil
 
@@ -277,6 +317,23 @@ func (c *Chain) EstimateTransactionCost(ctx context.Context,
 	}
 }
 
+// transactionCost returns cost of the transaction in the state context, it's
+// recorded in the transaction receipt
+func (c *Chain) transactionCost(txn *transaction.Transaction,
+	sctx bcstate.StateContextI) (int, error) {
+	switch txn.TransactionType {
+	case transaction.TxnTypeSmartContract:
+		return smartcontract.EstimateTransactionCost(txn,
+			sci.SmartContractTransactionData{FunctionName: txn.FunctionName}, sctx)
+	case transaction.TxnTypeBatch:
+		return c.estimateBatchCost(txn, sctx)
+	case transaction.TxnTypeSend:
+		return c.ChainConfig.TxnTransferCost(), nil
+	default:
+		return 0, nil
+	}
+}
+
 func (c *Chain) EstimateTransactionFeeLFB(ctx context.Context,
 	txn *transaction.Transaction,
 	opts ...SyncNodesOption) (currency.Coin, error) {
@@ -376,6 +433,27 @@ func (c *Chain) GetTransactionCostFeeTable(ctx context.Context,
 	return fees
 }
 
+// IsHardForkActive checks if the hard fork is active at the round of the block,
+// as registered in the given state of the block. The state is not changed.
+func (c *Chain) IsHardForkActive(b *block.Block, bState util.MerklePatriciaTrieI,
+	blockStateCache *statecache.BlockCache, name string) (bool, error) {
+
+	var (
+		tbc  = statecache.NewTransactionCache(blockStateCache)
+		sctx = c.NewStateContext(b, CreateTxnMPT(bState, tbc), &transaction.Transaction{}, nil)
+	)
+
+	round, err := bcstate.GetRoundByName(sctx, name)
+	switch err {
+	case nil:
+		return b.Round >= round, nil
+	case util.ErrValueNotPresent:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
 // NewStateContext creation helper.
 func (c *Chain) NewStateContext(
 	b *block.Block,
@@ -440,10 +518,42 @@ func (c *Chain) updateState(ctx context.Context,
 		return nil, err
 	}
 
+	if txn.TransactionType == transaction.TxnTypeBatch {
+		if err := checkBatchActive(txn, sctx); err != nil {
+			logging.Logger.Error("Invalid transaction type", zap.Int("txn type", txn.TransactionType))
+			return nil, err
+		}
+	}
+
+	// the cost is only recorded in the receipts
+	var cost int
+	if err = bcstate.WithActivation(sctx, block.ReceiptsHardFork, func() error {
+		return nil
+	}, func() error {
+		var costErr error
+		cost, costErr = c.transactionCost(txn, sctx)
+		if bcstate.ErrInvalidState(costErr) {
+			return costErr
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var errCode string
+
 	switch txn.TransactionType {
-	case transaction.TxnTypeSmartContract:
+	case transaction.TxnTypeSmartContract, transaction.TxnTypeBatch:
 		t := time.Now()
-		output, err := c.ExecuteSmartContract(ctx, txn, sctx)
+		var (
+			output string
+			err    error
+		)
+		if txn.TransactionType == transaction.TxnTypeBatch {
+			output, err = c.ExecuteBatch(ctx, txn, sctx)
+		} else {
+			output, err = c.ExecuteSmartContract(ctx, txn, sctx)
+		}
 		switch err {
 		//internal errors
 		case context.DeadlineExceeded, transaction.ErrSmartContractContext, util.ErrNodeNotFound:
@@ -500,6 +610,17 @@ func (c *Chain) updateState(ctx context.Context,
 
 				output = err.Error()
 				txn.Status = transaction.TxnError
+				if cerr, ok := err.(*common.Error); ok {
+					errCode = cerr.Code
+				}
+
+				// a failed scheduled call is dropped, not retried in next rounds
+				if err = schedulersc.DropFailedCall(txn, err, sctx); err != nil {
+					logging.Logger.Error("Failed to drop the failed scheduled call",
+						zap.String("txn_hash", txn.Hash),
+						zap.Error(err))
+					return nil, err
+				}
 			}
 		}
 		txn.TransactionOutput = output
@@ -628,9 +749,29 @@ func (c *Chain) updateState(ctx context.Context,
 		txn.Status = transaction.TxnSuccess
 	}
 
+	if err = bcstate.WithActivation(sctx, block.ReceiptsHardFork, func() error {
+		return nil
+	}, func() error {
+		txn.Receipt = transaction.NewTxnReceipt(txn, errCode, cost, sctx.GetEvents(), receiptTransfers(sctx))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
 	return sctx.GetEvents(), nil
 }
 
+// receiptTransfers returns all the transfers of the state context
+func receiptTransfers(sctx bcstate.StateContextI) []*state.Transfer {
+	transfers := make([]*state.Transfer, 0, len(sctx.GetTransfers())+len(sctx.GetSignedTransfers()))
+	transfers = append(transfers, sctx.GetTransfers()...)
+	for _, st := range sctx.GetSignedTransfers() {
+		transfer := st.Transfer
+		transfers = append(transfers, &transfer)
+	}
+	return transfers
+}
+
 func sumOfFromToBalance(sctx bcstate.StateContextI, from, to string) (currency.Coin, error) {
 	ofb, err := sctx.GetClientBalance(from)
 	if err != nil && err != util.ErrValueNotPresent {
@@ -19,6 +19,7 @@ import (
 	"0chain.net/core/encryption"
 	"0chain.net/smartcontract/faucetsc"
 	"0chain.net/smartcontract/minersc"
+	"0chain.net/smartcontract/multisigsc"
 	"0chain.net/smartcontract/rest"
 	"0chain.net/smartcontract/storagesc"
 	"0chain.net/smartcontract/vestingsc"
@@ -51,6 +52,7 @@ func SetupScRestApiHandlers() {
 	if c.EventDb != nil {
 		faucetsc.SetupRestHandler(restHandler)
 		minersc.SetupRestHandler(restHandler)
+		multisigsc.SetupRestHandler(restHandler)
 		storagesc.SetupRestHandler(restHandler)
 		vestingsc.SetupRestHandler(restHandler)
 		zcnsc.SetupRestHandler(restHandler)
@@ -272,6 +274,8 @@ func GetFunctionNames(address string) []string {
 		endpoints = faucetsc.GetEndpoints(nil)
 	case vestingsc.ADDRESS:
 		endpoints = vestingsc.GetEndpoints(nil)
+	case multisigsc.Address:
+		endpoints = multisigsc.GetEndpoints(nil)
 	case zcnsc.ADDRESS:
 		endpoints = zcnsc.GetEndpoints(nil)
 	default:
@@ -2,10 +2,14 @@ package chain
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"testing"
 
 	"0chain.net/chaincore/block"
+	bcstate "0chain.net/chaincore/chain/state"
 	"0chain.net/chaincore/transaction"
+	"0chain.net/core/encryption"
 	"github.com/0chain/common/core/statecache"
 	"github.com/0chain/common/core/util"
 )
@@ -21,10 +25,25 @@ func Test_EstimateTransactionCost(t *testing.T) {
 	}
 
 	ch := NewChainFromConfig()
+	ch.SetupStateCache()
 
 	clientState := util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil, statecache.NewEmpty())
 	bState := util.NewMerklePatriciaTrie(clientState.GetNodeDB(), 2, clientState.GetRoot(), statecache.NewEmpty())
 
+	forkState := util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil, statecache.NewEmpty())
+	fork := bcstate.NewHardFork(BatchHardFork, 0)
+	if _, err := forkState.Insert(util.Path(encryption.Hash(fork.GetKey())), fork); err != nil {
+		t.Fatal(err)
+	}
+	if err := forkState.SaveChanges(context.Background(), forkState.GetNodeDB(), false); err != nil {
+		t.Fatal(err)
+	}
+	batchTxn := &transaction.Transaction{
+		ClientID:        encryption.Hash("client"),
+		TransactionType: transaction.TxnTypeBatch,
+		TransactionData: fmt.Sprintf(`{"calls":[{"to_client_id":%q,"value":0}]}`, encryption.Hash("to")),
+	}
+
 	tests := []struct {
 		name string
 		args args
@@ -55,6 +74,16 @@ func Test_EstimateTransactionCost(t *testing.T) {
 			args: args{ctx: nil, b: block.NewBlock("", 1), bState: util.NewMerklePatriciaTrie(clientState.GetNodeDB(), 2, clientState.GetRoot(), statecache.NewEmpty()), txn: &transaction.Transaction{TransactionType: transaction.TxnTypeStorageRead}},
 			want: 0,
 		},
+		{
+			name: "Test_EstimateTransferCost_TxnTypeBatch_BeforeHardFork",
+			args: args{ctx: nil, b: block.NewBlock("", 1), bState: util.NewMerklePatriciaTrie(clientState.GetNodeDB(), 2, clientState.GetRoot(), statecache.NewEmpty()), txn: batchTxn},
+			want: math.MaxInt32,
+		},
+		{
+			name: "Test_EstimateTransferCost_TxnTypeBatch_AfterHardFork",
+			args: args{ctx: nil, b: block.NewBlock("", 1), bState: forkState, txn: batchTxn},
+			want: 10,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
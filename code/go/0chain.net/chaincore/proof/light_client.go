@@ -0,0 +1,132 @@
+package proof
+
+import (
+	"math"
+
+	"0chain.net/core/common"
+)
+
+var (
+	// ErrBrokenChain - the header doesn't extend the latest header
+	ErrBrokenChain = common.NewError("broken_chain", "header doesn't extend the latest verified header")
+	// ErrInvalidMagicBlock - the magic block carried by a header is not the
+	// successor of the current magic block
+	ErrInvalidMagicBlock = common.NewError("invalid_magic_block", "magic block doesn't follow the current magic block")
+)
+
+// Header - a compact block header along with its notarization, MagicBlock is
+// set for the blocks carrying a new magic block
+type Header struct {
+	Block      *BlockHeader      `json:"block"`
+	Tickets    []*Ticket         `json:"verification_tickets"`
+	MagicBlock *MagicBlockHeader `json:"magic_block,omitempty"`
+}
+
+// LightClient - follows the finalized chain from a trusted magic block,
+// verifying the headers notarizations against the miners of the magic block
+// of their round and following the magic block transitions
+type LightClient struct {
+	signatureScheme  string
+	thresholdPercent int
+
+	magicBlock     *MagicBlockHeader
+	nextMagicBlock *MagicBlockHeader
+	latest         *BlockHeader
+}
+
+// NewLightClient - create a new light client trusting the given magic block.
+// The threshold percent is the notarization threshold of the chain.
+func NewLightClient(signatureScheme string, thresholdPercent int,
+	mb *MagicBlockHeader) (*LightClient, error) {
+
+	if err := mb.Validate(); err != nil {
+		return nil, err
+	}
+	return &LightClient{
+		signatureScheme:  signatureScheme,
+		thresholdPercent: thresholdPercent,
+		magicBlock:       mb,
+	}, nil
+}
+
+// Latest - the latest verified block header, nil if none yet
+func (lc *LightClient) Latest() *BlockHeader {
+	return lc.latest
+}
+
+// MagicBlock - the magic block of the latest verified header
+func (lc *LightClient) MagicBlock() *MagicBlockHeader {
+	return lc.magicBlock
+}
+
+// Verifier - the verifier of the proofs against the current magic block
+func (lc *LightClient) Verifier() *Verifier {
+	return newMagicBlockVerifier(lc.signatureScheme, lc.thresholdPercent, lc.magicBlock)
+}
+
+// Sync - apply the headers in order, stopping at the first invalid header
+func (lc *LightClient) Sync(headers []*Header) error {
+	for _, h := range headers {
+		if err := lc.Apply(h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply - verify the header is notarized and extends the latest header, and
+// make it the latest header
+func (lc *LightClient) Apply(h *Header) error {
+	if h == nil || h.Block == nil {
+		return common.NewError("invalid_header", "missing block header")
+	}
+
+	b := h.Block
+	if lc.latest != nil {
+		if b.Round != lc.latest.Round+1 || b.PrevHash != lc.latest.Hash {
+			return ErrBrokenChain
+		}
+	} else if b.Round < lc.magicBlock.StartingRound {
+		return common.NewError("invalid_header", "header is older than the trusted magic block")
+	}
+
+	mb := lc.magicBlock
+	if lc.nextMagicBlock != nil && b.Round >= lc.nextMagicBlock.StartingRound {
+		mb = lc.nextMagicBlock
+	}
+
+	v := newMagicBlockVerifier(lc.signatureScheme, lc.thresholdPercent, mb)
+	if err := v.VerifyBlock(b, h.Tickets); err != nil {
+		return err
+	}
+
+	next := lc.nextMagicBlock
+	if mb == next {
+		next = nil
+	}
+	if b.MagicBlockHash != "" && b.MagicBlockHash != mb.Hash {
+		if h.MagicBlock == nil || h.MagicBlock.Hash != b.MagicBlockHash {
+			return common.NewError("invalid_header", "missing magic block of the header")
+		}
+		if err := h.MagicBlock.Validate(); err != nil {
+			return err
+		}
+		if h.MagicBlock.MagicBlockNumber != mb.MagicBlockNumber+1 ||
+			h.MagicBlock.PreviousMagicBlockHash != mb.Hash {
+			return ErrInvalidMagicBlock
+		}
+		next = h.MagicBlock
+	}
+
+	lc.magicBlock = mb
+	lc.nextMagicBlock = next
+	lc.latest = b
+	return nil
+}
+
+func newMagicBlockVerifier(signatureScheme string, thresholdPercent int,
+	mb *MagicBlockHeader) *Verifier {
+
+	threshold := int(math.Ceil(float64(len(mb.Miners)) * float64(thresholdPercent) / 100))
+	return NewVerifier(signatureScheme, mb.Miners, threshold)
+}
@@ -0,0 +1,83 @@
+package proof
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"0chain.net/core/encryption"
+	"github.com/stretchr/testify/require"
+)
+
+type testMagicBlock struct {
+	*MagicBlockHeader
+	schemes []encryption.SignatureScheme
+}
+
+func newTestMagicBlock(t *testing.T, number, startingRound int64, prevHash string, n int) *testMagicBlock {
+	tmb := &testMagicBlock{MagicBlockHeader: &MagicBlockHeader{
+		PreviousMagicBlockHash: prevHash,
+		MagicBlockNumber:       number,
+		StartingRound:          startingRound,
+		Miners:                 make(map[string]string, n),
+		T:                      n,
+		N:                      n,
+	}}
+	for i := 0; i < n; i++ {
+		ss := encryption.NewBLS0ChainScheme()
+		require.NoError(t, ss.GenerateKeys())
+		pkb, err := hex.DecodeString(ss.GetPublicKey())
+		require.NoError(t, err)
+		tmb.Miners[encryption.Hash(pkb)] = ss.GetPublicKey()
+		tmb.schemes = append(tmb.schemes, ss)
+	}
+	tmb.Hash = tmb.ComputeHash()
+	return tmb
+}
+
+func (tmb *testMagicBlock) notarize(t *testing.T, b *BlockHeader) *Header {
+	b.Hash = b.ComputeHash()
+	h := &Header{Block: b}
+	for _, ss := range tmb.schemes {
+		pkb, err := hex.DecodeString(ss.GetPublicKey())
+		require.NoError(t, err)
+		sig, err := ss.Sign(b.Hash)
+		require.NoError(t, err)
+		h.Tickets = append(h.Tickets, &Ticket{VerifierID: encryption.Hash(pkb), Signature: sig})
+	}
+	return h
+}
+
+func TestLightClient(t *testing.T) {
+	mb1 := newTestMagicBlock(t, 1, 1, "", 3)
+	mb2 := newTestMagicBlock(t, 2, 4, mb1.Hash, 3)
+
+	lc, err := NewLightClient(encryption.SignatureSchemeBls0chain, 66, mb1.MagicBlockHeader)
+	require.NoError(t, err)
+
+	h1 := mb1.notarize(t, &BlockHeader{Round: 1, MinerID: "m"})
+	h2 := mb1.notarize(t, &BlockHeader{Round: 2, PrevHash: h1.Block.Hash, MagicBlockHash: mb2.Hash})
+	h2.MagicBlock = mb2.MagicBlockHeader
+	h3 := mb1.notarize(t, &BlockHeader{Round: 3, PrevHash: h2.Block.Hash})
+	h4 := mb2.notarize(t, &BlockHeader{Round: 4, PrevHash: h3.Block.Hash})
+
+	require.NoError(t, lc.Sync([]*Header{h1, h2, h3}))
+	require.Equal(t, mb1.Hash, lc.MagicBlock().Hash)
+
+	// the header of the new view notarized by the previous miners
+	h4old := mb1.notarize(t, &BlockHeader{Round: 4, PrevHash: h3.Block.Hash})
+	require.Equal(t, ErrNotNotarized, lc.Apply(h4old))
+
+	// a header not extending the latest one
+	h5 := mb2.notarize(t, &BlockHeader{Round: 5, PrevHash: h4.Block.Hash})
+	require.Equal(t, ErrBrokenChain, lc.Apply(h5))
+
+	require.NoError(t, lc.Sync([]*Header{h4, h5}))
+	require.Equal(t, mb2.Hash, lc.MagicBlock().Hash)
+	require.Equal(t, h5.Block.Hash, lc.Latest().Hash)
+
+	// a magic block not following the current one
+	mb3 := newTestMagicBlock(t, 4, 10, mb2.Hash, 3)
+	h6 := mb2.notarize(t, &BlockHeader{Round: 6, PrevHash: h5.Block.Hash, MagicBlockHash: mb3.Hash})
+	h6.MagicBlock = mb3.MagicBlockHeader
+	require.Equal(t, ErrInvalidMagicBlock, lc.Apply(h6))
+}
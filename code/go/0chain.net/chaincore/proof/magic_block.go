@@ -0,0 +1,79 @@
+package proof
+
+import (
+	"encoding/hex"
+	"sort"
+	"strconv"
+
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/util"
+)
+
+// MagicBlockHeader - the fields of a magic block committed by the magic
+// block hash, with the miners public keys needed to verify notarizations
+type MagicBlockHeader struct {
+	Hash                   string `json:"hash"`
+	PreviousMagicBlockHash string `json:"previous_hash"`
+	MagicBlockNumber       int64  `json:"magic_block_number"`
+	StartingRound          int64  `json:"starting_round"`
+	// Miners maps the miners IDs to their public keys
+	Miners           map[string]string `json:"miners"`
+	Sharders         []string          `json:"sharders"`
+	ShareOrSignsHash string            `json:"share_or_signs_hash"`
+	Mpks             []string          `json:"mpks"`
+	T                int               `json:"t"`
+	N                int               `json:"n"`
+}
+
+// ComputeHash - compute the hash of the magic block from the header fields
+func (mh *MagicBlockHeader) ComputeHash() string {
+	data := []byte(strconv.FormatInt(mh.MagicBlockNumber, 10))
+	data = append(data, []byte(mh.PreviousMagicBlockHash)...)
+	data = append(data, []byte(strconv.FormatInt(mh.StartingRound, 10))...)
+
+	minerKeys := make([]string, 0, len(mh.Miners))
+	for id := range mh.Miners {
+		minerKeys = append(minerKeys, id)
+	}
+	sort.Strings(minerKeys)
+	for _, v := range minerKeys {
+		data = append(data, []byte(v)...)
+	}
+
+	sharderKeys := append([]string(nil), mh.Sharders...)
+	sort.Strings(sharderKeys)
+	for _, v := range sharderKeys {
+		data = append(data, []byte(v)...)
+	}
+
+	shareBytes, _ := hex.DecodeString(mh.ShareOrSignsHash)
+	data = append(data, shareBytes...)
+
+	mpkKeys := append([]string(nil), mh.Mpks...)
+	sort.Strings(mpkKeys)
+	for _, v := range mpkKeys {
+		data = append(data, []byte(v)...)
+	}
+
+	data = append(data, []byte(strconv.Itoa(mh.T))...)
+	data = append(data, []byte(strconv.Itoa(mh.N))...)
+	return util.ToHex(encryption.RawHash(data))
+}
+
+// Validate - check the header matches the magic block hash and the miners
+// public keys match the miners IDs
+func (mh *MagicBlockHeader) Validate() error {
+	if mh.ComputeHash() != mh.Hash {
+		return common.NewError("magic_block_hash_mismatch",
+			"magic block header doesn't match the magic block hash")
+	}
+	for id, pk := range mh.Miners {
+		pkb, err := hex.DecodeString(pk)
+		if err != nil || encryption.Hash(pkb) != id {
+			return common.NewErrorf("invalid_magic_block",
+				"public key doesn't match the miner %s", id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Package proof provides the inclusion proofs of transactions and client
+// state values served by sharders, and their verification. The package
+// depends on the block fields only, so it can be embedded by SDKs to verify
+// the proofs without trusting the sharder serving them.
+package proof
+
+import (
+	"strconv"
+	"strings"
+
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/util"
+)
+
+// BlockHeader - the fields of a block committed by the block hash
+type BlockHeader struct {
+	Hash                  string           `json:"hash"`
+	MinerID               string           `json:"miner_id"`
+	PrevHash              string           `json:"prev_hash"`
+	CreationDate          common.Timestamp `json:"creation_date"`
+	Round                 int64            `json:"round"`
+	RoundRandomSeed       int64            `json:"round_random_seed"`
+	StateChangesCount     int              `json:"state_changes_count"`
+	MerkleTreeRoot        string           `json:"merkle_tree_root"`
+	ReceiptMerkleTreeRoot string           `json:"receipt_merkle_tree_root"`
+	// StateHash is the hex encoded client state root, set only for the
+	// blocks committing to their client state.
+	StateHash      string `json:"state_hash,omitempty"`
+	MagicBlockHash string `json:"magic_block_hash,omitempty"`
+}
+
+// HashData - the data hashed to get the block hash
+func (h *BlockHeader) HashData() string {
+	hashBuilder := strings.Builder{}
+	hashBuilder.WriteString(h.MinerID)
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(h.PrevHash)
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(common.TimeToString(h.CreationDate))
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(strconv.FormatInt(h.Round, 10))
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(strconv.FormatInt(h.RoundRandomSeed, 10))
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(strconv.Itoa(h.StateChangesCount))
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(h.MerkleTreeRoot)
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(h.ReceiptMerkleTreeRoot)
+
+	if h.StateHash != "" {
+		hashBuilder.WriteString(":")
+		hashBuilder.WriteString(h.StateHash)
+	}
+
+	if h.MagicBlockHash != "" {
+		hashBuilder.WriteString(":")
+		hashBuilder.WriteString(h.MagicBlockHash)
+	}
+
+	return hashBuilder.String()
+}
+
+// ComputeHash - compute the hash of the block from the header fields
+func (h *BlockHeader) ComputeHash() string {
+	return encryption.Hash(h.HashData())
+}
+
+// Ticket - a verification ticket of a block, signing the block hash
+type Ticket struct {
+	VerifierID string `json:"verifier_id"`
+	Signature  string `json:"signature"`
+}
+
+// TxnProof - proof of a transaction included in a notarized block
+type TxnProof struct {
+	TxnHash        string       `json:"txn_hash"`
+	MerkleTreePath *util.MTPath `json:"merkle_tree_path"`
+	Block          *BlockHeader `json:"block"`
+	Tickets        []*Ticket    `json:"verification_tickets"`
+	// ReceiptHash and ReceiptMerkleTreePath prove the receipt of the
+	// transaction, they are optional.
+	ReceiptHash           string       `json:"receipt_hash,omitempty"`
+	ReceiptMerkleTreePath *util.MTPath `json:"receipt_merkle_tree_path,omitempty"`
+}
+
+// StateProof - proof of a value of a client state key at a notarized block
+type StateProof struct {
+	Key string `json:"key"`
+	// Value is the msgpack encoded value of the key
+	Value []byte `json:"value"`
+	// Nodes are the encoded MPT nodes on the path of the key, starting from
+	// the state root of the block
+	Nodes   [][]byte     `json:"nodes"`
+	Block   *BlockHeader `json:"block"`
+	Tickets []*Ticket    `json:"verification_tickets"`
+}
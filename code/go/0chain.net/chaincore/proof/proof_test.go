@@ -0,0 +1,139 @@
+package proof
+
+import (
+	"context"
+	"testing"
+
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/logging"
+	"github.com/0chain/common/core/statecache"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logging.InitLogging("testing", "")
+}
+
+func newTestVerifier(t *testing.T, h *BlockHeader, n, threshold int) (*Verifier, []*Ticket) {
+	var (
+		miners  = make(map[string]string, n)
+		tickets = make([]*Ticket, 0, n)
+	)
+	for i := 0; i < n; i++ {
+		ss := encryption.NewBLS0ChainScheme()
+		require.NoError(t, ss.GenerateKeys())
+		id := encryption.Hash(ss.GetPublicKey())
+		miners[id] = ss.GetPublicKey()
+
+		sig, err := ss.Sign(h.Hash)
+		require.NoError(t, err)
+		tickets = append(tickets, &Ticket{VerifierID: id, Signature: sig})
+	}
+	return NewVerifier(encryption.SignatureSchemeBls0chain, miners, threshold), tickets
+}
+
+func TestVerifyTxn(t *testing.T) {
+	var (
+		hashables = make([]util.Hashable, 5)
+		mt        util.MerkleTree
+	)
+	for i := range hashables {
+		hashables[i] = &util.SecureSerializableValue{Buffer: []byte{byte(i)}}
+	}
+	mt.ComputeTree(hashables)
+
+	h := &BlockHeader{
+		MinerID:               encryption.Hash("miner"),
+		PrevHash:              encryption.Hash("prev"),
+		CreationDate:          100,
+		Round:                 10,
+		RoundRandomSeed:       42,
+		MerkleTreeRoot:        mt.GetRoot(),
+		ReceiptMerkleTreeRoot: mt.GetRoot(),
+	}
+	h.Hash = h.ComputeHash()
+
+	v, tickets := newTestVerifier(t, h, 3, 2)
+	txnHash := hashables[3].GetHash()
+	p := &TxnProof{
+		TxnHash:        txnHash,
+		MerkleTreePath: mt.GetPath(hashables[3]),
+		Block:          h,
+		Tickets:        tickets,
+	}
+	require.NoError(t, v.VerifyTxn(p))
+
+	// not enough tickets
+	p.Tickets = []*Ticket{tickets[0], tickets[0], {VerifierID: "unknown", Signature: tickets[1].Signature}}
+	require.Equal(t, ErrNotNotarized, v.VerifyTxn(p))
+
+	// invalid ticket
+	invalid := &Ticket{VerifierID: tickets[1].VerifierID, Signature: tickets[0].Signature}
+	p.Tickets = []*Ticket{tickets[0], invalid}
+	require.Equal(t, ErrNotNotarized, v.VerifyTxn(p))
+
+	// invalid ticket, but enough valid ones
+	p.Tickets = []*Ticket{tickets[0], invalid, tickets[2]}
+	require.NoError(t, v.VerifyTxn(p))
+	p.Tickets = []*Ticket{tickets[0], {VerifierID: tickets[1].VerifierID, Signature: "bad"}, tickets[2]}
+	require.NoError(t, v.VerifyTxn(p))
+	p.Tickets = tickets
+
+	// other transaction
+	p.TxnHash = hashables[2].GetHash()
+	require.Equal(t, ErrTxnNotIncluded, v.VerifyTxn(p))
+	p.TxnHash = txnHash
+
+	// tampered header
+	p.Block.Round++
+	require.Equal(t, ErrBlockHashMismatch, v.VerifyTxn(p))
+}
+
+func TestVerifyState(t *testing.T) {
+	var (
+		ndb = util.NewMemoryNodeDB()
+		mpt = util.NewMerklePatriciaTrie(ndb, 1, nil, statecache.NewEmpty())
+		key = encryption.Hash("client")
+	)
+	for _, k := range []string{key, encryption.Hash("other"), key[:10] + encryption.Hash("x")[10:]} {
+		_, err := mpt.Insert(util.Path(k), &util.SecureSerializableValue{Buffer: []byte(k)})
+		require.NoError(t, err)
+	}
+	require.NoError(t, mpt.SaveChanges(context.TODO(), ndb, false))
+
+	nodes, value, err := GetStatePath(ndb, mpt.GetRoot(), util.Path(key))
+	require.NoError(t, err)
+	require.Equal(t, []byte(key), value)
+
+	_, _, err = GetStatePath(ndb, mpt.GetRoot(), util.Path(encryption.Hash("missing")))
+	require.Equal(t, util.ErrValueNotPresent, err)
+
+	h := &BlockHeader{
+		MinerID:   encryption.Hash("miner"),
+		Round:     10,
+		StateHash: util.ToHex(mpt.GetRoot()),
+	}
+	h.Hash = h.ComputeHash()
+
+	v, tickets := newTestVerifier(t, h, 1, 1)
+	p := &StateProof{
+		Key:     key,
+		Value:   value,
+		Nodes:   nodes,
+		Block:   h,
+		Tickets: tickets,
+	}
+	require.NoError(t, v.VerifyState(p))
+
+	p.Value = []byte("other")
+	require.Error(t, v.VerifyState(p))
+	p.Value = value
+
+	p.Key = encryption.Hash("other")
+	require.Equal(t, ErrInvalidStatePath, v.VerifyState(p))
+	p.Key = key
+
+	p.Nodes = nodes[:len(nodes)-1]
+	require.Equal(t, ErrInvalidStatePath, v.VerifyState(p))
+}
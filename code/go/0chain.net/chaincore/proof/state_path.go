@@ -0,0 +1,52 @@
+package proof
+
+import (
+	"bytes"
+
+	"github.com/0chain/common/core/util"
+)
+
+// GetStatePath - get the encoded MPT nodes on the path from the root to the
+// value of the path, returns util.ErrValueNotPresent if there is no value
+func GetStatePath(ndb util.NodeDB, root util.Key, path util.Path) ([][]byte, []byte, error) {
+	var (
+		nodes [][]byte
+		key   = root
+	)
+	for {
+		node, err := ndb.GetNode(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		nodes = append(nodes, node.Encode())
+
+		switch n := node.(type) {
+		case *util.LeafNode:
+			if !bytes.Equal(n.Path, path) || !n.HasValue() {
+				return nil, nil, util.ErrValueNotPresent
+			}
+			return nodes, n.GetValueBytes(), nil
+		case *util.FullNode:
+			if len(path) == 0 {
+				if !n.HasValue() {
+					return nil, nil, util.ErrValueNotPresent
+				}
+				return nodes, n.GetValueBytes(), nil
+			}
+			key = n.GetChild(path[0])
+			path = path[1:]
+		case *util.ExtensionNode:
+			if !bytes.HasPrefix(path, n.Path) {
+				return nil, nil, util.ErrValueNotPresent
+			}
+			key = n.NodeKey
+			path = path[len(n.Path):]
+		default:
+			return nil, nil, util.ErrValueNotPresent
+		}
+
+		if key == nil {
+			return nil, nil, util.ErrValueNotPresent
+		}
+	}
+}
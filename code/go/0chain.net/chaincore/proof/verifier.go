@@ -0,0 +1,213 @@
+package proof
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/util"
+)
+
+var (
+	// ErrBlockHashMismatch - the block header doesn't match the block hash
+	ErrBlockHashMismatch = common.NewError("block_hash_mismatch", "block header doesn't match the block hash")
+	// ErrNotNotarized - not enough valid verification tickets
+	ErrNotNotarized = common.NewError("block_not_notarized", "verification tickets not sufficient to reach notarization")
+	// ErrTxnNotIncluded - the merkle path doesn't lead to the block merkle root
+	ErrTxnNotIncluded = common.NewError("txn_not_included", "transaction merkle path doesn't match the block merkle root")
+	// ErrReceiptNotIncluded - the merkle path doesn't lead to the block receipts root
+	ErrReceiptNotIncluded = common.NewError("receipt_not_included", "receipt merkle path doesn't match the block receipts merkle root")
+	// ErrStateNotCommitted - the block hash doesn't commit to the client state
+	ErrStateNotCommitted = common.NewError("state_not_committed", "client state root is not committed by the block")
+	// ErrInvalidStatePath - the MPT nodes don't lead from the state root to the key value
+	ErrInvalidStatePath = common.NewError("invalid_state_path", "state path doesn't lead from the state root to the value")
+)
+
+// Verifier - verifies proofs against the miners of a magic block, the
+// miners and the threshold are expected to come from a trusted magic block
+type Verifier struct {
+	// SignatureScheme of the miners' keys, e.g. bls0chain
+	SignatureScheme string
+	// Miners maps the miners IDs to their public keys
+	Miners map[string]string
+	// Threshold is the number of the valid tickets notarizing a block
+	Threshold int
+}
+
+// NewVerifier - create a new proofs verifier
+func NewVerifier(signatureScheme string, miners map[string]string, threshold int) *Verifier {
+	return &Verifier{
+		SignatureScheme: signatureScheme,
+		Miners:          miners,
+		Threshold:       threshold,
+	}
+}
+
+// VerifyBlock - verify the header matches the block hash and the block is
+// notarized by the miners
+func (v *Verifier) VerifyBlock(h *BlockHeader, tickets []*Ticket) error {
+	if h == nil {
+		return common.NewError("invalid_proof", "missing block header")
+	}
+	if h.ComputeHash() != h.Hash {
+		return ErrBlockHashMismatch
+	}
+
+	var (
+		signers = make([]*Ticket, 0, len(tickets))
+		seen    = make(map[string]bool, len(tickets))
+	)
+	for _, t := range tickets {
+		if t == nil || seen[t.VerifierID] {
+			continue
+		}
+		if _, ok := v.Miners[t.VerifierID]; !ok {
+			continue
+		}
+		seen[t.VerifierID] = true
+		signers = append(signers, t)
+	}
+
+	if len(signers) == 0 || len(signers) < v.Threshold {
+		return ErrNotNotarized
+	}
+
+	// invalid tickets are tolerated as long as the valid ones reach the
+	// threshold, same as for the notarization of a block
+	if valid := v.validTickets(h.Hash, signers); valid == 0 || valid < v.Threshold {
+		return ErrNotNotarized
+	}
+	return nil
+}
+
+// validTickets returns the number of the tickets with a valid signature. The
+// tickets are verified aggregately when the signature scheme allows it, and
+// one by one if the aggregate verification fails or isn't possible.
+func (v *Verifier) validTickets(hash string, tickets []*Ticket) int {
+	if v.verifyAggregate(hash, tickets) {
+		return len(tickets)
+	}
+
+	var valid int
+	for _, t := range tickets {
+		ss := encryption.GetSignatureScheme(v.SignatureScheme)
+		if err := ss.SetPublicKey(v.Miners[t.VerifierID]); err != nil {
+			continue
+		}
+		if ok, err := ss.Verify(t.Signature, hash); err == nil && ok {
+			valid++
+		}
+	}
+	return valid
+}
+
+// verifyAggregate reports whether all the tickets are valid, as verified by
+// the aggregate signature scheme
+func (v *Verifier) verifyAggregate(hash string, tickets []*Ticket) bool {
+	aggScheme := encryption.GetAggregateSignatureScheme(v.SignatureScheme,
+		len(tickets), len(tickets))
+	if aggScheme == nil {
+		return false
+	}
+
+	for i, t := range tickets {
+		ss := encryption.GetSignatureScheme(v.SignatureScheme)
+		if err := ss.SetPublicKey(v.Miners[t.VerifierID]); err != nil {
+			return false
+		}
+		if err := aggScheme.Aggregate(ss, i, t.Signature, hash); err != nil {
+			return false
+		}
+	}
+
+	ok, err := aggScheme.Verify()
+	return err == nil && ok
+}
+
+// VerifyTxn - verify the transaction is included in a notarized block
+func (v *Verifier) VerifyTxn(p *TxnProof) error {
+	if p.MerkleTreePath == nil {
+		return common.NewError("invalid_proof", "missing merkle tree path")
+	}
+	if err := v.VerifyBlock(p.Block, p.Tickets); err != nil {
+		return err
+	}
+	if !util.VerifyMerklePath(p.TxnHash, p.MerkleTreePath, p.Block.MerkleTreeRoot) {
+		return ErrTxnNotIncluded
+	}
+	if p.ReceiptMerkleTreePath != nil &&
+		!util.VerifyMerklePath(p.ReceiptHash, p.ReceiptMerkleTreePath, p.Block.ReceiptMerkleTreeRoot) {
+		return ErrReceiptNotIncluded
+	}
+	return nil
+}
+
+// VerifyState - verify the value of the key in the client state of a
+// notarized block
+func (v *Verifier) VerifyState(p *StateProof) error {
+	if err := v.VerifyBlock(p.Block, p.Tickets); err != nil {
+		return err
+	}
+	if p.Block.StateHash == "" {
+		return ErrStateNotCommitted
+	}
+	root, err := hex.DecodeString(p.Block.StateHash)
+	if err != nil {
+		return common.NewErrorf("invalid_proof", "invalid state hash: %v", err)
+	}
+	value, err := VerifyStatePath(root, util.Path(p.Key), p.Nodes)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(value, p.Value) {
+		return common.NewError("state_value_mismatch", "proven value doesn't match the value of the proof")
+	}
+	return nil
+}
+
+// VerifyStatePath - verify the encoded MPT nodes lead from the root to the
+// path, returns the value at the path
+func VerifyStatePath(root util.Key, path util.Path, nodes [][]byte) ([]byte, error) {
+	key := root
+	for i, data := range nodes {
+		node, err := util.CreateNode(bytes.NewReader(data))
+		if err != nil {
+			return nil, common.NewErrorf("invalid_state_path", "decoding node %d: %v", i, err)
+		}
+		if !bytes.Equal(node.GetHashBytes(), key) {
+			return nil, ErrInvalidStatePath
+		}
+
+		last := i == len(nodes)-1
+		switch n := node.(type) {
+		case *util.LeafNode:
+			if !last || !bytes.Equal(n.Path, path) {
+				return nil, ErrInvalidStatePath
+			}
+			return n.GetValueBytes(), nil
+		case *util.FullNode:
+			if len(path) == 0 {
+				if !last {
+					return nil, ErrInvalidStatePath
+				}
+				return n.GetValueBytes(), nil
+			}
+			key = n.GetChild(path[0])
+			path = path[1:]
+		case *util.ExtensionNode:
+			if !bytes.HasPrefix(path, n.Path) {
+				return nil, ErrInvalidStatePath
+			}
+			key = n.NodeKey
+			path = path[len(n.Path):]
+		default:
+			return nil, ErrInvalidStatePath
+		}
+
+		if key == nil {
+			return nil, ErrInvalidStatePath
+		}
+	}
+	return nil, ErrInvalidStatePath
+}
@@ -0,0 +1,125 @@
+package transaction
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+)
+
+const (
+	// BatchFunctionName is the function name of a batch transaction
+	BatchFunctionName = "batch"
+
+	// MaxBatchCalls is the maximum number of calls in a batch transaction
+	MaxBatchCalls = 32
+)
+
+// builtInFunctions are the smart contract functions of the transactions the
+// miners add to their blocks. They're unique per block only as transactions,
+// so a batch can't call them.
+var builtInFunctions = map[string]struct{}{
+	"payFees":                 {},
+	"commit_settings_changes": {},
+	"blobber_block_rewards":   {},
+	"generate_challenge":      {},
+	"execute_scheduled":       {},
+}
+
+// IsBuiltInFunction reports whether the smart contract function is the one of
+// a transaction added by the miners to their blocks
+func IsBuiltInFunction(name string) bool {
+	_, ok := builtInFunctions[name]
+	return ok
+}
+
+// BatchCall is a smart contract call or, when it has no function name, a
+// transfer of a batch transaction. The calls are sent by the batch
+// transaction client.
+type BatchCall struct {
+	ToClientID   string          `json:"to_client_id"`
+	Value        currency.Coin   `json:"value"`
+	FunctionName string          `json:"name,omitempty"`
+	InputData    json.RawMessage `json:"input,omitempty"`
+}
+
+// IsTransfer reports whether the call is a plain transfer of tokens
+func (bc *BatchCall) IsTransfer() bool {
+	return bc.FunctionName == ""
+}
+
+// BatchData is the transaction data of a batch transaction. The calls are
+// executed in order and all of them are reverted if any of them fails.
+type BatchData struct {
+	Calls []BatchCall `json:"calls"`
+}
+
+// BatchCallOutput is the output of a call of a batch transaction
+type BatchCallOutput struct {
+	ToClientID   string `json:"to_client_id"`
+	FunctionName string `json:"name,omitempty"`
+	Output       string `json:"output"`
+}
+
+// DecodeBatchData decodes and validates the calls of a batch transaction
+func (t *Transaction) DecodeBatchData() (*BatchData, error) {
+	if t.TransactionType != TxnTypeBatch {
+		return nil, errors.New("not a batch transaction")
+	}
+
+	bd := &BatchData{}
+	if err := json.Unmarshal([]byte(t.TransactionData), bd); err != nil {
+		return nil, fmt.Errorf("invalid batch data: %v", err)
+	}
+
+	if len(bd.Calls) == 0 {
+		return nil, errors.New("empty batch")
+	}
+	if len(bd.Calls) > MaxBatchCalls {
+		return nil, fmt.Errorf("too many calls in batch, max %d", MaxBatchCalls)
+	}
+
+	var total currency.Coin
+	for i, c := range bd.Calls {
+		if !encryption.IsHash(c.ToClientID) {
+			return nil, fmt.Errorf("call %d: invalid to_client_id", i)
+		}
+		if c.IsTransfer() && c.ToClientID == t.ClientID {
+			return nil, fmt.Errorf("call %d: transfer to self", i)
+		}
+		if IsBuiltInFunction(c.FunctionName) {
+			return nil, fmt.Errorf("call %d: built-in function %s", i, c.FunctionName)
+		}
+		var err error
+		if total, err = currency.AddCoin(total, c.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	// the transaction value is the only amount the client is checked for
+	if total != t.Value {
+		return nil, fmt.Errorf("sum of call values %v doesn't match transaction value %v",
+			total, t.Value)
+	}
+
+	return bd, nil
+}
+
+// BatchCallTxn returns the transaction the i-th smart contract call of the
+// batch transaction is executed with. Each call gets its own hash, so calls
+// using the transaction hash as an ID don't collide.
+func (t *Transaction) BatchCallTxn(i int, c *BatchCall) *Transaction {
+	ct := t.Clone()
+	ct.Hash = encryption.Hash(t.Hash + ":" + strconv.Itoa(i))
+	ct.ToClientID = c.ToClientID
+	ct.Value = c.Value
+	ct.TransactionType = TxnTypeSmartContract
+	ct.SmartContractData = &SmartContractData{
+		FunctionName: c.FunctionName,
+		InputData:    c.InputData,
+	}
+	return ct
+}
@@ -0,0 +1,93 @@
+package transaction
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeBatchData(t *testing.T) {
+	var (
+		clientID = encryption.Hash("client")
+		scID     = encryption.Hash("sc")
+		toID     = encryption.Hash("to")
+	)
+
+	tt := []struct {
+		name  string
+		data  string
+		value currency.Coin
+		err   string
+	}{
+		{
+			name:  "ok",
+			data:  fmt.Sprintf(`{"calls":[{"to_client_id":%q,"value":3},{"to_client_id":%q,"value":7,"name":"lock","input":{}}]}`, toID, scID),
+			value: 10,
+		},
+		{
+			name: "empty",
+			data: `{"calls":[]}`,
+			err:  "empty batch",
+		},
+		{
+			name: "invalid_to_client_id",
+			data: `{"calls":[{"to_client_id":"x","name":"lock"}]}`,
+			err:  "invalid to_client_id",
+		},
+		{
+			name:  "transfer_to_self",
+			data:  fmt.Sprintf(`{"calls":[{"to_client_id":%q,"value":1}]}`, clientID),
+			value: 1,
+			err:   "transfer to self",
+		},
+		{
+			name:  "value_mismatch",
+			data:  fmt.Sprintf(`{"calls":[{"to_client_id":%q,"value":3}]}`, toID),
+			value: 4,
+			err:   "doesn't match transaction value",
+		},
+		{
+			name: "built_in_function",
+			data: fmt.Sprintf(`{"calls":[{"to_client_id":%q,"name":"payFees","input":{}},{"to_client_id":%q,"name":"payFees","input":{}}]}`, scID, scID),
+			err:  "built-in function payFees",
+		},
+		{
+			name: "too_many_calls",
+			data: `{"calls":[` + strings.TrimSuffix(strings.Repeat(
+				fmt.Sprintf(`{"to_client_id":%q,"name":"lock"},`, scID), MaxBatchCalls+1), ",") + `]}`,
+			err: "too many calls",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			txn := &Transaction{
+				ClientID:        clientID,
+				TransactionType: TxnTypeBatch,
+				TransactionData: tc.data,
+				Value:           tc.value,
+			}
+
+			bd, err := txn.DecodeBatchData()
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, bd.Calls, 2)
+			require.True(t, bd.Calls[0].IsTransfer())
+			require.False(t, bd.Calls[1].IsTransfer())
+
+			ct := txn.BatchCallTxn(1, &bd.Calls[1])
+			require.Equal(t, TxnTypeSmartContract, ct.TransactionType)
+			require.Equal(t, scID, ct.ToClientID)
+			require.Equal(t, "lock", ct.FunctionName)
+			require.NotEqual(t, txn.Hash, ct.Hash)
+		})
+	}
+}
@@ -120,6 +120,7 @@ type Transaction struct {
 	//		- 0: TxnTypeSend - A transaction to send tokens to another account, state is maintained by account.
 	//		- 10: TxnTypeData - A transaction to just store a piece of data on the block chain.
 	//		- 1000: TxnTypeSmartContract - A smart contract transaction type.
+	//		- 1002: TxnTypeBatch - A transaction executing several smart contract calls and transfers atomically.
 	// required: true
 	TransactionType int `json:"transaction_type" msgpack:"tt"`
 
@@ -137,6 +138,9 @@ type Transaction struct {
 	//
 	// required: true
 	Status int `json:"transaction_status" msgpack:"sot"`
+
+	// Receipt - the receipt of the processed transaction
+	Receipt *TxnReceipt `json:"receipt,omitempty" msgpack:"rc,omitempty"`
 }
 
 type FeeStats struct {
@@ -165,7 +169,16 @@ func (t *Transaction) ComputeProperties() error {
 			return fmt.Errorf("invalid smart contract data: %v", err)
 		}
 	}
-	return t.ComputeClientID()
+	if err := t.ComputeClientID(); err != nil {
+		return err
+	}
+	if t.TransactionType == TxnTypeBatch {
+		if _, err := t.DecodeBatchData(); err != nil {
+			return err
+		}
+		t.SmartContractData.FunctionName = BatchFunctionName
+	}
+	return nil
 }
 
 // swagger:model SmartContractData represents the smart contract data
@@ -521,6 +534,7 @@ func (t *Transaction) Clone() *Transaction {
 		TransactionOutput: t.TransactionOutput,
 		OutputHash:        t.OutputHash,
 		Status:            t.Status,
+		Receipt:           t.Receipt,
 	}
 
 	if t.SmartContractData != nil {
@@ -1,23 +1,118 @@
 package transaction
 
-import "github.com/0chain/common/core/util"
+import (
+	"encoding/json"
 
-//TxnReceipt - a transaction receipt is a processed transaction that contains the output
+	"0chain.net/chaincore/state"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+// TxnReceipt - a transaction receipt is a processed transaction that contains the output
+// swagger:model TxnReceipt
 type TxnReceipt struct {
-	Transaction *Transaction
+	// Transaction is set for receipts of transactions processed without
+	// structured receipts, they are hashed by the output only.
+	Transaction *Transaction `json:"-" msgpack:"-"`
+
+	TxnHash    string `json:"txn_hash" msgpack:"h"`
+	Status     int    `json:"status" msgpack:"s"`
+	ErrorCode  string `json:"error_code,omitempty" msgpack:"ec,omitempty"`
+	OutputHash string `json:"output_hash" msgpack:"oh"`
+	// CostUsed is the cost of the transaction from the cost tables
+	CostUsed  int               `json:"cost_used" msgpack:"c"`
+	Events    []ReceiptEvent    `json:"events,omitempty" msgpack:"e,omitempty"`
+	Transfers []*state.Transfer `json:"transfers,omitempty" msgpack:"t,omitempty"`
+	Mints     []*state.Mint     `json:"mints,omitempty" msgpack:"m,omitempty"`
+	Burns     []*state.Burn     `json:"burns,omitempty" msgpack:"b,omitempty"`
+}
+
+// ReceiptEvent is an event emitted by a transaction, without its data
+type ReceiptEvent struct {
+	Type  event.EventType `json:"type" msgpack:"ty"`
+	Tag   event.EventTag  `json:"tag" msgpack:"tg"`
+	Index string          `json:"index" msgpack:"i"`
 }
 
-//GetHash - implement interface
+// GetHash - implement interface
 func (rh *TxnReceipt) GetHash() string {
-	return rh.Transaction.OutputHash
+	if rh.Transaction != nil {
+		return rh.Transaction.OutputHash
+	}
+	data, err := json.Marshal(rh)
+	if err != nil {
+		panic(err) // must not happen
+	}
+	return encryption.Hash(data)
 }
 
 /*GetHashBytes - implement Hashable interface */
 func (rh *TxnReceipt) GetHashBytes() []byte {
-	return util.HashStringToBytes(rh.Transaction.OutputHash)
+	return util.HashStringToBytes(rh.GetHash())
 }
 
-//NewTransactionReceipt - create a new transaction receipt
+// NewTransactionReceipt - create a new transaction receipt
 func NewTransactionReceipt(t *Transaction) *TxnReceipt {
+	if t.Receipt != nil {
+		return t.Receipt
+	}
 	return &TxnReceipt{Transaction: t}
 }
+
+// NewTxnReceipt - create the structured receipt of a processed transaction
+// from the events and the transfers of its state context
+func NewTxnReceipt(t *Transaction, errCode string, cost int,
+	events []event.Event, transfers []*state.Transfer) *TxnReceipt {
+
+	r := &TxnReceipt{
+		TxnHash:    t.Hash,
+		Status:     t.Status,
+		ErrorCode:  errCode,
+		OutputHash: t.ComputeOutputHash(),
+		CostUsed:   cost,
+		Transfers:  transfers,
+	}
+
+	for _, e := range events {
+		r.Events = append(r.Events, ReceiptEvent{
+			Type:  e.Type,
+			Tag:   e.Tag,
+			Index: e.Index,
+		})
+
+		switch e.Tag {
+		case event.TagMintReward:
+			if rm, ok := eventData[event.RewardMint](e.Data); ok {
+				r.Mints = append(r.Mints, state.NewMint(t.ToClientID,
+					rm.ClientID, currency.Coin(rm.Amount)))
+			}
+		case event.TagAddBridgeMint:
+			if bm, ok := eventData[event.BridgeMint](e.Data); ok {
+				r.Mints = append(r.Mints, state.NewMint(t.ToClientID,
+					bm.UserID, bm.Amount))
+			}
+		case event.TagAuthorizerBurn:
+			if b, ok := eventData[state.Burn](e.Data); ok {
+				r.Burns = append(r.Burns, state.NewBurn(b.Burner, b.Amount))
+			}
+		}
+	}
+
+	return r
+}
+
+// eventData returns data of an event emitted either by value or by pointer
+func eventData[T any](data interface{}) (T, bool) {
+	switch v := data.(type) {
+	case T:
+		return v, true
+	case *T:
+		if v != nil {
+			return *v, true
+		}
+	}
+	var zero T
+	return zero, false
+}
@@ -0,0 +1,62 @@
+package transaction
+
+import (
+	"testing"
+
+	"0chain.net/chaincore/state"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTxnReceipt(t *testing.T) {
+	var (
+		clientID = encryption.Hash("client")
+		scID     = encryption.Hash("sc")
+	)
+
+	txn := &Transaction{
+		ClientID:          clientID,
+		ToClientID:        scID,
+		TransactionType:   TxnTypeSmartContract,
+		TransactionOutput: "ok",
+		Status:            TxnSuccess,
+	}
+	txn.Hash = encryption.Hash("txn")
+	txn.OutputHash = txn.ComputeOutputHash()
+
+	legacy := NewTransactionReceipt(txn)
+	require.Equal(t, txn.OutputHash, legacy.GetHash())
+
+	events := []event.Event{
+		{Type: event.TypeStats, Tag: event.TagMintReward, Index: clientID,
+			Data: event.RewardMint{ClientID: clientID, Amount: 5}},
+		{Type: event.TypeStats, Tag: event.TagAddBridgeMint, Index: clientID,
+			Data: &event.BridgeMint{UserID: clientID, Amount: 7}},
+		{Type: event.TypeStats, Tag: event.TagAuthorizerBurn, Index: clientID,
+			Data: state.Burn{Burner: clientID, Amount: 3}},
+	}
+	transfers := []*state.Transfer{state.NewTransfer(clientID, scID, 10)}
+
+	r := NewTxnReceipt(txn, "", 100, events, transfers)
+	require.Equal(t, txn.Hash, r.TxnHash)
+	require.Equal(t, TxnSuccess, r.Status)
+	require.Equal(t, txn.OutputHash, r.OutputHash)
+	require.Equal(t, 100, r.CostUsed)
+	require.Len(t, r.Events, 3)
+	require.Equal(t, event.TagMintReward, r.Events[0].Tag)
+	require.Equal(t, transfers, r.Transfers)
+	require.Equal(t, []*state.Mint{
+		state.NewMint(scID, clientID, 5),
+		state.NewMint(scID, clientID, 7),
+	}, r.Mints)
+	require.Equal(t, []*state.Burn{state.NewBurn(clientID, 3)}, r.Burns)
+
+	// the receipt replaces the output only receipt of the transaction
+	txn.Receipt = r
+	require.Equal(t, r, NewTransactionReceipt(txn))
+	require.NotEqual(t, txn.OutputHash, r.GetHash())
+
+	failed := NewTxnReceipt(txn, "invalid_request", 100, nil, transfers)
+	require.NotEqual(t, r.GetHash(), failed.GetHash())
+}
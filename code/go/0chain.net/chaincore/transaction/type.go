@@ -14,6 +14,8 @@ const (
 	TxnTypeData = 10 // A transaction to just store a piece of data on the block chain
 
 	TxnTypeSmartContract = 1000 // A smart contract transaction type
+
+	TxnTypeBatch = 1002 // A transaction executing several smart contract calls and transfers atomically
 )
 
 var ErrSmartContractContext = common.NewError("smart_contract_execution_ctx_err", "context deadline")
@@ -20,11 +20,13 @@ import (
 	"0chain.net/chaincore/chain"
 	"0chain.net/chaincore/client"
 	"0chain.net/chaincore/node"
+	"0chain.net/chaincore/smartcontract"
 	"0chain.net/chaincore/transaction"
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
 	"0chain.net/core/encryption"
 	"0chain.net/smartcontract/minersc"
+	"0chain.net/smartcontract/schedulersc"
 	"0chain.net/smartcontract/storagesc"
 	"github.com/0chain/common/core/logging"
 	"github.com/0chain/common/core/statecache"
@@ -192,7 +194,8 @@ func (mc *Chain) UpdatePendingBlock(ctx context.Context, b *block.Block, txns []
 
 func (mc *Chain) verifySmartContracts(ctx context.Context, b *block.Block) error {
 	for _, txn := range b.Txns {
-		if txn.TransactionType == transaction.TxnTypeSmartContract {
+		if txn.TransactionType == transaction.TxnTypeSmartContract ||
+			txn.TransactionType == transaction.TxnTypeBatch {
 			err := txn.VerifyOutputHash(ctx)
 			if err != nil {
 				logging.Logger.Error("Smart contract output verification failed", zap.Error(err), zap.String("output", txn.TransactionOutput))
@@ -501,10 +504,11 @@ func (mc *Chain) ValidateTransactions(ctx context.Context, b *block.Block) error
 			bicLock.Lock()
 			defer bicLock.Unlock()
 			if mc.isBuildInTxn(txn) {
-				if _, ok := buildInTxnsMap[txn.FunctionName]; ok {
+				key := buildInTxnKey(txn)
+				if _, ok := buildInTxnsMap[key]; ok {
 					return true
 				}
-				buildInTxnsMap[txn.FunctionName] = struct{}{}
+				buildInTxnsMap[key] = struct{}{}
 			}
 			return false
 		}
@@ -1294,6 +1298,14 @@ l:
 		zap.Int("txns", len(b.Txns)),
 		zap.Duration("time", time.Since(start)))
 
+	receiptsActive, err := mc.IsHardForkActive(b, blockState, blockStateCache, block.ReceiptsHardFork)
+	if err != nil {
+		return err
+	}
+	if receiptsActive {
+		b.ReceiptMerkleTreeRoot = b.GetReceiptsMerkleTree().GetRoot()
+	}
+
 	if err = mc.hashAndSignGeneratedBlock(ctx, b); err != nil {
 		return err
 	}
@@ -1367,6 +1379,14 @@ func (mc *Chain) buildInTxns(ctx context.Context, lfb, b *block.Block) ([]*trans
 		txns = append(txns, cscTxn)
 	}
 
+	if smartcontract.GetSmartContract(schedulersc.ADDRESS) != nil {
+		esTxns, err := mc.createExecuteScheduledTxns(b)
+		if err != nil {
+			return nil, 0, err
+		}
+		txns = append(txns, esTxns...)
+	}
+
 	var cost int
 	for _, txn := range txns {
 		c, err := mc.EstimateTransactionCost(ctx, lfb, txn, chain.WithSync())
@@ -1380,6 +1400,45 @@ func (mc *Chain) buildInTxns(ctx context.Context, lfb, b *block.Block) ([]*trans
 	return txns, cost, nil
 }
 
+// createExecuteScheduledTxns creates a transaction for each scheduled call due
+// at the round, so that a failing call doesn't affect the others. The due calls
+// are read from the state of the previous block, the one the block is built on.
+func (mc *Chain) createExecuteScheduledTxns(b *block.Block) ([]*transaction.Transaction, error) {
+	var (
+		qbc         = statecache.NewQueryBlockCache(mc.GetStateCache(), b.PrevHash)
+		tbc         = statecache.NewTransactionCache(qbc)
+		prevState   = block.CreateStateWithPreviousBlock(b.PrevBlock, mc.GetStateDB(), b.Round)
+		clientState = chain.CreateTxnMPT(prevState, tbc)
+		sctx        = mc.NewStateContext(b.PrevBlock, clientState, &transaction.Transaction{}, nil)
+	)
+
+	limit, err := schedulersc.GetMaxExecutionsPerRound(sctx)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := schedulersc.GetDueTxnIDs(sctx, b.Round, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	txns := make([]*transaction.Transaction, 0, len(ids))
+	for _, id := range ids {
+		esTxn := transaction.Provider().(*transaction.Transaction)
+		esTxn.ClientID = node.Self.ID
+		esTxn.PublicKey = node.Self.PublicKey
+		esTxn.ToClientID = schedulersc.ADDRESS
+		esTxn.CreationDate = b.CreationDate
+		esTxn.TransactionType = transaction.TxnTypeSmartContract
+		esTxn.TransactionData = fmt.Sprintf(`{"name":"%s","input":{"id":%q}}`, executeScheduledTxnName, id)
+		esTxn.Fee = 0
+		if err := esTxn.ComputeProperties(); err != nil {
+			return nil, err
+		}
+		txns = append(txns, esTxn)
+	}
+	return txns, nil
+}
+
 func (mc *Chain) createGenChalTxn(b *block.Block) (*transaction.Transaction, error) {
 	brTxn := transaction.Provider().(*transaction.Transaction)
 	brTxn.ClientID = node.Self.ID
@@ -1,20 +1,12 @@
 package miner
 
-import "0chain.net/chaincore/transaction"
+import (
+	"encoding/json"
 
-const (
-	payFeesTxnName               = "payFees"
-	commitSettingsChangesTxnName = "commit_settings_changes"
-	blobberBlockRewardsTxnName   = "blobber_block_rewards"
-	generateChallengeTxnName     = "generate_challenge"
+	"0chain.net/chaincore/transaction"
 )
 
-var gBuildInTxnsMap = map[string]struct{}{
-	payFeesTxnName:               {},
-	commitSettingsChangesTxnName: {},
-	blobberBlockRewardsTxnName:   {},
-	generateChallengeTxnName:     {},
-}
+const executeScheduledTxnName = "execute_scheduled"
 
 // isBuildInTxn checks if the txn is build-in txn.
 func (mc *Chain) isBuildInTxn(txn *transaction.Transaction) bool {
@@ -22,6 +14,22 @@ func (mc *Chain) isBuildInTxn(txn *transaction.Transaction) bool {
 		return false
 	}
 
-	_, ok := gBuildInTxnsMap[txn.FunctionName]
-	return ok
+	return transaction.IsBuiltInFunction(txn.FunctionName)
+}
+
+// buildInTxnKey returns the key a build-in txn must be unique by in a block.
+// A block has one execute_scheduled txn for each scheduled transaction due
+// at its round, so those are unique by the scheduled transaction id.
+func buildInTxnKey(txn *transaction.Transaction) string {
+	if txn.FunctionName != executeScheduledTxnName {
+		return txn.FunctionName
+	}
+
+	var req struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(txn.InputData, &req); err != nil {
+		return txn.FunctionName
+	}
+	return txn.FunctionName + ":" + req.ID
 }
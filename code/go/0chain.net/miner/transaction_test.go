@@ -0,0 +1,52 @@
+package miner
+
+import (
+	"encoding/json"
+	"testing"
+
+	"0chain.net/chaincore/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildInTxnKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		other string
+		dup   bool
+	}{
+		{
+			name:  "pay fees of the same round",
+			data:  `{"name":"payFees","input":{"round":1}}`,
+			other: `{"name":"payFees","input":{"round":2}}`,
+			dup:   true,
+		},
+		{
+			name:  "same scheduled transaction",
+			data:  `{"name":"execute_scheduled","input":{"id":"a"}}`,
+			other: `{"name":"execute_scheduled","input":{ "id" : "a" }}`,
+			dup:   true,
+		},
+		{
+			name:  "different scheduled transactions",
+			data:  `{"name":"execute_scheduled","input":{"id":"a"}}`,
+			other: `{"name":"execute_scheduled","input":{"id":"b"}}`,
+		},
+	}
+
+	newTxn := func(t *testing.T, data string) *transaction.Transaction {
+		txn := &transaction.Transaction{
+			TransactionType:   transaction.TxnTypeSmartContract,
+			SmartContractData: &transaction.SmartContractData{},
+		}
+		require.NoError(t, json.Unmarshal([]byte(data), txn.SmartContractData))
+		return txn
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := newTxn(t, tt.data), newTxn(t, tt.other)
+			require.Equal(t, tt.dup, buildInTxnKey(a) == buildInTxnKey(b))
+		})
+	}
+}
@@ -16,13 +16,18 @@ import (
 	"0chain.net/core/build"
 	"0chain.net/core/common"
 	"0chain.net/core/config"
+	"0chain.net/core/datastore"
+	"0chain.net/core/ememorystore"
 )
 
 func handlersMap() map[string]func(http.ResponseWriter, *http.Request) {
 	reqRespHandlers := map[string]common.ReqRespHandlerf{
 		"/v1/block/get":                    common.ToJSONResponse(BlockHandler),
 		"/v1/block/magic/get":              common.ToJSONResponse(MagicBlockHandler),
+		"/v1/block/get/headers":            common.ToJSONResponse(BlockHeadersHandler),
 		"/v1/transaction/get/confirmation": common.ToJSONResponse(TransactionConfirmationHandler),
+		"/v1/transaction/get/proof":        common.ToJSONResponse(TransactionProofHandler),
+		"/v1/state/proof":                  common.ToJSONResponse(StateProofHandler),
 		"/v1/healthcheck":                  common.ToJSONResponse(HealthcheckHandler),
 		"/v1/chain/get/stats":              common.ToJSONResponse(ChainStatsHandler),
 		"/_chain_stats":                    ChainStatsWriter,
@@ -189,6 +194,112 @@ func MagicBlockHandler(ctx context.Context, r *http.Request) (interface{}, error
 	return b, nil
 }
 
+/*TransactionProofHandler - given a transaction hash, prove it's presence in a finalized block */
+// swagger:route GET /v1/transaction/get/proof sharder GetTransactionProof
+// Get transaction proof.
+// Get the merkle path of the transaction in its finalized block, along with the block header and the block verification tickets.
+// The proof can be verified without trusting the sharder, given the miners of the magic block.
+//
+// parameters:
+//    +name: hash
+//      in: query
+//      required: true
+//      type: string
+//      description: Transaction hash
+//
+// responses:
+//    200: TxnProof
+//    400:
+func TransactionProofHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	hash := r.FormValue("hash")
+	if hash == "" {
+		return nil, common.InvalidRequest("transaction hash (parameter hash) is required")
+	}
+	transactionSummaryEntityMetadata := datastore.GetEntityMetadata("txn_summary")
+	ctx = ememorystore.WithEntityConnection(ctx, transactionSummaryEntityMetadata)
+	defer ememorystore.Close(ctx)
+	return GetSharderChain().GetTransactionProof(ctx, hash)
+}
+
+/*StateProofHandler - given a client state key, prove its value at a finalized block */
+// swagger:route GET /v1/state/proof sharder GetStateProof
+// Get state proof.
+// Get the MPT path of the client state key from the state root of the finalized block of the round, along with the block header and the block verification tickets.
+// The proof can be verified without trusting the sharder, given the miners of the magic block.
+//
+// parameters:
+//    +name: key
+//      in: query
+//      required: true
+//      type: string
+//      description: Client state key, e.g. a client ID
+//    +name: round
+//      in: query
+//      required: false
+//      type: string
+//      description: Round of the block, the latest finalized block by default
+//
+// responses:
+//    200: StateProof
+//    400:
+func StateProofHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	key := r.FormValue("key")
+	if key == "" {
+		return nil, common.InvalidRequest("state key (parameter key) is required")
+	}
+	sc := GetSharderChain()
+	round := sc.GetLatestFinalizedBlock().Round
+	if roundData := r.FormValue("round"); roundData != "" {
+		var err error
+		round, err = strconv.ParseInt(roundData, 10, 64)
+		if err != nil {
+			return nil, common.InvalidRequest("invalid round number")
+		}
+	}
+	return sc.GetStateProof(ctx, key, round)
+}
+
+// maxBlockHeaders is the maximum number of the block headers of a request
+const maxBlockHeaders = 100
+
+/*BlockHeadersHandler - a handler to stream the notarized block headers to light clients */
+// swagger:route GET /v1/block/get/headers sharder GetBlockHeaders
+// Get block headers.
+// Retrieve the compact headers of the finalized blocks starting from the given round, along with their verification tickets and the magic blocks they carry.
+// A light client trusting a magic block can follow the finality across view changes by verifying the headers in order.
+//
+// parameters:
+//   +name: start
+//	 in: query
+//	 type: string
+//	 required: true
+//	 description: Round of the first block header.
+//   +name: limit
+//	 in: query
+//	 type: string
+//	 description: Maximum number of the headers to retrieve, 100 at most. Default is 100.
+//
+// responses:
+//  200: []Header
+//  400:
+func BlockHeadersHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	start, err := strconv.ParseInt(r.FormValue("start"), 10, 64)
+	if err != nil || start < 0 {
+		return nil, common.InvalidRequest("invalid start round (parameter start)")
+	}
+	limit := maxBlockHeaders
+	if limitData := r.FormValue("limit"); limitData != "" {
+		limit, err = strconv.Atoi(limitData)
+		if err != nil || limit <= 0 {
+			return nil, common.InvalidRequest("invalid limit")
+		}
+		if limit > maxBlockHeaders {
+			limit = maxBlockHeaders
+		}
+	}
+	return GetSharderChain().GetBlockHeaders(ctx, start, limit)
+}
+
 func ChainStatsHandler(ctx context.Context, r *http.Request) (interface{}, error) {
 	c := GetSharderChain().Chain
 	return diagnostics.GetStatistics(c, chain.SteadyStateFinalizationTimer, 1000000.0), nil
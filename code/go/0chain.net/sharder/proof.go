@@ -0,0 +1,178 @@
+package sharder
+
+import (
+	"context"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/proof"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/ememorystore"
+	"github.com/0chain/common/core/util"
+)
+
+// GetTransactionProof - given a transaction hash, get the proof of its
+// presence in a finalized block
+func (sc *Chain) GetTransactionProof(ctx context.Context, hash string) (*proof.TxnProof, error) {
+	var ts *transaction.TransactionSummary
+	t, err := sc.BlockTxnCache.Get(hash)
+	if err != nil {
+		ts, err = sc.GetTransactionSummary(ctx, hash)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		ts = t.(*transaction.TransactionSummary)
+	}
+
+	b, err := sc.getFinalizedBlock(ctx, ts.Round)
+	if err != nil {
+		return nil, err
+	}
+
+	txn := b.GetTransaction(hash)
+	if txn == nil {
+		return nil, common.NewError("txn_not_found", "transaction not found in the block")
+	}
+
+	tickets, err := getProofTickets(b)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt := transaction.NewTransactionReceipt(txn)
+	return &proof.TxnProof{
+		TxnHash:               hash,
+		MerkleTreePath:        b.GetMerkleTree().GetPath(txn),
+		Block:                 b.GetHeader(),
+		Tickets:               tickets,
+		ReceiptHash:           receipt.GetHash(),
+		ReceiptMerkleTreePath: b.GetReceiptsMerkleTree().GetPath(receipt),
+	}, nil
+}
+
+// GetStateProof - get the proof of the value of the client state key at the
+// finalized block of the round
+func (sc *Chain) GetStateProof(ctx context.Context, key string, round int64) (*proof.StateProof, error) {
+	b, err := sc.getFinalizedBlock(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+
+	h := b.GetHeader()
+	if h.StateHash == "" {
+		return nil, proof.ErrStateNotCommitted
+	}
+
+	tickets, err := getProofTickets(b)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, value, err := proof.GetStatePath(sc.GetStateDB(), b.ClientStateHash, util.Path(key))
+	if err != nil {
+		return nil, err
+	}
+
+	return &proof.StateProof{
+		Key:     key,
+		Value:   value,
+		Nodes:   nodes,
+		Block:   h,
+		Tickets: tickets,
+	}, nil
+}
+
+// GetBlockHeaders - get the notarized headers of the finalized blocks starting
+// from the round, along with the magic blocks they carry
+func (sc *Chain) GetBlockHeaders(ctx context.Context, start int64, limit int) ([]*proof.Header, error) {
+	lfbRound := sc.GetLatestFinalizedBlock().Round
+	if start > lfbRound {
+		return nil, common.NewError("block_not_finalized", "block of the round is not finalized yet")
+	}
+	if end := lfbRound - start + 1; end < int64(limit) {
+		limit = int(end)
+	}
+
+	bSummaryEntityMetadata := datastore.GetEntityMetadata("block_summary")
+	bctx := ememorystore.WithEntityConnection(ctx, bSummaryEntityMetadata)
+	defer ememorystore.CloseEntityConnection(bctx, bSummaryEntityMetadata)
+
+	headers := make([]*proof.Header, 0, limit)
+	for round := start; round < start+int64(limit); round++ {
+		h, err := sc.getBlockHeader(bctx, round)
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, h)
+	}
+	return headers, nil
+}
+
+// getBlockHeader gets the header of the finalized block from its summary,
+// falling back to the block for the summaries stored without the header fields
+func (sc *Chain) getBlockHeader(ctx context.Context, round int64) (*proof.Header, error) {
+	bhash, err := sc.GetBlockHash(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+
+	bs, err := sc.GetBlockSummary(ctx, bhash)
+	if err != nil || bs.PrevHash == "" || len(bs.VerificationTickets) == 0 {
+		b, err := sc.getFinalizedBlock(ctx, round)
+		if err != nil {
+			return nil, err
+		}
+		bs = b.GetSummary()
+	}
+
+	h := &proof.Header{
+		Block:   bs.GetHeader(),
+		Tickets: toProofTickets(bs.VerificationTickets),
+	}
+	if len(h.Tickets) == 0 {
+		return nil, common.NewError("no_verification_tickets",
+			"no verification tickets for the block")
+	}
+	if bs.MagicBlock != nil {
+		h.MagicBlock = bs.MagicBlock.GetMagicBlockHeader()
+	}
+	return h, nil
+}
+
+func (sc *Chain) getFinalizedBlock(ctx context.Context, round int64) (*block.Block, error) {
+	if round > sc.GetLatestFinalizedBlock().Round {
+		return nil, common.NewError("block_not_finalized", "block of the round is not finalized yet")
+	}
+
+	bhash, err := sc.GetBlockHash(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+
+	if bc, err := sc.BlockCache.Get(bhash); err == nil {
+		return bc.(*block.Block), nil
+	}
+	return sc.GetBlockBySummary(ctx, &block.BlockSummary{Hash: bhash, Round: round})
+}
+
+func getProofTickets(b *block.Block) ([]*proof.Ticket, error) {
+	tickets := toProofTickets(b.GetVerificationTickets())
+	if len(tickets) == 0 {
+		return nil, common.NewError("no_verification_tickets",
+			"no verification tickets for the block")
+	}
+	return tickets, nil
+}
+
+func toProofTickets(vts []*block.VerificationTicket) []*proof.Ticket {
+	tickets := make([]*proof.Ticket, 0, len(vts))
+	for _, vt := range vts {
+		tickets = append(tickets, &proof.Ticket{
+			VerifierID: vt.VerifierID,
+			Signature:  vt.Signature,
+		})
+	}
+	return tickets
+}
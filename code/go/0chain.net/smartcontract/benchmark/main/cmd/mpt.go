@@ -420,6 +420,62 @@ func setUpMpt(
 		log.Println("added free storage assigners\t", time.Since(timer))
 	}()
 	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		timer := time.Now()
+		storagesc.AddMockAllocationTransfers(clients, balances)
+		log.Println("added allocation transfers\t", time.Since(timer))
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		timer := time.Now()
+		storagesc.AddMockReadACLs(clients, balances)
+		log.Println("added read acls\t", time.Since(timer))
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		timer := time.Now()
+		storagesc.AddMockAutoRepairPolicies(balances)
+		log.Println("added auto-repair policies\t", time.Since(timer))
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		timer := time.Now()
+		storagesc.AddMockMeteredBillings(balances)
+		log.Println("added metered billings\t", time.Since(timer))
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		timer := time.Now()
+		storagesc.AddMockShardMigrations(balances)
+		log.Println("added shard migrations\t", time.Since(timer))
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		timer := time.Now()
+		storagesc.AddMockReadSubPools(clients, balances)
+		log.Println("added read sub-pools\t", time.Since(timer))
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		timer := time.Now()
+		storagesc.AddMockPriceOffers(balances)
+		log.Println("added price offers\t", time.Since(timer))
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		timer := time.Now()
+		storagesc.AddMockChallengeDisputes(validators, clients, balances)
+		log.Println("added challenge disputes\t", time.Since(timer))
+	}()
+	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		timer := time.Now()
@@ -24,6 +24,7 @@ import (
 	ebk "0chain.net/smartcontract/dbs/benchmark"
 	"0chain.net/smartcontract/faucetsc"
 	"0chain.net/smartcontract/minersc"
+	"0chain.net/smartcontract/multisigsc"
 	"0chain.net/smartcontract/rest"
 	"0chain.net/smartcontract/storagesc"
 	"0chain.net/smartcontract/vestingsc"
@@ -83,6 +84,7 @@ func runSuites(
 	}
 	faucetsc.SetupRestHandler(restSetup)
 	minersc.SetupRestHandler(restSetup)
+	multisigsc.SetupRestHandler(restSetup)
 	storagesc.SetupRestHandler(restSetup)
 	vestingsc.SetupRestHandler(restSetup)
 	zcnsc.SetupRestHandler(restSetup)
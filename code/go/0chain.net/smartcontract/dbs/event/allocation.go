@@ -198,6 +198,24 @@ func (edb *EventDb) updateAllocations(allocs []Allocation) error {
 	return updater.Exec(edb).Debug().Error
 }
 
+// AllocationOwnershipTransfer is emitted when the recipient of an allocation
+// ownership transfer offer accepts it.
+type AllocationOwnershipTransfer struct {
+	AllocationID   string `json:"allocation_id"`
+	PreviousOwner  string `json:"previous_owner"`
+	Owner          string `json:"owner"`
+	OwnerPublicKey string `json:"owner_public_key"`
+}
+
+func (edb *EventDb) transferAllocationOwnership(transfer AllocationOwnershipTransfer) error {
+	return edb.Store.Get().Model(&Allocation{}).
+		Where("allocation_id = ?", transfer.AllocationID).
+		Updates(map[string]interface{}{
+			"owner":            transfer.Owner,
+			"owner_public_key": transfer.OwnerPublicKey,
+		}).Error
+}
+
 func mergeUpdateAllocEvents() *eventsMergerImpl[Allocation] {
 	return newEventsMerger[Allocation](TagUpdateAllocation, withUniqueEventOverwrite())
 }
@@ -0,0 +1,52 @@
+package event
+
+import (
+	common2 "0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/model"
+	"gorm.io/gorm/clause"
+)
+
+// AllocationRepair is a blobber of an allocation replaced by the auto-repair
+// policy. The added blobber uses it to start the repair of the allocation
+// data.
+type AllocationRepair struct {
+	model.UpdatableModel
+	AllocationID     string `json:"allocation_id" gorm:"index:idx_alloc_repair_allocation"`
+	RemovedBlobberID string `json:"removed_blobber_id"`
+	AddedBlobberID   string `json:"added_blobber_id" gorm:"index:idx_alloc_repair_added_blobber"`
+	Reason           string `json:"reason"`
+	TransactionHash  string `json:"transaction_hash"`
+	Round            int64  `json:"round"`
+}
+
+func (edb *EventDb) GetAllocationRepairs(allocationID string, limit common2.Pagination) ([]AllocationRepair, error) {
+	var repairs []AllocationRepair
+	err := edb.Store.Get().Model(&AllocationRepair{}).
+		Where("allocation_id = ?", allocationID).
+		Offset(limit.Offset).
+		Limit(limit.Limit).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{Name: "id"},
+			Desc:   limit.IsDescending,
+		}).
+		Find(&repairs).Error
+	return repairs, err
+}
+
+func (edb *EventDb) GetAllocationRepairsByBlobber(blobberID string, limit common2.Pagination) ([]AllocationRepair, error) {
+	var repairs []AllocationRepair
+	err := edb.Store.Get().Model(&AllocationRepair{}).
+		Where("added_blobber_id = ?", blobberID).
+		Offset(limit.Offset).
+		Limit(limit.Limit).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{Name: "id"},
+			Desc:   limit.IsDescending,
+		}).
+		Find(&repairs).Error
+	return repairs, err
+}
+
+func (edb *EventDb) addAllocationRepairs(repairs []AllocationRepair) error {
+	return edb.Store.Get().Create(&repairs).Error
+}
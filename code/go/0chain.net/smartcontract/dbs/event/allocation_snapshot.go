@@ -0,0 +1,57 @@
+package event
+
+import (
+	common2 "0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/model"
+	"gorm.io/gorm/clause"
+)
+
+// AllocationSnapshot is the allocation root of a blobber of a frozen
+// allocation, along with the snapshot it belongs to. The snapshot hash is
+// signed by the allocation owner.
+type AllocationSnapshot struct {
+	model.UpdatableModel
+	AllocationID         string `json:"allocation_id" gorm:"uniqueIndex:idx_alloc_snapshot_blobber,priority:1; not null"`
+	BlobberID            string `json:"blobber_id" gorm:"uniqueIndex:idx_alloc_snapshot_blobber,priority:2; not null"`
+	AllocationRoot       string `json:"allocation_root"`
+	WriteMarkerTimestamp int64  `json:"write_marker_timestamp"`
+	Owner                string `json:"owner" gorm:"index:idx_alloc_snapshot_owner"`
+	SnapshotHash         string `json:"snapshot_hash"`
+	Signature            string `json:"signature"`
+	TransactionHash      string `json:"transaction_hash"`
+	Round                int64  `json:"round"`
+	FrozenAt             int64  `json:"frozen_at"`
+}
+
+func (edb *EventDb) GetAllocationSnapshots(allocationID string, limit common2.Pagination) ([]AllocationSnapshot, error) {
+	var snapshots []AllocationSnapshot
+	err := edb.Store.Get().Model(&AllocationSnapshot{}).
+		Where("allocation_id = ?", allocationID).
+		Offset(limit.Offset).
+		Limit(limit.Limit).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{Name: "blobber_id"},
+			Desc:   limit.IsDescending,
+		}).
+		Find(&snapshots).Error
+	return snapshots, err
+}
+
+func (edb *EventDb) GetAllocationSnapshotsByOwner(owner string, limit common2.Pagination) ([]AllocationSnapshot, error) {
+	var snapshots []AllocationSnapshot
+	err := edb.Store.Get().Model(&AllocationSnapshot{}).
+		Where("owner = ?", owner).
+		Offset(limit.Offset).
+		Limit(limit.Limit).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{Name: "round"},
+			Desc:   limit.IsDescending,
+		}).
+		Order("blobber_id").
+		Find(&snapshots).Error
+	return snapshots, err
+}
+
+func (edb *EventDb) addAllocationSnapshots(snapshots []AllocationSnapshot) error {
+	return edb.Store.Get().Clauses(clause.OnConflict{DoNothing: true}).Create(&snapshots).Error
+}
@@ -35,6 +35,15 @@ type Blobber struct {
 	IsRestricted bool  `json:"is_restricted"`
 	IsEnterprise bool  `json:"is_enterprise"`
 
+	// placement
+	Region       string `json:"region"`
+	Country      string `json:"country"`
+	Jurisdiction string `json:"jurisdiction"`
+	Operator     string `json:"operator"`
+
+	// Reputation is the decayed rate of the challenges passed
+	Reputation float64 `json:"reputation" gorm:"default:1"`
+
 	OffersTotal currency.Coin `json:"offers_total"`
 	// todo update
 	TotalServiceCharge currency.Coin `json:"total_service_charge"`
@@ -233,6 +242,10 @@ type AllocationQuery struct {
 	AllocationSizeInGB float64
 	NumberOfDataShards int
 	IsRestricted       int
+	// ExcludedJurisdictions filters out the blobbers in the jurisdictions
+	ExcludedJurisdictions []string
+	// MinReputation filters out the blobbers with a lower reputation
+	MinReputation float64
 }
 
 func (edb *EventDb) GetBlobberIdsFromUrls(urls []string, data common2.Pagination) ([]string, error) {
@@ -260,6 +273,12 @@ func (edb *EventDb) GetBlobbersFromParams(allocation AllocationQuery, limit comm
 	} else if allocation.IsRestricted == 2 {
 		dbStore = dbStore.Where("is_restricted = false")
 	}
+	if len(allocation.ExcludedJurisdictions) > 0 {
+		dbStore = dbStore.Where("jurisdiction NOT IN ?", allocation.ExcludedJurisdictions)
+	}
+	if allocation.MinReputation > 0 {
+		dbStore = dbStore.Where("reputation >= ?", allocation.MinReputation)
+	}
 	dbStore = dbStore.Where("is_killed = false")
 	dbStore = dbStore.Where("is_shutdown = false")
 	dbStore = dbStore.Where("not_available = false")
@@ -292,6 +311,38 @@ type Result struct {
 	Unallocated int64
 }
 
+// BlobberPlacement is the location and the operator declared by a blobber
+type BlobberPlacement struct {
+	BlobberID    string `json:"blobber_id"`
+	Region       string `json:"region"`
+	Country      string `json:"country"`
+	Jurisdiction string `json:"jurisdiction"`
+	Operator     string `json:"operator"`
+}
+
+func (edb *EventDb) updateBlobberPlacement(bp BlobberPlacement) error {
+	return edb.Store.Get().Model(&Blobber{}).
+		Where("id = ?", bp.BlobberID).
+		Updates(map[string]interface{}{
+			"region":       bp.Region,
+			"country":      bp.Country,
+			"jurisdiction": bp.Jurisdiction,
+			"operator":     bp.Operator,
+		}).Error
+}
+
+// BlobberReputation is the reputation of a blobber updated on challenge results
+type BlobberReputation struct {
+	BlobberID  string  `json:"blobber_id"`
+	Reputation float64 `json:"reputation"`
+}
+
+func (edb *EventDb) updateBlobberReputation(br BlobberReputation) error {
+	return edb.Store.Get().Model(&Blobber{}).
+		Where("id = ?", br.BlobberID).
+		Update("reputation", br.Reputation).Error
+}
+
 func (edb *EventDb) addBlobbers(blobbers []Blobber) error {
 	return edb.Store.Get().Create(&blobbers).Error
 }
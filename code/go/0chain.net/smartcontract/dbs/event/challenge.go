@@ -38,6 +38,11 @@ type Challenge struct {
 	RoundCreatedAt int64            `json:"round_created_at"`
 	ExpiredN       int              `json:"expired_n" gorm:"-"`
 	Timestamp      common.Timestamp `json:"timestamp" gorm:"timestamp"`
+
+	// SourceAllocationRoot of the blobber in the source layout of a shard
+	// migration
+	SourceAllocationRoot string           `json:"source_allocation_root"`
+	SourceTimestamp      common.Timestamp `json:"source_timestamp"`
 }
 
 func (edb *EventDb) GetChallengesCountByQuery(whereQuery string) (map[string]int64, error) {
@@ -126,6 +131,17 @@ func (edb *EventDb) GetChallenge(challengeID string) (*Challenge, error) {
 	return &ch, nil
 }
 
+// GetChallengesCreatedInRound returns the challenges generated in the round
+func (edb *EventDb) GetChallengesCreatedInRound(round int64) ([]Challenge, error) {
+	var chs []Challenge
+	err := edb.Store.Get().
+		Model(&Challenge{}).
+		Where("round_created_at = ?", round).
+		Order("id").
+		Find(&chs).Error
+	return chs, err
+}
+
 func (edb *EventDb) GetChallenges(blobberId string, start, end int64) ([]Challenge, error) {
 	var chs []Challenge
 	result := edb.Store.Get().
@@ -0,0 +1,43 @@
+package event
+
+import (
+	common2 "0chain.net/smartcontract/common"
+	"github.com/0chain/common/core/currency"
+	"gorm.io/gorm/clause"
+)
+
+// ChallengeDispute is a failed challenge of a blobber open to a late proof
+// until its deadline. The slash of the blobber for the challenge is held in
+// the challenge pool of the allocation until the dispute is closed.
+type ChallengeDispute struct {
+	ChallengeID  string        `json:"challenge_id" gorm:"primarykey"`
+	AllocationID string        `json:"allocation_id" gorm:"index:idx_chall_dispute_allocation"`
+	BlobberID    string        `json:"blobber_id" gorm:"index:idx_chall_dispute_blobber"`
+	Status       string        `json:"status"`
+	Escrow       currency.Coin `json:"escrow"`
+	Deadline     int64         `json:"deadline"`
+	Round        int64         `json:"round"`
+}
+
+func (edb *EventDb) GetChallengeDisputesByBlobber(blobberID string, limit common2.Pagination) ([]ChallengeDispute, error) {
+	var disputes []ChallengeDispute
+	err := edb.Store.Get().Model(&ChallengeDispute{}).
+		Where("blobber_id = ?", blobberID).
+		Offset(limit.Offset).
+		Limit(limit.Limit).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{Name: "round"},
+			Desc:   limit.IsDescending,
+		}).
+		Find(&disputes).Error
+	return disputes, err
+}
+
+func (edb *EventDb) addOrUpdateChallengeDispute(cd ChallengeDispute) error {
+	updateFields := []string{"status", "escrow", "round"}
+
+	return edb.Store.Get().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "challenge_id"}},
+		DoUpdates: clause.AssignmentColumns(updateFields),
+	}).Create(&cd).Error
+}
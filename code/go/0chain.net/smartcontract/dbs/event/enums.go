@@ -113,6 +113,15 @@ const (
 	TagShutdownProvider
 	TagInsertReadpool
 	TagUpdateReadpool
+	TagTransferAllocationOwnership
+	TagAddAllocationSnapshot
+	TagUpdateBlobberPlacement
+	TagAddAllocationRepair
+	TagUpdateMeteredBilling
+	TagMeteredBillingLowBalance
+	TagUpdateBlobberReputation
+	TagUpdateChallengeDispute
+	TagAddSlashRecords
 	NumberOfTags
 )
 
@@ -203,6 +212,15 @@ func initTagString() {
 	TagString[TagShutdownProvider] = "TagShutdownProvider"
 	TagString[TagInsertReadpool] = "TagInsertReadpool"
 	TagString[TagUpdateReadpool] = "TagUpdateReadpool"
+	TagString[TagTransferAllocationOwnership] = "TagTransferAllocationOwnership"
+	TagString[TagAddAllocationSnapshot] = "TagAddAllocationSnapshot"
+	TagString[TagUpdateBlobberPlacement] = "TagUpdateBlobberPlacement"
+	TagString[TagAddAllocationRepair] = "TagAddAllocationRepair"
+	TagString[TagUpdateMeteredBilling] = "TagUpdateMeteredBilling"
+	TagString[TagMeteredBillingLowBalance] = "TagMeteredBillingLowBalance"
+	TagString[TagUpdateBlobberReputation] = "TagUpdateBlobberReputation"
+	TagString[TagUpdateChallengeDispute] = "TagUpdateChallengeDispute"
+	TagString[TagAddSlashRecords] = "TagAddSlashRecords"
 	TagString[NumberOfTags] = "invalid"
 }
 
@@ -257,6 +257,12 @@ func (edb *EventDb) AutoMigrate() error {
 		&RewardDelegate{},
 		&RewardProvider{},
 		&ReadPool{},
+		&AllocationSnapshot{},
+		&AllocationRepair{},
+		&MeteredBilling{},
+		&MeteredBillingAlert{},
+		&ChallengeDispute{},
+		&SlashRecord{},
 	); err != nil {
 		return err
 	}
@@ -0,0 +1,66 @@
+package event
+
+import (
+	common2 "0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/model"
+	"github.com/0chain/common/core/currency"
+	"gorm.io/gorm/clause"
+)
+
+// MeteredBilling is the prepaid balance of an allocation paying its blobbers
+// per committed byte-time instead of an upfront write pool lock.
+type MeteredBilling struct {
+	AllocationID        string        `json:"allocation_id" gorm:"primarykey"`
+	Owner               string        `json:"owner" gorm:"index:idx_metered_billing_owner"`
+	Balance             currency.Coin `json:"balance"`
+	LowBalanceThreshold currency.Coin `json:"low_balance_threshold"`
+	Paid                currency.Coin `json:"paid"`
+	ExhaustedAt         int64         `json:"exhausted_at"`
+	Closed              bool          `json:"closed"`
+}
+
+// MeteredBillingAlert is emitted when the balance of a metered allocation
+// drops below its low balance threshold or gets exhausted.
+type MeteredBillingAlert struct {
+	model.UpdatableModel
+	AllocationID        string        `json:"allocation_id" gorm:"index:idx_metered_alert_allocation"`
+	Owner               string        `json:"owner" gorm:"index:idx_metered_alert_owner"`
+	Balance             currency.Coin `json:"balance"`
+	LowBalanceThreshold currency.Coin `json:"low_balance_threshold"`
+	Exhausted           bool          `json:"exhausted"`
+	Round               int64         `json:"round"`
+}
+
+func (edb *EventDb) GetMeteredBilling(allocationID string) (*MeteredBilling, error) {
+	var mb MeteredBilling
+	return &mb, edb.Store.Get().Model(&MeteredBilling{}).
+		Where("allocation_id = ?", allocationID).
+		Take(&mb).Error
+}
+
+func (edb *EventDb) GetMeteredBillingAlerts(owner string, limit common2.Pagination) ([]MeteredBillingAlert, error) {
+	var alerts []MeteredBillingAlert
+	err := edb.Store.Get().Model(&MeteredBillingAlert{}).
+		Where("owner = ?", owner).
+		Offset(limit.Offset).
+		Limit(limit.Limit).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{Name: "id"},
+			Desc:   limit.IsDescending,
+		}).
+		Find(&alerts).Error
+	return alerts, err
+}
+
+func (edb *EventDb) addOrUpdateMeteredBilling(mb MeteredBilling) error {
+	updateFields := []string{"owner", "balance", "low_balance_threshold", "paid", "exhausted_at", "closed"}
+
+	return edb.Store.Get().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "allocation_id"}},
+		DoUpdates: clause.AssignmentColumns(updateFields),
+	}).Create(&mb).Error
+}
+
+func (edb *EventDb) addMeteredBillingAlert(alert MeteredBillingAlert) error {
+	return edb.Store.Get().Create(&alert).Error
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"0chain.net/core/common"
 	common2 "0chain.net/smartcontract/common"
 	"github.com/0chain/common/core/currency"
 	"gorm.io/gorm/clause"
@@ -25,6 +26,7 @@ type Miner struct {
 	Delete          bool
 	Fees            currency.Coin
 	Active          bool
+	JailedUntil     common.Timestamp
 	BlocksFinalised int64
 	CreationRound   int64 `json:"creation_round" gorm:"index:idx_miner_creation_round"`
 }
@@ -1004,6 +1004,65 @@ func (edb *EventDb) addStat(event Event) (err error) {
 			return ErrInvalidEventData
 		}
 		return edb.updateReadPool(*rps)
+	case TagTransferAllocationOwnership:
+		transfer, ok := fromEvent[AllocationOwnershipTransfer](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.transferAllocationOwnership(*transfer)
+	case TagAddAllocationSnapshot:
+		snapshots, ok := fromEvent[[]AllocationSnapshot](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.addAllocationSnapshots(*snapshots)
+	case TagUpdateBlobberPlacement:
+		placement, ok := fromEvent[BlobberPlacement](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.updateBlobberPlacement(*placement)
+	case TagUpdateBlobberReputation:
+		reputation, ok := fromEvent[BlobberReputation](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.updateBlobberReputation(*reputation)
+	case TagUpdateChallengeDispute:
+		cd, ok := fromEvent[ChallengeDispute](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.addOrUpdateChallengeDispute(*cd)
+	case TagAddAllocationRepair:
+		repairs, ok := fromEvent[[]AllocationRepair](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.addAllocationRepairs(*repairs)
+	case TagAddSlashRecords:
+		records, ok := fromEvent[[]SlashRecord](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+
+		for i := range *records {
+			(*records)[i].TransactionHash = event.TxHash
+			(*records)[i].Round = event.BlockNumber
+		}
+		return edb.addSlashRecords(*records)
+	case TagUpdateMeteredBilling:
+		mb, ok := fromEvent[MeteredBilling](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.addOrUpdateMeteredBilling(*mb)
+	case TagMeteredBillingLowBalance:
+		alert, ok := fromEvent[MeteredBillingAlert](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.addMeteredBillingAlert(*alert)
 	case TagCollectProviderReward:
 		return edb.collectRewards(event.Index)
 	case TagMinerHealthCheck:
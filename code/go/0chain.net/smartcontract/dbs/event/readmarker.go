@@ -5,6 +5,7 @@ import (
 
 	common2 "0chain.net/smartcontract/common"
 	"0chain.net/smartcontract/dbs/model"
+	"github.com/0chain/common/core/currency"
 	"gorm.io/gorm/clause"
 
 	"0chain.net/core/common"
@@ -13,18 +14,20 @@ import (
 // swagger:model ReadMarker
 type ReadMarker struct {
 	model.ImmutableModel
-	ClientID      string  `json:"client_id"`
-	BlobberID     string  `json:"blobber_id"`
-	AllocationID  string  `json:"allocation_id" gorm:"index:idx_ralloc_block,priority:1;index:idx_rauth_alloc,priority:2"` //used in alloc_read_size, used in readmarkers
-	TransactionID string  `json:"transaction_id" gorm:"uniqueIndex"`
-	OwnerID       string  `json:"owner_id"`
-	Timestamp     int64   `json:"timestamp"`
-	ReadCounter   int64   `json:"read_counter"`
-	ReadSize      float64 `json:"read_size"`
-	Signature     string  `json:"signature"`
-	PayerID       string  `json:"payer_id"`
-	AuthTicket    string  `json:"auth_ticket" gorm:"index:idx_rauth_alloc,priority:1"`   //used in readmarkers
-	BlockNumber   int64   `json:"block_number" gorm:"index:idx_ralloc_block,priority:2"` //used in alloc_read_size
+	ClientID      string        `json:"client_id"`
+	BlobberID     string        `json:"blobber_id"`
+	AllocationID  string        `json:"allocation_id" gorm:"index:idx_ralloc_block,priority:1;index:idx_rauth_alloc,priority:2"` //used in alloc_read_size, used in readmarkers
+	TransactionID string        `json:"transaction_id" gorm:"uniqueIndex"`
+	OwnerID       string        `json:"owner_id"`
+	Timestamp     int64         `json:"timestamp"`
+	ReadCounter   int64         `json:"read_counter"`
+	ReadSize      float64       `json:"read_size"`
+	Signature     string        `json:"signature"`
+	PayerID       string        `json:"payer_id"`
+	AuthTicket    string        `json:"auth_ticket" gorm:"index:idx_rauth_alloc,priority:1"`   //used in readmarkers
+	BlockNumber   int64         `json:"block_number" gorm:"index:idx_ralloc_block,priority:2"` //used in alloc_read_size
+	ReadSubPoolID string        `json:"read_sub_pool_id" gorm:"index:idx_rsub_pool"`
+	Cost          currency.Coin `json:"cost"`
 	//ref
 	Allocation Allocation `gorm:"references:AllocationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
@@ -66,6 +69,34 @@ func (edb *EventDb) CountReadMarkersFromQuery(query *ReadMarker) (count int64, e
 	return
 }
 
+// ReadSubPoolConsumption is the spend of a read sub-pool by a reader
+type ReadSubPoolConsumption struct {
+	ReadSubPoolID string  `json:"read_sub_pool_id"`
+	PayerID       string  `json:"payer_id"`
+	ClientID      string  `json:"client_id"`
+	ReadMarkers   int64   `json:"read_markers"`
+	ReadSize      float64 `json:"read_size"`
+	Cost          int64   `json:"cost"`
+}
+
+// GetReadSubPoolConsumption sums the read markers of an allocation paid by
+// its read sub-pools, per sub-pool and reader, optionally of a single payer.
+func (edb *EventDb) GetReadSubPoolConsumption(allocationID, payerID string) ([]ReadSubPoolConsumption, error) {
+	query := edb.Store.Get().Model(&ReadMarker{}).
+		Select("read_sub_pool_id, payer_id, client_id, count(*) as read_markers, "+
+			"sum(read_size) as read_size, sum(cost) as cost").
+		Where("allocation_id = ? AND read_sub_pool_id <> ''", allocationID)
+	if payerID != "" {
+		query = query.Where("payer_id = ?", payerID)
+	}
+
+	var consumption []ReadSubPoolConsumption
+	return consumption, query.
+		Group("read_sub_pool_id, payer_id, client_id").
+		Order("read_sub_pool_id, client_id").
+		Scan(&consumption).Error
+}
+
 func (edb *EventDb) addOrOverwriteReadMarker(rms []ReadMarker) error {
 	return edb.Store.Get().Clauses(clause.OnConflict{
 		Columns:   []clause.Column{{Name: "transaction_id"}},
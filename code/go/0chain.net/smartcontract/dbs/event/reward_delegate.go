@@ -18,6 +18,8 @@ type RewardDelegate struct {
 	ProviderID   string        `json:"provider_id"`
 	RewardType   spenum.Reward `json:"reward_type"`
 	AllocationID string        `json:"allocation_id"`
+	// Compounded is the part of the amount added to the delegate pool balance
+	Compounded currency.Coin `json:"compounded"`
 }
 
 func (edb *EventDb) insertDelegateReward(inserts []dbs.StakePoolReward, round int64) error {
@@ -31,6 +33,7 @@ func (edb *EventDb) insertDelegateReward(inserts []dbs.StakePoolReward, round in
 				ProviderID:   sp.ID,
 				RewardType:   sp.RewardType,
 				AllocationID: sp.AllocationID,
+				Compounded:   sp.DelegateCompounds[poolId],
 			}
 			drs = append(drs, dr)
 		}
@@ -3,6 +3,7 @@ package event
 import (
 	"fmt"
 
+	"0chain.net/core/common"
 	common2 "0chain.net/smartcontract/common"
 	"github.com/0chain/common/core/currency"
 	"gorm.io/gorm/clause"
@@ -26,6 +27,7 @@ type Sharder struct {
 	Fees      currency.Coin
 	Active    bool
 
+	JailedUntil   common.Timestamp
 	CreationRound int64 `json:"creation_round" gorm:"index:idx_sharder_creation_round"`
 }
 
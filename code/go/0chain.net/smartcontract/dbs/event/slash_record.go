@@ -0,0 +1,59 @@
+package event
+
+import (
+	common2 "0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/model"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"gorm.io/gorm/clause"
+)
+
+// SlashRecord is the evidence of a slash of a delegate pool of a provider:
+// why the provider was slashed, the fraction of the stake slashed and the
+// amount the delegate lost.
+type SlashRecord struct {
+	model.UpdatableModel
+	ProviderID   string          `json:"provider_id" gorm:"index:idx_slash_record_provider"`
+	ProviderType spenum.Provider `json:"provider_type"`
+	DelegateID   string          `json:"delegate_id" gorm:"index:idx_slash_record_delegate"`
+	Reason       string          `json:"reason"`
+	// Evidence is the failed challenge, the kill or shutdown transaction or
+	// the missed health check the slash is for
+	Evidence        string        `json:"evidence"`
+	Fraction        float64       `json:"fraction"`
+	Amount          currency.Coin `json:"amount"`
+	TransactionHash string        `json:"transaction_hash"`
+	Round           int64         `json:"round"`
+}
+
+func (edb *EventDb) GetProviderSlashRecords(providerID string, limit common2.Pagination) ([]SlashRecord, error) {
+	var records []SlashRecord
+	err := edb.Store.Get().Model(&SlashRecord{}).
+		Where("provider_id = ?", providerID).
+		Offset(limit.Offset).
+		Limit(limit.Limit).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{Name: "id"},
+			Desc:   limit.IsDescending,
+		}).
+		Find(&records).Error
+	return records, err
+}
+
+func (edb *EventDb) GetDelegateSlashRecords(delegateID string, pType spenum.Provider, limit common2.Pagination) ([]SlashRecord, error) {
+	var records []SlashRecord
+	err := edb.Store.Get().Model(&SlashRecord{}).
+		Where("delegate_id = ? AND provider_type = ?", delegateID, pType).
+		Offset(limit.Offset).
+		Limit(limit.Limit).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{Name: "id"},
+			Desc:   limit.IsDescending,
+		}).
+		Find(&records).Error
+	return records, err
+}
+
+func (edb *EventDb) addSlashRecords(records []SlashRecord) error {
+	return edb.Store.Get().Create(&records).Error
+}
@@ -17,6 +17,7 @@ type providerRewardsDelegates struct {
 	rewards       map[string]currency.Coin
 	totalRewards  map[string]currency.Coin
 	delegatePools map[string]map[string]currency.Coin
+	compounds     map[string]map[string]currency.Coin
 }
 
 type providerPenaltiesDelegates struct {
@@ -28,6 +29,7 @@ func aggregateProviderRewards(spus []dbs.StakePoolReward) (*providerRewardsDeleg
 		rewardsMap      = make(map[string]currency.Coin)
 		totalRewardsMap = make(map[string]currency.Coin)
 		dpRewardsMap    = make(map[string]map[string]currency.Coin)
+		dpCompoundsMap  = make(map[string]map[string]currency.Coin)
 	)
 	for i, sp := range spus {
 		if sp.Reward != 0 {
@@ -41,12 +43,19 @@ func aggregateProviderRewards(spus []dbs.StakePoolReward) (*providerRewardsDeleg
 			dpRewardsMap[sp.ID][poolId] = dpRewardsMap[sp.ID][poolId] + spus[i].DelegateRewards[poolId]
 			totalRewardsMap[sp.ID] = totalRewardsMap[sp.ID] + spus[i].DelegateRewards[poolId]
 		}
+		for poolId, c := range spus[i].DelegateCompounds {
+			if _, found := dpCompoundsMap[sp.ID]; !found {
+				dpCompoundsMap[sp.ID] = make(map[string]currency.Coin, len(spus[i].DelegateCompounds))
+			}
+			dpCompoundsMap[sp.ID][poolId] = dpCompoundsMap[sp.ID][poolId] + c
+		}
 	}
 
 	return &providerRewardsDelegates{
 		rewards:       rewardsMap,
 		totalRewards:  totalRewardsMap,
 		delegatePools: dpRewardsMap,
+		compounds:     dpCompoundsMap,
 	}, nil
 }
 
@@ -92,6 +101,14 @@ func withProviderRewardsPenaltiesAdded() eventMergeMiddleware {
 			a.DelegateRewards[k] += v
 		}
 
+		// merge delegate pool compounds
+		for k, v := range b.DelegateCompounds {
+			if a.DelegateCompounds == nil {
+				a.DelegateCompounds = make(map[string]currency.Coin, len(b.DelegateCompounds))
+			}
+			a.DelegateCompounds[k] += v
+		}
+
 		// merge delegate pool penalties
 		for k, v := range b.DelegatePenalties {
 			_, ok := a.DelegatePenalties[k]
@@ -148,7 +165,7 @@ func (edb *EventDb) rewardUpdate(spus []dbs.StakePoolReward, round int64) error
 
 	if len(rewards.delegatePools) > 0 {
 		logging.Logger.Debug("reward provider pools", zap.Any("rewards", rewards))
-		if err := edb.rewardProviderDelegates(rewards.delegatePools, round); err != nil {
+		if err := edb.rewardProviderDelegates(rewards.delegatePools, rewards.compounds, round); err != nil {
 			return fmt.Errorf("could not rewards delegate pool: %v", err)
 		}
 	}
@@ -237,16 +254,23 @@ func (edb *EventDb) rewardProviders(
 		Exec(edb).Error
 }
 
-func (edb *EventDb) rewardProviderDelegates(dps map[string]map[string]currency.Coin, round int64) error {
+// rewardProviderDelegates adds the rewards to the delegate pools, the compounded
+// part of a reward goes to the balance of the pool instead of its unclaimed reward
+func (edb *EventDb) rewardProviderDelegates(dps, compounds map[string]map[string]currency.Coin, round int64) error {
 	var poolIds []string
 	var providerIds []string
 	var reward []uint64
+	var totalReward []uint64
+	var balance []uint64
 	var lastUpdated []uint64
 	for id, pools := range dps {
 		for poolId, r := range pools {
+			c := compounds[id][poolId]
 			poolIds = append(poolIds, poolId)
 			providerIds = append(providerIds, id)
-			reward = append(reward, uint64(r))
+			reward = append(reward, uint64(r-c))
+			totalReward = append(totalReward, uint64(r))
+			balance = append(balance, uint64(c))
 			lastUpdated = append(lastUpdated, uint64(round))
 		}
 	}
@@ -254,7 +278,8 @@ func (edb *EventDb) rewardProviderDelegates(dps map[string]map[string]currency.C
 	ret := CreateBuilder("delegate_pools", "pool_id", poolIds).
 		AddCompositeId("provider_id", providerIds).
 		AddUpdate("reward", reward, "delegate_pools.reward + t.reward").
-		AddUpdate("total_reward", reward, "delegate_pools.total_reward + t.reward").
+		AddUpdate("total_reward", totalReward, "delegate_pools.total_reward + t.total_reward").
+		AddUpdate("balance", balance, "delegate_pools.balance + t.balance").
 		AddUpdate("round_pool_last_updated", lastUpdated).
 		Exec(edb)
 	return ret.Error
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE allocation_snapshots (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    allocation_id text NOT NULL,
+    blobber_id text NOT NULL,
+    allocation_root text,
+    write_marker_timestamp bigint,
+    owner text,
+    snapshot_hash text,
+    signature text,
+    transaction_hash text,
+    round bigint,
+    frozen_at bigint
+);
+
+ALTER TABLE allocation_snapshots OWNER TO zchain_user;
+
+CREATE UNIQUE INDEX idx_alloc_snapshot_blobber ON allocation_snapshots USING btree (allocation_id, blobber_id);
+CREATE INDEX idx_alloc_snapshot_owner ON allocation_snapshots USING btree (owner);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE allocation_snapshots;
+-- +goose StatementEnd
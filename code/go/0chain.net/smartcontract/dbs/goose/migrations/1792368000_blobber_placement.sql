@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE blobbers ADD COLUMN region TEXT DEFAULT '';
+ALTER TABLE blobbers ADD COLUMN country TEXT DEFAULT '';
+ALTER TABLE blobbers ADD COLUMN jurisdiction TEXT DEFAULT '';
+ALTER TABLE blobbers ADD COLUMN operator TEXT DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE blobbers DROP COLUMN region;
+ALTER TABLE blobbers DROP COLUMN country;
+ALTER TABLE blobbers DROP COLUMN jurisdiction;
+ALTER TABLE blobbers DROP COLUMN operator;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE allocation_repairs (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    allocation_id text,
+    removed_blobber_id text,
+    added_blobber_id text,
+    reason text,
+    transaction_hash text,
+    round bigint
+);
+
+ALTER TABLE allocation_repairs OWNER TO zchain_user;
+
+CREATE INDEX idx_alloc_repair_allocation ON allocation_repairs USING btree (allocation_id);
+CREATE INDEX idx_alloc_repair_added_blobber ON allocation_repairs USING btree (added_blobber_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE allocation_repairs;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE metered_billings (
+    allocation_id text PRIMARY KEY,
+    owner text,
+    balance bigint,
+    low_balance_threshold bigint,
+    paid bigint,
+    exhausted_at bigint,
+    closed boolean
+);
+
+ALTER TABLE metered_billings OWNER TO zchain_user;
+
+CREATE INDEX idx_metered_billing_owner ON metered_billings USING btree (owner);
+
+CREATE TABLE metered_billing_alerts (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    allocation_id text,
+    owner text,
+    balance bigint,
+    low_balance_threshold bigint,
+    exhausted boolean,
+    round bigint
+);
+
+ALTER TABLE metered_billing_alerts OWNER TO zchain_user;
+
+CREATE INDEX idx_metered_alert_allocation ON metered_billing_alerts USING btree (allocation_id);
+CREATE INDEX idx_metered_alert_owner ON metered_billing_alerts USING btree (owner);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE metered_billing_alerts;
+DROP TABLE metered_billings;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE blobbers ADD COLUMN reputation DOUBLE PRECISION DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE blobbers DROP COLUMN reputation;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE read_markers ADD COLUMN read_sub_pool_id TEXT;
+ALTER TABLE read_markers ADD COLUMN cost BIGINT;
+CREATE INDEX idx_rsub_pool ON read_markers USING btree (read_sub_pool_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_rsub_pool;
+ALTER TABLE read_markers DROP COLUMN cost;
+ALTER TABLE read_markers DROP COLUMN read_sub_pool_id;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE challenge_disputes (
+    challenge_id text PRIMARY KEY,
+    allocation_id text,
+    blobber_id text,
+    status text,
+    escrow bigint,
+    deadline bigint,
+    round bigint
+);
+
+ALTER TABLE challenge_disputes OWNER TO zchain_user;
+
+CREATE INDEX idx_chall_dispute_allocation ON challenge_disputes USING btree (allocation_id);
+CREATE INDEX idx_chall_dispute_blobber ON challenge_disputes USING btree (blobber_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE challenge_disputes;
+-- +goose StatementEnd
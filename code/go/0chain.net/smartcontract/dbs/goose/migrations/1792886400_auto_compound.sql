@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE delegate_pools ADD COLUMN IF NOT EXISTS auto_compound boolean NOT NULL DEFAULT false;
+ALTER TABLE reward_delegates ADD COLUMN IF NOT EXISTS compounded bigint NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE delegate_pools DROP COLUMN auto_compound;
+ALTER TABLE reward_delegates DROP COLUMN compounded;
+-- +goose StatementEnd
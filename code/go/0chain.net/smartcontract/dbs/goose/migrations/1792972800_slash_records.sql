@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE slash_records (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    provider_id text,
+    provider_type bigint,
+    delegate_id text,
+    reason text,
+    evidence text,
+    fraction numeric,
+    amount bigint,
+    transaction_hash text,
+    round bigint
+);
+
+ALTER TABLE slash_records OWNER TO zchain_user;
+
+CREATE INDEX idx_slash_record_provider ON slash_records USING btree (provider_id);
+CREATE INDEX idx_slash_record_delegate ON slash_records USING btree (delegate_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE slash_records;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE miners ADD COLUMN IF NOT EXISTS jailed_until bigint NOT NULL DEFAULT 0;
+ALTER TABLE sharders ADD COLUMN IF NOT EXISTS jailed_until bigint NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE miners DROP COLUMN jailed_until;
+ALTER TABLE sharders DROP COLUMN jailed_until;
+-- +goose StatementEnd
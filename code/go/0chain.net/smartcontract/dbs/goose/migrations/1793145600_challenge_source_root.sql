@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE challenges ADD COLUMN IF NOT EXISTS source_allocation_root text;
+ALTER TABLE challenges ADD COLUMN IF NOT EXISTS source_timestamp bigint NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE challenges DROP COLUMN source_allocation_root;
+ALTER TABLE challenges DROP COLUMN source_timestamp;
+-- +goose StatementEnd
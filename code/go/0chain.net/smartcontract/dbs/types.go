@@ -50,6 +50,8 @@ type StakePoolReward struct {
 	DelegateRewards map[string]currency.Coin `json:"delegate_rewards"`
 	// penalties delegate pools
 	DelegatePenalties map[string]currency.Coin `json:"delegate_penalties"`
+	// part of the delegate pools rewards compounded into their balances
+	DelegateCompounds map[string]currency.Coin `json:"delegate_compounds"`
 	// allocation id
 	AllocationID string `json:"allocation_id"`
 
@@ -12,17 +12,18 @@ import (
 // The corresponding model is storagesc.StorageNode.
 type StorageDtoNode struct {
 	provider.Provider
-	BaseURL                 *string      `json:"url,omitempty"`
-	Terms                   *Terms       `json:"terms,omitempty"`
-	Capacity                *int64       `json:"capacity,omitempty"`
-	Allocated               *int64       `json:"allocated,omitempty"`
-	SavedData               *int64       `json:"saved_data,omitempty"`
-	DataReadLastRewardRound *float64     `json:"data_read_last_reward_round,omitempty"`
-	LastRewardDataReadRound *int64       `json:"last_reward_data_read_round,omitempty"`
-	StakePoolSettings       *Settings    `json:"stake_pool_settings,omitempty"`
-	RewardRound             *RewardRound `json:"reward_round,omitempty"`
-	NotAvailable            *bool        `json:"not_available,omitempty"`
-	IsRestricted            *bool        `json:"is_restricted,omitempty"`
+	BaseURL                 *string           `json:"url,omitempty"`
+	Terms                   *Terms            `json:"terms,omitempty"`
+	Capacity                *int64            `json:"capacity,omitempty"`
+	Allocated               *int64            `json:"allocated,omitempty"`
+	SavedData               *int64            `json:"saved_data,omitempty"`
+	DataReadLastRewardRound *float64          `json:"data_read_last_reward_round,omitempty"`
+	LastRewardDataReadRound *int64            `json:"last_reward_data_read_round,omitempty"`
+	StakePoolSettings       *Settings         `json:"stake_pool_settings,omitempty"`
+	RewardRound             *RewardRound      `json:"reward_round,omitempty"`
+	NotAvailable            *bool             `json:"not_available,omitempty"`
+	IsRestricted            *bool             `json:"is_restricted,omitempty"`
+	Placement               *BlobberPlacement `json:"placement,omitempty"`
 }
 
 type RewardRound struct {
@@ -40,3 +41,12 @@ type ResetBlobberStatsDto struct {
 	PrevTotalOffers currency.Coin `json:"prev_total_offers"`
 	NewTotalOffers  currency.Coin `json:"new_total_offers"`
 }
+
+// BlobberPlacement is the location and the operator of a blobber, used by the
+// placement constraints of the allocations.
+type BlobberPlacement struct {
+	Region       string `json:"region,omitempty"`
+	Country      string `json:"country,omitempty"`
+	Jurisdiction string `json:"jurisdiction,omitempty"`
+	Operator     string `json:"operator,omitempty"`
+}
@@ -157,6 +157,21 @@ func BenchmarkRestTests(
 				},
 				Endpoint: mrh.getDelegateRewards,
 			},
+			{
+				FuncName: "unbonding-entries",
+				Params: map[string]string{
+					"provider_id":   data.Miners[0],
+					"provider_type": strconv.Itoa(int(spenum.Miner)),
+				},
+				Endpoint: mrh.getUnbondingEntries,
+			},
+			{
+				FuncName: "provider-slashes",
+				Params: map[string]string{
+					"provider_id": data.Miners[0],
+				},
+				Endpoint: mrh.getProviderSlashes,
+			},
 		},
 		ADDRESS,
 		mrh,
@@ -71,6 +71,10 @@ func AddMockMiners(
 		}
 		newNode.ProviderType = providerType
 		newNode.LastHealthCheck = common.Timestamp(viper.GetInt64(benchmark.MptCreationTime))
+		if i == 1 {
+			// jailed, for the unjail benchmarks
+			newNode.JailedUntil = newNode.LastHealthCheck
+		}
 		newNode.Settings.ServiceChargeRatio = viper.GetFloat64(benchmark.MinerMaxCharge)
 		newNode.Settings.MaxNumDelegates = viper.GetInt(benchmark.MinerMaxDelegates)
 		newNode.NodeType = NodeTypeMiner
@@ -233,6 +237,10 @@ func AddMockSharders(
 		}
 		newNode.ProviderType = providerType
 		newNode.LastHealthCheck = common.Timestamp(viper.GetInt64(benchmark.MptCreationTime))
+		if i == 1 {
+			// jailed, for the unjail benchmarks
+			newNode.JailedUntil = newNode.LastHealthCheck
+		}
 		newNode.Settings.ServiceChargeRatio = viper.GetFloat64(benchmark.MinerMaxCharge)
 		newNode.Settings.MaxNumDelegates = viper.GetInt(benchmark.MinerMaxDelegates)
 		newNode.NodeType = NodeTypeMiner
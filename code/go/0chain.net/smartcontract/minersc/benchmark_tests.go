@@ -211,6 +211,28 @@ func BenchmarkTests(
 				CreationDate: creationTime,
 			},
 		},
+		{
+			name: "miner.unjail_miner",
+			input: (&provider.ProviderRequest{
+				ID: data.Miners[1],
+			}).Encode(),
+			endpoint: msc.unjailMiner,
+			txn: &transaction.Transaction{
+				ClientID:     data.Clients[0],
+				CreationDate: creationTime,
+			},
+		},
+		{
+			name: "miner.unjail_sharder",
+			input: (&provider.ProviderRequest{
+				ID: data.Sharders[1],
+			}).Encode(),
+			endpoint: msc.unjailSharder,
+			txn: &transaction.Transaction{
+				ClientID:     data.Clients[0],
+				CreationDate: creationTime,
+			},
+		},
 		{
 			name:     "miner.contributeMpk",
 			endpoint: msc.contributeMpk,
@@ -323,6 +345,12 @@ func BenchmarkTests(
 					"cost.sharder_keep":                            "111",
 					"cost.kill_miner":                              "111",
 					"cost.kill_sharder":                            "111",
+					"cost.stake_pool_redelegate":                   "111",
+					"cost.stake_pool_auto_compound":                "111",
+					"cost.stake_pool_unbond":                       "111",
+					"cost.stake_pool_claim_unbonded":               "111",
+					"cost.unjail_miner":                            "111",
+					"cost.unjail_sharder":                          "111",
 				},
 			}).Encode(),
 		},
@@ -402,6 +430,62 @@ func BenchmarkTests(
 				ProviderID:   data.Miners[0],
 			}).Encode(),
 		},
+		{
+			name:     "miner.stake_pool_redelegate",
+			endpoint: msc.stakePoolRedelegate,
+			txn: &transaction.Transaction{
+				ClientID:     getMinerDelegatePoolId(0, 0, data.Clients),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.StakePoolRedelegateRequest{
+				FromProviderType: spenum.Miner,
+				FromProviderID:   data.Miners[0],
+				ToProviderType:   spenum.Miner,
+				ToProviderID:     data.Miners[1],
+			}).Encode(),
+		},
+		{
+			name:     "miner.stake_pool_auto_compound",
+			endpoint: msc.stakePoolAutoCompound,
+			txn: &transaction.Transaction{
+				ClientID:     getMinerDelegatePoolId(0, 0, data.Clients),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.StakePoolAutoCompoundRequest{
+				ProviderType: spenum.Miner,
+				ProviderID:   data.Miners[0],
+				AutoCompound: true,
+			}).Encode(),
+		},
+		{
+			name:     "miner.stake_pool_unbond",
+			endpoint: msc.stakePoolUnbond,
+			txn: &transaction.Transaction{
+				ClientID:     getMinerDelegatePoolId(0, 0, data.Clients),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.StakePoolUnbondRequest{
+				ProviderType: spenum.Miner,
+				ProviderID:   data.Miners[0],
+				Amount:       1,
+			}).Encode(),
+		},
+		{
+			name:     "miner.stake_pool_claim_unbonded",
+			endpoint: msc.stakePoolClaimUnbonded,
+			txn: &transaction.Transaction{
+				ClientID:     getMinerDelegatePoolId(0, 0, data.Clients),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.StakePoolRequest{
+				ProviderType: spenum.Miner,
+				ProviderID:   data.Miners[0],
+			}).Encode(),
+		},
 		{
 			name:     "miner.sharder_keep",
 			endpoint: msc.sharderKeep,
@@ -7,9 +7,21 @@ import (
 	"0chain.net/core/datastore"
 	"0chain.net/smartcontract/stakepool"
 	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
 	"github.com/0chain/common/core/util"
 )
 
+func init() {
+	// the rewards of miners and sharders compound up to the max stake
+	stakepool.RegisterMaxStake(func(balances cstate.CommonStateContextI) (currency.Coin, error) {
+		gn, err := getGlobalNode(balances)
+		if err != nil {
+			return 0, err
+		}
+		return gn.MaxStake, nil
+	}, spenum.Miner, spenum.Sharder)
+}
+
 func (msc *MinerSmartContract) addToDelegatePool(t *transaction.Transaction,
 	input []byte, gn *GlobalNode, balances cstate.StateContextI) (
 	string, error) {
@@ -57,6 +69,37 @@ func (msc *MinerSmartContract) deleteFromDelegatePool(
 	return stakepool.StakePoolUnlock(t, inputData, balances, msc.getStakePoolAdapter, msc.refreshProvider)
 }
 
+// stakePoolRedelegate moves a delegate pool between two miners or sharders at once
+func (msc *MinerSmartContract) stakePoolRedelegate(
+	t *transaction.Transaction, inputData []byte, gn *GlobalNode,
+	balances cstate.StateContextI) (string, error) {
+	return stakepool.StakePoolRedelegate(t, inputData, balances,
+		stakepool.ValidationSettings{MaxStake: gn.MaxStake, MinStake: gn.MinStake, MaxNumDelegates: gn.MaxDelegates},
+		gn.MinRedelegatePeriod, msc.getStakePoolAdapter, msc.refreshProvider)
+}
+
+// stakePoolAutoCompound turns the auto-compounding of the rewards of a delegate pool on or off
+func (msc *MinerSmartContract) stakePoolAutoCompound(
+	t *transaction.Transaction, inputData []byte, gn *GlobalNode,
+	balances cstate.StateContextI) (string, error) {
+	return stakepool.StakePoolAutoCompound(t, inputData, balances, msc.getStakePoolAdapter)
+}
+
+// stakePoolUnbond unstakes a part of a delegate pool into an unbonding entry
+func (msc *MinerSmartContract) stakePoolUnbond(
+	t *transaction.Transaction, inputData []byte, gn *GlobalNode,
+	balances cstate.StateContextI) (string, error) {
+	return stakepool.StakePoolUnbond(t, inputData, balances, gn.UnbondingPeriod,
+		msc.getStakePoolAdapter, msc.refreshProvider)
+}
+
+// stakePoolClaimUnbonded pays the matured unbonding entries of a delegate pool out
+func (msc *MinerSmartContract) stakePoolClaimUnbonded(
+	t *transaction.Transaction, inputData []byte, _ *GlobalNode,
+	balances cstate.StateContextI) (string, error) {
+	return stakepool.StakePoolClaimUnbonded(t, inputData, balances, msc.getStakePoolAdapter)
+}
+
 // getStakePool of given blobber
 func (msc *MinerSmartContract) refreshProvider(
 	providerType spenum.Provider, providerID string, balances cstate.StateContextI,
@@ -104,7 +147,6 @@ func (msc *MinerSmartContract) refreshProvider(
 	return nil, nil
 }
 
-//nolint:unused
 func getStakePool(providerType spenum.Provider, providerID datastore.Key, balances cstate.CommonStateContextI) (
 	sp *stakepool.StakePool, err error) {
 	sp = stakepool.NewStakePool()
@@ -326,6 +326,9 @@ func (msc *MinerSmartContract) createDKGMinersForContribute(
 		return err
 	}
 
+	// jailed miners are left out of the next magic block
+	allMinersList.Nodes = filterJailedNodes(allMinersList.Nodes, gn.MinN)
+
 	if len(allMinersList.Nodes) < gn.MinN {
 		return common.NewErrorf("failed to create dkg miners", "too few miners for dkg, l_all_miners: %d, N: %d", len(allMinersList.Nodes), gn.MinN)
 	}
@@ -420,6 +423,9 @@ func (msc *MinerSmartContract) reduceShardersList(
 			return nil, common.NewErrorf("invalid state", "a sharder exists in"+
 				" keep list doesn't exists in all sharders list: %s", keepNode.ID)
 		}
+		if found.IsJailed() {
+			continue
+		}
 		tmpMinerNodes = append(tmpMinerNodes, found)
 		simpleNodes[found.ID] = found.SimpleNode
 	}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"sort"
 
 	"0chain.net/core/config"
 	"github.com/0chain/common/core/currency"
@@ -342,6 +343,11 @@ func (msc *MinerSmartContract) payFees(t *transaction.Transaction,
 		); err != nil {
 			return "", err
 		}
+
+		// the auto-compounded rewards are staked
+		if mn.TotalStaked, err = mn.TotalStake(); err != nil {
+			return "", err
+		}
 	}
 
 	shardersIDs, err := getLiveSharderIds(balances)
@@ -409,6 +415,9 @@ func (msc *MinerSmartContract) payFees(t *transaction.Transaction,
 			if err = msc.viewChangeDeleteNodes(balances); err != nil {
 				return "", err
 			}
+			if err = jailStaleNodes(t.CreationDate, gn, balances); err != nil {
+				return "", common.NewErrorf("pay_fees", "jailing stale nodes: %v", err)
+			}
 		} else {
 			return "", common.NewError("pay_fees", "cannot find latest magic bock")
 		}
@@ -424,8 +433,8 @@ func (msc *MinerSmartContract) payFees(t *transaction.Transaction,
 }
 
 // getRewardedMiner
-// if there is a valid un-killed block miner use that
-// otherwise select a random un-killed miner.
+// if there is a valid un-killed and un-jailed block miner use that
+// otherwise select a random un-killed and un-jailed miner.
 func getRewardedMiner(bk *block.Block, balances cstate.CommonStateContextI) (*MinerNode, error) {
 	mn, err := getMinerNode(bk.MinerID, balances)
 	if err != nil {
@@ -434,7 +443,7 @@ func getRewardedMiner(bk *block.Block, balances cstate.CommonStateContextI) (*Mi
 			zap.String("block miner id", bk.MinerID),
 			zap.Error(err))
 	} else {
-		if !mn.HasBeenKilled {
+		if !mn.HasBeenKilled && !mn.IsJailed() {
 			return mn, nil
 		}
 	}
@@ -454,13 +463,37 @@ func getRewardedMiner(bk *block.Block, balances cstate.CommonStateContextI) (*Mi
 func filterDeadNodes(nodes []*MinerNode) []*MinerNode {
 	var filteredNodes []*MinerNode
 	for _, node := range nodes {
-		if !node.SimpleNode.HasBeenKilled {
+		if !node.SimpleNode.HasBeenKilled && !node.IsJailed() {
 			filteredNodes = append(filteredNodes, node)
 		}
 	}
 	return filteredNodes
 }
 
+// filterJailedNodes leaves the jailed nodes out, unless fewer than minNodes
+// nodes would be left: the jailed ones with the latest health checks are kept then
+func filterJailedNodes(nodes []*MinerNode, minNodes int) []*MinerNode {
+	var filteredNodes, jailed []*MinerNode
+	for _, node := range nodes {
+		if node.IsJailed() {
+			jailed = append(jailed, node)
+			continue
+		}
+		filteredNodes = append(filteredNodes, node)
+	}
+
+	sort.SliceStable(jailed, func(i, j int) bool {
+		return jailed[i].LastHealthCheck > jailed[j].LastHealthCheck
+	})
+	for _, node := range jailed {
+		if len(filteredNodes) >= minNodes {
+			break
+		}
+		filteredNodes = append(filteredNodes, node)
+	}
+	return filteredNodes
+}
+
 func getLiveSharderIds(balances cstate.StateContextI) ([]string, error) {
 	nodes, err := getAllShardersList(balances)
 	if err != nil {
@@ -468,7 +501,7 @@ func getLiveSharderIds(balances cstate.StateContextI) ([]string, error) {
 	}
 	var ids []string
 	for i := range nodes.Nodes {
-		if !nodes.Nodes[i].SimpleNode.HasBeenKilled {
+		if !nodes.Nodes[i].SimpleNode.HasBeenKilled && !nodes.Nodes[i].IsJailed() {
 			ids = append(ids, nodes.Nodes[i].ID)
 		}
 	}
@@ -583,7 +616,9 @@ func (msc *MinerSmartContract) payShardersAndDelegates(
 				"distributing rewards: %v", err)
 		}
 
-		return nil
+		// the auto-compounded rewards are staked
+		sh.TotalStaked, err = sh.TotalStake()
+		return err
 	}
 
 	for i := range rewardSharders {
@@ -63,6 +63,8 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 		rest.MakeEndpoint(miner+"/hardfork", common.UserRateLimit(mrh.getHardfork)),
 		rest.MakeEndpoint(miner+"/provider-rewards", common.UserRateLimit(mrh.getProviderRewards)),
 		rest.MakeEndpoint(miner+"/delegate-rewards", common.UserRateLimit(mrh.getDelegateRewards)),
+		rest.MakeEndpoint(miner+"/unbonding-entries", common.UserRateLimit(mrh.getUnbondingEntries)),
+		rest.MakeEndpoint(miner+"/provider-slashes", common.UserRateLimit(mrh.getProviderSlashes)),
 
 		//test endpoints
 		rest.MakeEndpoint("/test/screst/nodeStat", common.UserRateLimit(mrh.testNodeStat)),
@@ -129,6 +131,117 @@ func (mrh *MinerRestHandler) getDelegateRewards(w http.ResponseWriter, r *http.R
 	common.Respond(w, r, rtv, nil)
 }
 
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d9/unbonding-entries miner-sc GetUnbondingEntries
+// Get unbonding entries.
+// Retrieve the pending unbonding entries of the delegate pools of a miner or a sharder, per delegate,
+// with the amount and the round they can be claimed at.
+//
+// parameters:
+//
+//	 +name: provider_id
+//	  description: id of a provider
+//	  required: true
+//	  in: query
+//	  type: string
+//	 +name: provider_type
+//	  description: type of the provider, possible values are 1 (miner), 2 (sharder)
+//	  required: true
+//	  in: query
+//	  type: string
+//	 +name: client_id
+//	  description: delegate to get the unbonding entries of, all the delegates if not set
+//	  in: query
+//	  type: string
+//
+// responses:
+//
+//	200: map[string][]UnbondingEntry
+//	400:
+func (mrh *MinerRestHandler) getUnbondingEntries(w http.ResponseWriter, r *http.Request) {
+	var (
+		providerID = r.URL.Query().Get("provider_id")
+		clientID   = r.URL.Query().Get("client_id")
+	)
+	providerType, err := strconv.Atoi(r.URL.Query().Get("provider_type"))
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrBadRequest("invalid provider_type: "+err.Error()))
+		return
+	}
+
+	sp, err := getStakePool(spenum.Provider(providerType), providerID, mrh.GetQueryStateContext())
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrBadRequest("can't get stake pool: "+err.Error()))
+		return
+	}
+
+	entries := make(map[string][]*stakepool.UnbondingEntry)
+	for id, dp := range sp.Pools {
+		if len(dp.Unbonding) == 0 || (clientID != "" && id != clientID) {
+			continue
+		}
+		entries[id] = dp.Unbonding
+	}
+	common.Respond(w, r, entries, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d9/provider-slashes miner-sc GetMinerProviderSlashes
+// Get miner or sharder slashes.
+//
+// Gets the slashing history of a miner or sharder, one record per delegate pool slashed with the reason,
+// the evidence, the fraction slashed and the amount the delegate lost. Supports pagination.
+//
+// parameters:
+//
+//	+name: provider_id
+//	 description: miner or sharder ID
+//	 required: true
+//	 in: query
+//	 type: string
+//	+name: offset
+//	 description: offset
+//	 in: query
+//	 type: string
+//	+name: limit
+//	 description: limit
+//	 in: query
+//	 type: string
+//	+name: sort
+//	 description: desc or asc
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []SlashRecord
+//	400:
+//	500:
+func (mrh *MinerRestHandler) getProviderSlashes(w http.ResponseWriter, r *http.Request) {
+	providerID := r.URL.Query().Get("provider_id")
+	if providerID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing provider_id"))
+		return
+	}
+
+	limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := mrh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+
+	slashes, err := edb.GetProviderSlashRecords(providerID, limit)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get provider slashes", err.Error()))
+		return
+	}
+	common.Respond(w, r, slashes, nil)
+}
+
 // swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d9/provider-rewards miner-sc GetProviderRewards
 // Get provider rewards.
 // Retrieve list of provider rewards satisfying filter, supports pagination.
@@ -284,6 +397,7 @@ func (mrh *MinerRestHandler) getNodePoolStat(w http.ResponseWriter, r *http.Requ
 			RoundCreated: dp.RoundCreated,
 			DelegateID:   dp.DelegateID,
 			StakedAt:     common.Timestamp(dp.CreatedAt.Unix()),
+			AutoCompound: dp.AutoCompound,
 		},
 	}
 
@@ -949,6 +1063,7 @@ func toUPS(pool event.DelegatePool) storagesc.DelegatePoolStat {
 	dp.ProviderType = pool.ProviderType
 	dp.ProviderId = pool.ProviderID
 	dp.StakedAt = pool.StakedAt
+	dp.AutoCompound = pool.AutoCompound
 
 	return dp
 }
@@ -0,0 +1,201 @@
+package minersc
+
+import (
+	"fmt"
+	"sort"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/provider"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+)
+
+// jailHardFork is the hard fork enabling the jailing of the nodes, the nodes
+// are encoded as before until then
+const jailHardFork = "hermes"
+
+// unjailMiner
+// a jailed miner is unjailed by its delegate wallet once the jail period is over
+func (_ *MinerSmartContract) unjailMiner(
+	txn *transaction.Transaction,
+	input []byte,
+	gn *GlobalNode,
+	balances cstate.StateContextI,
+) (resp string, err error) {
+	if err := unjail(input, txn, gn, getMinerNode, emitUpdateMiner, balances); err != nil {
+		return "", common.NewError("unjail_miner_failed", err.Error())
+	}
+	return "", nil
+}
+
+// unjailSharder
+// a jailed sharder is unjailed by its delegate wallet once the jail period is over
+func (_ *MinerSmartContract) unjailSharder(
+	txn *transaction.Transaction,
+	input []byte,
+	gn *GlobalNode,
+	balances cstate.StateContextI,
+) (resp string, err error) {
+	if err := unjail(input, txn, gn, getSharderNode, emitUpdateSharder, balances); err != nil {
+		return "", common.NewError("unjail_sharder_failed", err.Error())
+	}
+	return "", nil
+}
+
+// unjail
+// unjails a miner or sharder, the node must be sending health checks again
+// or it would be jailed back at once.
+func unjail(
+	input []byte,
+	txn *transaction.Transaction,
+	gn *GlobalNode,
+	getNode func(string, cstate.CommonStateContextI) (*MinerNode, error),
+	emitUpdate func(*MinerNode, cstate.StateContextI, bool) error,
+	balances cstate.StateContextI,
+) error {
+	var req provider.ProviderRequest
+	if err := req.Decode(input); err != nil {
+		return err
+	}
+
+	node, err := getNode(req.ID, balances)
+	if err != nil {
+		return err
+	}
+
+	if err := smartcontractinterface.AuthorizeWithOwner("only the delegate wallet can unjail a provider", func() bool {
+		return node.Settings.DelegateWallet == txn.ClientID
+	}); err != nil {
+		return err
+	}
+
+	if node.SimpleNode.HasBeenKilled {
+		return fmt.Errorf("%s is killed", req.ID)
+	}
+	if !node.IsJailed() {
+		return fmt.Errorf("%s is not jailed", req.ID)
+	}
+	if txn.CreationDate < node.JailedUntil {
+		return fmt.Errorf("%s is jailed until %v", req.ID, node.JailedUntil)
+	}
+	if gn.isHealthCheckStale(node.SimpleNode, txn.CreationDate) {
+		return fmt.Errorf("%s has no health check since %v", req.ID, node.LastHealthCheck)
+	}
+
+	node.JailedUntil = 0
+	if err := node.save(balances); err != nil {
+		return err
+	}
+	return emitUpdate(node, balances, false)
+}
+
+// isHealthCheckStale reports whether the last health check of the node is
+// older than the staleness allowed, a node without any health check is stale
+func (gn *GlobalNode) isHealthCheckStale(sn *SimpleNode, now common.Timestamp) bool {
+	if gn.HealthCheckStaleness <= 0 {
+		return false
+	}
+	if sn.LastHealthCheck == 0 {
+		return true
+	}
+	return common.ToTime(now).Sub(common.ToTime(sn.LastHealthCheck)) > gn.HealthCheckStaleness
+}
+
+// jailStaleNodes jails the miners and sharders whose last health check is
+// stale and slashes their stake, a jailed node is left out of the next magic
+// block and of the rewards. At least gn.MinN miners and gn.MinS sharders are
+// left unjailed.
+func jailStaleNodes(now common.Timestamp, gn *GlobalNode, balances cstate.StateContextI) error {
+	if gn.HealthCheckStaleness <= 0 {
+		return nil
+	}
+
+	return cstate.WithActivation(balances, jailHardFork, func() error {
+		return nil
+	}, func() error {
+		return jailStaleMinersAndSharders(now, gn, balances)
+	})
+}
+
+func jailStaleMinersAndSharders(now common.Timestamp, gn *GlobalNode, balances cstate.StateContextI) error {
+	miners, err := getMinersList(balances)
+	if err != nil {
+		return fmt.Errorf("getting all miners list: %v", err)
+	}
+	if err := jailStale(miners, gn.MinN, now, gn, emitUpdateMiner, balances); err != nil {
+		return fmt.Errorf("jailing miners: %v", err)
+	}
+
+	sharders, err := getAllShardersList(balances)
+	if err != nil {
+		return fmt.Errorf("getting all sharders list: %v", err)
+	}
+	if err := jailStale(sharders, gn.MinS, now, gn, emitUpdateSharder, balances); err != nil {
+		return fmt.Errorf("jailing sharders: %v", err)
+	}
+	return nil
+}
+
+// jailStale jails the stalest nodes first, as long as more than minNodes
+// nodes are left unjailed
+func jailStale(
+	nodes *MinerNodes,
+	minNodes int,
+	now common.Timestamp,
+	gn *GlobalNode,
+	emitUpdate func(*MinerNode, cstate.StateContextI, bool) error,
+	balances cstate.StateContextI,
+) error {
+	var (
+		live  int
+		stale []*MinerNode
+	)
+	for _, node := range nodes.Nodes {
+		if node.SimpleNode.HasBeenKilled || node.Delete || node.IsJailed() {
+			continue
+		}
+		live++
+		if gn.isHealthCheckStale(node.SimpleNode, now) {
+			stale = append(stale, node)
+		}
+	}
+
+	sort.SliceStable(stale, func(i, j int) bool {
+		return stale[i].LastHealthCheck < stale[j].LastHealthCheck
+	})
+	for _, node := range stale {
+		if live <= minNodes {
+			logging.Logger.Warn("too few nodes left to jail the stale ones",
+				zap.Int("live", live),
+				zap.Int("min", minNodes),
+				zap.Int("stale", len(stale)))
+			return nil
+		}
+		live--
+
+		node.JailedUntil = common.Timestamp(common.ToTime(now).Add(gn.JailPeriod).Unix())
+		logging.Logger.Info("jailing node with stale health check",
+			zap.String("id", node.ID),
+			zap.Int64("last_health_check", int64(node.LastHealthCheck)),
+			zap.Int64("jailed_until", int64(node.JailedUntil)))
+
+		// the missed health check is the evidence of the slash
+		if err := node.StakePool.SlashFraction(gn.JailSlash, node.ID, node.ProviderType,
+			spenum.SlashHealthCheckMissed, fmt.Sprintf("last_health_check:%d", node.LastHealthCheck),
+			balances); err != nil {
+			return fmt.Errorf("slashing %s: %v", node.ID, err)
+		}
+
+		if err := node.save(balances); err != nil {
+			return err
+		}
+		if err := emitUpdate(node, balances, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
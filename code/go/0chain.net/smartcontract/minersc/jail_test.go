@@ -0,0 +1,244 @@
+package minersc
+
+import (
+	"testing"
+	"time"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/provider"
+	"github.com/stretchr/testify/require"
+)
+
+func newJailTestEnv(t *testing.T, hardFork bool) (*testBalances, *MinerSmartContract, *GlobalNode) {
+	balances := newTestBalances()
+	if hardFork {
+		h := cstate.NewHardFork(jailHardFork, 0)
+		_, err := balances.InsertTrieNode(h.GetKey(), h)
+		require.NoError(t, err)
+	}
+
+	gn := setConfig(t, balances)
+	gn.HealthCheckStaleness = time.Hour
+	gn.JailPeriod = 24 * time.Hour
+	return balances, newTestMinerSC(), gn
+}
+
+func TestJail(t *testing.T) {
+	const (
+		now  = int64(100)
+		hour = int64(time.Hour / time.Second)
+	)
+
+	tests := []struct {
+		name       string
+		minN, minS int
+		noHardFork bool
+		// healthChecks are the last health checks of the miners, zero for none
+		healthChecks []int64
+		at           int64
+		wantJailed   []bool
+		// wantSharderJailed is for a sharder registered at now
+		wantSharderJailed bool
+	}{
+		{
+			name:         "health check not stale yet",
+			healthChecks: []int64{now},
+			at:           now + hour,
+			wantJailed:   []bool{false},
+		},
+		{
+			name:              "stale nodes jailed",
+			healthChecks:      []int64{now},
+			at:                now + hour + 1,
+			wantJailed:        []bool{true},
+			wantSharderJailed: true,
+		},
+		{
+			name:         "no health check",
+			healthChecks: []int64{0, now},
+			at:           now + 1,
+			wantJailed:   []bool{true, false},
+		},
+		{
+			name:              "min miners left unjailed",
+			minN:              2,
+			healthChecks:      []int64{now + 1, now, now + hour},
+			at:                now + hour + 2,
+			wantJailed:        []bool{false, true, false},
+			wantSharderJailed: true,
+		},
+		{
+			name:              "min miners live already",
+			minN:              1,
+			healthChecks:      []int64{now},
+			at:                now + hour + 1,
+			wantJailed:        []bool{false},
+			wantSharderJailed: true,
+		},
+		{
+			name:              "min sharders left unjailed",
+			minS:              1,
+			healthChecks:      []int64{now},
+			at:                now + hour + 1,
+			wantJailed:        []bool{true},
+			wantSharderJailed: false,
+		},
+		{
+			name:         "not active before the hard fork",
+			noHardFork:   true,
+			healthChecks: []int64{now},
+			at:           now + hour + 1,
+			wantJailed:   []bool{false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			balances, msc, gn := newJailTestEnv(t, !tt.noHardFork)
+			gn.MinN, gn.MinS = tt.minN, tt.minS
+
+			var ids []string
+			for _, hc := range tt.healthChecks {
+				m, err := addMiner(t, msc, now, true, balances)
+				require.NoError(t, err)
+				mn, err := getMinerNode(m.miner.id, balances)
+				require.NoError(t, err)
+				mn.LastHealthCheck = common.Timestamp(hc)
+				require.NoError(t, mn.save(balances))
+				ids = append(ids, m.miner.id)
+			}
+			s, err := addSharder(t, msc, now, true, balances)
+			require.NoError(t, err)
+
+			require.NoError(t, jailStaleNodes(common.Timestamp(tt.at), gn, balances))
+
+			for i, id := range ids {
+				mn, err := getMinerNode(id, balances)
+				require.NoError(t, err)
+				require.Equal(t, tt.wantJailed[i], mn.IsJailed(), "miner %d", i)
+				if tt.wantJailed[i] {
+					require.EqualValues(t, tt.at+24*hour, mn.JailedUntil)
+					require.Empty(t, filterDeadNodes([]*MinerNode{mn}), "a jailed miner earns no rewards")
+				}
+			}
+
+			sn, err := getSharderNode(s.sharder.id, balances)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantSharderJailed, sn.IsJailed())
+			live, err := getLiveSharderIds(balances)
+			require.NoError(t, err)
+			require.Equal(t, !tt.wantSharderJailed, len(live) == 1)
+		})
+	}
+}
+
+func TestFilterJailedNodes(t *testing.T) {
+	node := func(id string, lastHealthCheck, jailedUntil common.Timestamp) *MinerNode {
+		mn := NewMinerNode()
+		mn.ID = id
+		mn.LastHealthCheck = lastHealthCheck
+		mn.JailedUntil = jailedUntil
+		return mn
+	}
+	nodes := []*MinerNode{
+		node("jailed_earlier", 10, 100),
+		node("live", 30, 0),
+		node("jailed_later", 20, 100),
+	}
+
+	tests := []struct {
+		name     string
+		minNodes int
+		want     []string
+	}{
+		{name: "jailed left out", minNodes: 1, want: []string{"live"}},
+		{name: "latest health check kept for the min", minNodes: 2, want: []string{"live", "jailed_later"}},
+		{name: "all kept for the min", minNodes: 4, want: []string{"live", "jailed_later", "jailed_earlier"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []string
+			for _, mn := range filterJailedNodes(nodes, tt.minNodes) {
+				ids = append(ids, mn.ID)
+			}
+			require.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestUnjail(t *testing.T) {
+	const (
+		now  = int64(100)
+		hour = int64(time.Hour / time.Second)
+	)
+
+	tests := []struct {
+		name string
+		// notDelegate unjails by the miner instead of its delegate wallet
+		notDelegate bool
+		// early unjails a second before the jail period is over
+		early       bool
+		healthCheck bool
+		unjailed    bool
+		wantErr     string
+	}{
+		{name: "ok", healthCheck: true},
+		{name: "not the delegate wallet", notDelegate: true, healthCheck: true, wantErr: "only the delegate wallet"},
+		{name: "jail period not over", early: true, healthCheck: true, wantErr: "jailed until"},
+		{name: "no health check since", wantErr: "no health check since"},
+		{name: "not jailed", healthCheck: true, unjailed: true, wantErr: "not jailed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			balances, msc, gn := newJailTestEnv(t, true)
+			gn.MinN, gn.MinS = 0, 0
+			m, err := addMiner(t, msc, now, true, balances)
+			require.NoError(t, err)
+
+			minerNode := func() *MinerNode {
+				mn, err := getMinerNode(m.miner.id, balances)
+				require.NoError(t, err)
+				return mn
+			}
+			unjail := func(clientID string, at int64) error {
+				tx := newTransaction(clientID, ADDRESS, 0, at)
+				balances.txn = tx
+				_, err := msc.unjailMiner(tx, mustEncode(&provider.ProviderRequest{ID: m.miner.id}), gn, balances)
+				return err
+			}
+
+			require.NoError(t, jailStaleNodes(common.Timestamp(now+hour+1), gn, balances))
+			jailedUntil := int64(minerNode().JailedUntil)
+			require.NotZero(t, jailedUntil)
+
+			if tt.healthCheck {
+				tx := newTransaction(m.miner.id, ADDRESS, 0, jailedUntil)
+				balances.txn = tx
+				_, err = msc.minerHealthCheck(tx, nil, gn, balances)
+				require.NoError(t, err)
+				require.True(t, minerNode().IsJailed(), "a health check does not unjail the node")
+			}
+			if tt.unjailed {
+				require.NoError(t, unjail(m.delegate.id, jailedUntil))
+			}
+
+			clientID, at := m.delegate.id, jailedUntil
+			if tt.notDelegate {
+				clientID = m.miner.id
+			}
+			if tt.early {
+				at--
+			}
+			err = unjail(clientID, at)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, minerNode().IsJailed())
+		})
+	}
+}
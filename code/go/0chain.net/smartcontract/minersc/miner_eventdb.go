@@ -28,6 +28,7 @@ type SimpleNodeResponse struct {
 	LastSettingUpdateRound        int64            `json:"last_setting_update_round"`
 	RoundServiceChargeLastUpdated int64            `json:"round_service_charge_last_updated"`
 	IsKilled                      bool             `json:"is_killed"`
+	JailedUntil                   common.Timestamp `json:"jailed_until,omitempty"`
 }
 
 type DelegatePoolResponse struct {
@@ -68,6 +69,7 @@ func minerTableToMinerNode(edbMiner event.Miner, delegates []event.DelegatePool)
 		Status:                        status,
 		RoundServiceChargeLastUpdated: edbMiner.Rewards.RoundServiceChargeLastUpdated,
 		IsKilled:                      edbMiner.IsKilled,
+		JailedUntil:                   edbMiner.JailedUntil,
 	}
 
 	mn := NodeResponse{
@@ -127,6 +129,7 @@ func minerNodeToMinerTable(mn *MinerNode, round int64) event.Miner {
 		},
 
 		Active:        mn.Status == node.NodeStatusActive,
+		JailedUntil:   mn.JailedUntil,
 		CreationRound: round,
 	}
 }
@@ -166,6 +169,7 @@ func emitUpdateMiner(mn *MinerNode, balances cstate.StateContextI, updateStatus
 			"service_charge":    mn.Settings.ServiceChargeRatio,
 			"num_delegates":     mn.Settings.MaxNumDelegates,
 			"last_health_check": mn.LastHealthCheck,
+			"jailed_until":      mn.JailedUntil,
 		},
 	}
 
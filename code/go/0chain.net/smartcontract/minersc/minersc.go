@@ -42,6 +42,8 @@ func (msc *MinerSmartContract) InitSmartContractFunctions() {
 
 	msc.smartContractFunctions["kill_miner"] = msc.killMiner
 	msc.smartContractFunctions["kill_sharder"] = msc.killSharder
+	msc.smartContractFunctions["unjail_miner"] = msc.unjailMiner
+	msc.smartContractFunctions["unjail_sharder"] = msc.unjailSharder
 
 	msc.smartContractFunctions["miner_health_check"] = msc.minerHealthCheck
 	msc.smartContractFunctions["sharder_health_check"] = msc.sharderHealthCheck
@@ -58,6 +60,10 @@ func (msc *MinerSmartContract) InitSmartContractFunctions() {
 
 	msc.smartContractFunctions["addToDelegatePool"] = msc.addToDelegatePool
 	msc.smartContractFunctions["deleteFromDelegatePool"] = msc.deleteFromDelegatePool
+	msc.smartContractFunctions["stake_pool_redelegate"] = msc.stakePoolRedelegate
+	msc.smartContractFunctions["stake_pool_auto_compound"] = msc.stakePoolAutoCompound
+	msc.smartContractFunctions["stake_pool_unbond"] = msc.stakePoolUnbond
+	msc.smartContractFunctions["stake_pool_claim_unbonded"] = msc.stakePoolClaimUnbonded
 
 	msc.smartContractFunctions["sharder_keep"] = msc.sharderKeep
 	msc.smartContractFunctions["add_hardfork"] = msc.addHardFork
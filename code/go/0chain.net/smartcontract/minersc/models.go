@@ -36,6 +36,9 @@ import (
 
 //go:generate msgp -io=false -tests=false -v
 
+// the timestamps are encoded as int64, for msgp to leave the zero ones out
+//msgp:shim common.Timestamp as:int64 using:int64/common.Timestamp mode:cast
+
 var validate *validator.Validate
 
 func init() {
@@ -229,6 +232,17 @@ type GlobalNode struct {
 	MinStake            currency.Coin `json:"min_stake"`
 	MinStakePerDelegate currency.Coin `json:"min_stake_per_delegate"`
 	HealthCheckPeriod   time.Duration `json:"health_check_period"`
+	// MinRedelegatePeriod is the minimal time between two redelegations of a delegate.
+	MinRedelegatePeriod time.Duration `json:"min_redelegate_period"`
+	// UnbondingPeriod is the number of rounds before unbonded tokens can be claimed.
+	UnbondingPeriod int64 `json:"unbonding_period"`
+	// HealthCheckStaleness is the time without a health check after which
+	// a node is jailed, zero disables the jailing.
+	HealthCheckStaleness time.Duration `json:"health_check_staleness"`
+	// JailPeriod is the time a jailed node stays in jail before it can be unjailed.
+	JailPeriod time.Duration `json:"jail_period"`
+	// JailSlash is the fraction of the stake slashed when a node is jailed.
+	JailSlash float64 `json:"jail_slash"`
 
 	// Reward rate.
 	RewardRate float64 `json:"reward_rate"`
@@ -275,6 +289,11 @@ func (gn *GlobalNode) readConfig() (err error) {
 		return
 	}
 	gn.HealthCheckPeriod = config2.SmartContractConfig.GetDuration(pfx + SettingName[HealthCheckPeriod])
+	gn.MinRedelegatePeriod = config2.SmartContractConfig.GetDuration(pfx + SettingName[MinRedelegatePeriod])
+	gn.UnbondingPeriod = config2.SmartContractConfig.GetInt64(pfx + SettingName[UnbondingPeriod])
+	gn.HealthCheckStaleness = config2.SmartContractConfig.GetDuration(pfx + SettingName[HealthCheckStaleness])
+	gn.JailPeriod = config2.SmartContractConfig.GetDuration(pfx + SettingName[JailPeriod])
+	gn.JailSlash = config2.SmartContractConfig.GetFloat64(pfx + SettingName[JailSlash])
 
 	gn.MaxN = config2.SmartContractConfig.GetInt(pfx + SettingName[MaxN])
 	gn.MinN = config2.SmartContractConfig.GetInt(pfx + SettingName[MinN])
@@ -335,6 +354,26 @@ func (gn *GlobalNode) validate() error {
 		return fmt.Errorf("%s cannot be negative: %d",
 			NumShardersRewarded.String(), gn.NumShardersRewarded)
 	}
+	if gn.MinRedelegatePeriod < 0 {
+		return fmt.Errorf("%s cannot be negative: %v",
+			MinRedelegatePeriod.String(), gn.MinRedelegatePeriod)
+	}
+	if gn.UnbondingPeriod < 0 {
+		return fmt.Errorf("%s cannot be negative: %d",
+			UnbondingPeriod.String(), gn.UnbondingPeriod)
+	}
+	if gn.HealthCheckStaleness < 0 {
+		return fmt.Errorf("%s cannot be negative: %v",
+			HealthCheckStaleness.String(), gn.HealthCheckStaleness)
+	}
+	if gn.JailPeriod < 0 {
+		return fmt.Errorf("%s cannot be negative: %v",
+			JailPeriod.String(), gn.JailPeriod)
+	}
+	if gn.JailSlash < 0 || gn.JailSlash > 1 {
+		return fmt.Errorf("%s should be in the interval [0,1]: %v",
+			JailSlash.String(), gn.JailSlash)
+	}
 	return nil
 }
 
@@ -375,6 +414,16 @@ func (gn *GlobalNode) Get(key Setting) (interface{}, error) {
 		return gn.MinStakePerDelegate, nil
 	case HealthCheckPeriod:
 		return gn.HealthCheckPeriod, nil
+	case MinRedelegatePeriod:
+		return gn.MinRedelegatePeriod, nil
+	case UnbondingPeriod:
+		return gn.UnbondingPeriod, nil
+	case HealthCheckStaleness:
+		return gn.HealthCheckStaleness, nil
+	case JailPeriod:
+		return gn.JailPeriod, nil
+	case JailSlash:
+		return gn.JailSlash, nil
 	case MaxStake:
 		return gn.MaxStake, nil
 	case MaxN:
@@ -712,6 +761,10 @@ type SimpleNode struct {
 	// LastHealthCheck used to check for active node
 	LastHealthCheck common.Timestamp `json:"last_health_check"`
 
+	// JailedUntil is the time a jailed node can be unjailed from, zero
+	// if the node is not jailed. It's left out of the encoding when zero.
+	JailedUntil common.Timestamp `json:"jailed_until,omitempty" msg:"JailedUntil,omitempty"`
+
 	// Status will be set either node.NodeStatusActive or node.NodeStatusInactive
 	Status int `json:"-" msg:"-"`
 
@@ -732,6 +785,12 @@ func (smn *SimpleNode) Validate() error {
 	return validate.Struct(smn)
 }
 
+// IsJailed reports whether the node is jailed, a jailed node stays out of the
+// magic block and earns no rewards until it is unjailed
+func (smn *SimpleNode) IsJailed() bool {
+	return smn.JailedUntil > 0
+}
+
 func (smn *SimpleNode) GetN2NHostKey(scAddress string) string {
 	return scAddress + encryption.Hash(fmt.Sprintf("node_n2n_host_port:%s:%d", smn.N2NHost, smn.Port))
 }
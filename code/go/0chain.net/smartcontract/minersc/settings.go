@@ -66,6 +66,17 @@ const (
 	CostKillMiner
 	CostKillSharder
 	HealthCheckPeriod
+	MinRedelegatePeriod
+	CostStakePoolRedelegate
+	CostStakePoolAutoCompound
+	UnbondingPeriod
+	CostStakePoolUnbond
+	CostStakePoolClaimUnbonded
+	HealthCheckStaleness
+	JailPeriod
+	CostUnjailMiner
+	CostUnjailSharder
+	JailSlash
 	NumberOfSettings
 )
 
@@ -114,6 +125,11 @@ func initSettingName() {
 	SettingName[OwnerId] = "owner_id"
 	SettingName[CooldownPeriod] = "cooldown_period"
 	SettingName[HealthCheckPeriod] = "health_check_period"
+	SettingName[MinRedelegatePeriod] = "min_redelegate_period"
+	SettingName[UnbondingPeriod] = "unbonding_period"
+	SettingName[HealthCheckStaleness] = "health_check_staleness"
+	SettingName[JailPeriod] = "jail_period"
+	SettingName[JailSlash] = "jail_slash"
 	SettingName[CostAddMiner] = "cost.add_miner"
 	SettingName[CostAddSharder] = "cost.add_sharder"
 	SettingName[CostDeleteMiner] = "cost.delete_miner"
@@ -134,6 +150,12 @@ func initSettingName() {
 	SettingName[CostSharderKeep] = "cost.sharder_keep"
 	SettingName[CostKillMiner] = "cost.kill_miner"
 	SettingName[CostKillSharder] = "cost.kill_sharder"
+	SettingName[CostStakePoolRedelegate] = "cost.stake_pool_redelegate"
+	SettingName[CostStakePoolAutoCompound] = "cost.stake_pool_auto_compound"
+	SettingName[CostStakePoolUnbond] = "cost.stake_pool_unbond"
+	SettingName[CostStakePoolClaimUnbonded] = "cost.stake_pool_claim_unbonded"
+	SettingName[CostUnjailMiner] = "cost.unjail_miner"
+	SettingName[CostUnjailSharder] = "cost.unjail_sharder"
 }
 
 func initSettings() {
@@ -165,6 +187,7 @@ func initSettings() {
 		OwnerId.String():                     {OwnerId, config.Key},
 		CooldownPeriod.String():              {CooldownPeriod, config.Int64},
 		HealthCheckPeriod.String():           {HealthCheckPeriod, config.Duration},
+		MinRedelegatePeriod.String():         {MinRedelegatePeriod, config.Duration},
 		CostAddMiner.String():                {CostAddMiner, config.Cost},
 		CostAddSharder.String():              {CostAddSharder, config.Cost},
 		CostDeleteMiner.String():             {CostDeleteMiner, config.Cost},
@@ -185,6 +208,16 @@ func initSettings() {
 		CostSharderKeep.String():             {CostSharderKeep, config.Cost},
 		CostKillMiner.String():               {CostKillMiner, config.Cost},
 		CostKillSharder.String():             {CostKillSharder, config.Cost},
+		CostStakePoolRedelegate.String():     {CostStakePoolRedelegate, config.Cost},
+		CostStakePoolAutoCompound.String():   {CostStakePoolAutoCompound, config.Cost},
+		UnbondingPeriod.String():             {UnbondingPeriod, config.Int64},
+		CostStakePoolUnbond.String():         {CostStakePoolUnbond, config.Cost},
+		CostStakePoolClaimUnbonded.String():  {CostStakePoolClaimUnbonded, config.Cost},
+		HealthCheckStaleness.String():        {HealthCheckStaleness, config.Duration},
+		JailPeriod.String():                  {JailPeriod, config.Duration},
+		CostUnjailMiner.String():             {CostUnjailMiner, config.Cost},
+		CostUnjailSharder.String():           {CostUnjailSharder, config.Cost},
+		JailSlash.String():                   {JailSlash, config.Float64},
 	}
 }
 
@@ -216,6 +249,12 @@ func (gn *GlobalNode) setDuration(key string, change time.Duration) error {
 	switch Settings[key].Setting {
 	case HealthCheckPeriod:
 		gn.HealthCheckPeriod = change
+	case MinRedelegatePeriod:
+		gn.MinRedelegatePeriod = change
+	case HealthCheckStaleness:
+		gn.HealthCheckStaleness = change
+	case JailPeriod:
+		gn.JailPeriod = change
 	default:
 		return fmt.Errorf("key: %v not implemented as int", key)
 	}
@@ -246,6 +285,8 @@ func (gn *GlobalNode) setInt64(key string, change int64) error {
 		gn.Epoch = change
 	case CooldownPeriod:
 		gn.CooldownPeriod = change
+	case UnbondingPeriod:
+		gn.UnbondingPeriod = change
 	default:
 		return fmt.Errorf("key: %v not implemented as int64", key)
 	}
@@ -268,6 +309,8 @@ func (gn *GlobalNode) setFloat64(key string, change float64) error {
 		gn.MaxCharge = change
 	case RewardDeclineRate:
 		gn.RewardDeclineRate = change
+	case JailSlash:
+		gn.JailSlash = change
 	default:
 		return fmt.Errorf("key: %v not implemented as float64", key)
 	}
@@ -219,6 +219,13 @@ func TestUpdateSettings(t *testing.T) {
 					"cost.sharder_keep":                            "111",
 					"cost.kill_miner":                              "111",
 					"cost.kill_sharder":                            "111",
+					"cost.stake_pool_redelegate":                   "111",
+					"cost.stake_pool_auto_compound":                "111",
+					"cost.stake_pool_unbond":                       "111",
+					"cost.stake_pool_claim_unbonded":               "111",
+					"cost.unjail_miner":                            "111",
+					"cost.unjail_sharder":                          "111",
+					"jail_slash":                                   "0.1",
 				},
 			},
 		},
@@ -31,6 +31,7 @@ func sharderTableToSharderNode(edbSharder event.Sharder, delegates []event.Deleg
 		Status:                        status,
 		RoundServiceChargeLastUpdated: edbSharder.Rewards.RoundServiceChargeLastUpdated,
 		IsKilled:                      edbSharder.IsKilled,
+		JailedUntil:                   edbSharder.JailedUntil,
 	}
 
 	sn := NodeResponse{
@@ -90,7 +91,8 @@ func sharderNodeToSharderTable(sn *MinerNode, round int64) event.Sharder {
 			IsKilled:        sn.IsKilled(),
 		},
 
-		Active: sn.Status == node.NodeStatusActive,
+		Active:      sn.Status == node.NodeStatusActive,
+		JailedUntil: sn.JailedUntil,
 
 		CreationRound: round,
 	}
@@ -127,6 +129,7 @@ func emitUpdateSharder(sn *MinerNode, balances cstate.StateContextI, updateStatu
 			"service_charge":    sn.Settings.ServiceChargeRatio,
 			"num_delegates":     sn.Settings.MaxNumDelegates,
 			"last_health_check": sn.LastHealthCheck,
+			"jailed_until":      sn.JailedUntil,
 		},
 	}
 
@@ -0,0 +1,159 @@
+package multisigsc
+
+import (
+	"0chain.net/chaincore/block"
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/statecache"
+	"github.com/0chain/common/core/util"
+)
+
+//
+// helper for tests implements chainState.StateContextI
+//
+
+type testBalances struct {
+	balances        map[datastore.Key]currency.Coin
+	signedTransfers []*state.SignedTransfer
+	tree            map[datastore.Key]util.MPTSerializable
+	tc              *statecache.TransactionCache
+	block           *block.Block
+}
+
+func newTestBalances() *testBalances {
+	bc := statecache.NewBlockCache(statecache.NewStateCache(), statecache.Block{})
+
+	tb := &testBalances{
+		balances: make(map[datastore.Key]currency.Coin),
+		tree:     make(map[datastore.Key]util.MPTSerializable),
+		tc:       statecache.NewTransactionCache(bc),
+		block:    &block.Block{},
+	}
+	h := cstate.NewHardFork(walletProposalsHardFork, 0)
+	tb.tree[h.GetKey()] = h
+	return tb
+}
+
+func (tb *testBalances) Cache() *statecache.TransactionCache {
+	return tb.tc
+}
+
+// stubs
+func (tb *testBalances) GetBlock() *block.Block                       { return tb.block }
+func (tb *testBalances) GetState() util.MerklePatriciaTrieI           { return nil }
+func (tb *testBalances) GetTransaction() *transaction.Transaction     { return nil }
+func (tb *testBalances) Validate() error                              { return nil }
+func (tb *testBalances) GetMints() []*state.Mint                      { return nil }
+func (tb *testBalances) SetStateContext(*state.State) error           { return nil }
+func (tb *testBalances) AddMint(*state.Mint) error                    { return nil }
+func (tb *testBalances) AddTransfer(*state.Transfer) error            { return nil }
+func (tb *testBalances) GetTransfers() []*state.Transfer              { return nil }
+func (tb *testBalances) GetChainCurrentMagicBlock() *block.MagicBlock { return nil }
+func (tb *testBalances) GetEventDB() *event.EventDb                   { return nil }
+func (tb *testBalances) EmitEventWithVersion(eventVersion event.EventVersion, eventType event.EventType, tag event.EventTag, index string, data interface{}, appenders ...cstate.Appender) {
+}
+func (tb *testBalances) EmitEvent(event.EventType, event.EventTag, string, interface{}, ...cstate.Appender) {
+}
+func (tb *testBalances) EmitError(error)                             {}
+func (tb *testBalances) GetEvents() []event.Event                    { return nil }
+func (tb *testBalances) GetLatestFinalizedBlock() *block.Block       { return nil }
+func (tb *testBalances) GetMagicBlock(round int64) *block.MagicBlock { return nil }
+func (tb *testBalances) SetMagicBlock(block *block.MagicBlock)       {}
+func (tb *testBalances) GetLastestFinalizedMagicBlock() *block.Block {
+	return nil
+}
+func (tb *testBalances) Now() common.Timestamp { return common.Now() }
+
+func (tb *testBalances) GetSignatureScheme() encryption.SignatureScheme {
+	return encryption.NewBLS0ChainScheme()
+}
+
+func (tb *testBalances) AddSignedTransfer(st *state.SignedTransfer) {
+	tb.signedTransfers = append(tb.signedTransfers, st)
+}
+
+func (tb *testBalances) GetSignedTransfers() []*state.SignedTransfer {
+	return tb.signedTransfers
+}
+
+func (tb *testBalances) DeleteTrieNode(key datastore.Key) (
+	datastore.Key, error) {
+
+	delete(tb.tree, key)
+	return key, nil
+}
+
+func (tb *testBalances) GetClientBalance(clientID datastore.Key) (
+	b currency.Coin, err error) {
+
+	var ok bool
+	if b, ok = tb.balances[clientID]; !ok {
+		return 0, util.ErrValueNotPresent
+	}
+	return
+}
+
+func (tb *testBalances) GetTrieNode(key datastore.Key, v util.MPTSerializable) error {
+	nd, ok := tb.tree[key]
+	if !ok {
+		return util.ErrValueNotPresent
+	}
+
+	b, err := nd.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = v.UnmarshalMsg(b)
+	if err != nil {
+		panic(err)
+	}
+
+	return nil
+}
+
+func (tb *testBalances) InsertTrieNode(key datastore.Key,
+	node util.MPTSerializable) (_ datastore.Key, _ error) {
+
+	b, err := node.MarshalMsg(nil)
+	if err != nil {
+		return "", err
+	}
+
+	// store a copy, so that later changes of the node are not saved
+	// without another insert
+	tb.tree[key] = &rawNode{b: b}
+	return key, nil
+}
+
+func (tb *testBalances) GetInvalidStateErrors() []error { return nil }
+
+func (tb *testBalances) GetClientState(clientID datastore.Key) (*state.State, error) {
+	return nil, nil
+}
+
+func (tb *testBalances) SetClientState(clientID datastore.Key, s *state.State) (util.Key, error) {
+	return nil, nil
+}
+
+func (tb *testBalances) GetMissingNodeKeys() []util.Key { return nil }
+
+// rawNode keeps the encoded form of a saved node
+type rawNode struct {
+	b []byte
+}
+
+func (n *rawNode) MarshalMsg(o []byte) ([]byte, error) {
+	return append(o, n.b...), nil
+}
+
+func (n *rawNode) UnmarshalMsg(b []byte) ([]byte, error) {
+	n.b = append(n.b[:0], b...)
+	return nil, nil
+}
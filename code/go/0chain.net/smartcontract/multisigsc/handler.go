@@ -0,0 +1,169 @@
+package multisigsc
+
+import (
+	"net/http"
+
+	"0chain.net/chaincore/state"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract"
+	"0chain.net/smartcontract/rest"
+	"github.com/0chain/common/core/util"
+)
+
+type MultiSigRestHandler struct {
+	rest.RestHandlerI
+}
+
+func NewMultiSigRestHandler(rh rest.RestHandlerI) *MultiSigRestHandler {
+	return &MultiSigRestHandler{rh}
+}
+
+func SetupRestHandler(rh rest.RestHandlerI) {
+	rh.Register(GetEndpoints(rh))
+}
+
+func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
+	mrh := NewMultiSigRestHandler(rh)
+	multisig := "/v1/screst/" + Address
+	return []rest.Endpoint{
+		rest.MakeEndpoint(multisig+"/getSigners", common.UserRateLimit(mrh.getSigners)),
+		rest.MakeEndpoint(multisig+"/getPendingProposals", common.UserRateLimit(mrh.getPendingProposals)),
+	}
+}
+
+// swagger:model multisigSigner
+type signerInfo struct {
+	ClientID    string `json:"client_id"`
+	ThresholdID string `json:"threshold_id"`
+	PublicKey   string `json:"public_key"`
+}
+
+// swagger:model multisigSigners
+type signersInfo struct {
+	ClientID    string       `json:"client_id"`
+	NumRequired int          `json:"num_required"`
+	Signers     []signerInfo `json:"signers"`
+}
+
+// swagger:model multisigProposal
+type proposalInfo struct {
+	ProposalID        string             `json:"proposal_id"`
+	ExpirationDate    common.Timestamp   `json:"expiration_date"`
+	Transfer          state.Transfer     `json:"transfer"`
+	SmartContractCall *SmartContractCall `json:"smart_contract_call,omitempty"`
+	// Signers that voted for the proposal so far.
+	Votes       []signerInfo `json:"votes"`
+	NumRequired int          `json:"num_required"`
+}
+
+// swagger:route GET /v1/screst/27b5ef7120252b79f9dd9c05505dd28f328c80f6863ee446daede08a84d651a7/getSigners getSigners
+// get the signers and the threshold of a multi-sig wallet
+//
+// parameters:
+//
+//	+name: client_id
+//	 description: client id of the multi-sig wallet
+//	 required: true
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: multisigSigners
+//	400:
+//	500:
+func (mrh *MultiSigRestHandler) getSigners(w http.ResponseWriter, r *http.Request) {
+	clientID := r.URL.Query().Get("client_id")
+	if clientID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing client_id"))
+		return
+	}
+
+	var ms MultiSigSmartContract
+	wallet, err := ms.getWallet(clientID, mrh.GetQueryStateContext())
+	if err != nil {
+		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't get wallet"))
+		return
+	}
+
+	info := signersInfo{
+		ClientID:    wallet.ClientID,
+		NumRequired: wallet.NumRequired,
+		Signers:     make([]signerInfo, 0, len(wallet.SignerThresholdIDs)),
+	}
+	for _, id := range wallet.SignerThresholdIDs {
+		info.Signers = append(info.Signers, wallet.signerInfo(id))
+	}
+
+	common.Respond(w, r, info, nil)
+}
+
+// swagger:route GET /v1/screst/27b5ef7120252b79f9dd9c05505dd28f328c80f6863ee446daede08a84d651a7/getPendingProposals getPendingProposals
+// get the proposals of a multi-sig wallet that are neither executed nor expired, with their current votes
+//
+// parameters:
+//
+//	+name: client_id
+//	 description: client id of the multi-sig wallet
+//	 required: true
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []multisigProposal
+//	400:
+//	500:
+func (mrh *MultiSigRestHandler) getPendingProposals(w http.ResponseWriter, r *http.Request) {
+	clientID := r.URL.Query().Get("client_id")
+	if clientID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing client_id"))
+		return
+	}
+
+	var (
+		ms   MultiSigSmartContract
+		sctx = mrh.GetQueryStateContext()
+	)
+	wallet, err := ms.getWallet(clientID, sctx)
+	if err != nil {
+		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't get wallet"))
+		return
+	}
+
+	wp, err := ms.getWalletProposals(clientID, sctx)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get wallet proposals", err.Error()))
+		return
+	}
+
+	var (
+		now       = common.Now()
+		proposals = make([]proposalInfo, 0, len(wp.ProposalIDs))
+	)
+	for _, id := range wp.ProposalIDs {
+		p, err := ms.getProposal(proposalRef{ClientID: clientID, ProposalID: id}, sctx)
+		if err != nil && err != util.ErrValueNotPresent {
+			common.Respond(w, r, nil, common.NewErrInternal("can't get proposal", err.Error()))
+			return
+		}
+		if p.isEmpty() || p.ExecutedInTxnHash != "" || p.isExpired(now) {
+			continue
+		}
+
+		info := proposalInfo{
+			ProposalID:        p.ProposalID,
+			ExpirationDate:    p.ExpirationDate,
+			Transfer:          p.Transfer,
+			SmartContractCall: p.SmartContractCall,
+			Votes:             make([]signerInfo, 0, len(p.SignerThresholdIDs)),
+			NumRequired:       wallet.NumRequired,
+		}
+		for _, id := range p.SignerThresholdIDs {
+			info.Votes = append(info.Votes, wallet.signerInfo(id))
+		}
+		proposals = append(proposals, info)
+	}
+
+	common.Respond(w, r, proposals, nil)
+}
@@ -0,0 +1,118 @@
+package multisigsc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"0chain.net/smartcontract/rest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestHandlers(t *testing.T) {
+	var (
+		balances = newTestBalances()
+		ms       = newTestMultiSigSC()
+		tw       = newTestWallet(t, 2, 3)
+		now      = balances.Now()
+	)
+	tw.register(t, ms, balances)
+
+	// a pending proposal, an executed one and an expired one
+	_, err := castVote(t, ms, tw.vote(t, 1, "pending", otherClientID, 10, nil),
+		tw.signerID(1), now, balances)
+	require.NoError(t, err)
+	for _, i := range []int{0, 1} {
+		_, err := castVote(t, ms, tw.vote(t, i, "executed", otherClientID, 10, nil),
+			tw.signerID(i), now, balances)
+		require.NoError(t, err)
+	}
+	_, err = castVote(t, ms, tw.vote(t, 0, "expired", otherClientID, 10, nil),
+		tw.signerID(0), now-ExpirationTime, balances)
+	require.NoError(t, err)
+
+	rh := rest.NewRestHandler(&rest.TestQueryChainer{})
+	mrh := NewMultiSigRestHandler(rh)
+	mrh.SetQueryStateContext(balances)
+
+	get := func(handler http.HandlerFunc, clientID string, resp interface{}) int {
+		target := url.URL{Path: "/v1/screst/" + Address}
+		if clientID != "" {
+			target.RawQuery = url.Values{"client_id": {clientID}}.Encode()
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, target.String(), nil))
+		if rr.Code == http.StatusOK {
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(resp))
+		}
+		return rr.Code
+	}
+
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		clientID string
+		wantCode int
+		check    func(t *testing.T)
+	}{
+		{
+			name:     "signers",
+			handler:  mrh.getSigners,
+			clientID: tw.wallet.ClientID,
+			wantCode: http.StatusOK,
+			check: func(t *testing.T) {
+				var info signersInfo
+				get(mrh.getSigners, tw.wallet.ClientID, &info)
+				require.Equal(t, 2, info.NumRequired)
+				require.Len(t, info.Signers, 3)
+				for i, s := range info.Signers {
+					require.Equal(t, tw.signerID(i), s.ClientID)
+					require.Equal(t, tw.wallet.SignerThresholdIDs[i], s.ThresholdID)
+				}
+			},
+		},
+		{
+			name:     "signers without client id",
+			handler:  mrh.getSigners,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "signers of an unknown wallet",
+			handler:  mrh.getSigners,
+			clientID: otherClientID,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "pending proposals",
+			handler:  mrh.getPendingProposals,
+			clientID: tw.wallet.ClientID,
+			wantCode: http.StatusOK,
+			check: func(t *testing.T) {
+				var proposals []proposalInfo
+				get(mrh.getPendingProposals, tw.wallet.ClientID, &proposals)
+				require.Len(t, proposals, 1)
+				require.Equal(t, "pending", proposals[0].ProposalID)
+				require.Equal(t, 2, proposals[0].NumRequired)
+				require.Len(t, proposals[0].Votes, 1)
+				require.Equal(t, tw.signerID(1), proposals[0].Votes[0].ClientID)
+			},
+		},
+		{
+			name:     "pending proposals without client id",
+			handler:  mrh.getPendingProposals,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp interface{}
+			require.Equal(t, tt.wantCode, get(tt.handler, tt.clientID, &resp))
+			if tt.check != nil {
+				tt.check(t)
+			}
+		})
+	}
+}
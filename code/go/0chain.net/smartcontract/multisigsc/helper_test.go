@@ -0,0 +1,112 @@
+package multisigsc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/logging"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func init() {
+	logging.Logger = zap.NewNop()
+}
+
+// testWallet holds the group key of a multi-sig wallet and the key shares of
+// all of its signers
+type testWallet struct {
+	groupKey encryption.SignatureScheme
+	signers  []encryption.ThresholdSignatureScheme
+	wallet   Wallet
+}
+
+func clientIDForKey(key encryption.SignatureScheme) string {
+	b, err := hex.DecodeString(key.GetPublicKey())
+	if err != nil {
+		panic(err)
+	}
+	return encryption.Hash(b)
+}
+
+func newTestWallet(t *testing.T, numRequired, numSigners int) *testWallet {
+	groupKey := encryption.NewBLS0ChainScheme()
+	require.NoError(t, groupKey.GenerateKeys())
+
+	signers, err := encryption.GenerateThresholdKeyShares(
+		encryption.SignatureSchemeBls0chain, numRequired, numSigners, groupKey)
+	require.NoError(t, err)
+
+	tw := &testWallet{
+		groupKey: groupKey,
+		signers:  signers,
+		wallet: Wallet{
+			ClientID:        clientIDForKey(groupKey),
+			SignatureScheme: encryption.SignatureSchemeBls0chain,
+			PublicKey:       groupKey.GetPublicKey(),
+			NumRequired:     numRequired,
+		},
+	}
+	for _, s := range signers {
+		tw.wallet.SignerThresholdIDs = append(tw.wallet.SignerThresholdIDs, s.GetID())
+		tw.wallet.SignerPublicKeys = append(tw.wallet.SignerPublicKeys, s.GetPublicKey())
+	}
+	return tw
+}
+
+func (tw *testWallet) register(t *testing.T, ms *MultiSigSmartContract, balances *testBalances) {
+	_, err := ms.register(tw.wallet.ClientID, mustEncode(t, tw.wallet), balances)
+	require.NoError(t, err)
+}
+
+func (tw *testWallet) signerID(i int) string {
+	return clientIDForKey(tw.signers[i])
+}
+
+// vote of the i-th signer for a proposal, the proposal calls a smart contract
+// if call is set and transfers the amount otherwise
+func (tw *testWallet) vote(t *testing.T, i int, proposalID, to string,
+	amount currency.Coin, call *SmartContractCall) Vote {
+
+	v := Vote{
+		ProposalID: proposalID,
+		Transfer: state.Transfer{
+			ClientID:   tw.wallet.ClientID,
+			ToClientID: to,
+			Amount:     amount,
+		},
+		SmartContractCall: call,
+	}
+
+	sig, err := tw.signers[i].Sign(v.hash())
+	require.NoError(t, err)
+	v.Signature = sig
+	return v
+}
+
+func mustEncode(t *testing.T, val interface{}) []byte {
+	b, err := json.Marshal(val)
+	require.NoError(t, err)
+	return b
+}
+
+func newTransaction(clientID string, now common.Timestamp) *transaction.Transaction {
+	tx := new(transaction.Transaction)
+	tx.ClientID = clientID
+	tx.ToClientID = Address
+	tx.CreationDate = now
+	tx.Hash = encryption.Hash(clientID + string(rune(now)))
+	return tx
+}
+
+func castVote(t *testing.T, ms *MultiSigSmartContract, v Vote, signerID string,
+	now common.Timestamp, balances *testBalances) (string, error) {
+
+	return ms.vote(newTransaction(signerID, now), now, mustEncode(t, v), balances)
+}
@@ -10,7 +10,7 @@ import (
 	"0chain.net/core/encryption"
 )
 
-//msgp:ignore Vote
+//msgp:ignore Vote walletUpdate cancelProposalRequest
 //go:generate msgp -io=false -tests=false -unexported -v
 
 const (
@@ -19,6 +19,8 @@ const (
 	MaxSigners   = 20
 	MinSigners   = 2
 	MaxFieldSize = 256
+	// MaxInputDataSize limits the input of a smart contract call proposal.
+	MaxInputDataSize = 16 * 1024
 )
 
 type Wallet struct {
@@ -151,10 +153,24 @@ func (w Wallet) isVoteAuthorized(signingClientID string, v Vote) bool {
 		return false
 	}
 
+	if v.isSmartContractCall() {
+		ok, err := w.verify(publicKey, v.Signature, v.hash())
+		return err == nil && ok
+	}
+
 	err := w.makeSignedTransferForVote(publicKey, v).VerifySignature(false)
 	return err == nil
 }
 
+// Verify a signature on the given hash with one of the wallet's keys.
+func (w Wallet) verify(publicKey, signature, hash string) (bool, error) {
+	scheme := encryption.GetSignatureScheme(w.SignatureScheme)
+	if err := scheme.SetPublicKey(publicKey); err != nil {
+		return false, err
+	}
+	return scheme.Verify(signature, hash)
+}
+
 func (w Wallet) makeSignedTransferForVote(signingPublicKey string, v Vote) state.SignedTransfer {
 	return state.SignedTransfer{
 		Transfer:   v.Transfer,
@@ -212,6 +228,17 @@ func (w Wallet) publicKeyForThresholdID(signerThresholdID string) string {
 	return ""
 }
 
+func (w Wallet) signerInfo(signerThresholdID string) signerInfo {
+	info := signerInfo{
+		ThresholdID: signerThresholdID,
+		PublicKey:   w.publicKeyForThresholdID(signerThresholdID),
+	}
+	if b, err := hex.DecodeString(info.PublicKey); err == nil && info.PublicKey != "" {
+		info.ClientID = encryption.Hash(b)
+	}
+	return info
+}
+
 // Compute the Lagrange polynomial of Wallet.NumRequired signature shares. The
 // y-intercept of this polynomial is the proposal's signature. (This process is
 // called reconstruction in the literature.)
@@ -252,16 +279,40 @@ func (w Wallet) constructTransferSignature(p proposal) (string, error) {
 	return rec.Reconstruct()
 }
 
+// SmartContractCall is a smart contract function that a proposal calls on
+// behalf of the multi-sig wallet. The transfer of such a proposal plays the
+// role of a transaction: ClientID is the multi-sig wallet, ToClientID is the
+// smart contract address and Amount is the value sent along with the call.
+type SmartContractCall struct {
+	FunctionName string `json:"name"`
+	InputData    string `json:"input"`
+}
+
+func (c *SmartContractCall) Encode() []byte {
+	buff, _ := json.Marshal(c)
+	return buff
+}
+
 type Vote struct {
 	ProposalID string `json:"proposal_id"`
 
 	// Client ID in transfer is that of the multi-sig wallet, not the signer.
 	Transfer state.Transfer `json:"transfer"`
 
+	// Optional. When set, the proposal calls a smart contract function
+	// instead of transferring tokens.
+	SmartContractCall *SmartContractCall `json:"smart_contract_call,omitempty"`
+
 	Signature string `json:"signature"`
 }
 
 func (v Vote) notTooBig() bool {
+	if v.isSmartContractCall() &&
+		(len(v.SmartContractCall.FunctionName) > MaxFieldSize ||
+			len(v.SmartContractCall.InputData) > MaxInputDataSize) {
+		return false
+	}
+
 	return len(v.ProposalID) <= MaxFieldSize &&
 		len(v.Transfer.ClientID) <= MaxFieldSize &&
 		len(v.Transfer.ToClientID) <= MaxFieldSize &&
@@ -269,7 +320,17 @@ func (v Vote) notTooBig() bool {
 }
 
 func (v Vote) hasValidAmount() bool {
-	return v.Transfer.Amount > 0
+	// Smart contract calls may be sent without any tokens.
+	return v.isSmartContractCall() || v.Transfer.Amount > 0
+}
+
+func (v Vote) isSmartContractCall() bool {
+	return v.SmartContractCall != nil
+}
+
+// The hash signed by the voters.
+func (v Vote) hash() string {
+	return proposalHash(v.Transfer, v.SmartContractCall)
 }
 
 func (v Vote) hasSignature() bool {
@@ -284,9 +345,25 @@ func (v Vote) getProposalRef() proposalRef {
 }
 
 func (v Vote) isCompatibleWithProposal(p proposal) bool {
+	if v.isSmartContractCall() != p.isSmartContractCall() {
+		return false
+	}
+	if v.isSmartContractCall() && *v.SmartContractCall != *p.SmartContractCall {
+		return false
+	}
 	return v.Transfer == p.Transfer
 }
 
+// A transfer proposal is signed over the transfer alone, so that the
+// reconstructed signature makes a valid signed transfer. A smart contract call
+// proposal is signed over both the transfer and the call.
+func proposalHash(t state.Transfer, call *SmartContractCall) string {
+	if call == nil {
+		return encryption.Hash(t.Encode())
+	}
+	return encryption.Hash(append(t.Encode(), call.Encode()...))
+}
+
 // Uniquely identifies a proposal. Can be used to refer to one.
 type proposalRef struct {
 	ClientID   string `json:"client_id"`
@@ -303,8 +380,8 @@ func (pr *proposalRef) Decode(input []byte) error {
 	return err
 }
 
-// Proposal to transfer tokens out of the multi-sig wallet. Built up from T
-// different votes.
+// Proposal to transfer tokens out of the multi-sig wallet, or to call a smart
+// contract on its behalf. Built up from T different votes.
 type proposal struct {
 	// Proposal ID is unique only within a single multi-sig wallet. Globally, a
 	// proposal may be referred to by a wallet ID / proposal ID pair.
@@ -315,7 +392,8 @@ type proposal struct {
 	Next proposalRef `json:"next"`
 	Prev proposalRef `json:"prev"`
 
-	Transfer state.Transfer `json:"transfer"`
+	Transfer          state.Transfer     `json:"transfer"`
+	SmartContractCall *SmartContractCall `json:"smart_contract_call,omitempty"`
 
 	// Pertinent data from votes.
 	SignerThresholdIDs []string `json:"signer_threshold_ids"`
@@ -340,6 +418,51 @@ func (p proposal) isEmpty() bool {
 	return p.Transfer.ClientID == ""
 }
 
+func (p proposal) isSmartContractCall() bool {
+	return p.SmartContractCall != nil
+}
+
+func (p proposal) hash() string {
+	return proposalHash(p.Transfer, p.SmartContractCall)
+}
+
+// Drop the votes of signers that are no longer registered on the updated
+// wallet, or whose key has changed. Returns true if any vote was dropped.
+func (p *proposal) dropStaleVotes(old, updated Wallet) bool {
+	var (
+		ids  = make([]string, 0, len(p.SignerThresholdIDs))
+		sigs = make([]string, 0, len(p.SignerSignatures))
+	)
+	for i, id := range p.SignerThresholdIDs {
+		key := updated.publicKeyForThresholdID(id)
+		if key == "" || key != old.publicKeyForThresholdID(id) {
+			continue
+		}
+		ids = append(ids, id)
+		sigs = append(sigs, p.SignerSignatures[i])
+	}
+
+	dropped := len(ids) != len(p.SignerThresholdIDs)
+	p.SignerThresholdIDs, p.SignerSignatures = ids, sigs
+	return dropped
+}
+
+// Keep the earliest votes up to one short of the threshold, so the next vote
+// executes the proposal with exactly the threshold of signatures. Returns true
+// if any vote was dropped.
+func (p *proposal) capVotes(numRequired int) bool {
+	keep := numRequired - 1
+	if keep < 0 {
+		keep = 0
+	}
+	if len(p.SignerSignatures) <= keep {
+		return false
+	}
+	p.SignerThresholdIDs = p.SignerThresholdIDs[:keep]
+	p.SignerSignatures = p.SignerSignatures[:keep]
+	return true
+}
+
 func (p proposal) isExpired(now common.Timestamp) bool {
 	return now >= p.ExpirationDate
 }
@@ -375,6 +498,78 @@ func (q *expirationQueue) Decode(input []byte) error {
 	return err
 }
 
+// Index of the proposals of a single multi-sig wallet that have not been
+// pruned yet. Proposals created before the index existed are added to it
+// when they receive their next vote.
+type walletProposals struct {
+	ClientID    string   `json:"client_id"`
+	ProposalIDs []string `json:"proposal_ids"`
+}
+
+func (wp *walletProposals) Encode() []byte {
+	buff, _ := json.Marshal(wp)
+	return buff
+}
+
+func (wp *walletProposals) Decode(input []byte) error {
+	return json.Unmarshal(input, wp)
+}
+
+func (wp *walletProposals) add(proposalID string) {
+	wp.ProposalIDs = append(wp.ProposalIDs, proposalID)
+}
+
+func (wp *walletProposals) has(proposalID string) bool {
+	for _, id := range wp.ProposalIDs {
+		if id == proposalID {
+			return true
+		}
+	}
+	return false
+}
+
+func (wp *walletProposals) remove(proposalID string) bool {
+	for i, id := range wp.ProposalIDs {
+		if id == proposalID {
+			wp.ProposalIDs = append(wp.ProposalIDs[:i], wp.ProposalIDs[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func getWalletProposalsKey(clientID string) datastore.Key {
+	return datastore.Key(Address + clientID + encryption.Hash("proposals"))
+}
+
+// New signer set and threshold of a multi-sig wallet. The wallet key itself
+// doesn't change, so the new signers must hold shares of the same key. The
+// shares are dealt off chain.
+type walletUpdate struct {
+	SignerThresholdIDs []string `json:"signer_threshold_ids"`
+	SignerPublicKeys   []string `json:"signer_public_keys"`
+	NumRequired        int      `json:"num_required"`
+}
+
+func (wu *walletUpdate) decode(input []byte) error {
+	return json.Unmarshal(input, wu)
+}
+
+func (w Wallet) update(wu walletUpdate) Wallet {
+	w.SignerThresholdIDs = wu.SignerThresholdIDs
+	w.SignerPublicKeys = wu.SignerPublicKeys
+	w.NumRequired = wu.NumRequired
+	return w
+}
+
+type cancelProposalRequest struct {
+	ProposalID string `json:"proposal_id"`
+}
+
+func (cr *cancelProposalRequest) decode(input []byte) error {
+	return json.Unmarshal(input, cr)
+}
+
 func getExpirationQueueKey() datastore.Key {
 	return datastore.Key(Address + encryption.Hash("queue"))
 }
@@ -6,6 +6,67 @@ import (
 	"github.com/tinylib/msgp/msgp"
 )
 
+// MarshalMsg implements msgp.Marshaler
+func (z SmartContractCall) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "FunctionName"
+	o = append(o, 0x82, 0xac, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65)
+	o = msgp.AppendString(o, z.FunctionName)
+	// string "InputData"
+	o = append(o, 0xa9, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x44, 0x61, 0x74, 0x61)
+	o = msgp.AppendString(o, z.InputData)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SmartContractCall) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "FunctionName":
+			z.FunctionName, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "FunctionName")
+				return
+			}
+		case "InputData":
+			z.InputData, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "InputData")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z SmartContractCall) Msgsize() (s int) {
+	s = 1 + 13 + msgp.StringPrefixSize + len(z.FunctionName) + 10 + msgp.StringPrefixSize + len(z.InputData)
+	return
+}
+
 // MarshalMsg implements msgp.Marshaler
 func (z *Wallet) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
@@ -277,9 +338,9 @@ func (z *expirationQueue) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *proposal) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 9
+	// map header, size 10
 	// string "ProposalID"
-	o = append(o, 0x89, 0xaa, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x49, 0x44)
+	o = append(o, 0x8a, 0xaa, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ProposalID)
 	// string "ExpirationDate"
 	o = append(o, 0xae, 0x45, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65)
@@ -313,6 +374,19 @@ func (z *proposal) MarshalMsg(b []byte) (o []byte, err error) {
 		err = msgp.WrapError(err, "Transfer")
 		return
 	}
+	// string "SmartContractCall"
+	o = append(o, 0xb1, 0x53, 0x6d, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x43, 0x61, 0x6c, 0x6c)
+	if z.SmartContractCall == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		// map header, size 2
+		// string "FunctionName"
+		o = append(o, 0x82, 0xac, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65)
+		o = msgp.AppendString(o, z.SmartContractCall.FunctionName)
+		// string "InputData"
+		o = append(o, 0xa9, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x44, 0x61, 0x74, 0x61)
+		o = msgp.AppendString(o, z.SmartContractCall.InputData)
+	}
 	// string "SignerThresholdIDs"
 	o = append(o, 0xb2, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x49, 0x44, 0x73)
 	o = msgp.AppendArrayHeader(o, uint32(len(z.SignerThresholdIDs)))
@@ -440,17 +514,63 @@ func (z *proposal) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "Transfer")
 				return
 			}
+		case "SmartContractCall":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.SmartContractCall = nil
+			} else {
+				if z.SmartContractCall == nil {
+					z.SmartContractCall = new(SmartContractCall)
+				}
+				var zb0004 uint32
+				zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "SmartContractCall")
+					return
+				}
+				for zb0004 > 0 {
+					zb0004--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "SmartContractCall")
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "FunctionName":
+						z.SmartContractCall.FunctionName, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "SmartContractCall", "FunctionName")
+							return
+						}
+					case "InputData":
+						z.SmartContractCall.InputData, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "SmartContractCall", "InputData")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "SmartContractCall")
+							return
+						}
+					}
+				}
+			}
 		case "SignerThresholdIDs":
-			var zb0004 uint32
-			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			var zb0005 uint32
+			zb0005, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "SignerThresholdIDs")
 				return
 			}
-			if cap(z.SignerThresholdIDs) >= int(zb0004) {
-				z.SignerThresholdIDs = (z.SignerThresholdIDs)[:zb0004]
+			if cap(z.SignerThresholdIDs) >= int(zb0005) {
+				z.SignerThresholdIDs = (z.SignerThresholdIDs)[:zb0005]
 			} else {
-				z.SignerThresholdIDs = make([]string, zb0004)
+				z.SignerThresholdIDs = make([]string, zb0005)
 			}
 			for za0001 := range z.SignerThresholdIDs {
 				z.SignerThresholdIDs[za0001], bts, err = msgp.ReadStringBytes(bts)
@@ -460,16 +580,16 @@ func (z *proposal) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				}
 			}
 		case "SignerSignatures":
-			var zb0005 uint32
-			zb0005, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			var zb0006 uint32
+			zb0006, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "SignerSignatures")
 				return
 			}
-			if cap(z.SignerSignatures) >= int(zb0005) {
-				z.SignerSignatures = (z.SignerSignatures)[:zb0005]
+			if cap(z.SignerSignatures) >= int(zb0006) {
+				z.SignerSignatures = (z.SignerSignatures)[:zb0006]
 			} else {
-				z.SignerSignatures = make([]string, zb0005)
+				z.SignerSignatures = make([]string, zb0006)
 			}
 			for za0002 := range z.SignerSignatures {
 				z.SignerSignatures[za0002], bts, err = msgp.ReadStringBytes(bts)
@@ -504,7 +624,13 @@ func (z *proposal) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *proposal) Msgsize() (s int) {
-	s = 1 + 11 + msgp.StringPrefixSize + len(z.ProposalID) + 15 + z.ExpirationDate.Msgsize() + 5 + 1 + 9 + msgp.StringPrefixSize + len(z.Next.ClientID) + 11 + msgp.StringPrefixSize + len(z.Next.ProposalID) + 5 + 1 + 9 + msgp.StringPrefixSize + len(z.Prev.ClientID) + 11 + msgp.StringPrefixSize + len(z.Prev.ProposalID) + 9 + z.Transfer.Msgsize() + 19 + msgp.ArrayHeaderSize
+	s = 1 + 11 + msgp.StringPrefixSize + len(z.ProposalID) + 15 + z.ExpirationDate.Msgsize() + 5 + 1 + 9 + msgp.StringPrefixSize + len(z.Next.ClientID) + 11 + msgp.StringPrefixSize + len(z.Next.ProposalID) + 5 + 1 + 9 + msgp.StringPrefixSize + len(z.Prev.ClientID) + 11 + msgp.StringPrefixSize + len(z.Prev.ProposalID) + 9 + z.Transfer.Msgsize() + 18
+	if z.SmartContractCall == nil {
+		s += msgp.NilSize
+	} else {
+		s += 1 + 13 + msgp.StringPrefixSize + len(z.SmartContractCall.FunctionName) + 10 + msgp.StringPrefixSize + len(z.SmartContractCall.InputData)
+	}
+	s += 19 + msgp.ArrayHeaderSize
 	for za0001 := range z.SignerThresholdIDs {
 		s += msgp.StringPrefixSize + len(z.SignerThresholdIDs[za0001])
 	}
@@ -576,3 +702,83 @@ func (z proposalRef) Msgsize() (s int) {
 	s = 1 + 9 + msgp.StringPrefixSize + len(z.ClientID) + 11 + msgp.StringPrefixSize + len(z.ProposalID)
 	return
 }
+
+// MarshalMsg implements msgp.Marshaler
+func (z *walletProposals) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "ClientID"
+	o = append(o, 0x82, 0xa8, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44)
+	o = msgp.AppendString(o, z.ClientID)
+	// string "ProposalIDs"
+	o = append(o, 0xab, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x49, 0x44, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.ProposalIDs)))
+	for za0001 := range z.ProposalIDs {
+		o = msgp.AppendString(o, z.ProposalIDs[za0001])
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *walletProposals) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ClientID":
+			z.ClientID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ClientID")
+				return
+			}
+		case "ProposalIDs":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ProposalIDs")
+				return
+			}
+			if cap(z.ProposalIDs) >= int(zb0002) {
+				z.ProposalIDs = (z.ProposalIDs)[:zb0002]
+			} else {
+				z.ProposalIDs = make([]string, zb0002)
+			}
+			for za0001 := range z.ProposalIDs {
+				z.ProposalIDs[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "ProposalIDs", za0001)
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *walletProposals) Msgsize() (s int) {
+	s = 1 + 9 + msgp.StringPrefixSize + len(z.ClientID) + 12 + msgp.ArrayHeaderSize
+	for za0001 := range z.ProposalIDs {
+		s += msgp.StringPrefixSize + len(z.ProposalIDs[za0001])
+	}
+	return
+}
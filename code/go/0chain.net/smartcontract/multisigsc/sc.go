@@ -20,11 +20,16 @@ import (
 )
 
 const (
-	name             = "multisig"
-	Address          = "27b5ef7120252b79f9dd9c05505dd28f328c80f6863ee446daede08a84d651a7"
-	RegisterFuncName = "register"
-	VoteFuncName     = "vote"
-	LogTimingInfo    = false
+	name                   = "multisig"
+	Address                = "27b5ef7120252b79f9dd9c05505dd28f328c80f6863ee446daede08a84d651a7"
+	RegisterFuncName       = "register"
+	VoteFuncName           = "vote"
+	UpdateWalletFuncName   = "update_wallet"
+	CancelProposalFuncName = "cancel_proposal"
+	LogTimingInfo          = false
+
+	// The hard fork from which the proposals are indexed by wallet.
+	walletProposalsHardFork = "hermes"
 )
 
 type MultiSigSmartContract struct {
@@ -73,7 +78,11 @@ func (ms *MultiSigSmartContract) Execute(t *transaction.Transaction, funcName st
 	case RegisterFuncName:
 		return ms.register(t.ClientID, inputData, balances)
 	case VoteFuncName:
-		return ms.vote(t.Hash, t.ClientID, balances.GetBlock().CreationDate, inputData, balances)
+		return ms.vote(t, balances.GetBlock().CreationDate, inputData, balances)
+	case UpdateWalletFuncName:
+		return ms.updateWallet(t.ClientID, inputData, balances)
+	case CancelProposalFuncName:
+		return ms.cancelProposal(t.ClientID, inputData, balances)
 	default:
 		return "err_execute_function_not_found: no multi sig smart contract function with that name: " + funcName, nil
 	}
@@ -128,7 +137,9 @@ func (ms MultiSigSmartContract) register(registeringClientID string, inputData [
 	return "success: multi-signature wallet registered", nil
 }
 
-func (ms MultiSigSmartContract) vote(currentTxnHash, signingClientID string, now common.Timestamp, inputData []byte, balances state.StateContextI) (string, error) {
+func (ms MultiSigSmartContract) vote(t *transaction.Transaction, now common.Timestamp, inputData []byte, balances state.StateContextI) (string, error) {
+	currentTxnHash, signingClientID := t.Hash, t.ClientID
+
 	// Garbage collection of old proposals happens incrementally with every
 	// incoming vote.
 	err := ms.pruneExpirationQueue(now, balances)
@@ -156,6 +167,10 @@ func (ms MultiSigSmartContract) vote(currentTxnHash, signingClientID string, now
 	if !v.hasSignature() {
 		return "", common.NewError("err_vote_no_signature", " must sign vote")
 	}
+	if v.isSmartContractCall() && v.Transfer.ToClientID == Address &&
+		!isWalletManagementFunc(v.SmartContractCall.FunctionName) {
+		return "", common.NewError("err_vote_invalid_call", " multi-sig smart contract can only be called to manage the wallet")
+	}
 
 	// Every vote is associated with a proposal. If an appropriate proposal does
 	// not exist yet, create one.
@@ -195,6 +210,9 @@ func (ms MultiSigSmartContract) vote(currentTxnHash, signingClientID string, now
 		return "", common.NewError("err_vote_auth", " authorization failure")
 	}
 
+	// Proposals voted before the threshold was lowered may hold more votes
+	// than are needed now.
+	p.capVotes(w.NumRequired)
 	remaining := w.NumRequired - len(p.SignerSignatures)
 
 	// Check if this is a duplicate vote.
@@ -232,6 +250,10 @@ func (ms MultiSigSmartContract) vote(currentTxnHash, signingClientID string, now
 
 	p.ClientSignature = thresholdSignature
 
+	if p.isSmartContractCall() {
+		return ms.executeProposalCall(t, w, p, balances)
+	}
+
 	// Request the transfer. The blockchain will validate the signature and
 	// execute the transfer soon. If the signature is found to be invalid,
 	// this vote transaction will fail.
@@ -251,6 +273,140 @@ func (ms MultiSigSmartContract) vote(currentTxnHash, signingClientID string, now
 	return msg, nil
 }
 
+// Execute the smart contract call of a proposal that has enough votes. The call
+// is dispatched through the smart contract registry as if the multi-sig wallet
+// had sent the transaction itself.
+func (ms MultiSigSmartContract) executeProposalCall(t *transaction.Transaction, w Wallet, p proposal, balances state.StateContextI) (string, error) {
+	// Unlike a signed transfer, nobody checks the recovered signature after
+	// this transaction, so it must be checked before the call.
+	ok, err := w.verify(w.PublicKey, p.ClientSignature, p.hash())
+	if err != nil || !ok {
+		return "", common.NewError("err_vote_recover", " recovered signature is invalid")
+	}
+
+	sc := smartcontract.GetSmartContract(p.Transfer.ToClientID)
+	if sc == nil {
+		return "", common.NewError("err_vote_invalid_call", " invalid smart contract address")
+	}
+
+	// Mark the proposal executed before the call, so the call can't cancel it.
+	p.ExecutedInTxnHash = t.Hash
+
+	err = ms.putProposal(&p, balances)
+	if err != nil {
+		// I/O error.
+		return "", err
+	}
+
+	call := t.Clone()
+	call.ClientID = p.Transfer.ClientID
+	call.PublicKey = w.PublicKey
+	call.ToClientID = p.Transfer.ToClientID
+	call.Value = p.Transfer.Amount
+	call.Fee = 0
+	call.SmartContractData = &transaction.SmartContractData{
+		FunctionName: p.SmartContractCall.FunctionName,
+		InputData:    json.RawMessage(p.SmartContractCall.InputData),
+	}
+
+	output, err := smartcontract.ExecuteWithStats(sc, call, balances)
+	if err != nil {
+		return "", common.NewError("err_vote_call", " smart contract call failed: "+err.Error())
+	}
+
+	return "success 0: smart contract call executed with signature " + p.ClientSignature + ": " + output, nil
+}
+
+// Functions of this smart contract that a proposal may call. They can only be
+// sent by the multi-sig wallet itself, that is, with the approval of its
+// signers.
+func isWalletManagementFunc(funcName string) bool {
+	return funcName == UpdateWalletFuncName || funcName == CancelProposalFuncName
+}
+
+// Replace the signers and the threshold of a multi-sig wallet. Must be sent by
+// the wallet, usually through a proposal its current signers voted for. Votes
+// of removed signers are dropped from pending proposals, and the votes above a
+// lowered threshold too.
+func (ms MultiSigSmartContract) updateWallet(clientID string, inputData []byte, balances state.StateContextI) (string, error) {
+	var wu walletUpdate
+	if err := wu.decode(inputData); err != nil {
+		return "", common.NewError("err_update_wallet_formatting", "incorrect request format: "+err.Error())
+	}
+
+	w, err := ms.getWallet(clientID, balances)
+	if err != nil {
+		if err == util.ErrValueNotPresent {
+			return "", common.NewError("err_update_wallet_not_registered", "wallet not registered")
+		}
+		return "", err
+	}
+
+	updated := w.update(wu)
+	isValid, err := updated.valid(clientID)
+	if err != nil {
+		return "", common.NewError("err_update_wallet_invalid", err.Error())
+	}
+	if !isValid {
+		return "", common.NewError("err_update_wallet_invalid", "invalid request")
+	}
+
+	wp, err := ms.getWalletProposals(clientID, balances)
+	if err != nil {
+		return "", err
+	}
+
+	for _, id := range wp.ProposalIDs {
+		p, err := ms.getProposal(proposalRef{ClientID: clientID, ProposalID: id}, balances)
+		if err != nil {
+			return "", err
+		}
+		if p.isEmpty() || p.ExecutedInTxnHash != "" {
+			continue
+		}
+		dropped := p.dropStaleVotes(w, updated)
+		if p.capVotes(updated.NumRequired) || dropped {
+			if err := ms.putProposal(&p, balances); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if err := ms.putWallet(updated, balances); err != nil {
+		return "", err
+	}
+
+	return "success: multi-signature wallet updated", nil
+}
+
+// Withdraw a pending proposal before it expires. Must be sent by the wallet,
+// usually through a proposal its current signers voted for.
+func (ms MultiSigSmartContract) cancelProposal(clientID string, inputData []byte, balances state.StateContextI) (string, error) {
+	var cr cancelProposalRequest
+	if err := cr.decode(inputData); err != nil {
+		return "", common.NewError("err_cancel_proposal_formatting", "incorrect request format: "+err.Error())
+	}
+
+	ref := proposalRef{ClientID: clientID, ProposalID: cr.ProposalID}
+	p, err := ms.getProposal(ref, balances)
+	if err != nil {
+		return "", err
+	}
+	if p.isEmpty() {
+		return "", common.NewError("err_cancel_proposal_not_found", "proposal not found")
+	}
+	if p.ExecutedInTxnHash != "" {
+		return "", common.NewError("err_cancel_proposal_executed",
+			"proposal already executed in transaction hash "+p.ExecutedInTxnHash)
+	}
+
+	if err := ms.prune(ref, balances); err != nil {
+		return "", err
+	}
+
+	return "success: proposal cancelled", nil
+}
+
 // Prune the oldest proposal if it has expired.
 func (ms MultiSigSmartContract) pruneExpirationQueue(now common.Timestamp, balances state.StateContextI) error {
 	q, err := ms.getOrCreateExpirationQueue(balances)
@@ -344,6 +500,14 @@ func (ms MultiSigSmartContract) prune(ref proposalRef, balances c_state.StateCon
 		return err
 	}
 
+	wp, err := ms.getWalletProposals(ref.ClientID, balances)
+	if err != nil {
+		return err
+	}
+	if wp.remove(ref.ProposalID) {
+		return ms.putWalletProposals(&wp, balances)
+	}
+
 	return nil
 }
 
@@ -370,11 +534,37 @@ func (ms MultiSigSmartContract) findOrCreateProposal(now common.Timestamp, v Vot
 		if err != nil {
 			return proposal{}, err
 		}
+		return p, nil
+	}
+
+	// Proposals created before the wallet index existed are indexed now, so
+	// that wallet updates and the REST views see them.
+	if err := ms.indexProposal(p, balances); err != nil {
+		return proposal{}, err
 	}
 
 	return p, nil
 }
 
+// Add a proposal to the index of its wallet if it isn't there yet. The index
+// is only written after walletProposalsHardFork, it adds a node to the state
+// of each wallet.
+func (ms MultiSigSmartContract) indexProposal(p proposal, balances state.StateContextI) error {
+	return state.WithActivation(balances, walletProposalsHardFork, func() error {
+		return nil
+	}, func() error {
+		wp, err := ms.getWalletProposals(p.Transfer.ClientID, balances)
+		if err != nil {
+			return err
+		}
+		if wp.has(p.ProposalID) {
+			return nil
+		}
+		wp.add(p.ProposalID)
+		return ms.putWalletProposals(&wp, balances)
+	})
+}
+
 // Create a proposal and add it to the expiration queue. Performs I/O.
 func (ms MultiSigSmartContract) createProposal(now common.Timestamp, v Vote, balances state.StateContextI) (proposal, error) {
 	q, err := ms.getOrCreateExpirationQueue(balances)
@@ -392,7 +582,8 @@ func (ms MultiSigSmartContract) createProposal(now common.Timestamp, v Vote, bal
 		Next: proposalRef{},
 		Prev: q.Tail,
 
-		Transfer: v.Transfer,
+		Transfer:          v.Transfer,
+		SmartContractCall: v.SmartContractCall,
 
 		SignerThresholdIDs: []string{},
 		SignerSignatures:   []string{},
@@ -435,6 +626,12 @@ func (ms MultiSigSmartContract) createProposal(now common.Timestamp, v Vote, bal
 		return proposal{}, err
 	}
 
+	// Index the proposal under its wallet.
+	err = ms.indexProposal(p, balances)
+	if err != nil {
+		return proposal{}, err
+	}
+
 	return p, nil
 }
 
@@ -451,7 +648,7 @@ func (ms MultiSigSmartContract) walletExists(clientID string, balances c_state.S
 	}
 }
 
-func (ms MultiSigSmartContract) getWallet(clientID string, balances c_state.StateContextI) (Wallet, error) {
+func (ms MultiSigSmartContract) getWallet(clientID string, balances c_state.CommonStateContextI) (Wallet, error) {
 
 	w := Wallet{}
 	err := balances.GetTrieNode(getWalletKey(clientID), &w)
@@ -474,7 +671,7 @@ func (ms MultiSigSmartContract) putWallet(w Wallet, balances c_state.StateContex
 	return err
 }
 
-func (ms MultiSigSmartContract) getProposal(ref proposalRef, balances c_state.StateContextI) (proposal, error) {
+func (ms MultiSigSmartContract) getProposal(ref proposalRef, balances c_state.CommonStateContextI) (proposal, error) {
 	p := proposal{}
 	err := balances.GetTrieNode(getProposalKey(ref.ClientID, ref.ProposalID), &p)
 	switch err {
@@ -515,3 +712,21 @@ func (ms MultiSigSmartContract) putExpirationQueue(q *expirationQueue, balances
 	_, err := balances.InsertTrieNode(getExpirationQueueKey(), q)
 	return err
 }
+
+func (ms MultiSigSmartContract) getWalletProposals(clientID string, balances c_state.CommonStateContextI) (walletProposals, error) {
+	wp := walletProposals{}
+	err := balances.GetTrieNode(getWalletProposalsKey(clientID), &wp)
+	switch err {
+	case nil:
+		return wp, nil
+	case util.ErrValueNotPresent:
+		return walletProposals{ClientID: clientID}, nil
+	default:
+		return walletProposals{}, err
+	}
+}
+
+func (ms MultiSigSmartContract) putWalletProposals(wp *walletProposals, balances c_state.StateContextI) error {
+	_, err := balances.InsertTrieNode(getWalletProposalsKey(wp.ClientID), wp)
+	return err
+}
@@ -0,0 +1,413 @@
+package multisigsc
+
+import (
+	"testing"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	"0chain.net/core/common"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/require"
+)
+
+const otherClientID = "d5e3c4a7b1f2e6c8d9a0b3f4e5c6d7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4"
+
+func newTestMultiSigSC() *MultiSigSmartContract {
+	ms := NewMultiSigSmartContract().(*MultiSigSmartContract)
+	smartcontract.ContractMap[Address] = ms
+	return ms
+}
+
+func TestVote(t *testing.T) {
+	const now = common.Timestamp(100)
+
+	type want struct {
+		err             string
+		output          string
+		signedTransfers int
+		executed        bool
+		signers         int
+	}
+
+	tests := []struct {
+		name     string
+		voters   []int
+		amount   int64
+		to       string
+		call     func(tw *testWallet) *SmartContractCall
+		outsider bool
+		want     want
+	}{
+		{
+			name:   "not enough votes",
+			voters: []int{0},
+			amount: 10,
+			to:     otherClientID,
+			want:   want{output: "success 1: need 1 more votes"},
+		},
+		{
+			name:   "duplicate vote",
+			voters: []int{0, 0},
+			amount: 10,
+			to:     otherClientID,
+			want:   want{output: "success 1: already voted, still need 1 other votes"},
+		},
+		{
+			name:   "transfer",
+			voters: []int{0, 2},
+			amount: 10,
+			to:     otherClientID,
+			want: want{
+				output:          "success 0: transfer executed with signature",
+				signedTransfers: 1,
+				executed:        true,
+			},
+		},
+		{
+			name:   "zero transfer",
+			voters: []int{0},
+			to:     otherClientID,
+			want:   want{err: "err_vote_invalid_tokens"},
+		},
+		{
+			name:   "smart contract call",
+			voters: []int{1, 2},
+			to:     Address,
+			call: func(tw *testWallet) *SmartContractCall {
+				return &SmartContractCall{
+					FunctionName: UpdateWalletFuncName,
+					InputData: string(mustEncode(t, walletUpdate{
+						SignerThresholdIDs: tw.wallet.SignerThresholdIDs[1:],
+						SignerPublicKeys:   tw.wallet.SignerPublicKeys[1:],
+						NumRequired:        2,
+					})),
+				}
+			},
+			want: want{
+				output:   "success 0: smart contract call executed with signature",
+				executed: true,
+				signers:  2,
+			},
+		},
+		{
+			name:   "call to a multi-sig function other than wallet management",
+			voters: []int{0},
+			to:     Address,
+			call: func(*testWallet) *SmartContractCall {
+				return &SmartContractCall{FunctionName: RegisterFuncName, InputData: "{}"}
+			},
+			want: want{err: "err_vote_invalid_call"},
+		},
+		{
+			name:   "call to an unknown smart contract",
+			voters: []int{0, 1},
+			to:     otherClientID,
+			call: func(*testWallet) *SmartContractCall {
+				return &SmartContractCall{FunctionName: "lock", InputData: "{}"}
+			},
+			want: want{err: "invalid smart contract address"},
+		},
+		{
+			name:     "vote of a client that is not a signer",
+			voters:   []int{0},
+			amount:   10,
+			to:       otherClientID,
+			outsider: true,
+			want:     want{err: "err_vote_auth"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				balances = newTestBalances()
+				ms       = newTestMultiSigSC()
+				tw       = newTestWallet(t, 2, 3)
+			)
+			tw.register(t, ms, balances)
+
+			var call *SmartContractCall
+			if tt.call != nil {
+				call = tt.call(tw)
+			}
+
+			var (
+				output string
+				err    error
+			)
+			for _, i := range tt.voters {
+				v := tw.vote(t, i, "p1", tt.to, currency.Coin(tt.amount), call)
+				signerID := tw.signerID(i)
+				if tt.outsider {
+					signerID = otherClientID
+				}
+				output, err = castVote(t, ms, v, signerID, now, balances)
+				if err != nil {
+					break
+				}
+			}
+
+			if tt.want.err != "" {
+				require.ErrorContains(t, err, tt.want.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, output, tt.want.output)
+			require.Len(t, balances.signedTransfers, tt.want.signedTransfers)
+
+			p, err := ms.getProposal(proposalRef{ClientID: tw.wallet.ClientID, ProposalID: "p1"}, balances)
+			require.NoError(t, err)
+			require.Equal(t, tt.want.executed, p.ExecutedInTxnHash != "")
+
+			if tt.want.signers > 0 {
+				w, err := ms.getWallet(tw.wallet.ClientID, balances)
+				require.NoError(t, err)
+				require.Len(t, w.SignerThresholdIDs, tt.want.signers)
+			}
+		})
+	}
+}
+
+func TestUpdateWallet(t *testing.T) {
+	const now = common.Timestamp(100)
+
+	tests := []struct {
+		name      string
+		fromOther bool
+		// votes are cast by the first signers before the update
+		votes       int
+		numRequired int
+		keep        []int
+		wantErr     string
+		wantVotes   int
+		// nextVoteExecutes is for a vote of the third signer after the update
+		nextVoteExecutes bool
+	}{
+		{
+			name:        "drop the votes of removed signers",
+			votes:       1,
+			numRequired: 2,
+			keep:        []int{1, 2, 3},
+			wantVotes:   0,
+		},
+		{
+			name:        "keep the votes of remaining signers",
+			votes:       1,
+			numRequired: 3,
+			keep:        []int{0, 1, 2},
+			wantVotes:   1,
+		},
+		{
+			name:             "cap the votes to a lowered threshold",
+			votes:            2,
+			numRequired:      2,
+			keep:             []int{0, 1, 2},
+			wantVotes:        1,
+			nextVoteExecutes: true,
+		},
+		{
+			name:        "threshold too low",
+			votes:       1,
+			numRequired: 1,
+			keep:        []int{0, 1, 2},
+			wantErr:     "err_update_wallet_invalid",
+		},
+		{
+			name:        "threshold above the number of signers",
+			votes:       1,
+			numRequired: 4,
+			keep:        []int{0, 1, 2},
+			wantErr:     "err_update_wallet_invalid",
+		},
+		{
+			name:        "wallet not registered",
+			fromOther:   true,
+			votes:       1,
+			numRequired: 2,
+			keep:        []int{0, 1, 2},
+			wantErr:     "err_update_wallet_not_registered",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				balances = newTestBalances()
+				ms       = newTestMultiSigSC()
+				tw       = newTestWallet(t, 3, 4)
+			)
+			tw.register(t, ms, balances)
+
+			// a pending proposal with the votes of the first signers
+			for i := 0; i < tt.votes; i++ {
+				_, err := castVote(t, ms, tw.vote(t, i, "p1", otherClientID, 10, nil),
+					tw.signerID(i), now, balances)
+				require.NoError(t, err)
+			}
+
+			wu := walletUpdate{NumRequired: tt.numRequired}
+			for _, i := range tt.keep {
+				wu.SignerThresholdIDs = append(wu.SignerThresholdIDs, tw.wallet.SignerThresholdIDs[i])
+				wu.SignerPublicKeys = append(wu.SignerPublicKeys, tw.wallet.SignerPublicKeys[i])
+			}
+
+			clientID := tw.wallet.ClientID
+			if tt.fromOther {
+				clientID = otherClientID
+			}
+			_, err := ms.updateWallet(clientID, mustEncode(t, wu), balances)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			w, err := ms.getWallet(tw.wallet.ClientID, balances)
+			require.NoError(t, err)
+			require.Equal(t, tt.numRequired, w.NumRequired)
+			require.Equal(t, wu.SignerThresholdIDs, w.SignerThresholdIDs)
+
+			p, err := ms.getProposal(proposalRef{ClientID: tw.wallet.ClientID, ProposalID: "p1"}, balances)
+			require.NoError(t, err)
+			require.Len(t, p.SignerThresholdIDs, tt.wantVotes)
+			require.Len(t, p.SignerSignatures, tt.wantVotes)
+
+			if tt.nextVoteExecutes {
+				output, err := castVote(t, ms, tw.vote(t, 2, "p1", otherClientID, 10, nil),
+					tw.signerID(2), now, balances)
+				require.NoError(t, err)
+				require.Contains(t, output, "success 0: transfer executed")
+				require.Len(t, balances.signedTransfers, 1)
+			}
+		})
+	}
+}
+
+func TestCancelProposal(t *testing.T) {
+	const now = common.Timestamp(100)
+
+	tests := []struct {
+		name       string
+		proposalID string
+		executed   bool
+		wantErr    string
+	}{
+		{
+			name:       "pending proposal",
+			proposalID: "p2",
+		},
+		{
+			name:       "first proposal of the queue",
+			proposalID: "p1",
+		},
+		{
+			name:       "unknown proposal",
+			proposalID: "p4",
+			wantErr:    "err_cancel_proposal_not_found",
+		},
+		{
+			name:       "executed proposal",
+			proposalID: "p2",
+			executed:   true,
+			wantErr:    "err_cancel_proposal_executed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				balances = newTestBalances()
+				ms       = newTestMultiSigSC()
+				tw       = newTestWallet(t, 2, 3)
+			)
+			tw.register(t, ms, balances)
+
+			for _, id := range []string{"p1", "p2", "p3"} {
+				_, err := castVote(t, ms, tw.vote(t, 0, id, otherClientID, 10, nil),
+					tw.signerID(0), now, balances)
+				require.NoError(t, err)
+			}
+			if tt.executed {
+				_, err := castVote(t, ms, tw.vote(t, 1, tt.proposalID, otherClientID, 10, nil),
+					tw.signerID(1), now, balances)
+				require.NoError(t, err)
+			}
+
+			_, err := ms.cancelProposal(tw.wallet.ClientID,
+				mustEncode(t, cancelProposalRequest{ProposalID: tt.proposalID}), balances)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			ref := proposalRef{ClientID: tw.wallet.ClientID, ProposalID: tt.proposalID}
+			p, err := ms.getProposal(ref, balances)
+			require.NoError(t, err)
+			require.True(t, p.isEmpty())
+
+			wp, err := ms.getWalletProposals(tw.wallet.ClientID, balances)
+			require.NoError(t, err)
+			require.NotContains(t, wp.ProposalIDs, tt.proposalID)
+			require.Len(t, wp.ProposalIDs, 2)
+
+			// the expiration queue skips the cancelled proposal
+			q, err := ms.getOrCreateExpirationQueue(balances)
+			require.NoError(t, err)
+			var queued []string
+			for ref := q.Head; ref != (proposalRef{}); {
+				p, err := ms.getProposal(ref, balances)
+				require.NoError(t, err)
+				queued = append(queued, p.ProposalID)
+				ref = p.Next
+			}
+			require.Equal(t, wp.ProposalIDs, queued)
+		})
+	}
+}
+
+func TestWalletProposalsBeforeHardFork(t *testing.T) {
+	const now = common.Timestamp(100)
+
+	var (
+		balances = newTestBalances()
+		ms       = newTestMultiSigSC()
+		tw       = newTestWallet(t, 3, 4)
+	)
+	delete(balances.tree, cstate.NewHardFork(walletProposalsHardFork, 0).GetKey())
+	tw.register(t, ms, balances)
+
+	_, err := castVote(t, ms, tw.vote(t, 0, "p1", otherClientID, 10, nil),
+		tw.signerID(0), now, balances)
+	require.NoError(t, err)
+
+	_, ok := balances.tree[getWalletProposalsKey(tw.wallet.ClientID)]
+	require.False(t, ok, "the proposals are not indexed before the hard fork")
+}
+
+func TestIndexProposalsCreatedBeforeTheIndex(t *testing.T) {
+	const now = common.Timestamp(100)
+
+	var (
+		balances = newTestBalances()
+		ms       = newTestMultiSigSC()
+		tw       = newTestWallet(t, 3, 4)
+	)
+	tw.register(t, ms, balances)
+
+	_, err := castVote(t, ms, tw.vote(t, 0, "p1", otherClientID, 10, nil),
+		tw.signerID(0), now, balances)
+	require.NoError(t, err)
+
+	// drop the index, as for a proposal created before it existed
+	_, err = balances.DeleteTrieNode(getWalletProposalsKey(tw.wallet.ClientID))
+	require.NoError(t, err)
+
+	_, err = castVote(t, ms, tw.vote(t, 1, "p1", otherClientID, 10, nil),
+		tw.signerID(1), now, balances)
+	require.NoError(t, err)
+
+	wp, err := ms.getWalletProposals(tw.wallet.ClientID, balances)
+	require.NoError(t, err)
+	require.Equal(t, []string{"p1"}, wp.ProposalIDs)
+}
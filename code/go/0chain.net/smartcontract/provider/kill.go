@@ -8,6 +8,7 @@ import (
 	"0chain.net/smartcontract/dbs/event"
 
 	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
 
 	cstate "0chain.net/chaincore/chain/state"
 	"0chain.net/chaincore/smartcontractinterface"
@@ -65,7 +66,7 @@ func Kill(
 
 	p.Kill()
 
-	if err := sp.Kill(killSlash, p.Id(), p.Type(), balances); err != nil {
+	if err := sp.Kill(killSlash, p.Id(), p.Type(), spenum.SlashKill, balances); err != nil {
 		return err
 	}
 
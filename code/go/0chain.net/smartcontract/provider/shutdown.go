@@ -7,6 +7,7 @@ import (
 	"0chain.net/smartcontract/dbs/event"
 
 	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
 
 	cstate "0chain.net/chaincore/chain/state"
 	"0chain.net/chaincore/smartcontractinterface"
@@ -45,7 +46,7 @@ func ShutDown(
 
 	p.ShutDown()
 
-	if err = sp.Kill(killSlash, p.Id(), p.Type(), balances); err != nil {
+	if err = sp.Kill(killSlash, p.Id(), p.Type(), spenum.SlashShutdown, balances); err != nil {
 		return fmt.Errorf("can't kill the stake pool: %v", err)
 	}
 
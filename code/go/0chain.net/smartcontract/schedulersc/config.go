@@ -0,0 +1,105 @@
+package schedulersc
+
+import (
+	"encoding/json"
+	"errors"
+
+	chainstate "0chain.net/chaincore/chain/state"
+	config2 "0chain.net/core/config"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/util"
+)
+
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+var costFunctions = []string{
+	ScheduleFuncName,
+	CancelScheduledFuncName,
+	ExecuteScheduledFuncName,
+}
+
+func scConfigKey(scKey string) datastore.Key {
+	return scKey + encryption.Hash("schedulersc_config")
+}
+
+// config represents SC configurations ('schedulersc:' from sc.yaml)
+type config struct {
+	// MaxRoundsAhead is how far in the future a call can be scheduled.
+	MaxRoundsAhead int64 `json:"max_rounds_ahead"`
+	// MaxExecutionDelay is the number of rounds after the due round during
+	// which a failing call is retried. After that the call is dropped and
+	// its tokens are returned to the owner.
+	MaxExecutionDelay int64 `json:"max_execution_delay"`
+	// MaxExecutionsPerRound limits the calls a block generator executes.
+	MaxExecutionsPerRound int `json:"max_executions_per_round"`
+	// MaxPending limits the calls an owner has waiting in the queue.
+	MaxPending   int            `json:"max_pending"`
+	MaxInputSize int            `json:"max_input_size"`
+	Cost         map[string]int `json:"cost"`
+}
+
+func (c *config) validate() (err error) {
+	switch {
+	case c.MaxRoundsAhead < 1:
+		return errors.New("invalid max_rounds_ahead (< 1)")
+	case c.MaxExecutionDelay < 0:
+		return errors.New("invalid max_execution_delay (< 0)")
+	case c.MaxExecutionsPerRound < 1:
+		return errors.New("invalid max_executions_per_round (< 1)")
+	case c.MaxPending < 1:
+		return errors.New("invalid max_pending (< 1)")
+	case c.MaxInputSize < 1:
+		return errors.New("invalid max_input_size (< 1)")
+	}
+	return
+}
+
+func (c *config) Encode() (b []byte) {
+	var err error
+	if b, err = json.Marshal(c); err != nil {
+		panic(err) // must not happens
+	}
+	return
+}
+
+func (c *config) Decode(b []byte) error {
+	return json.Unmarshal(b, c)
+}
+
+// configurations from sc.yaml
+func getConfiguredConfig() (conf *config, err error) {
+	const prefix = "smart_contracts.schedulersc."
+
+	conf = new(config)
+
+	// short hand
+	var scconf = config2.SmartContractConfig
+	conf.MaxRoundsAhead = scconf.GetInt64(prefix + "max_rounds_ahead")
+	conf.MaxExecutionDelay = scconf.GetInt64(prefix + "max_execution_delay")
+	conf.MaxExecutionsPerRound = scconf.GetInt(prefix + "max_executions_per_round")
+	conf.MaxPending = scconf.GetInt(prefix + "max_pending")
+	conf.MaxInputSize = scconf.GetInt(prefix + "max_input_size")
+	conf.Cost = scconf.GetStringMapInt(prefix + "cost")
+
+	err = conf.validate()
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func getConfig(
+	balances chainstate.CommonStateContextI,
+) (conf *config, err error) {
+	conf = new(config)
+	err = balances.GetTrieNode(scConfigKey(ADDRESS), conf)
+	switch err {
+	case nil:
+		return conf, nil
+	case util.ErrValueNotPresent:
+		return getConfiguredConfig()
+	default:
+		return nil, err
+	}
+}
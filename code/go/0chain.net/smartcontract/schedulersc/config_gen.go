@@ -0,0 +1,144 @@
+package schedulersc
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *config) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 6
+	// string "MaxRoundsAhead"
+	o = append(o, 0x86, 0xae, 0x4d, 0x61, 0x78, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x41, 0x68, 0x65, 0x61, 0x64)
+	o = msgp.AppendInt64(o, z.MaxRoundsAhead)
+	// string "MaxExecutionDelay"
+	o = append(o, 0xb1, 0x4d, 0x61, 0x78, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x61, 0x79)
+	o = msgp.AppendInt64(o, z.MaxExecutionDelay)
+	// string "MaxExecutionsPerRound"
+	o = append(o, 0xb5, 0x4d, 0x61, 0x78, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x65, 0x72, 0x52, 0x6f, 0x75, 0x6e, 0x64)
+	o = msgp.AppendInt(o, z.MaxExecutionsPerRound)
+	// string "MaxPending"
+	o = append(o, 0xaa, 0x4d, 0x61, 0x78, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67)
+	o = msgp.AppendInt(o, z.MaxPending)
+	// string "MaxInputSize"
+	o = append(o, 0xac, 0x4d, 0x61, 0x78, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x53, 0x69, 0x7a, 0x65)
+	o = msgp.AppendInt(o, z.MaxInputSize)
+	// string "Cost"
+	o = append(o, 0xa4, 0x43, 0x6f, 0x73, 0x74)
+	o = msgp.AppendMapHeader(o, uint32(len(z.Cost)))
+	keys_za0001 := make([]string, 0, len(z.Cost))
+	for k := range z.Cost {
+		keys_za0001 = append(keys_za0001, k)
+	}
+	msgp.Sort(keys_za0001)
+	for _, k := range keys_za0001 {
+		za0002 := z.Cost[k]
+		o = msgp.AppendString(o, k)
+		o = msgp.AppendInt(o, za0002)
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *config) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "MaxRoundsAhead":
+			z.MaxRoundsAhead, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxRoundsAhead")
+				return
+			}
+		case "MaxExecutionDelay":
+			z.MaxExecutionDelay, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxExecutionDelay")
+				return
+			}
+		case "MaxExecutionsPerRound":
+			z.MaxExecutionsPerRound, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxExecutionsPerRound")
+				return
+			}
+		case "MaxPending":
+			z.MaxPending, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxPending")
+				return
+			}
+		case "MaxInputSize":
+			z.MaxInputSize, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxInputSize")
+				return
+			}
+		case "Cost":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Cost")
+				return
+			}
+			if z.Cost == nil {
+				z.Cost = make(map[string]int, zb0002)
+			} else if len(z.Cost) > 0 {
+				for key := range z.Cost {
+					delete(z.Cost, key)
+				}
+			}
+			for zb0002 > 0 {
+				var za0001 string
+				var za0002 int
+				zb0002--
+				za0001, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Cost")
+					return
+				}
+				za0002, bts, err = msgp.ReadIntBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Cost", za0001)
+					return
+				}
+				z.Cost[za0001] = za0002
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *config) Msgsize() (s int) {
+	s = 1 + 15 + msgp.Int64Size + 18 + msgp.Int64Size + 22 + msgp.IntSize + 11 + msgp.IntSize + 13 + msgp.IntSize + 5 + msgp.MapHeaderSize
+	if z.Cost != nil {
+		for za0001, za0002 := range z.Cost {
+			_ = za0002
+			s += msgp.StringPrefixSize + len(za0001) + msgp.IntSize
+		}
+	}
+	return
+}
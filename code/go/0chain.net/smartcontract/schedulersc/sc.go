@@ -0,0 +1,95 @@
+package schedulersc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"0chain.net/chaincore/smartcontract"
+
+	chainstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	metrics "github.com/rcrowley/go-metrics"
+)
+
+const (
+	ADDRESS = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e1"
+	name    = "scheduler"
+
+	ScheduleFuncName         = "schedule"
+	CancelScheduledFuncName  = "cancel_scheduled"
+	ExecuteScheduledFuncName = "execute_scheduled"
+)
+
+// SchedulerSmartContract runs smart contract calls at a future round. The
+// calls are escrowed with their value and fee when scheduled, and the block
+// generator of the due round executes them on behalf of their owners.
+type SchedulerSmartContract struct {
+	*smartcontractinterface.SmartContract
+}
+
+func NewSchedulerSmartContract() smartcontractinterface.SmartContractInterface {
+	var sscCopy = &SchedulerSmartContract{
+		smartcontractinterface.NewSC(ADDRESS),
+	}
+	sscCopy.setSC(sscCopy.SmartContract, &smartcontract.BCContext{})
+	return sscCopy
+}
+
+func (ssc *SchedulerSmartContract) GetHandlerStats(ctx context.Context, params url.Values) (interface{}, error) {
+	return ssc.SmartContract.HandlerStats(ctx, params)
+}
+
+func (ssc *SchedulerSmartContract) GetExecutionStats() map[string]interface{} {
+	return ssc.SmartContractExecutionStats
+}
+
+func (ssc *SchedulerSmartContract) GetName() string {
+	return name
+}
+
+func (ssc *SchedulerSmartContract) GetAddress() string {
+	return ADDRESS
+}
+
+func (ssc *SchedulerSmartContract) GetCostTable(balances chainstate.StateContextI) (map[string]int, error) {
+	conf, err := getConfig(balances)
+	if err != nil {
+		return map[string]int{}, err
+	}
+	if conf.Cost == nil {
+		return map[string]int{}, err
+	}
+	return conf.Cost, nil
+}
+
+func (ssc *SchedulerSmartContract) setSC(sc *smartcontractinterface.SmartContract,
+	_ smartcontractinterface.BCContextI) {
+
+	ssc.SmartContract = sc
+
+	for _, funcName := range costFunctions {
+		ssc.SmartContractExecutionStats[funcName] = metrics.GetOrRegisterTimer(
+			fmt.Sprintf("sc:%v:func:%v", ssc.ID, funcName), nil)
+	}
+}
+
+func (ssc *SchedulerSmartContract) Execute(t *transaction.Transaction,
+	function string, input []byte, balances chainstate.StateContextI) (
+	resp string, err error) {
+
+	switch function {
+	case ScheduleFuncName:
+		resp, err = ssc.schedule(t, input, balances)
+	case CancelScheduledFuncName:
+		resp, err = ssc.cancelScheduled(t, input, balances)
+	case ExecuteScheduledFuncName:
+		resp, err = ssc.executeScheduled(t, input, balances)
+	default:
+		err = common.NewError("scheduler_sc_failed",
+			fmt.Sprintf("no function with %q name", function))
+	}
+	return
+}
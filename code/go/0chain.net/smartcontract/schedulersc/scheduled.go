@@ -0,0 +1,591 @@
+package schedulersc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"0chain.net/chaincore/smartcontract"
+	"0chain.net/chaincore/state"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/logging"
+	"github.com/0chain/common/core/util"
+	"go.uber.org/zap"
+
+	chainstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+)
+
+//msgp:ignore scheduleRequest scheduledIDRequest
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+// callFailedCode is the error code of a scheduled call failed in the
+// 'execute_scheduled' transaction
+const callFailedCode = "scheduled_call_failed"
+
+// scheduleRequest is input of the 'schedule' function. The transaction value
+// must equal to Value + Fee: the Value is sent with the call and the Fee goes
+// to the block generator executing it.
+type scheduleRequest struct {
+	DueRound     int64           `json:"due_round"`
+	ToClientID   string          `json:"to_client_id"`
+	FunctionName string          `json:"name"`
+	InputData    json.RawMessage `json:"input"`
+	Value        currency.Coin   `json:"value"`
+	Fee          currency.Coin   `json:"fee"`
+}
+
+func (sr *scheduleRequest) decode(b []byte) error {
+	return json.Unmarshal(b, sr)
+}
+
+func (sr *scheduleRequest) validate(round int64, conf *config) error {
+	switch {
+	case sr.DueRound <= round:
+		return errors.New("due round must be in the future")
+	case sr.DueRound-round > conf.MaxRoundsAhead:
+		return fmt.Errorf("due round is more than %d rounds ahead",
+			conf.MaxRoundsAhead)
+	case sr.ToClientID == ADDRESS:
+		return errors.New("can't schedule calls to the scheduler")
+	case smartcontract.GetSmartContract(sr.ToClientID) == nil:
+		return errors.New("unknown smart contract address")
+	case sr.FunctionName == "":
+		return errors.New("missing function name")
+	case len(sr.FunctionName)+len(sr.InputData) > conf.MaxInputSize:
+		return fmt.Errorf("call is bigger than %d bytes", conf.MaxInputSize)
+	}
+	return nil
+}
+
+// scheduledIDRequest is input of the 'cancel_scheduled' and
+// 'execute_scheduled' functions.
+type scheduledIDRequest struct {
+	ID string `json:"id"`
+}
+
+func (sr *scheduledIDRequest) decode(b []byte) error {
+	if err := json.Unmarshal(b, sr); err != nil {
+		return err
+	}
+	if sr.ID == "" {
+		return errors.New("missing scheduled transaction id")
+	}
+	return nil
+}
+
+// ScheduledTxn is a smart contract call escrowed until its due round.
+type ScheduledTxn struct {
+	// ID is hash of the scheduling transaction.
+	ID             string        `json:"id"`
+	OwnerID        string        `json:"owner_id"`
+	OwnerPublicKey string        `json:"owner_public_key"`
+	DueRound       int64         `json:"due_round"`
+	ToClientID     string        `json:"to_client_id"`
+	FunctionName   string        `json:"name"`
+	InputData      string        `json:"input"`
+	Value          currency.Coin `json:"value"`
+	Fee            currency.Coin `json:"fee"`
+}
+
+func scheduledTxnKey(id string) datastore.Key {
+	return ADDRESS + encryption.Hash("scheduled_txn:"+id)
+}
+
+// escrowed tokens of the scheduled transaction
+func (st *ScheduledTxn) total() (currency.Coin, error) {
+	return currency.AddCoin(st.Value, st.Fee)
+}
+
+func getScheduledTxn(id string, balances chainstate.CommonStateContextI) (
+	*ScheduledTxn, error) {
+
+	st := new(ScheduledTxn)
+	if err := balances.GetTrieNode(scheduledTxnKey(id), st); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// roundTxns lists IDs of the transactions due at the round, in the order
+// they were scheduled.
+type roundTxns struct {
+	Round int64    `json:"round"`
+	IDs   []string `json:"ids"`
+}
+
+func roundTxnsKey(round int64) datastore.Key {
+	return ADDRESS + encryption.Hash("scheduled_round:"+strconv.FormatInt(round, 10))
+}
+
+func (rt *roundTxns) remove(id string) bool {
+	for i, rid := range rt.IDs {
+		if rid == id {
+			rt.IDs = append(rt.IDs[:i], rt.IDs[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func getRoundTxns(round int64, balances chainstate.CommonStateContextI) (
+	*roundTxns, error) {
+
+	rt := &roundTxns{Round: round}
+	err := balances.GetTrieNode(roundTxnsKey(round), rt)
+	if err != nil && err != util.ErrValueNotPresent {
+		return nil, err
+	}
+	return rt, nil
+}
+
+// scheduleQueue is the ordered list of rounds having scheduled transactions.
+type scheduleQueue struct {
+	Rounds  []int64 `json:"rounds"`
+	Pending int     `json:"pending"`
+}
+
+func scheduleQueueKey() datastore.Key {
+	return ADDRESS + encryption.Hash("schedule_queue")
+}
+
+func (sq *scheduleQueue) addRound(round int64) {
+	i := sort.Search(len(sq.Rounds), func(i int) bool {
+		return sq.Rounds[i] >= round
+	})
+	if i < len(sq.Rounds) && sq.Rounds[i] == round {
+		return
+	}
+	sq.Rounds = append(sq.Rounds, 0)
+	copy(sq.Rounds[i+1:], sq.Rounds[i:])
+	sq.Rounds[i] = round
+}
+
+func (sq *scheduleQueue) removeRound(round int64) {
+	i := sort.Search(len(sq.Rounds), func(i int) bool {
+		return sq.Rounds[i] >= round
+	})
+	if i < len(sq.Rounds) && sq.Rounds[i] == round {
+		sq.Rounds = append(sq.Rounds[:i], sq.Rounds[i+1:]...)
+	}
+}
+
+func getScheduleQueue(balances chainstate.CommonStateContextI) (
+	*scheduleQueue, error) {
+
+	sq := new(scheduleQueue)
+	err := balances.GetTrieNode(scheduleQueueKey(), sq)
+	if err != nil && err != util.ErrValueNotPresent {
+		return nil, err
+	}
+	return sq, nil
+}
+
+// ownerTxns counts the pending scheduled transactions of an owner.
+type ownerTxns struct {
+	OwnerID string `json:"owner_id"`
+	Pending int    `json:"pending"`
+}
+
+func ownerTxnsKey(ownerID string) datastore.Key {
+	return ADDRESS + encryption.Hash("scheduled_owner:"+ownerID)
+}
+
+func getOwnerTxns(ownerID string, balances chainstate.CommonStateContextI) (
+	*ownerTxns, error) {
+
+	ot := &ownerTxns{OwnerID: ownerID}
+	err := balances.GetTrieNode(ownerTxnsKey(ownerID), ot)
+	if err != nil && err != util.ErrValueNotPresent {
+		return nil, err
+	}
+	return ot, nil
+}
+
+func (ot *ownerTxns) save(balances chainstate.StateContextI) (err error) {
+	if ot.Pending == 0 {
+		_, err = balances.DeleteTrieNode(ownerTxnsKey(ot.OwnerID))
+		return
+	}
+	_, err = balances.InsertTrieNode(ownerTxnsKey(ot.OwnerID), ot)
+	return
+}
+
+// enqueue saves the scheduled transaction and adds it to its round
+func enqueue(st *ScheduledTxn, conf *config,
+	balances chainstate.StateContextI) error {
+
+	ot, err := getOwnerTxns(st.OwnerID, balances)
+	if err != nil {
+		return err
+	}
+	if ot.Pending >= conf.MaxPending {
+		return fmt.Errorf("too many pending scheduled transactions of the owner (%d)",
+			conf.MaxPending)
+	}
+
+	sq, err := getScheduleQueue(balances)
+	if err != nil {
+		return err
+	}
+
+	rt, err := getRoundTxns(st.DueRound, balances)
+	if err != nil {
+		return err
+	}
+	rt.IDs = append(rt.IDs, st.ID)
+	sq.addRound(st.DueRound)
+	sq.Pending++
+	ot.Pending++
+
+	if _, err = balances.InsertTrieNode(scheduledTxnKey(st.ID), st); err != nil {
+		return err
+	}
+	if err = ot.save(balances); err != nil {
+		return err
+	}
+	if _, err = balances.InsertTrieNode(roundTxnsKey(st.DueRound), rt); err != nil {
+		return err
+	}
+	_, err = balances.InsertTrieNode(scheduleQueueKey(), sq)
+	return err
+}
+
+// dequeue removes the scheduled transaction and its queue entry
+func dequeue(st *ScheduledTxn, balances chainstate.StateContextI) error {
+	sq, err := getScheduleQueue(balances)
+	if err != nil {
+		return err
+	}
+	rt, err := getRoundTxns(st.DueRound, balances)
+	if err != nil {
+		return err
+	}
+	if !rt.remove(st.ID) {
+		return errors.New("scheduled transaction is not queued")
+	}
+
+	if len(rt.IDs) == 0 {
+		sq.removeRound(st.DueRound)
+		_, err = balances.DeleteTrieNode(roundTxnsKey(st.DueRound))
+	} else {
+		_, err = balances.InsertTrieNode(roundTxnsKey(st.DueRound), rt)
+	}
+	if err != nil {
+		return err
+	}
+	sq.Pending--
+
+	ot, err := getOwnerTxns(st.OwnerID, balances)
+	if err != nil {
+		return err
+	}
+	if ot.Pending > 0 {
+		ot.Pending--
+	}
+	if err = ot.save(balances); err != nil {
+		return err
+	}
+
+	if _, err = balances.DeleteTrieNode(scheduledTxnKey(st.ID)); err != nil {
+		return err
+	}
+	_, err = balances.InsertTrieNode(scheduleQueueKey(), sq)
+	return err
+}
+
+// GetDueTxnIDs returns IDs of up to limit scheduled transactions due at or
+// before the round, earliest first. Used by block generators to build the
+// 'execute_scheduled' transactions of a block.
+func GetDueTxnIDs(balances chainstate.CommonStateContextI, round int64,
+	limit int) ([]string, error) {
+
+	sq, err := getScheduleQueue(balances)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, r := range sq.Rounds {
+		if r > round || len(ids) >= limit {
+			break
+		}
+		rt, err := getRoundTxns(r, balances)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range rt.IDs {
+			if len(ids) >= limit {
+				break
+			}
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// GetMaxExecutionsPerRound returns the number of scheduled transactions a
+// block generator executes in a block.
+func GetMaxExecutionsPerRound(balances chainstate.CommonStateContextI) (int, error) {
+	conf, err := getConfig(balances)
+	if err != nil {
+		return 0, err
+	}
+	return conf.MaxExecutionsPerRound, nil
+}
+
+// schedule escrows the transaction value and queues the call for its due
+// round
+func (ssc *SchedulerSmartContract) schedule(t *transaction.Transaction,
+	input []byte, balances chainstate.StateContextI) (string, error) {
+
+	conf, err := getConfig(balances)
+	if err != nil {
+		return "", common.NewError("schedule_failed",
+			"can't get config: "+err.Error())
+	}
+
+	var req scheduleRequest
+	if err = req.decode(input); err != nil {
+		return "", common.NewError("schedule_failed",
+			"malformed request: "+err.Error())
+	}
+	if err = req.validate(balances.GetBlock().Round, conf); err != nil {
+		return "", common.NewError("schedule_failed",
+			"invalid request: "+err.Error())
+	}
+
+	total, err := currency.AddCoin(req.Value, req.Fee)
+	if err != nil {
+		return "", common.NewError("schedule_failed", err.Error())
+	}
+	if t.Value != total {
+		return "", common.NewError("schedule_failed",
+			fmt.Sprintf("transaction value %d doesn't equal to value + fee %d",
+				t.Value, total))
+	}
+
+	st := &ScheduledTxn{
+		ID:             t.Hash,
+		OwnerID:        t.ClientID,
+		OwnerPublicKey: t.PublicKey,
+		DueRound:       req.DueRound,
+		ToClientID:     req.ToClientID,
+		FunctionName:   req.FunctionName,
+		InputData:      string(req.InputData),
+		Value:          req.Value,
+		Fee:            req.Fee,
+	}
+
+	if total > 0 {
+		err = balances.AddTransfer(state.NewTransfer(t.ClientID, ADDRESS, total))
+		if err != nil {
+			return "", common.NewError("schedule_failed", err.Error())
+		}
+	}
+
+	if err = enqueue(st, conf, balances); err != nil {
+		return "", common.NewError("schedule_failed",
+			"saving scheduled transaction: "+err.Error())
+	}
+
+	return string(mustEncode(st)), nil
+}
+
+// cancelScheduled removes a pending scheduled transaction, returning the
+// escrowed tokens to its owner
+func (ssc *SchedulerSmartContract) cancelScheduled(t *transaction.Transaction,
+	input []byte, balances chainstate.StateContextI) (string, error) {
+
+	var req scheduledIDRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("cancel_scheduled_failed",
+			"malformed request: "+err.Error())
+	}
+
+	st, err := getScheduledTxn(req.ID, balances)
+	if err != nil {
+		return "", common.NewError("cancel_scheduled_failed",
+			"can't get scheduled transaction: "+err.Error())
+	}
+	if st.OwnerID != t.ClientID {
+		return "", common.NewError("cancel_scheduled_failed",
+			"only owner can cancel a scheduled transaction")
+	}
+
+	if err = refund(st, balances); err != nil {
+		return "", common.NewError("cancel_scheduled_failed", err.Error())
+	}
+	if err = dequeue(st, balances); err != nil {
+		return "", common.NewError("cancel_scheduled_failed",
+			"removing scheduled transaction: "+err.Error())
+	}
+
+	return "scheduled transaction " + st.ID + " cancelled", nil
+}
+
+// executeScheduled runs a due scheduled call on behalf of its owner. It's
+// sent by the block generator, which gets the fee of the call. A failed call
+// is dropped by DropFailedCall, it's not retried in next rounds.
+func (ssc *SchedulerSmartContract) executeScheduled(t *transaction.Transaction,
+	input []byte, balances chainstate.StateContextI) (string, error) {
+
+	var b = balances.GetBlock()
+	if t.ClientID != b.MinerID {
+		return "", common.NewError("execute_scheduled_failed",
+			"not block generator")
+	}
+
+	var req scheduledIDRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("execute_scheduled_failed",
+			"malformed request: "+err.Error())
+	}
+
+	st, err := getScheduledTxn(req.ID, balances)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		// cancelled or executed after the state the generator used
+		return "scheduled transaction " + req.ID + " not found", nil
+	default:
+		return "", common.NewError("execute_scheduled_failed",
+			"can't get scheduled transaction: "+err.Error())
+	}
+
+	if b.Round < st.DueRound {
+		return "", common.NewError("execute_scheduled_failed",
+			"scheduled transaction is not due yet")
+	}
+
+	conf, err := getConfig(balances)
+	if err != nil {
+		return "", common.NewError("execute_scheduled_failed",
+			"can't get config: "+err.Error())
+	}
+
+	if err = dequeue(st, balances); err != nil {
+		return "", common.NewError("execute_scheduled_failed",
+			"removing scheduled transaction: "+err.Error())
+	}
+
+	if b.Round > st.DueRound+conf.MaxExecutionDelay {
+		if err = refund(st, balances); err != nil {
+			return "", common.NewError("execute_scheduled_failed", err.Error())
+		}
+		logging.Logger.Info("scheduled transaction expired",
+			zap.String("id", st.ID),
+			zap.Int64("due_round", st.DueRound),
+			zap.Int64("round", b.Round))
+		return "scheduled transaction " + st.ID + " expired", nil
+	}
+
+	// the value goes back to the owner, who sends it with the call
+	if err = release(st, t.ClientID, balances); err != nil {
+		return "", common.NewError("execute_scheduled_failed", err.Error())
+	}
+
+	sc := smartcontract.GetSmartContract(st.ToClientID)
+	if sc == nil {
+		return "", common.NewError("execute_scheduled_failed",
+			"unknown smart contract address")
+	}
+
+	call := t.Clone()
+	call.ClientID = st.OwnerID
+	call.PublicKey = st.OwnerPublicKey
+	call.ToClientID = st.ToClientID
+	call.Value = st.Value
+	call.Fee = 0
+	call.SmartContractData = &transaction.SmartContractData{
+		FunctionName: st.FunctionName,
+		InputData:    json.RawMessage(st.InputData),
+	}
+
+	output, err := smartcontract.ExecuteWithStats(sc, call, balances)
+	if err != nil {
+		return "", common.NewError(callFailedCode,
+			"smart contract call failed: "+err.Error())
+	}
+
+	return output, nil
+}
+
+// DropFailedCall removes the scheduled transaction whose call failed in the
+// 'execute_scheduled' transaction, so that it's not retried in next rounds
+// holding an execution slot. All changes of the failed transaction are
+// rejected, so the chain calls it with a fresh state context. The generator
+// gets the fee and the value goes back to the owner, as for a successful call.
+func DropFailedCall(t *transaction.Transaction, callErr error,
+	balances chainstate.StateContextI) error {
+
+	if t.ToClientID != ADDRESS || t.FunctionName != ExecuteScheduledFuncName {
+		return nil
+	}
+	if cerr, ok := callErr.(*common.Error); !ok || cerr.Code != callFailedCode {
+		return nil
+	}
+
+	var req scheduledIDRequest
+	if err := req.decode(t.InputData); err != nil {
+		return err
+	}
+	st, err := getScheduledTxn(req.ID, balances)
+	if err != nil {
+		return err
+	}
+	if err = dequeue(st, balances); err != nil {
+		return err
+	}
+	if err = release(st, t.ClientID, balances); err != nil {
+		return err
+	}
+
+	logging.Logger.Info("scheduled transaction dropped",
+		zap.String("id", st.ID),
+		zap.Int64("due_round", st.DueRound),
+		zap.Error(callErr))
+	return nil
+}
+
+// release returns the escrowed value to the owner and pays the fee to the
+// generator that executed the call
+func release(st *ScheduledTxn, generatorID string,
+	balances chainstate.StateContextI) error {
+
+	if st.Value > 0 {
+		err := balances.AddTransfer(state.NewTransfer(ADDRESS, st.OwnerID, st.Value))
+		if err != nil {
+			return err
+		}
+	}
+	if st.Fee > 0 {
+		return balances.AddTransfer(state.NewTransfer(ADDRESS, generatorID, st.Fee))
+	}
+	return nil
+}
+
+// refund returns the escrowed tokens to the owner
+func refund(st *ScheduledTxn, balances chainstate.StateContextI) error {
+	total, err := st.total()
+	if err != nil {
+		return err
+	}
+	if total == 0 {
+		return nil
+	}
+	return balances.AddTransfer(state.NewTransfer(ADDRESS, st.OwnerID, total))
+}
+
+func mustEncode(v interface{}) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err) // must not happens
+	}
+	return b
+}
@@ -0,0 +1,507 @@
+package schedulersc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	chainstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	"0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/logging"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	ownerID     = "owner"
+	otherID     = "other"
+	generatorID = "generator"
+	targetSC    = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a7671200"
+)
+
+func init() {
+	logging.Logger = zap.NewNop()
+}
+
+// targetSmartContract records the calls it executes
+type targetSmartContract struct {
+	*smartcontractinterface.SmartContract
+	calls []*transaction.Transaction
+}
+
+func (tsc *targetSmartContract) Execute(t *transaction.Transaction, funcName string,
+	input []byte, _ chainstate.StateContextI) (string, error) {
+
+	if funcName == "fail" {
+		return "", errors.New("call failed")
+	}
+	tsc.calls = append(tsc.calls, t)
+	return "called " + funcName + " with " + string(input), nil
+}
+
+func (tsc *targetSmartContract) GetHandlerStats(context.Context, url.Values) (interface{}, error) {
+	return nil, nil
+}
+
+func (tsc *targetSmartContract) GetExecutionStats() map[string]interface{} {
+	return tsc.SmartContractExecutionStats
+}
+
+func (tsc *targetSmartContract) GetName() string    { return "target" }
+func (tsc *targetSmartContract) GetAddress() string { return targetSC }
+
+func (tsc *targetSmartContract) GetCostTable(chainstate.StateContextI) (map[string]int, error) {
+	return map[string]int{}, nil
+}
+
+func testConfig() *config {
+	return &config{
+		MaxRoundsAhead:        100,
+		MaxExecutionDelay:     10,
+		MaxExecutionsPerRound: 2,
+		MaxPending:            2,
+		MaxInputSize:          64,
+	}
+}
+
+func setup(t *testing.T) (*SchedulerSmartContract, *targetSmartContract, *testBalances) {
+	ssc := NewSchedulerSmartContract().(*SchedulerSmartContract)
+	target := &targetSmartContract{SmartContract: smartcontractinterface.NewSC(targetSC)}
+	smartcontract.ContractMap[ADDRESS] = ssc
+	smartcontract.ContractMap[targetSC] = target
+
+	balances := newTestBalances()
+	_, err := balances.InsertTrieNode(scConfigKey(ADDRESS), testConfig())
+	require.NoError(t, err)
+	balances.setRound(10, generatorID)
+	return ssc, target, balances
+}
+
+func newTransaction(clientID string, value currency.Coin, balances *testBalances) *transaction.Transaction {
+	tx := &transaction.Transaction{
+		ClientID:   clientID,
+		ToClientID: ADDRESS,
+		Value:      value,
+	}
+	tx.Hash = encryption.Hash(clientID + string(rune(len(balances.tree))) + string(rune(len(balances.transfers))))
+	balances.txn = tx
+	return tx
+}
+
+func validRequest() scheduleRequest {
+	return scheduleRequest{
+		DueRound:     20,
+		ToClientID:   targetSC,
+		FunctionName: "call",
+		InputData:    json.RawMessage(`{"a":1}`),
+		Value:        5,
+		Fee:          2,
+	}
+}
+
+func schedule(t *testing.T, ssc *SchedulerSmartContract, clientID string,
+	req scheduleRequest, balances *testBalances) (*ScheduledTxn, error) {
+
+	total, err := currency.AddCoin(req.Value, req.Fee)
+	require.NoError(t, err)
+	balances.setBalance(clientID, balances.balances[clientID]+total)
+
+	resp, err := ssc.schedule(newTransaction(clientID, total, balances),
+		mustEncode(req), balances)
+	if err != nil {
+		return nil, err
+	}
+	st := new(ScheduledTxn)
+	require.NoError(t, json.Unmarshal([]byte(resp), st))
+	return st, nil
+}
+
+func TestSchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(req *scheduleRequest)
+		value   func(req scheduleRequest) currency.Coin
+		pending map[string]int
+		wantErr string
+	}{
+		{
+			name: "ok",
+		},
+		{
+			name:    "another owner at the pending limit",
+			pending: map[string]int{otherID: 2},
+		},
+		{
+			name:    "owner at the pending limit",
+			pending: map[string]int{ownerID: 2},
+			wantErr: "too many pending scheduled transactions of the owner",
+		},
+		{
+			name:    "due round not in the future",
+			modify:  func(req *scheduleRequest) { req.DueRound = 10 },
+			wantErr: "due round must be in the future",
+		},
+		{
+			name:    "due round too far ahead",
+			modify:  func(req *scheduleRequest) { req.DueRound = 111 },
+			wantErr: "more than 100 rounds ahead",
+		},
+		{
+			name:    "call to the scheduler",
+			modify:  func(req *scheduleRequest) { req.ToClientID = ADDRESS },
+			wantErr: "can't schedule calls to the scheduler",
+		},
+		{
+			name:    "unknown smart contract",
+			modify:  func(req *scheduleRequest) { req.ToClientID = otherID },
+			wantErr: "unknown smart contract address",
+		},
+		{
+			name:    "missing function name",
+			modify:  func(req *scheduleRequest) { req.FunctionName = "" },
+			wantErr: "missing function name",
+		},
+		{
+			name: "input too big",
+			modify: func(req *scheduleRequest) {
+				req.InputData = json.RawMessage(`"` + strings.Repeat("a", 64) + `"`)
+			},
+			wantErr: "call is bigger than 64 bytes",
+		},
+		{
+			name:    "value doesn't cover the fee",
+			value:   func(req scheduleRequest) currency.Coin { return req.Value },
+			wantErr: "doesn't equal to value + fee",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ssc, _, balances := setup(t)
+
+			for owner, n := range tt.pending {
+				for i := 0; i < n; i++ {
+					_, err := schedule(t, ssc, owner, validRequest(), balances)
+					require.NoError(t, err)
+				}
+			}
+
+			req := validRequest()
+			if tt.modify != nil {
+				tt.modify(&req)
+			}
+			value := req.Value + req.Fee
+			if tt.value != nil {
+				value = tt.value(req)
+			}
+
+			balances.setBalance(ownerID, value)
+			escrowed := balances.balances[ADDRESS]
+			resp, err := ssc.schedule(newTransaction(ownerID, value, balances),
+				mustEncode(req), balances)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var st ScheduledTxn
+			require.NoError(t, json.Unmarshal([]byte(resp), &st))
+			saved, err := getScheduledTxn(st.ID, balances)
+			require.NoError(t, err)
+			require.Equal(t, st, *saved)
+			require.Equal(t, ownerID, saved.OwnerID)
+			require.EqualValues(t, 0, balances.balances[ownerID])
+			require.Equal(t, escrowed+7, balances.balances[ADDRESS])
+
+			ot, err := getOwnerTxns(ownerID, balances)
+			require.NoError(t, err)
+			require.Equal(t, 1, ot.Pending)
+
+			ids, err := GetDueTxnIDs(balances, req.DueRound, 10)
+			require.NoError(t, err)
+			require.Contains(t, ids, st.ID)
+		})
+	}
+}
+
+func TestCancelScheduled(t *testing.T) {
+	tests := []struct {
+		name     string
+		clientID string
+		id       func(st *ScheduledTxn) string
+		wantErr  string
+	}{
+		{
+			name:     "ok",
+			clientID: ownerID,
+		},
+		{
+			name:     "not the owner",
+			clientID: otherID,
+			wantErr:  "only owner can cancel a scheduled transaction",
+		},
+		{
+			name:     "unknown scheduled transaction",
+			clientID: ownerID,
+			id:       func(*ScheduledTxn) string { return "unknown" },
+			wantErr:  "can't get scheduled transaction",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ssc, _, balances := setup(t)
+
+			st, err := schedule(t, ssc, ownerID, validRequest(), balances)
+			require.NoError(t, err)
+
+			id := st.ID
+			if tt.id != nil {
+				id = tt.id(st)
+			}
+			_, err = ssc.cancelScheduled(newTransaction(tt.clientID, 0, balances),
+				mustEncode(scheduledIDRequest{ID: id}), balances)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			_, err = getScheduledTxn(st.ID, balances)
+			require.Equal(t, util.ErrValueNotPresent, err)
+			require.EqualValues(t, 7, balances.balances[ownerID])
+			require.EqualValues(t, 0, balances.balances[ADDRESS])
+
+			ot, err := getOwnerTxns(ownerID, balances)
+			require.NoError(t, err)
+			require.Zero(t, ot.Pending)
+
+			sq, err := getScheduleQueue(balances)
+			require.NoError(t, err)
+			require.Empty(t, sq.Rounds)
+			require.Zero(t, sq.Pending)
+		})
+	}
+}
+
+func TestExecuteScheduled(t *testing.T) {
+	type want struct {
+		err      string
+		resp     string
+		called   bool
+		dequeued bool
+		owner    currency.Coin
+		gen      currency.Coin
+	}
+
+	tests := []struct {
+		name     string
+		clientID string
+		round    int64
+		function string
+		id       string
+		want     want
+	}{
+		{
+			name:     "ok",
+			clientID: generatorID,
+			round:    20,
+			want: want{
+				resp:     `called call with {"a":1}`,
+				called:   true,
+				dequeued: true,
+				owner:    5,
+				gen:      2,
+			},
+		},
+		{
+			name:     "retried after the due round",
+			clientID: generatorID,
+			round:    30,
+			want: want{
+				resp:     `called call with {"a":1}`,
+				called:   true,
+				dequeued: true,
+				owner:    5,
+				gen:      2,
+			},
+		},
+		{
+			name:     "not the block generator",
+			clientID: otherID,
+			round:    20,
+			want:     want{err: "not block generator"},
+		},
+		{
+			name:     "not due yet",
+			clientID: generatorID,
+			round:    19,
+			want:     want{err: "scheduled transaction is not due yet"},
+		},
+		{
+			name:     "expired",
+			clientID: generatorID,
+			round:    31,
+			want: want{
+				resp:     "expired",
+				dequeued: true,
+				owner:    7,
+			},
+		},
+		{
+			name:     "already executed or cancelled",
+			clientID: generatorID,
+			round:    20,
+			id:       "unknown",
+			want:     want{resp: "not found"},
+		},
+		{
+			name:     "failing call",
+			clientID: generatorID,
+			round:    20,
+			function: "fail",
+			want:     want{err: "smart contract call failed: call failed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ssc, target, balances := setup(t)
+
+			req := validRequest()
+			if tt.function != "" {
+				req.FunctionName = tt.function
+			}
+			st, err := schedule(t, ssc, ownerID, req, balances)
+			require.NoError(t, err)
+			balances.setBalance(ownerID, 0)
+
+			id := st.ID
+			if tt.id != "" {
+				id = tt.id
+			}
+			balances.setRound(tt.round, generatorID)
+			resp, err := ssc.executeScheduled(newTransaction(tt.clientID, 0, balances),
+				mustEncode(scheduledIDRequest{ID: id}), balances)
+			if tt.want.err != "" {
+				require.ErrorContains(t, err, tt.want.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, resp, tt.want.resp)
+
+			require.Equal(t, tt.want.called, len(target.calls) == 1)
+			if tt.want.called {
+				call := target.calls[0]
+				require.Equal(t, ownerID, call.ClientID)
+				require.Equal(t, targetSC, call.ToClientID)
+				require.Equal(t, req.Value, call.Value)
+			}
+
+			_, err = getScheduledTxn(st.ID, balances)
+			require.Equal(t, tt.want.dequeued, err == util.ErrValueNotPresent)
+			require.Equal(t, tt.want.owner, balances.balances[ownerID])
+			require.Equal(t, tt.want.gen, balances.balances[generatorID])
+		})
+	}
+}
+
+func TestDropFailedCall(t *testing.T) {
+	tests := []struct {
+		name     string
+		function string
+		err      error
+		want     bool
+	}{
+		{
+			name:     "failed call dropped",
+			function: ExecuteScheduledFuncName,
+			err:      common.NewError(callFailedCode, "smart contract call failed: call failed"),
+			want:     true,
+		},
+		{
+			name:     "execute_scheduled failed before the call",
+			function: ExecuteScheduledFuncName,
+			err:      common.NewError("execute_scheduled_failed", "not block generator"),
+		},
+		{
+			name:     "another function",
+			function: CancelScheduledFuncName,
+			err:      common.NewError(callFailedCode, "smart contract call failed: call failed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ssc, _, balances := setup(t)
+
+			req := validRequest()
+			req.FunctionName = "fail"
+			st, err := schedule(t, ssc, ownerID, req, balances)
+			require.NoError(t, err)
+			balances.setBalance(ownerID, 0)
+			balances.setRound(20, generatorID)
+
+			tx := newTransaction(generatorID, 0, balances)
+			tx.SmartContractData = &transaction.SmartContractData{
+				FunctionName: tt.function,
+				InputData:    mustEncode(scheduledIDRequest{ID: st.ID}),
+			}
+			require.NoError(t, DropFailedCall(tx, tt.err, balances))
+
+			_, err = getScheduledTxn(st.ID, balances)
+			require.Equal(t, tt.want, err == util.ErrValueNotPresent)
+			ids, err := GetDueTxnIDs(balances, 20, 10)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, len(ids) == 0, "a dropped call is not due anymore")
+			if tt.want {
+				require.EqualValues(t, 5, balances.balances[ownerID])
+				require.EqualValues(t, 2, balances.balances[generatorID])
+			}
+		})
+	}
+}
+
+func TestGetDueTxnIDs(t *testing.T) {
+	ssc, _, balances := setup(t)
+
+	var ids []string
+	for i, due := range []int64{30, 20, 20} {
+		req := validRequest()
+		req.DueRound = due
+		owner := []string{ownerID, otherID, generatorID}[i]
+		st, err := schedule(t, ssc, owner, req, balances)
+		require.NoError(t, err)
+		ids = append(ids, st.ID)
+	}
+
+	tests := []struct {
+		name  string
+		round int64
+		limit int
+		want  []string
+	}{
+		{name: "nothing due", round: 19, limit: 10},
+		{name: "due at the round", round: 20, limit: 10, want: ids[1:]},
+		{name: "earliest first", round: 30, limit: 10, want: []string{ids[1], ids[2], ids[0]}},
+		{name: "limited", round: 30, limit: 2, want: ids[1:]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetDueTxnIDs(balances, tt.round, tt.limit)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"0chain.net/smartcontract/faucetsc"
 	"0chain.net/smartcontract/minersc"
 	"0chain.net/smartcontract/multisigsc"
+	"0chain.net/smartcontract/schedulersc"
 	"0chain.net/smartcontract/storagesc"
 	"0chain.net/smartcontract/vestingsc"
 	"0chain.net/smartcontract/zcnsc"
@@ -23,6 +24,7 @@ const (
 	Miner
 	Vesting
 	Zcn
+	Scheduler
 )
 
 var (
@@ -33,15 +35,17 @@ var (
 		"miner",
 		"vesting",
 		"zcn",
+		"scheduler",
 	}
 
 	SCCode = map[string]SCName{
-		"faucet":   Faucet,
-		"storage":  Storage,
-		"multisig": Multisig,
-		"miner":    Miner,
-		"vesting":  Vesting,
-		"zcn":      Zcn,
+		"faucet":    Faucet,
+		"storage":   Storage,
+		"multisig":  Multisig,
+		"miner":     Miner,
+		"vesting":   Vesting,
+		"zcn":       Zcn,
+		"scheduler": Scheduler,
 	}
 )
 
@@ -73,6 +77,8 @@ func newSmartContract(name string) sci.SmartContractInterface {
 		return vestingsc.NewVestingSmartContract()
 	case Zcn:
 		return zcnsc.NewZCNSmartContract()
+	case Scheduler:
+		return schedulersc.NewSchedulerSmartContract()
 	default:
 		return nil
 	}
@@ -0,0 +1,168 @@
+package stakepool
+
+import (
+	"encoding/json"
+	"errors"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+)
+
+// autoCompoundHardFork is the hard fork enabling the auto-compounding, the
+// delegate pools are encoded as before until then
+const autoCompoundHardFork = "hermes"
+
+// MaxStakeFunc returns the max stake of the delegate pools of a provider
+// type, the rewards compound up to it
+type MaxStakeFunc func(balances cstate.CommonStateContextI) (currency.Coin, error)
+
+var maxStakeFuncs = make(map[spenum.Provider]MaxStakeFunc)
+
+// RegisterMaxStake sets the max stake of the delegate pools of the provider
+// types of a smart contract, the rewards of them don't compound without it
+func RegisterMaxStake(f MaxStakeFunc, providerTypes ...spenum.Provider) {
+	for _, pt := range providerTypes {
+		maxStakeFuncs[pt] = f
+	}
+}
+
+// StakePoolAutoCompoundRequest sets the auto-compounding of the rewards of the
+// delegate pool of the client
+type StakePoolAutoCompoundRequest struct {
+	ProviderType spenum.Provider `json:"provider_type"`
+	ProviderID   string          `json:"provider_id"`
+	AutoCompound bool            `json:"auto_compound"`
+}
+
+func (sacr *StakePoolAutoCompoundRequest) Encode() []byte {
+	bytes, _ := json.Marshal(sacr)
+	return bytes
+}
+
+func (sacr *StakePoolAutoCompoundRequest) decode(p []byte) error {
+	return json.Unmarshal(p, sacr)
+}
+
+// compoundMaxStake returns the max stake the rewards of the stake pool
+// compound up to, zero if none of its delegate pools auto-compounds
+func (sp *StakePool) compoundMaxStake(providerType spenum.Provider,
+	balances cstate.CommonStateContextI) (currency.Coin, error) {
+
+	f, ok := maxStakeFuncs[providerType]
+	if !ok {
+		return 0, nil
+	}
+	for _, dp := range sp.Pools {
+		if dp.AutoCompound {
+			return f(balances)
+		}
+	}
+	return 0, nil
+}
+
+// addReward to the delegate pool, an auto-compounding pool adds it to its
+// balance up to the max stake and the overflow to its reward
+func (dp *DelegatePool) addReward(reward, maxStake currency.Coin) (compounded currency.Coin, err error) {
+	if dp.AutoCompound && dp.Balance < maxStake {
+		compounded = maxStake - dp.Balance
+		if compounded > reward {
+			compounded = reward
+		}
+		if dp.Balance, err = currency.AddCoin(dp.Balance, compounded); err != nil {
+			return 0, err
+		}
+	}
+
+	if dp.Reward, err = currency.AddCoin(dp.Reward, reward-compounded); err != nil {
+		return 0, err
+	}
+	return compounded, nil
+}
+
+// creditDelegate credits the reward to the delegate pool and records it
+func (spu *StakePoolReward) creditDelegate(dp *DelegatePool, reward, maxStake currency.Coin) error {
+	compounded, err := dp.addReward(reward, maxStake)
+	if err != nil {
+		return err
+	}
+
+	spu.DelegateRewards[dp.DelegateID], err = currency.AddCoin(spu.DelegateRewards[dp.DelegateID], reward)
+	if err != nil {
+		return err
+	}
+
+	if compounded > 0 {
+		spu.DelegateCompounds[dp.DelegateID], err = currency.AddCoin(spu.DelegateCompounds[dp.DelegateID], compounded)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// emitCompoundedStake emits the new stake of the provider if rewards were compounded
+func (sp *StakePool) emitCompoundedStake(
+	spu *StakePoolReward,
+	providerType spenum.Provider,
+	providerID string,
+	balances cstate.StateContextI,
+) error {
+	if len(spu.DelegateCompounds) == 0 {
+		return nil
+	}
+	return sp.EmitStakeEvent(providerType, providerID, balances)
+}
+
+// StakePoolAutoCompound turns the auto-compounding of the rewards of the
+// delegate pool of the client on or off. The rewards compound up to the
+// max stake of the smart contract at the time of the reward.
+func StakePoolAutoCompound(t *transaction.Transaction, input []byte, balances cstate.StateContextI,
+	funcs ...func(providerType spenum.Provider, providerID string, balances cstate.StateContextI) (AbstractStakePool, error),
+) (resp string, err error) {
+	if err := cstate.WithActivation(balances, autoCompoundHardFork, func() error {
+		return errors.New("auto-compounding is not active yet")
+	}, func() error {
+		return nil
+	}); err != nil {
+		return "", common.NewError("stake_pool_auto_compound_failed", err.Error())
+	}
+
+	var sacr StakePoolAutoCompoundRequest
+	if err = sacr.decode(input); err != nil {
+		return "", common.NewErrorf("stake_pool_auto_compound_failed",
+			"invalid request: %v", err)
+	}
+	if len(funcs) < 1 {
+		return "", common.NewError("stake_pool_auto_compound_failed",
+			"provide get func")
+	}
+
+	get := funcs[0]
+	sp, err := get(sacr.ProviderType, sacr.ProviderID, balances)
+	if err != nil {
+		return "", common.NewErrorf("stake_pool_auto_compound_failed",
+			"can't get stake pool: %v", err)
+	}
+
+	dp, ok := sp.GetPools()[t.ClientID]
+	if !ok {
+		return "", common.NewErrorf("stake_pool_auto_compound_failed",
+			"no such delegate pool: %v", t.ClientID)
+	}
+
+	dp.AutoCompound = sacr.AutoCompound
+
+	if err := sp.Save(sacr.ProviderType, sacr.ProviderID, balances); err != nil {
+		return "", common.NewErrorf("stake_pool_auto_compound_failed",
+			"saving stake pool: %v", err)
+	}
+
+	update := newDelegatePoolUpdate(t.ClientID, sacr.ProviderID, sacr.ProviderType)
+	update.Updates["auto_compound"] = dp.AutoCompound
+	update.emitUpdate(balances)
+
+	return toJson(sacr), nil
+}
@@ -0,0 +1,273 @@
+package stakepool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+//msgp:ignore StakePoolRedelegateRequest
+//go:generate msgp -v -io=false -tests=false
+
+// StakePoolRedelegateRequest moves the delegate pool of the client from a
+// provider to another one of the same smart contract
+type StakePoolRedelegateRequest struct {
+	FromProviderType spenum.Provider `json:"from_provider_type"`
+	FromProviderID   string          `json:"from_provider_id"`
+	ToProviderType   spenum.Provider `json:"to_provider_type"`
+	ToProviderID     string          `json:"to_provider_id"`
+}
+
+func (srr *StakePoolRedelegateRequest) Encode() []byte {
+	bytes, _ := json.Marshal(srr)
+	return bytes
+}
+
+func (srr *StakePoolRedelegateRequest) decode(p []byte) error {
+	return json.Unmarshal(p, srr)
+}
+
+// Redelegation is the last redelegation of a client in a smart contract,
+// used to rate limit the redelegations
+type Redelegation struct {
+	LastRedelegatedAt common.Timestamp `json:"last_redelegated_at"`
+}
+
+func redelegationKey(scID, clientID string) datastore.Key {
+	return scID + ":redelegation:" + clientID
+}
+
+func (r *Redelegation) Encode() []byte {
+	var b, err = json.Marshal(r)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (r *Redelegation) Decode(p []byte) error {
+	return json.Unmarshal(p, r)
+}
+
+func getRedelegation(scID, clientID string, balances cstate.StateContextI) (*Redelegation, error) {
+	r := new(Redelegation)
+	err := balances.GetTrieNode(redelegationKey(scID, clientID), r)
+	switch err {
+	case nil, util.ErrValueNotPresent:
+		return r, nil
+	default:
+		return nil, err
+	}
+}
+
+// MoveOut takes the delegate pool of the client out of the stake pool
+// without paying its balance out, the tokens stay in the smart contract
+func (sp *StakePool) MoveOut(clientID string, _ cstate.StateContextI) (*DelegatePool, error) {
+	dp, ok := sp.Pools[clientID]
+	if !ok {
+		return nil, fmt.Errorf("no such delegate pool: %q", clientID)
+	}
+
+	if dp.DelegateID != clientID {
+		return nil, errors.New("trying to move not by delegate pool owner")
+	}
+
+	if dp.Status != spenum.Active && dp.Status != spenum.Pending {
+		return nil, fmt.Errorf("could not move pool in %s status", dp.Status)
+	}
+
+	if len(dp.Unbonding) > 0 {
+		return nil, errors.New("could not move pool with unbonding tokens")
+	}
+
+	moved := *dp
+	dp.Balance = 0
+	dp.Status = spenum.Deleted
+	return &moved, nil
+}
+
+// MoveIn stakes the tokens of a delegate pool moved out of another stake pool
+// of the smart contract, it's a lock without a transfer from the client
+func (sp *StakePool) MoveIn(
+	txn *transaction.Transaction,
+	moved *DelegatePool,
+	providerType spenum.Provider,
+	providerId datastore.Key,
+	balances cstate.StateContextI,
+) (string, error) {
+	var poolID = txn.ClientID
+	dp, ok := sp.Pools[poolID]
+	if !ok {
+		dp = &DelegatePool{
+			Balance:      moved.Balance,
+			Status:       spenum.Active,
+			DelegateID:   txn.ClientID,
+			RoundCreated: balances.GetBlock().Round,
+			StakedAt:     moved.StakedAt,
+		}
+		sp.Pools[poolID] = dp
+		dp.EmitNew(poolID, providerId, providerType, balances)
+	} else {
+		if dp.Status != spenum.Active && dp.Status != spenum.Pending {
+			return "", fmt.Errorf("could not stake pool in %s status", dp.Status)
+		}
+
+		b, err := currency.AddCoin(dp.Balance, moved.Balance)
+		if err != nil {
+			return "", err
+		}
+
+		dp.Balance = b
+		// the moved tokens keep their lock period
+		if moved.StakedAt > dp.StakedAt {
+			dp.StakedAt = moved.StakedAt
+		}
+
+		update := newDelegatePoolUpdate(poolID, providerId, providerType)
+		update.Updates["balance"] = dp.Balance
+		update.emitUpdate(balances)
+	}
+
+	i, err := moved.Balance.Int64()
+	if err != nil {
+		return "", err
+	}
+	lock := event.DelegatePoolLock{
+		Client:       txn.ClientID,
+		ProviderId:   providerId,
+		ProviderType: providerType,
+		Amount:       i,
+		Reward:       currency.Coin(0),
+		Total:        i,
+	}
+	balances.EmitEvent(event.TypeStats, event.TagLockStakePool, poolID, lock)
+
+	return toJson(lock), nil
+}
+
+// StakePoolRedelegate moves the delegate pool of the client between two
+// providers of the smart contract at once, with no unlock and no cool down.
+// The pending rewards are paid out. The tokens never leave the smart contract,
+// so the providers must both belong to the smart contract called.
+func StakePoolRedelegate(t *transaction.Transaction, input []byte, balances cstate.StateContextI,
+	vs ValidationSettings, minRedelegatePeriod time.Duration,
+	funcs ...func(providerType spenum.Provider, providerID string, balances cstate.StateContextI) (AbstractStakePool, error),
+) (resp string, err error) {
+	var srr StakePoolRedelegateRequest
+	if err = srr.decode(input); err != nil {
+		return "", common.NewErrorf("stake_pool_redelegate_failed",
+			"invalid request: %v", err)
+	}
+	if srr.FromProviderType == srr.ToProviderType && srr.FromProviderID == srr.ToProviderID {
+		return "", common.NewError("stake_pool_redelegate_failed",
+			"can't redelegate to the same provider")
+	}
+	if len(funcs) < 1 {
+		return "", common.NewError("stake_pool_redelegate_failed",
+			"provide get func")
+	}
+
+	r, err := getRedelegation(t.ToClientID, t.ClientID, balances)
+	if err != nil {
+		return "", common.NewErrorf("stake_pool_redelegate_failed",
+			"can't get last redelegation: %v", err)
+	}
+	if minRedelegatePeriod > 0 && r.LastRedelegatedAt > 0 {
+		next := common.ToTime(r.LastRedelegatedAt).Add(minRedelegatePeriod)
+		if common.ToTime(t.CreationDate).Before(next) {
+			return "", common.NewErrorf("stake_pool_redelegate_failed",
+				"next redelegation allowed at: %s", next)
+		}
+	}
+
+	get := funcs[0]
+	from, err := get(srr.FromProviderType, srr.FromProviderID, balances)
+	if err != nil {
+		return "", common.NewErrorf("stake_pool_redelegate_failed",
+			"can't get stake pool to redelegate from: %v", err)
+	}
+	to, err := get(srr.ToProviderType, srr.ToProviderID, balances)
+	if err != nil {
+		return "", common.NewErrorf("stake_pool_redelegate_failed",
+			"can't get stake pool to redelegate to: %v", err)
+	}
+	if to.IsDead() {
+		return "", common.NewError("stake_pool_redelegate_failed",
+			"can't redelegate to a killed provider")
+	}
+
+	dp, ok := from.GetPools()[t.ClientID]
+	if !ok {
+		return "", common.NewErrorf("stake_pool_redelegate_failed",
+			"no such delegate pool: %v", t.ClientID)
+	}
+	if err := validateStake("stake_pool_redelegate_failed", t.ClientID, dp.Balance, to, vs); err != nil {
+		return "", err
+	}
+
+	if _, err := from.UnlockPool(t.ClientID, srr.FromProviderType, srr.FromProviderID, balances); err != nil {
+		return "", common.NewErrorf("stake_pool_redelegate_failed", "%v", err)
+	}
+
+	moved, err := from.MoveOut(t.ClientID, balances)
+	if err != nil {
+		return "", common.NewErrorf("stake_pool_redelegate_failed",
+			"moving tokens out: %v", err)
+	}
+
+	if err := from.DeletePool(t.ClientID, srr.FromProviderType, srr.FromProviderID, balances); err != nil {
+		return "", common.NewErrorf("stake_pool_redelegate_failed",
+			"deleting stake pool: %v", err)
+	}
+
+	out, err := to.MoveIn(t, moved, srr.ToProviderType, srr.ToProviderID, balances)
+	if err != nil {
+		return "", common.NewErrorf("stake_pool_redelegate_failed",
+			"moving tokens in: %v", err)
+	}
+
+	for _, p := range []struct {
+		sp           AbstractStakePool
+		providerType spenum.Provider
+		providerID   string
+	}{
+		{from, srr.FromProviderType, srr.FromProviderID},
+		{to, srr.ToProviderType, srr.ToProviderID},
+	} {
+		if err := p.sp.Save(p.providerType, p.providerID, balances); err != nil {
+			return "", common.NewErrorf("stake_pool_redelegate_failed",
+				"saving stake pool: %v", err)
+		}
+
+		if err := p.sp.EmitStakeEvent(p.providerType, p.providerID, balances); err != nil {
+			return "", common.NewErrorf("stake_pool_redelegate_failed",
+				"stake pool staking error: %v", err)
+		}
+
+		if len(funcs) > 1 {
+			refresh := funcs[1]
+			if _, err := refresh(p.providerType, p.providerID, balances); err != nil {
+				return "", common.NewErrorf("stake_pool_redelegate_failed",
+					"can't refresh provider: %v", err)
+			}
+		}
+	}
+
+	r.LastRedelegatedAt = t.CreationDate
+	if _, err := balances.InsertTrieNode(redelegationKey(t.ToClientID, t.ClientID), r); err != nil {
+		return "", common.NewErrorf("stake_pool_redelegate_failed",
+			"saving redelegation: %v", err)
+	}
+
+	return out, nil
+}
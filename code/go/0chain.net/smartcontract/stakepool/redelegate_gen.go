@@ -0,0 +1,63 @@
+package stakepool
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *Redelegation) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "LastRedelegatedAt"
+	o = append(o, 0x81, 0xb1, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o, err = z.LastRedelegatedAt.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "LastRedelegatedAt")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *Redelegation) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "LastRedelegatedAt":
+			bts, err = z.LastRedelegatedAt.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LastRedelegatedAt")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *Redelegation) Msgsize() (s int) {
+	s = 1 + 18 + z.LastRedelegatedAt.Msgsize()
+	return
+}
@@ -0,0 +1,53 @@
+package stakepool
+
+import (
+	"sort"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+)
+
+// EmitSlashRecords emits the evidence of a slash of the provider, one record
+// per delegate pool slashed with the amount it lost
+func EmitSlashRecords(
+	providerID string,
+	providerType spenum.Provider,
+	reason spenum.SlashReason,
+	evidence string,
+	fraction float64,
+	penalties map[string]currency.Coin,
+	balances cstate.StateContextI,
+) {
+	if len(penalties) == 0 {
+		return
+	}
+
+	delegates := make([]string, 0, len(penalties))
+	for id := range penalties {
+		delegates = append(delegates, id)
+	}
+	sort.Strings(delegates)
+
+	records := make([]event.SlashRecord, 0, len(delegates))
+	for _, id := range delegates {
+		if penalties[id] == 0 {
+			continue
+		}
+		records = append(records, event.SlashRecord{
+			ProviderID:   providerID,
+			ProviderType: providerType,
+			DelegateID:   id,
+			Reason:       reason.String(),
+			Evidence:     evidence,
+			Fraction:     fraction,
+			Amount:       penalties[id],
+		})
+	}
+	if len(records) == 0 {
+		return
+	}
+
+	balances.EmitEvent(event.TypeStats, event.TagAddSlashRecords, providerID, records)
+}
@@ -79,6 +79,8 @@ const (
 	ChallengeSlashPenalty
 	CancellationChargeReward
 	EnterpriseBlobberReward
+	MeteredStorageReward
+	ChallengeDisputeRefund
 	NumOfRewards
 )
 
@@ -96,6 +98,8 @@ func initRewardString() {
 	rewardString[CancellationChargeReward] = "cancellation_charge"
 	rewardString[NumOfRewards] = "invalid"
 	rewardString[EnterpriseBlobberReward] = "enterprise_blobber_reward"
+	rewardString[MeteredStorageReward] = "metered_storage_reward"
+	rewardString[ChallengeDisputeRefund] = "challenge_dispute_refund"
 }
 
 func (r Reward) String() string {
@@ -108,3 +112,22 @@ func (r Reward) String() string {
 func (r Reward) Int() int {
 	return int(r)
 }
+
+// SlashReason is why the stake of a provider is slashed
+type SlashReason int
+
+const (
+	SlashChallengeFailed SlashReason = iota + 1
+	SlashKill
+	SlashShutdown
+	SlashHealthCheckMissed
+)
+
+var slashReasonString = []string{"invalid", "challenge_failed", "kill", "shutdown", "health_check_missed"}
+
+func (r SlashReason) String() string {
+	if r < 1 || int(r) >= len(slashReasonString) {
+		return "unknown_slash_reason"
+	}
+	return slashReasonString[r]
+}
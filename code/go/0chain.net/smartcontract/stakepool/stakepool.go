@@ -44,10 +44,14 @@ type AbstractStakePool interface {
 	Empty(sscID, poolID, clientID string, balances cstate.StateContextI) error
 	UnlockPool(clientID string, providerType spenum.Provider, providerId datastore.Key, balances cstate.StateContextI) (string, error)
 	DeletePool(clientID string, providerType spenum.Provider, providerId datastore.Key, balances cstate.StateContextI) error
-	Kill(float64, string, spenum.Provider, cstate.StateContextI) error
+	Kill(float64, string, spenum.Provider, spenum.SlashReason, cstate.StateContextI) error
 	IsDead() bool
-	SlashFraction(float64, string, spenum.Provider, cstate.StateContextI) error
+	SlashFraction(float64, string, spenum.Provider, spenum.SlashReason, string, cstate.StateContextI) error
 	TotalStake() (currency.Coin, error)
+	MoveOut(clientID string, balances cstate.StateContextI) (*DelegatePool, error)
+	MoveIn(t *transaction.Transaction, moved *DelegatePool, providerType spenum.Provider, providerId datastore.Key, balances cstate.StateContextI) (string, error)
+	Unbond(clientID string, amount currency.Coin, maturityRound int64, balances cstate.StateContextI) error
+	ClaimUnbonded(sscID, clientID string, round int64, balances cstate.StateContextI) (currency.Coin, error)
 }
 
 // StakePool holds delegate information for an 0chain providers
@@ -73,6 +77,12 @@ type DelegatePool struct {
 	RoundCreated int64             `json:"round_created"` // used for cool down
 	DelegateID   string            `json:"delegate_id"`
 	StakedAt     common.Timestamp  `json:"staked_at"`
+	// AutoCompound adds the rewards to the balance, up to the max stake of
+	// the smart contract. It's left out of the encoding when it's off.
+	AutoCompound bool `json:"auto_compound,omitempty" msg:"AutoCompound,omitempty"`
+	// Unbonding are the unstaked parts of the pool waiting to be claimed,
+	// up to MaxUnbondingEntries. They're left out of the encoding when empty.
+	Unbonding []*UnbondingEntry `json:"unbonding,omitempty" msg:"Unbonding,omitempty"`
 }
 
 // StakePoolStat Deprecated
@@ -101,6 +111,7 @@ type DelegatePoolStat struct {
 	Status       string           `json:"status"`
 	RoundCreated int64            `json:"round_created"`
 	StakedAt     common.Timestamp `json:"staked_at"`
+	AutoCompound bool             `json:"auto_compound"`
 }
 
 // UserPoolStat Deprecated
@@ -137,6 +148,7 @@ func ToProviderStakePoolStats(provider *event.Provider, delegatePools []event.De
 			Rewards:      dp.Reward,
 			TotalPenalty: dp.TotalPenalty,
 			TotalReward:  dp.TotalReward,
+			AutoCompound: dp.AutoCompound,
 		}
 
 		newBal, err := currency.AddCoin(spStat.Balance, dpStats.Balance)
@@ -180,14 +192,22 @@ func (sp *StakePool) IsDead() bool {
 	return sp.HasBeenKilled
 }
 
+// Kill marks the stake pool dead and slashes it, the kill or shutdown
+// transaction is the evidence of the slash
 func (sp *StakePool) Kill(
-	killSlash float64, providerId string, pType spenum.Provider, balances cstate.StateContextI,
+	killSlash float64, providerId string, pType spenum.Provider, reason spenum.SlashReason, balances cstate.StateContextI,
 ) error {
 	sp.HasBeenKilled = true
+	var evidence string
+	if txn := balances.GetTransaction(); txn != nil {
+		evidence = txn.Hash
+	}
 	return sp.SlashFraction(
 		killSlash,
 		providerId,
 		pType,
+		reason,
+		evidence,
 		balances,
 	)
 }
@@ -345,11 +365,14 @@ func (sp *StakePool) Empty(sscID, poolID, clientID string, balances cstate.State
 }
 
 // SlashFraction
-// slash stake pools funds, if a provider is killed
+// slash stake pools funds, if a provider is killed. The amount slashed from
+// every delegate pool is recorded with the reason and the evidence.
 func (sp *StakePool) SlashFraction(
 	killSlashFraction float64,
 	providerId string,
 	providerType spenum.Provider,
+	reason spenum.SlashReason,
+	evidence string,
 	balances cstate.StateContextI,
 ) error {
 	if killSlashFraction == 0.0 {
@@ -365,16 +388,28 @@ func (sp *StakePool) SlashFraction(
 	if reduction > 1 {
 		reduction = 1
 	}
-	orderedPoolIds := sp.OrderedPoolIds()
+	var (
+		penalties      = make(map[string]currency.Coin, len(sp.Pools))
+		round          = balances.GetBlock().Round
+		orderedPoolIds = sp.OrderedPoolIds()
+	)
 	for _, id := range orderedPoolIds {
-		var err error
 		dp := sp.Pools[id]
-		dp.Balance, err = currency.MultFloat64(dp.Balance, reduction)
+		balance, err := currency.MultFloat64(dp.Balance, reduction)
+		if err != nil {
+			return err
+		}
+		penalties[dp.DelegateID] = dp.Balance - balance
+		dp.Balance = balance
+
+		unbondingSlash, err := dp.SlashUnbonding(killSlashFraction, round)
 		if err != nil {
 			return err
 		}
+		penalties[dp.DelegateID] += unbondingSlash
 	}
 	sp.EmitStakePoolBalanceUpdate(providerId, providerType, balances)
+	EmitSlashRecords(providerId, providerType, reason, evidence, killSlashFraction, penalties, balances)
 	return nil
 }
 
@@ -456,6 +491,10 @@ func (sp *StakePool) DistributeRewardsRandN(
 	if err != nil {
 		return err
 	}
+	maxStake, err := sp.compoundMaxStake(providerType, balances)
+	if err != nil {
+		return err
+	}
 
 	if stake == 0 {
 		if err := spUpdate.Emit(event.TagStakePoolReward, balances); err != nil {
@@ -479,15 +518,13 @@ func (sp *StakePool) DistributeRewardsRandN(
 		} else {
 			valueBalance -= reward
 		}
-		pool.Reward, err = currency.AddCoin(pool.Reward, reward)
-		if err != nil {
+		if err := spUpdate.creditDelegate(pool, reward, maxStake); err != nil {
 			return err
 		}
-		spUpdate.DelegateRewards[pool.DelegateID] = reward
 	}
 
 	if valueBalance > 0 {
-		err = equallyDistributeRewards(valueBalance, pools, spUpdate)
+		err = equallyDistributeRewards(valueBalance, pools, spUpdate, maxStake)
 		if err != nil {
 			return err
 		}
@@ -495,7 +532,7 @@ func (sp *StakePool) DistributeRewardsRandN(
 	if err := spUpdate.Emit(event.TagStakePoolReward, balances); err != nil {
 		return err
 	}
-	return nil
+	return sp.emitCompoundedStake(spUpdate, providerType, providerId, balances)
 }
 
 func (sp *StakePool) getRandPools(balances cstate.StateContextI, seed int64, n int) []*DelegatePool {
@@ -643,6 +680,10 @@ func (sp *StakePool) DistributeRewards(
 		}
 		return fmt.Errorf("no stake")
 	}
+	maxStake, err := sp.compoundMaxStake(providerType, balances)
+	if err != nil {
+		return err
+	}
 
 	orderedPoolIds := sp.OrderedPoolIds()
 	for _, id := range orderedPoolIds {
@@ -661,15 +702,13 @@ func (sp *StakePool) DistributeRewards(
 		} else {
 			valueBalance -= reward
 		}
-		dp.Reward, err = currency.AddCoin(dp.Reward, reward)
-		if err != nil {
+		if err := spUpdate.creditDelegate(dp, reward, maxStake); err != nil {
 			return err
 		}
-		spUpdate.DelegateRewards[dp.DelegateID] = reward
 	}
 
 	if valueBalance > 0 {
-		err = sp.equallyDistributeRewards(valueBalance, spUpdate)
+		err = sp.equallyDistributeRewards(valueBalance, spUpdate, maxStake)
 		if err != nil {
 			return err
 		}
@@ -678,7 +717,7 @@ func (sp *StakePool) DistributeRewards(
 		return err
 	}
 
-	return nil
+	return sp.emitCompoundedStake(spUpdate, providerType, providerId, balances)
 }
 
 func (sp *StakePool) stake() (stake currency.Coin, err error) {
@@ -694,11 +733,11 @@ func (sp *StakePool) stake() (stake currency.Coin, err error) {
 	return
 }
 
-func (sp *StakePool) equallyDistributeRewards(coins currency.Coin, spUpdate *StakePoolReward) error {
-	return equallyDistributeRewards(coins, sp.GetOrderedPools(), spUpdate)
+func (sp *StakePool) equallyDistributeRewards(coins currency.Coin, spUpdate *StakePoolReward, maxStake currency.Coin) error {
+	return equallyDistributeRewards(coins, sp.GetOrderedPools(), spUpdate, maxStake)
 }
 
-func equallyDistributeRewards(coins currency.Coin, pools []*DelegatePool, spUpdate *StakePoolReward) error {
+func equallyDistributeRewards(coins currency.Coin, pools []*DelegatePool, spUpdate *StakePoolReward, maxStake currency.Coin) error {
 	share, r, err := currency.DistributeCoin(coins, int64(len(pools)))
 	if err != nil {
 		return err
@@ -709,34 +748,24 @@ func equallyDistributeRewards(coins currency.Coin, pools []*DelegatePool, spUpda
 	}
 	if share == 0 {
 		for i := int64(0); i < c; i++ {
-			pools[i].Reward++
-			spUpdate.DelegateRewards[pools[i].DelegateID]++
+			if err := spUpdate.creditDelegate(pools[i], 1, maxStake); err != nil {
+				return err
+			}
 		}
 		return nil
 	}
 
-	iShare, err := share.Int64()
-	if err != nil {
-		return err
-	}
 	for i := range pools {
-		pools[i].Reward, err = currency.AddCoin(pools[i].Reward, share)
-		if err != nil {
+		if err := spUpdate.creditDelegate(pools[i], share, maxStake); err != nil {
 			return err
 		}
-
-		spUpdate.DelegateRewards[pools[i].DelegateID], err =
-			currency.AddInt64(spUpdate.DelegateRewards[pools[i].DelegateID], iShare)
-		if err != nil {
-			return err
-		}
-
 	}
 
 	if r > 0 {
 		for i := 0; i < int(r); i++ {
-			pools[i].Reward++
-			spUpdate.DelegateRewards[pools[i].DelegateID]++
+			if err := spUpdate.creditDelegate(pools[i], 1, maxStake); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -818,36 +847,44 @@ type ValidationSettings struct {
 }
 
 func validateLockRequest(t *transaction.Transaction, sp AbstractStakePool, vs ValidationSettings, balances cstate.StateContextI) (string, error) {
-	if t.Value == 0 {
-		return "", common.NewError("stake_pool_lock_failed",
-			fmt.Sprintf("no stake to lock: %v", t.Value))
+	if err := validateStake("stake_pool_lock_failed", t.ClientID, t.Value, sp, vs); err != nil {
+		return "", err
 	}
-	if t.Value < vs.MinStake {
-		return "", common.NewError("stake_pool_lock_failed",
-			fmt.Sprintf("too small stake to lock: %v < %v", t.Value, vs.MinStake))
+	return "", nil
+}
+
+// validateStake checks the stake pool can take the value staked by the client
+func validateStake(code, clientID string, value currency.Coin, sp AbstractStakePool, vs ValidationSettings) error {
+	if value == 0 {
+		return common.NewError(code,
+			fmt.Sprintf("no stake to lock: %v", value))
+	}
+	if value < vs.MinStake {
+		return common.NewError(code,
+			fmt.Sprintf("too small stake to lock: %v < %v", value, vs.MinStake))
 	}
 	poolStakeBefore := currency.Coin(0)
-	pool, ok := sp.GetPools()[t.ClientID]
+	pool, ok := sp.GetPools()[clientID]
 	if ok {
 		poolStakeBefore = pool.Balance
 	}
-	poolStakeAfter, err := currency.AddCoin(poolStakeBefore, t.Value)
+	poolStakeAfter, err := currency.AddCoin(poolStakeBefore, value)
 	if err != nil {
-		return "", common.NewError("stake_pool_lock_failed", err.Error())
+		return common.NewError(code, err.Error())
 	}
 
 	if poolStakeAfter > vs.MaxStake {
-		return "", common.NewError("stake_pool_lock_failed",
+		return common.NewError(code,
 			fmt.Sprintf("too large stake to lock: %v > %v", poolStakeAfter, vs.MaxStake))
 	}
 
-	if len(sp.GetPools()) >= sp.GetSettings().MaxNumDelegates && !sp.HasStakePool(t.ClientID) {
-		return "", common.NewErrorf("stake_pool_lock_failed",
+	if len(sp.GetPools()) >= sp.GetSettings().MaxNumDelegates && !sp.HasStakePool(clientID) {
+		return common.NewErrorf(code,
 			"max_delegates reached: %v, no more stake pools allowed",
 			vs.MaxNumDelegates)
 	}
 
-	return "", nil
+	return nil
 }
 
 // StakePoolUnlock unlock tokens from provider, stake pool can return excess tokens from stake pool
@@ -875,13 +912,12 @@ func StakePoolUnlock(t *transaction.Transaction, input []byte, balances cstate.S
 		return "", common.NewErrorf("stake_pool_unlock_failed", "no such delegate pool: %v ", t.ClientID)
 	}
 
-	// if StakeAt has valid value and lock period is less than MinLockPeriod
-	if dp.StakedAt > 0 {
-		stakedAt := common.ToTime(dp.StakedAt)
-		minLockPeriod := config.SmartContractConfig.GetDuration("stakepool.min_lock_period")
-		if !stakedAt.Add(minLockPeriod).Before(time.Now()) {
-			return "", common.NewErrorf("stake_pool_unlock_failed", "token can only be unstaked till: %s", stakedAt.Add(minLockPeriod))
-		}
+	if err := checkMinLockPeriod(dp); err != nil {
+		return "", common.NewErrorf("stake_pool_unlock_failed", "%v", err)
+	}
+	if len(dp.Unbonding) > 0 {
+		return "", common.NewError("stake_pool_unlock_failed",
+			"the delegate pool has unbonding tokens, claim them first")
 	}
 
 	output, err := sp.UnlockPool(t.ClientID, spr.ProviderType, spr.ProviderID, balances)
@@ -924,6 +960,19 @@ func StakePoolUnlock(t *transaction.Transaction, input []byte, balances cstate.S
 	return output, nil
 }
 
+// checkMinLockPeriod of the tokens of the delegate pool before an unstake
+func checkMinLockPeriod(dp *DelegatePool) error {
+	// if StakeAt has valid value and lock period is less than MinLockPeriod
+	if dp.StakedAt > 0 {
+		stakedAt := common.ToTime(dp.StakedAt)
+		minLockPeriod := config.SmartContractConfig.GetDuration("stakepool.min_lock_period")
+		if !stakedAt.Add(minLockPeriod).Before(time.Now()) {
+			return fmt.Errorf("token can only be unstaked till: %s", stakedAt.Add(minLockPeriod))
+		}
+	}
+	return nil
+}
+
 func toJson(val interface{}) string {
 	var b, err = json.Marshal(val)
 	if err != nil {
@@ -9,9 +9,24 @@ import (
 // MarshalMsg implements msgp.Marshaler
 func (z *DelegatePool) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 6
+	// omitempty: check for empty values
+	zb0001Len := uint32(8)
+	var zb0001Mask uint8 /* 8 bits */
+	if z.AutoCompound == false {
+		zb0001Len--
+		zb0001Mask |= 0x40
+	}
+	if z.Unbonding == nil {
+		zb0001Len--
+		zb0001Mask |= 0x80
+	}
+	// variable map header, size zb0001Len
+	o = append(o, 0x80|uint8(zb0001Len))
+	if zb0001Len == 0 {
+		return
+	}
 	// string "Balance"
-	o = append(o, 0x86, 0xa7, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65)
+	o = append(o, 0xa7, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65)
 	o, err = z.Balance.MarshalMsg(o)
 	if err != nil {
 		err = msgp.WrapError(err, "Balance")
@@ -44,6 +59,27 @@ func (z *DelegatePool) MarshalMsg(b []byte) (o []byte, err error) {
 		err = msgp.WrapError(err, "StakedAt")
 		return
 	}
+	if (zb0001Mask & 0x40) == 0 { // if not empty
+		// string "AutoCompound"
+		o = append(o, 0xac, 0x41, 0x75, 0x74, 0x6f, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x75, 0x6e, 0x64)
+		o = msgp.AppendBool(o, z.AutoCompound)
+	}
+	if (zb0001Mask & 0x80) == 0 { // if not empty
+		// string "Unbonding"
+		o = append(o, 0xa9, 0x55, 0x6e, 0x62, 0x6f, 0x6e, 0x64, 0x69, 0x6e, 0x67)
+		o = msgp.AppendArrayHeader(o, uint32(len(z.Unbonding)))
+		for za0001 := range z.Unbonding {
+			if z.Unbonding[za0001] == nil {
+				o = msgp.AppendNil(o)
+			} else {
+				o, err = z.Unbonding[za0001].MarshalMsg(o)
+				if err != nil {
+					err = msgp.WrapError(err, "Unbonding", za0001)
+					return
+				}
+			}
+		}
+	}
 	return
 }
 
@@ -101,6 +137,42 @@ func (z *DelegatePool) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "StakedAt")
 				return
 			}
+		case "AutoCompound":
+			z.AutoCompound, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AutoCompound")
+				return
+			}
+		case "Unbonding":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Unbonding")
+				return
+			}
+			if cap(z.Unbonding) >= int(zb0002) {
+				z.Unbonding = (z.Unbonding)[:zb0002]
+			} else {
+				z.Unbonding = make([]*UnbondingEntry, zb0002)
+			}
+			for za0001 := range z.Unbonding {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Unbonding[za0001] = nil
+				} else {
+					if z.Unbonding[za0001] == nil {
+						z.Unbonding[za0001] = new(UnbondingEntry)
+					}
+					bts, err = z.Unbonding[za0001].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Unbonding", za0001)
+						return
+					}
+				}
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -115,16 +187,23 @@ func (z *DelegatePool) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *DelegatePool) Msgsize() (s int) {
-	s = 1 + 8 + z.Balance.Msgsize() + 7 + z.Reward.Msgsize() + 7 + z.Status.Msgsize() + 13 + msgp.Int64Size + 11 + msgp.StringPrefixSize + len(z.DelegateID) + 9 + z.StakedAt.Msgsize()
+	s = 1 + 8 + z.Balance.Msgsize() + 7 + z.Reward.Msgsize() + 7 + z.Status.Msgsize() + 13 + msgp.Int64Size + 11 + msgp.StringPrefixSize + len(z.DelegateID) + 9 + z.StakedAt.Msgsize() + 13 + msgp.BoolSize + 10 + msgp.ArrayHeaderSize
+	for za0001 := range z.Unbonding {
+		if z.Unbonding[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Unbonding[za0001].Msgsize()
+		}
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *DelegatePoolStat) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 12
+	// map header, size 13
 	// string "ID"
-	o = append(o, 0x8c, 0xa2, 0x49, 0x44)
+	o = append(o, 0x8d, 0xa2, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ID)
 	// string "Balance"
 	o = append(o, 0xa7, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65)
@@ -183,6 +262,9 @@ func (z *DelegatePoolStat) MarshalMsg(b []byte) (o []byte, err error) {
 		err = msgp.WrapError(err, "StakedAt")
 		return
 	}
+	// string "AutoCompound"
+	o = append(o, 0xac, 0x41, 0x75, 0x74, 0x6f, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x75, 0x6e, 0x64)
+	o = msgp.AppendBool(o, z.AutoCompound)
 	return
 }
 
@@ -276,6 +358,12 @@ func (z *DelegatePoolStat) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "StakedAt")
 				return
 			}
+		case "AutoCompound":
+			z.AutoCompound, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AutoCompound")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -290,7 +378,7 @@ func (z *DelegatePoolStat) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *DelegatePoolStat) Msgsize() (s int) {
-	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 8 + z.Balance.Msgsize() + 11 + msgp.StringPrefixSize + len(z.DelegateID) + 8 + z.Rewards.Msgsize() + 8 + msgp.BoolSize + 11 + msgp.StringPrefixSize + len(z.ProviderId) + 13 + z.ProviderType.Msgsize() + 12 + z.TotalReward.Msgsize() + 13 + z.TotalPenalty.Msgsize() + 7 + msgp.StringPrefixSize + len(z.Status) + 13 + msgp.Int64Size + 9 + z.StakedAt.Msgsize()
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 8 + z.Balance.Msgsize() + 11 + msgp.StringPrefixSize + len(z.DelegateID) + 8 + z.Rewards.Msgsize() + 8 + msgp.BoolSize + 11 + msgp.StringPrefixSize + len(z.ProviderId) + 13 + z.ProviderType.Msgsize() + 12 + z.TotalReward.Msgsize() + 13 + z.TotalPenalty.Msgsize() + 7 + msgp.StringPrefixSize + len(z.Status) + 13 + msgp.Int64Size + 9 + z.StakedAt.Msgsize() + 13 + msgp.BoolSize
 	return
 }
 
@@ -16,6 +16,7 @@ import (
 	"0chain.net/smartcontract/dbs/event"
 	"0chain.net/smartcontract/stakepool/spenum"
 	"github.com/0chain/common/core/currency"
+	"github.com/tinylib/msgp/msgp"
 )
 
 func init() {
@@ -269,6 +270,7 @@ func TestStakePool_DistributeRewards(t *testing.T) {
 						Reward:            tt.want.poolReward,
 						DelegateRewards:   DelegateRewards,
 						DelegatePenalties: make(map[string]currency.Coin),
+						DelegateCompounds: make(map[string]currency.Coin),
 						RewardType:        spenum.BlockRewardBlobber,
 						AllocationID:      "",
 						DelegateWallet:    "",
@@ -280,6 +282,190 @@ func TestStakePool_DistributeRewards(t *testing.T) {
 	}
 }
 
+func TestStakePool_DistributeRewardsAutoCompound(t *testing.T) {
+	tests := []struct {
+		name         string
+		providerType spenum.Provider
+		maxStake     currency.Coin
+		compounded   currency.Coin
+	}{
+		{
+			name:         "compounded up to the max stake",
+			providerType: spenum.Blobber,
+			maxStake:     100,
+			compounded:   5,
+		},
+		{
+			name:         "max stake of the reward time",
+			providerType: spenum.Blobber,
+			maxStake:     200,
+			compounded:   9,
+		},
+		{
+			name:         "balance over the max stake",
+			providerType: spenum.Blobber,
+			maxStake:     90,
+		},
+		{
+			name:         "no max stake of the provider type",
+			providerType: spenum.Authorizer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxStakeFuncs = map[spenum.Provider]MaxStakeFunc{}
+			t.Cleanup(func() { maxStakeFuncs = map[spenum.Provider]MaxStakeFunc{} })
+			RegisterMaxStake(func(state.CommonStateContextI) (currency.Coin, error) {
+				return tt.maxStake, nil
+			}, spenum.Blobber)
+
+			var (
+				balances = newTestBalances(t, false)
+				sp       = NewStakePool()
+			)
+			sp.Pools["compound"] = &DelegatePool{DelegateID: "compound", Balance: 95, AutoCompound: true}
+			sp.Pools["collect"] = &DelegatePool{DelegateID: "collect", Balance: 105}
+
+			require.NoError(t, sp.DistributeRewards(20, "provider_id", tt.providerType, spenum.BlockRewardBlobber, balances))
+			require.EqualValues(t, 95+tt.compounded, sp.Pools["compound"].Balance)
+			require.EqualValues(t, 9-tt.compounded, sp.Pools["compound"].Reward, "the overflow goes to the reward")
+			require.EqualValues(t, 105, sp.Pools["collect"].Balance)
+			require.EqualValues(t, 11, sp.Pools["collect"].Reward)
+
+			events := balances.GetEvents()
+			require.EqualValues(t, event.TagStakePoolReward, events[0].Tag)
+			spr := events[0].Data.(*dbs.StakePoolReward)
+			require.EqualValues(t, 9, spr.DelegateRewards["compound"])
+			if tt.compounded == 0 {
+				require.Empty(t, spr.DelegateCompounds)
+				require.Len(t, events, 1)
+				return
+			}
+			require.Equal(t, map[string]currency.Coin{"compound": tt.compounded}, spr.DelegateCompounds)
+			require.Len(t, events, 2)
+			require.EqualValues(t, event.TagUpdateBlobberTotalStake, events[1].Tag, "the compounded stake is emitted")
+		})
+	}
+}
+
+func TestDelegatePool_EncodeAutoCompound(t *testing.T) {
+	for _, autoCompound := range []bool{false, true} {
+		dp := &DelegatePool{DelegateID: "delegate", Balance: 10, AutoCompound: autoCompound}
+		b, err := dp.MarshalMsg(nil)
+		require.NoError(t, err)
+
+		fields, _, err := msgp.ReadMapStrIntfBytes(b, nil)
+		require.NoError(t, err)
+		_, ok := fields["AutoCompound"]
+		require.Equal(t, autoCompound, ok, "the flag is only encoded when it's on")
+
+		var decoded DelegatePool
+		_, err = decoded.UnmarshalMsg(b)
+		require.NoError(t, err)
+		require.Equal(t, autoCompound, decoded.AutoCompound)
+	}
+}
+
+func TestDelegatePool_EncodeUnbonding(t *testing.T) {
+	for _, unbonding := range [][]*UnbondingEntry{nil, {{Amount: 5, MaturityRound: 10}}} {
+		dp := &DelegatePool{DelegateID: "delegate", Balance: 10, Unbonding: unbonding}
+		b, err := dp.MarshalMsg(nil)
+		require.NoError(t, err)
+
+		fields, _, err := msgp.ReadMapStrIntfBytes(b, nil)
+		require.NoError(t, err)
+		_, ok := fields["Unbonding"]
+		require.Equal(t, len(unbonding) > 0, ok, "the entries are only encoded when there are any")
+
+		var decoded DelegatePool
+		_, err = decoded.UnmarshalMsg(b)
+		require.NoError(t, err)
+		require.Equal(t, unbonding, decoded.Unbonding)
+	}
+}
+
+func TestStakePool_Unbond(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries int
+		amount  currency.Coin
+		wantErr string
+	}{
+		{name: "ok", amount: 1},
+		{name: "below the cap", entries: MaxUnbondingEntries - 1, amount: 1},
+		{name: "cap reached", entries: MaxUnbondingEntries, amount: 1, wantErr: "too many unbonding entries"},
+		{name: "zero", amount: 0, wantErr: "can't unbond"},
+		{name: "above the stake", amount: 101, wantErr: "can't unbond"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp := NewStakePool()
+			dp := &DelegatePool{DelegateID: "delegate", Balance: 100, Status: spenum.Active}
+			for i := 0; i < tt.entries; i++ {
+				dp.Unbonding = append(dp.Unbonding, &UnbondingEntry{Amount: 1, MaturityRound: 10})
+			}
+			sp.Pools[dp.DelegateID] = dp
+
+			err := sp.Unbond(dp.DelegateID, tt.amount, 10, nil)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				require.Len(t, dp.Unbonding, tt.entries)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, dp.Unbonding, tt.entries+1)
+			require.Equal(t, 100-tt.amount, dp.Balance)
+		})
+	}
+
+	t.Run("not active yet", func(t *testing.T) {
+		balances := newTestBalances(t, false)
+		balances.txn = &transaction.Transaction{ClientID: "delegate"}
+		req := &StakePoolUnbondRequest{ProviderType: spenum.Blobber, ProviderID: "provider", Amount: 1}
+		_, err := StakePoolUnbond(balances.txn, req.Encode(), balances, 10,
+			func(spenum.Provider, string, state.StateContextI) (AbstractStakePool, error) {
+				t.Fatal("the stake pool is not read before the hard fork")
+				return nil, nil
+			})
+		require.ErrorContains(t, err, "unbonding is not active yet")
+	})
+}
+
+func TestStakePool_Kill(t *testing.T) {
+	var (
+		balances = newTestBalances(t, false)
+		sp       = NewStakePool()
+	)
+	balances.txn.Hash = "kill_txn"
+	sp.Pools["first"] = &DelegatePool{DelegateID: "first", Balance: 100}
+	sp.Pools["second"] = &DelegatePool{DelegateID: "second", Balance: 40}
+
+	require.NoError(t, sp.Kill(0.25, "provider_id", spenum.Blobber, spenum.SlashKill, balances))
+	require.True(t, sp.IsDead())
+	require.EqualValues(t, 75, sp.Pools["first"].Balance)
+	require.EqualValues(t, 30, sp.Pools["second"].Balance)
+
+	var records []event.SlashRecord
+	for _, e := range balances.GetEvents() {
+		if e.Tag == event.TagAddSlashRecords {
+			records = append(records, e.Data.([]event.SlashRecord)...)
+		}
+	}
+	require.Len(t, records, 2, "a slash record per delegate")
+	for _, r := range records {
+		require.Equal(t, "provider_id", r.ProviderID)
+		require.Equal(t, spenum.SlashKill.String(), r.Reason)
+		require.Equal(t, "kill_txn", r.Evidence)
+		require.Equal(t, 0.25, r.Fraction)
+	}
+	require.Equal(t, "first", records[0].DelegateID)
+	require.EqualValues(t, 25, records[0].Amount)
+	require.Equal(t, "second", records[1].DelegateID)
+	require.EqualValues(t, 10, records[1].Amount)
+}
+
 func TestStakePool_DistributeRewardsRandN(t *testing.T) {
 	providerID := "provider_id"
 	providerType := spenum.Blobber
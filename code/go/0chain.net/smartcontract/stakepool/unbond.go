@@ -0,0 +1,295 @@
+package stakepool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+)
+
+//msgp:ignore StakePoolUnbondRequest
+//go:generate msgp -v -io=false -tests=false
+
+// unbondingHardFork is the hard fork enabling the unbonding, the delegate
+// pools are encoded as before until then
+const unbondingHardFork = "hermes"
+
+// MaxUnbondingEntries is the max number of the unbonding entries of a
+// delegate pool, the matured ones have to be claimed to unbond more
+const MaxUnbondingEntries = 16
+
+// UnbondingEntry is a part of a delegate pool unstaked and waiting for its
+// maturity round to be claimed. It earns no rewards, but it is slashed with
+// the stake pool until it matures.
+type UnbondingEntry struct {
+	Amount        currency.Coin `json:"amount"`
+	MaturityRound int64         `json:"maturity_round"`
+}
+
+// StakePoolUnbondRequest unstakes a part of the delegate pool of the client
+type StakePoolUnbondRequest struct {
+	ProviderType spenum.Provider `json:"provider_type"`
+	ProviderID   string          `json:"provider_id"`
+	Amount       currency.Coin   `json:"amount"`
+}
+
+func (sur *StakePoolUnbondRequest) Encode() []byte {
+	bytes, _ := json.Marshal(sur)
+	return bytes
+}
+
+func (sur *StakePoolUnbondRequest) decode(p []byte) error {
+	return json.Unmarshal(p, sur)
+}
+
+// SlashUnbonding slashes the ratio of the unbonding entries of the delegate
+// pool not matured at the round, it returns the amount slashed
+func (dp *DelegatePool) SlashUnbonding(ratio float64, round int64) (slashed currency.Coin, err error) {
+	for _, ue := range dp.Unbonding {
+		if ue.MaturityRound <= round {
+			continue
+		}
+
+		s, err := currency.MultFloat64(ue.Amount, ratio)
+		if err != nil {
+			return 0, err
+		}
+		if s > ue.Amount {
+			s = ue.Amount
+		}
+
+		ue.Amount -= s
+		if slashed, err = currency.AddCoin(slashed, s); err != nil {
+			return 0, err
+		}
+	}
+	return slashed, nil
+}
+
+// UnbondingStake is the total of the unbonding entries of the stake pool not
+// matured at the round, they are slashed with the stake
+func (sp *StakePool) UnbondingStake(round int64) (currency.Coin, error) {
+	var total currency.Coin
+	for _, id := range sp.OrderedPoolIds() {
+		for _, ue := range sp.Pools[id].Unbonding {
+			if ue.MaturityRound <= round {
+				continue
+			}
+
+			var err error
+			if total, err = currency.AddCoin(total, ue.Amount); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return total, nil
+}
+
+// Unbond moves the amount out of the stake of the delegate pool of the client
+// into an unbonding entry maturing at the round
+func (sp *StakePool) Unbond(clientID string, amount currency.Coin, maturityRound int64, _ cstate.StateContextI) error {
+	dp, ok := sp.Pools[clientID]
+	if !ok {
+		return fmt.Errorf("no such delegate pool: %q", clientID)
+	}
+
+	if dp.DelegateID != clientID {
+		return errors.New("trying to unbond not by delegate pool owner")
+	}
+
+	if dp.Status != spenum.Active && dp.Status != spenum.Pending {
+		return fmt.Errorf("could not unbond pool in %s status", dp.Status)
+	}
+
+	if amount == 0 || amount > dp.Balance {
+		return fmt.Errorf("can't unbond %v of the stake %v", amount, dp.Balance)
+	}
+
+	if len(dp.Unbonding) >= MaxUnbondingEntries {
+		return fmt.Errorf("too many unbonding entries, max %d, claim the matured ones first",
+			MaxUnbondingEntries)
+	}
+
+	dp.Balance -= amount
+	dp.Unbonding = append(dp.Unbonding, &UnbondingEntry{
+		Amount:        amount,
+		MaturityRound: maturityRound,
+	})
+	return nil
+}
+
+// ClaimUnbonded pays the unbonding entries of the delegate pool of the client
+// matured at the round out of the smart contract. The delegate pool is deleted
+// once it has no stake and no unbonding entries left.
+func (sp *StakePool) ClaimUnbonded(sscID, clientID string, round int64, balances cstate.StateContextI) (currency.Coin, error) {
+	dp, ok := sp.Pools[clientID]
+	if !ok {
+		return 0, fmt.Errorf("no such delegate pool: %q", clientID)
+	}
+
+	var (
+		claimed currency.Coin
+		pending []*UnbondingEntry
+		err     error
+	)
+	for _, ue := range dp.Unbonding {
+		if ue.MaturityRound > round {
+			pending = append(pending, ue)
+			continue
+		}
+
+		if claimed, err = currency.AddCoin(claimed, ue.Amount); err != nil {
+			return 0, err
+		}
+	}
+	if len(pending) == len(dp.Unbonding) {
+		return 0, errors.New("no matured unbonding entries")
+	}
+
+	if claimed > 0 {
+		if err := balances.AddTransfer(state.NewTransfer(sscID, clientID, claimed)); err != nil {
+			return 0, err
+		}
+	}
+
+	dp.Unbonding = pending
+	if dp.Balance == 0 && len(dp.Unbonding) == 0 {
+		dp.Status = spenum.Deleted
+	}
+	return claimed, nil
+}
+
+// StakePoolUnbond unstakes a part of the delegate pool of the client. The
+// tokens go to an unbonding entry claimable after the unbonding period, in
+// rounds, with StakePoolClaimUnbonded.
+func StakePoolUnbond(t *transaction.Transaction, input []byte, balances cstate.StateContextI,
+	unbondingPeriod int64,
+	funcs ...func(providerType spenum.Provider, providerID string, balances cstate.StateContextI) (AbstractStakePool, error),
+) (resp string, err error) {
+	if err := cstate.WithActivation(balances, unbondingHardFork, func() error {
+		return errors.New("unbonding is not active yet")
+	}, func() error {
+		return nil
+	}); err != nil {
+		return "", common.NewError("stake_pool_unbond_failed", err.Error())
+	}
+
+	var sur StakePoolUnbondRequest
+	if err = sur.decode(input); err != nil {
+		return "", common.NewErrorf("stake_pool_unbond_failed",
+			"invalid request: %v", err)
+	}
+	if len(funcs) < 1 {
+		return "", common.NewError("stake_pool_unbond_failed",
+			"provide get func")
+	}
+
+	get := funcs[0]
+	sp, err := get(sur.ProviderType, sur.ProviderID, balances)
+	if err != nil {
+		return "", common.NewErrorf("stake_pool_unbond_failed",
+			"can't get stake pool: %v", err)
+	}
+
+	dp, ok := sp.GetPools()[t.ClientID]
+	if !ok {
+		return "", common.NewErrorf("stake_pool_unbond_failed",
+			"no such delegate pool: %v", t.ClientID)
+	}
+	if err := checkMinLockPeriod(dp); err != nil {
+		return "", common.NewErrorf("stake_pool_unbond_failed", "%v", err)
+	}
+
+	ue := UnbondingEntry{
+		Amount:        sur.Amount,
+		MaturityRound: balances.GetBlock().Round + unbondingPeriod,
+	}
+	if err := sp.Unbond(t.ClientID, ue.Amount, ue.MaturityRound, balances); err != nil {
+		return "", common.NewErrorf("stake_pool_unbond_failed",
+			"unbonding tokens: %v", err)
+	}
+
+	if err := sp.Save(sur.ProviderType, sur.ProviderID, balances); err != nil {
+		return "", common.NewErrorf("stake_pool_unbond_failed",
+			"saving stake pool: %v", err)
+	}
+
+	if err := sp.EmitStakeEvent(sur.ProviderType, sur.ProviderID, balances); err != nil {
+		return "", common.NewErrorf("stake_pool_unbond_failed",
+			"stake pool staking error: %v", err)
+	}
+
+	if len(funcs) > 1 {
+		refresh := funcs[1]
+		if _, err := refresh(sur.ProviderType, sur.ProviderID, balances); err != nil {
+			return "", common.NewErrorf("stake_pool_unbond_failed",
+				"can't refresh provider: %v", err)
+		}
+	}
+
+	update := newDelegatePoolUpdate(t.ClientID, sur.ProviderID, sur.ProviderType)
+	update.Updates["balance"] = dp.Balance
+	update.emitUpdate(balances)
+
+	i, err := ue.Amount.Int64()
+	if err != nil {
+		return "", common.NewErrorf("stake_pool_unbond_failed",
+			"can't cast amount of value (%v) to Int64", ue.Amount)
+	}
+	balances.EmitEvent(event.TypeStats, event.TagUnlockStakePool, t.ClientID, event.DelegatePoolLock{
+		Client:       t.ClientID,
+		ProviderId:   sur.ProviderID,
+		ProviderType: sur.ProviderType,
+		Amount:       i,
+		Total:        i,
+	})
+
+	return toJson(ue), nil
+}
+
+// StakePoolClaimUnbonded pays the matured unbonding entries of the delegate
+// pool of the client out
+func StakePoolClaimUnbonded(t *transaction.Transaction, input []byte, balances cstate.StateContextI,
+	funcs ...func(providerType spenum.Provider, providerID string, balances cstate.StateContextI) (AbstractStakePool, error),
+) (resp string, err error) {
+	var spr StakePoolRequest
+	if err = spr.decode(input); err != nil {
+		return "", common.NewErrorf("stake_pool_claim_unbonded_failed",
+			"invalid request: %v", err)
+	}
+	if len(funcs) < 1 {
+		return "", common.NewError("stake_pool_claim_unbonded_failed",
+			"provide get func")
+	}
+
+	get := funcs[0]
+	sp, err := get(spr.ProviderType, spr.ProviderID, balances)
+	if err != nil {
+		return "", common.NewErrorf("stake_pool_claim_unbonded_failed",
+			"can't get stake pool: %v", err)
+	}
+
+	claimed, err := sp.ClaimUnbonded(t.ToClientID, t.ClientID, balances.GetBlock().Round, balances)
+	if err != nil {
+		return "", common.NewErrorf("stake_pool_claim_unbonded_failed", "%v", err)
+	}
+
+	if err := sp.DeletePool(t.ClientID, spr.ProviderType, spr.ProviderID, balances); err != nil {
+		return "", common.NewErrorf("stake_pool_claim_unbonded_failed",
+			"deleting stake pool: %v", err)
+	}
+
+	if err := sp.Save(spr.ProviderType, spr.ProviderID, balances); err != nil {
+		return "", common.NewErrorf("stake_pool_claim_unbonded_failed",
+			"saving stake pool: %v", err)
+	}
+
+	return toJson(map[string]currency.Coin{"claimed": claimed}), nil
+}
@@ -0,0 +1,72 @@
+package stakepool
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *UnbondingEntry) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Amount"
+	o = append(o, 0x82, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o, err = z.Amount.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Amount")
+		return
+	}
+	// string "MaturityRound"
+	o = append(o, 0xad, 0x4d, 0x61, 0x74, 0x75, 0x72, 0x69, 0x74, 0x79, 0x52, 0x6f, 0x75, 0x6e, 0x64)
+	o = msgp.AppendInt64(o, z.MaturityRound)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *UnbondingEntry) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Amount":
+			bts, err = z.Amount.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		case "MaturityRound":
+			z.MaturityRound, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaturityRound")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *UnbondingEntry) Msgsize() (s int) {
+	s = 1 + 7 + z.Amount.Msgsize() + 14 + msgp.Int64Size
+	return
+}
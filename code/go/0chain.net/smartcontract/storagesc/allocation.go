@@ -96,6 +96,20 @@ type newAllocationRequest struct {
 	FileOptions          uint16     `json:"file_options"`
 
 	IsEnterprise bool `json:"is_enterprise"`
+
+	Placement *placementConstraints `json:"placement,omitempty"`
+
+	// MinReputation is the lowest challenge reputation, from 0 to 1, of
+	// the blobbers accepted for the allocation
+	MinReputation float64 `json:"min_reputation,omitempty"`
+
+	// Metered pays the blobbers per committed byte-time from a prepaid
+	// balance instead of locking the allocation cost in the write pool
+	Metered *meteredBillingRequest `json:"metered,omitempty"`
+
+	// PriceOffers are the price offers of the blobbers, by blobber ID, the
+	// allocation gets the offered prices
+	PriceOffers map[string]string `json:"price_offers,omitempty"`
 }
 
 // storageAllocation from the request
@@ -169,6 +183,21 @@ func (nar *newAllocationRequest) validate(conf *Config) error {
 		return errors.New("insufficient allocation size")
 	}
 
+	if nar.Placement != nil {
+		nar.Placement.normalize()
+		if err := nar.Placement.validate(nar.DataShards + nar.ParityShards); err != nil {
+			return fmt.Errorf("invalid placement constraints: %v", err)
+		}
+	}
+
+	if nar.MinReputation < 0 || nar.MinReputation > 1 {
+		return errors.New("invalid min_reputation, must be between 0 and 1")
+	}
+
+	if nar.Metered != nil && nar.IsEnterprise {
+		return errors.New("enterprise allocation can't be metered")
+	}
+
 	return nil
 }
 
@@ -261,12 +290,146 @@ func (sc *StorageSmartContract) newAllocationRequestInternal(
 	timings map[string]time.Duration,
 ) (resp string, err error) {
 	m := Timings{timings: timings, start: common.ToTime(txn.CreationDate)}
+	request, sa, blobberNodes, spMap, err := sc.prepareNewAllocation(txn, input, conf, balances, m)
+	if err != nil {
+		return "", err
+	}
+
+	alloc := sa.mustBase()
+
+	// the allocation uses the capacity reserved by the price offers
+	for _, ba := range alloc.BlobberAllocs {
+		offerID, ok := request.PriceOffers[ba.BlobberID]
+		if !ok {
+			continue
+		}
+		bpo, err := sc.getBlobberPriceOffers(ba.BlobberID, balances)
+		if err != nil {
+			return "", common.NewError("allocation_creation_failed", err.Error())
+		}
+		if err := bpo.use(offerID, ba.Size); err != nil {
+			return "", common.NewError("allocation_creation_failed", err.Error())
+		}
+		if err := bpo.save(sc.ID, balances); err != nil {
+			return "", common.NewErrorf("allocation_creation_failed",
+				"saving price offers: %v", err)
+		}
+	}
+
+	for _, b := range blobberNodes {
+		bcm := b.mustBase()
+		_, err = balances.InsertTrieNode(b.GetKey(), b)
+		if err != nil {
+			logging.Logger.Error("new_allocation_request_failed: error inserting blobber",
+				zap.String("txn", txn.Hash),
+				zap.String("blobber", bcm.ID),
+				zap.Error(err))
+			return "", fmt.Errorf("can't Save blobber: %v", err)
+		}
+
+		if err := spMap[bcm.ID].addOffer(alloc.BlobberAllocsMap[bcm.ID].Offer()); err != nil {
+			logging.Logger.Error("new_allocation_request_failed: error adding offer to blobber",
+				zap.String("txn", txn.Hash),
+				zap.String("blobber", bcm.ID),
+				zap.Error(err))
+			return "", fmt.Errorf("ading offer: %v", err)
+		}
+
+		if err = spMap[bcm.ID].Save(spenum.Blobber, bcm.ID, balances); err != nil {
+			logging.Logger.Error("new_allocation_request_failed: error saving blobber pool",
+				zap.String("txn", txn.Hash),
+				zap.String("blobber", bcm.ID),
+				zap.Error(err))
+			return "", fmt.Errorf("can't Save blobber's stake pool: %v", err)
+		}
+
+		emitUpdateBlobberAllocatedSavedHealth(b, balances)
+	}
+
+	var mb *meteredBilling
+	if request.Metered != nil {
+		// the tokens are the prepaid balance, the write pool stays empty
+		if mb, err = newMeteredBilling(alloc, request.Metered, transfer, balances); err != nil {
+			return "", common.NewError("allocation_creation_failed", err.Error())
+		}
+
+		if err := mb.checkFunding(alloc, conf.MeteredBillingMinPrepaidPeriod); err != nil {
+			return "", common.NewError("allocation_creation_failed", err.Error())
+		}
+	} else {
+		// create write pool and lock tokens
+		if err := alloc.addToWritePool(txn, balances, transfer); err != nil {
+			logging.Logger.Error("new_allocation_request_failed: error adding to allocation write pool",
+				zap.String("txn", txn.Hash),
+				zap.Error(err))
+			return "", common.NewError("allocation_creation_failed", err.Error())
+		}
+
+		if err := alloc.checkFunding(); err != nil {
+			return "", common.NewError("allocation_creation_failed", err.Error())
+		}
+	}
+	m.tick("create_write_pool")
+
+	if !request.IsEnterprise {
+		if err = sc.createChallengePool(txn, alloc, balances, conf); err != nil {
+			logging.Logger.Error("new_allocation_request_failed: error creating challenge pool",
+				zap.String("txn", txn.Hash),
+				zap.Error(err))
+			return "", common.NewError("allocation_creation_failed", err.Error())
+		}
+		m.tick("create_challenge_pool")
+	}
+
+	_ = sa.mustUpdateBase(func(sab *storageAllocationBase) error {
+		alloc.deepCopy(sab)
+		return nil
+	})
+
+	if resp, err = sc.addAllocation(sa, balances); err != nil {
+		logging.Logger.Error("new_allocation_request_failed: error adding allocation",
+			zap.String("txn", txn.Hash),
+			zap.Error(err))
+		return "", common.NewErrorf("allocation_creation_failed", "%v", err)
+	}
+	m.tick("add_allocation")
+
+	if request.Placement != nil {
+		if _, err := balances.InsertTrieNode(placementConstraintsKey(sc.ID, alloc.ID), request.Placement); err != nil {
+			return "", common.NewErrorf("allocation_creation_failed",
+				"saving placement constraints: %v", err)
+		}
+	}
+
+	if mb != nil {
+		mb.notify(alloc.Owner, false, balances)
+		if err := mb.save(sc.ID, balances); err != nil {
+			return "", common.NewErrorf("allocation_creation_failed",
+				"saving metered billing: %v", err)
+		}
+	}
+
+	// emit event to eventDB
+	emitAddOrOverwriteAllocationBlobberTerms(alloc, balances, txn)
+
+	return resp, err
+}
+
+// prepareNewAllocation validates the new allocation request and selects its
+// blobbers, without saving anything nor locking tokens
+func (sc *StorageSmartContract) prepareNewAllocation(
+	txn *transaction.Transaction,
+	input []byte,
+	conf *Config,
+	balances chainstate.StateContextI,
+	m Timings,
+) (*newAllocationRequest, *StorageAllocation, []*StorageNode, map[string]*stakePool, error) {
 	var request newAllocationRequest
-	if err = request.decode(input); err != nil {
+	if err := request.decode(input); err != nil {
 		logging.Logger.Error("new_allocation_request_failed: error decoding input",
 			zap.String("txn", txn.Hash),
 			zap.Error(err))
-		return "", common.NewErrorf("allocation_creation_failed",
+		return nil, nil, nil, nil, common.NewErrorf("allocation_creation_failed",
 			"malformed request: %v", err)
 	}
 
@@ -276,11 +439,11 @@ func (sc *StorageSmartContract) newAllocationRequestInternal(
 	}, func() error {
 		return nil
 	}); actErr != nil {
-		return "", common.NewErrorf("allocation_creation_failed", "activation error: %v", actErr)
+		return nil, nil, nil, nil, common.NewErrorf("allocation_creation_failed", "activation error: %v", actErr)
 	}
 
 	if err := request.validate(conf); err != nil {
-		return "", common.NewErrorf("allocation_creation_failed", "invalid request: "+err.Error())
+		return nil, nil, nil, nil, common.NewErrorf("allocation_creation_failed", "invalid request: "+err.Error())
 	}
 
 	if request.Owner == "" {
@@ -289,7 +452,7 @@ func (sc *StorageSmartContract) newAllocationRequestInternal(
 	}
 
 	if len(request.BlobberAuthTickets) < len(request.Blobbers) {
-		return "", common.NewErrorf("allocation_creation_failed", "blobber_auth_tickets are less than blobbers")
+		return nil, nil, nil, nil, common.NewErrorf("allocation_creation_failed", "blobber_auth_tickets are less than blobbers")
 	}
 
 	if len(request.BlobberAuthTickets) > len(request.Blobbers) {
@@ -298,7 +461,7 @@ func (sc *StorageSmartContract) newAllocationRequestInternal(
 
 	blobbers, err := getBlobbersByIDs(request.Blobbers, balances)
 	if err != nil {
-		return "", common.NewErrorf("allocation_creation_failed", "get blobbers failed: %v", err)
+		return nil, nil, nil, nil, common.NewErrorf("allocation_creation_failed", "get blobbers failed: %v", err)
 	}
 
 	if len(blobbers) < (request.DataShards + request.ParityShards) {
@@ -307,7 +470,7 @@ func (sc *StorageSmartContract) newAllocationRequestInternal(
 			zap.Int("fetched blobbers", len(blobbers)),
 			zap.Int("data shards", request.DataShards),
 			zap.Int("parity_shards", request.ParityShards))
-		return "", common.NewErrorf("allocation_creation_failed",
+		return nil, nil, nil, nil, common.NewErrorf("allocation_creation_failed",
 			"Not enough provided blobbers found in mpt")
 	}
 
@@ -317,116 +480,61 @@ func (sc *StorageSmartContract) newAllocationRequestInternal(
 	}
 
 	logging.Logger.Debug("new_allocation_request", zap.String("t_hash", txn.Hash), zap.Strings("blobbers", request.Blobbers), zap.Any("amount", txn.Value))
-	_, err = request.storageAllocation(balances, conf, txn.CreationDate) // (set fields, ignore expiration)
+	requestAlloc, err := request.storageAllocation(balances, conf, txn.CreationDate) // (set fields, ignore expiration)
 	if err != nil {
-		return "", common.NewErrorf("allocation_creation_failed", "creating storage allocation: %v", err)
+		return nil, nil, nil, nil, common.NewErrorf("allocation_creation_failed", "creating storage allocation: %v", err)
 	}
 	spMap, err := getStakePoolsByIDs(request.Blobbers, spenum.Blobber, balances)
 	if err != nil {
-		return "", common.NewErrorf("allocation_creation_failed", "getting stake pools: %v", err)
+		return nil, nil, nil, nil, common.NewErrorf("allocation_creation_failed", "getting stake pools: %v", err)
 	}
 	if len(spMap) != len(blobbers) {
-		return "", common.NewErrorf("allocation_creation_failed", "missing blobber's stake pool: %v", err)
+		return nil, nil, nil, nil, common.NewErrorf("allocation_creation_failed", "missing blobber's stake pool: %v", err)
 	}
 	var sns []*storageNodeResponse
 	for i := 0; i < len(blobbers); i++ {
 		bcm := blobbers[i].mustBase()
 		stake, err := spMap[bcm.ID].stake()
 		if err != nil {
-			return "", common.NewErrorf("allocation_creation_failed", "cannot total stake pool for blobber %s: %v", bcm.ID, err)
+			return nil, nil, nil, nil, common.NewErrorf("allocation_creation_failed", "cannot total stake pool for blobber %s: %v", bcm.ID, err)
 		}
-		snr, err := StoragNodeToStorageNodeResponse(balances, *blobbers[i])
+		snr, err := StoragNodeToStorageNodeResponse(sc.ID, balances, *blobbers[i])
 		if err != nil {
-			return "", err
+			return nil, nil, nil, nil, err
 		}
 		snr.TotalOffers = spMap[bcm.ID].TotalOffers
 		snr.TotalStake = stake
 		stakedCapacity, err := spMap[bcm.ID].stakedCapacity(bcm.Terms.WritePrice)
 		if err != nil {
-			return "", common.NewErrorf("allocation_creation_failed", "can not get total staked capacity for blobber %s: %v", bcm.ID, err)
+			return nil, nil, nil, nil, common.NewErrorf("allocation_creation_failed", "can not get total staked capacity for blobber %s: %v", bcm.ID, err)
 		}
 		snr.StakedCapacity = stakedCapacity
 
 		sns = append(sns, &snr)
 	}
 
-	sa, blobberNodes, err := setupNewAllocation(balances, request, sns, m, txn.CreationDate, conf, txn.Hash)
+	blobberTerms, err := sc.applyPriceOffers(&request, sns, requestAlloc.mustBase().bSize(), txn.CreationDate, balances)
 	if err != nil {
-		return "", err
-	}
-
-	alloc := sa.mustBase()
-
-	for _, b := range blobberNodes {
-		bcm := b.mustBase()
-		_, err = balances.InsertTrieNode(b.GetKey(), b)
-		if err != nil {
-			logging.Logger.Error("new_allocation_request_failed: error inserting blobber",
-				zap.String("txn", txn.Hash),
-				zap.String("blobber", bcm.ID),
-				zap.Error(err))
-			return "", fmt.Errorf("can't Save blobber: %v", err)
-		}
-
-		if err := spMap[bcm.ID].addOffer(alloc.BlobberAllocsMap[bcm.ID].Offer()); err != nil {
-			logging.Logger.Error("new_allocation_request_failed: error adding offer to blobber",
-				zap.String("txn", txn.Hash),
-				zap.String("blobber", bcm.ID),
-				zap.Error(err))
-			return "", fmt.Errorf("ading offer: %v", err)
-		}
-
-		if err = spMap[bcm.ID].Save(spenum.Blobber, bcm.ID, balances); err != nil {
-			logging.Logger.Error("new_allocation_request_failed: error saving blobber pool",
-				zap.String("txn", txn.Hash),
-				zap.String("blobber", bcm.ID),
-				zap.Error(err))
-			return "", fmt.Errorf("can't Save blobber's stake pool: %v", err)
-		}
-
-		emitUpdateBlobberAllocatedSavedHealth(b, balances)
+		return nil, nil, nil, nil, common.NewErrorf("allocation_creation_failed", "invalid price offers: %v", err)
 	}
 
-	// create write pool and lock tokens
-	if err := alloc.addToWritePool(txn, balances, transfer); err != nil {
-		logging.Logger.Error("new_allocation_request_failed: error adding to allocation write pool",
-			zap.String("txn", txn.Hash),
-			zap.Error(err))
-		return "", common.NewError("allocation_creation_failed", err.Error())
-	}
-
-	if err := alloc.checkFunding(); err != nil {
-		return "", common.NewError("allocation_creation_failed", err.Error())
+	sa, blobberNodes, err := setupNewAllocation(balances, request, sns, m, txn.CreationDate, conf, txn.Hash)
+	if err != nil {
+		return nil, nil, nil, nil, err
 	}
-	m.tick("create_write_pool")
 
-	if !request.IsEnterprise {
-		if err = sc.createChallengePool(txn, alloc, balances, conf); err != nil {
-			logging.Logger.Error("new_allocation_request_failed: error creating challenge pool",
-				zap.String("txn", txn.Hash),
-				zap.Error(err))
-			return "", common.NewError("allocation_creation_failed", err.Error())
+	// the offered prices are the terms of the allocation only
+	for _, b := range blobberNodes {
+		if terms, ok := blobberTerms[b.mustBase().ID]; ok {
+			//nolint:errcheck
+			b.mustUpdateBase(func(snb *storageNodeBase) error {
+				snb.Terms = terms
+				return nil
+			})
 		}
-		m.tick("create_challenge_pool")
-	}
-
-	_ = sa.mustUpdateBase(func(sab *storageAllocationBase) error {
-		alloc.deepCopy(sab)
-		return nil
-	})
-
-	if resp, err = sc.addAllocation(sa, balances); err != nil {
-		logging.Logger.Error("new_allocation_request_failed: error adding allocation",
-			zap.String("txn", txn.Hash),
-			zap.Error(err))
-		return "", common.NewErrorf("allocation_creation_failed", "%v", err)
 	}
-	m.tick("add_allocation")
-
-	// emit event to eventDB
-	emitAddOrOverwriteAllocationBlobberTerms(alloc, balances, txn)
 
-	return resp, err
+	return &request, sa, blobberNodes, spMap, nil
 }
 
 func setupNewAllocation(
@@ -549,6 +657,22 @@ func validateBlobbers(
 		return nil, 0, errors.New("Not enough blobbers to honor the allocation: " + strings.Join(errs, ", "))
 	}
 
+	if request.Placement != nil {
+		ids := make([]string, 0, len(list))
+		for _, b := range list {
+			ids = append(ids, b.mustBase().ID)
+		}
+		selected, _, err := request.Placement.selectBlobbers(ids, size, balances)
+		if err != nil {
+			return nil, 0, err
+		}
+		placed := make([]*StorageNode, 0, size)
+		for _, i := range selected {
+			placed = append(placed, list[i])
+		}
+		list = placed
+	}
+
 	sa.BlobberAllocs = make([]*BlobberAllocation, 0)
 	sa.Stats = &StorageAllocationStats{}
 
@@ -568,6 +692,12 @@ type updateAllocationRequest struct {
 	SetThirdPartyExtendable bool   `json:"set_third_party_extendable"`
 	FileOptionsChanged      bool   `json:"file_options_changed"`
 	FileOptions             uint16 `json:"file_options"`
+
+	// Placement replaces the placement constraints of the allocation
+	Placement *placementConstraints `json:"placement,omitempty"`
+
+	// ShardMigration moves the allocation to other data and parity shards
+	ShardMigration *shardMigrationRequest `json:"shard_migration,omitempty"`
 }
 
 func (uar *updateAllocationRequest) decode(b []byte) error {
@@ -585,6 +715,8 @@ func (uar *updateAllocationRequest) validate(
 		len(uar.Name) == 0 &&
 		(!uar.SetThirdPartyExtendable || (uar.SetThirdPartyExtendable && alloc.ThirdPartyExtendable)) &&
 		(!uar.FileOptionsChanged || uar.FileOptions == alloc.FileOptions) &&
+		uar.Placement == nil &&
+		uar.ShardMigration == nil &&
 		(alloc.Owner == uar.OwnerID) {
 		return errors.New("update allocation changes nothing")
 	} else {
@@ -993,7 +1125,13 @@ func (sc *StorageSmartContract) extendAllocation(
 		}
 	}
 
-	if !isEnterprise {
+	// metered allocations don't fund the challenge pool
+	mb, err := sc.findMeteredBilling(alloc.ID, balances)
+	if err != nil {
+		return common.NewErrorf("allocation_extending_failed", "%v", err)
+	}
+
+	if !isEnterprise && mb == nil {
 		var remainingDuration = alloc.Expiration - txn.CreationDate
 		err = sc.adjustChallengePool(alloc, originalRemainingDuration, remainingDuration, originalTerms, conf.TimeUnit, balances)
 		if err != nil {
@@ -1026,14 +1164,55 @@ func (sc *StorageSmartContract) updateAllocationRequestInternal(
 	conf *Config,
 	balances chainstate.StateContextI,
 ) (resp string, err error) {
+	sa, blobbers, tokensRequiredToLock, err := sc.applyAllocationUpdate(t, input, conf, balances)
+	if err != nil {
+		return "", err
+	}
+	alloc := sa.mustBase()
+
+	if actErr := chainstate.WithActivation(balances, "electra", func() error {
+		if t.Value < tokensRequiredToLock {
+			return common.NewError("allocation_updating_failed",
+				fmt.Sprintf("not enough tokens to cover update allocation cost (locked : %d < required : %d)", t.Value, tokensRequiredToLock+t.Value))
+		}
+		return nil
+	}, func() error {
+		if tokensRequiredToLock > 0 {
+			return common.NewError("allocation_updating_failed",
+				fmt.Sprintf("not enough tokens to cover update allocation cost (locked : %d < required : %d)", t.Value, tokensRequiredToLock+t.Value))
+		}
+		return nil
+	}); actErr != nil {
+		return "", actErr
+	}
+
+	err = sa.saveUpdatedAllocation(blobbers, balances)
+	if err != nil {
+		return "", common.NewErrorf("allocation_reducing_failed", "%v", err)
+	}
+
+	emitAddOrOverwriteAllocationBlobberTerms(alloc, balances, t)
+
+	return string(sa.Encode()), nil
+}
+
+// applyAllocationUpdate applies the update allocation request to the
+// allocation and its blobbers, without saving them, and returns the tokens
+// still required to lock for it
+func (sc *StorageSmartContract) applyAllocationUpdate(
+	t *transaction.Transaction,
+	input []byte,
+	conf *Config,
+	balances chainstate.StateContextI,
+) (sa *StorageAllocation, blobbers []*StorageNode, tokensRequiredToLock currency.Coin, err error) {
 	if t.ClientID == "" {
-		return "", common.NewError("allocation_updating_failed",
+		return nil, nil, 0, common.NewError("allocation_updating_failed",
 			"missing client_id in transaction")
 	}
 
 	var request updateAllocationRequest
 	if err = request.decode(input); err != nil {
-		return "", common.NewError("allocation_updating_failed",
+		return nil, nil, 0, common.NewError("allocation_updating_failed",
 			"invalid request: "+err.Error())
 	}
 
@@ -1046,9 +1225,8 @@ func (sc *StorageSmartContract) updateAllocationRequestInternal(
 		request.OwnerID = t.ClientID
 	}
 
-	var sa *StorageAllocation
 	if sa, err = sc.getAllocation(request.ID, balances); err != nil {
-		return "", common.NewError("allocation_updating_failed",
+		return nil, nil, 0, common.NewError("allocation_updating_failed",
 			"can't get existing allocation: "+err.Error())
 	}
 
@@ -1056,28 +1234,69 @@ func (sc *StorageSmartContract) updateAllocationRequestInternal(
 
 	if t.ClientID != alloc.Owner {
 		if !alloc.ThirdPartyExtendable || !request.Extend {
-			return "", common.NewError("allocation_updating_failed",
+			return nil, nil, 0, common.NewError("allocation_updating_failed",
 				"only owner can update the allocation")
 		}
 	}
 
 	if err = request.validate(conf, alloc); err != nil {
-		return "", common.NewError("allocation_updating_failed", err.Error())
+		return nil, nil, 0, common.NewError("allocation_updating_failed", err.Error())
 	}
 
 	// can't update expired allocation
 	if alloc.Expiration < t.CreationDate {
-		return "", common.NewError("allocation_updating_failed",
+		return nil, nil, 0, common.NewError("allocation_updating_failed",
 			"can't update expired allocation")
 	}
 
+	// the blobbers of a frozen allocation and its size can't change, it can
+	// still be extended
+	if request.Size > 0 || len(request.AddBlobberId) > 0 || request.ShardMigration != nil {
+		frozen, err := sc.isAllocationFrozen(alloc.ID, balances)
+		if err != nil {
+			return nil, nil, 0, common.NewError("allocation_updating_failed", err.Error())
+		}
+		if frozen {
+			return nil, nil, 0, common.NewError("allocation_updating_failed",
+				"can't resize, change blobbers or migrate shards of a frozen allocation")
+		}
+	}
+
 	// update allocation transaction hash
 	alloc.Tx = t.Hash
 
+	mb, err := sc.findMeteredBilling(alloc.ID, balances)
+	if err != nil {
+		return nil, nil, 0, common.NewError("allocation_updating_failed", err.Error())
+	}
+
+	// the blobbers keep the size of their layout until the migration is over
+	sm, err := sc.findShardMigration(alloc.ID, balances)
+	if err != nil {
+		return nil, nil, 0, common.NewError("allocation_updating_failed", err.Error())
+	}
+	if sm != nil && (request.Extend || request.ShardMigration != nil ||
+		(len(request.AddBlobberId) > 0 && len(request.RemoveBlobberId) == 0)) {
+		return nil, nil, 0, common.NewError("allocation_updating_failed",
+			"allocation is migrating its shards, only blobber replacements are allowed until it's finalized or canceled")
+	}
+
 	actErr := chainstate.WithActivation(balances, "demeter", func() error {
 		return nil
 	}, func() error {
-		if t.Value > 0 {
+		if t.Value > 0 && mb != nil {
+			// metered allocations are topped up instead
+			if _, err = NewTokenTransfer(t.Value, t.ClientID, t.ToClientID, false).transfer(balances); err != nil {
+				return common.NewError("allocation_updating_failed", err.Error())
+			}
+			if err = mb.topUp(t.Value); err != nil {
+				return common.NewError("allocation_updating_failed", err.Error())
+			}
+			mb.notify(alloc.Owner, false, balances)
+			if err = mb.save(sc.ID, balances); err != nil {
+				return common.NewError("allocation_updating_failed", err.Error())
+			}
+		} else if t.Value > 0 {
 			if err = alloc.addToWritePool(t, balances, NewTokenTransfer(t.Value, t.ClientID, t.ToClientID, false)); err != nil {
 				return common.NewError("allocation_updating_failed", err.Error())
 			}
@@ -1085,12 +1304,11 @@ func (sc *StorageSmartContract) updateAllocationRequestInternal(
 		return nil
 	})
 	if actErr != nil {
-		return "", actErr
+		return nil, nil, 0, actErr
 	}
 
-	var blobbers []*StorageNode
 	if blobbers, err = sc.getAllocationBlobbers(alloc, balances); err != nil {
-		return "", common.NewError("allocation_updating_failed",
+		return nil, nil, 0, common.NewError("allocation_updating_failed",
 			err.Error())
 	}
 
@@ -1105,7 +1323,7 @@ func (sc *StorageSmartContract) updateAllocationRequestInternal(
 		}
 		return nil
 	}); actErr != nil {
-		return "", actErr
+		return nil, nil, 0, actErr
 	}
 
 	// If the txn client_id is not the owner of the allocation, should just be able to extend the allocation if permissible
@@ -1113,7 +1331,7 @@ func (sc *StorageSmartContract) updateAllocationRequestInternal(
 	if t.ClientID != alloc.Owner /* Third-party actions */ {
 		err = sc.extendAllocation(t, conf, isEnterprise, alloc, blobbers, &request, balances)
 		if err != nil {
-			return "", err
+			return nil, nil, 0, err
 		}
 	} else /* Owner Actions */ {
 
@@ -1125,21 +1343,41 @@ func (sc *StorageSmartContract) updateAllocationRequestInternal(
 				conf, blobbers, request.AddBlobberId, request.AddBlobberAuthTicket, request.RemoveBlobberId, t.CreationDate, balances, sc, t, isEnterprise,
 			)
 			if err != nil {
-				return "", common.NewError("allocation_updating_failed", err.Error())
+				return nil, nil, 0, common.NewError("allocation_updating_failed", err.Error())
+			}
+			// keep track of the blobbers a cancel of the migration releases
+			if sm != nil && sm.replaceAdded(request.RemoveBlobberId, request.AddBlobberId) {
+				if err = sm.save(sc.ID, balances); err != nil {
+					return nil, nil, 0, common.NewError("allocation_updating_failed",
+						"saving shard migration: "+err.Error())
+				}
+			}
+		}
+
+		if request.ShardMigration != nil {
+			blobbers, err = sc.startShardMigration(t, conf, alloc, blobbers, request.ShardMigration, isEnterprise, balances)
+			if err != nil {
+				return nil, nil, 0, common.NewError("allocation_updating_failed", err.Error())
 			}
 		}
 
 		if len(blobbers) != len(alloc.BlobberAllocs) {
-			return "", common.NewError("allocation_updating_failed",
+			return nil, nil, 0, common.NewError("allocation_updating_failed",
 				"error allocation blobber size mismatch")
 		}
 
+		if request.Placement != nil || len(request.AddBlobberId) > 0 || request.ShardMigration != nil {
+			if err = sc.updatePlacementConstraints(alloc, request.Placement, balances); err != nil {
+				return nil, nil, 0, common.NewError("allocation_updating_failed", err.Error())
+			}
+		}
+
 		// if size or expiration increased, then we use new terms
 		// otherwise, we use the same terms
 		if request.Extend {
 			err = sc.extendAllocation(t, conf, isEnterprise, alloc, blobbers, &request, balances)
 			if err != nil {
-				return "", err
+				return nil, nil, 0, err
 			}
 		}
 
@@ -1163,7 +1401,7 @@ func (sc *StorageSmartContract) updateAllocationRequestInternal(
 		if request.OwnerID != alloc.Owner {
 			alloc.Owner = request.OwnerID
 			if request.OwnerPublicKey == "" {
-				return "", common.NewError("allocation_updating_failed", "owner public key is required when updating owner id")
+				return nil, nil, 0, common.NewError("allocation_updating_failed", "owner public key is required when updating owner id")
 			}
 			alloc.OwnerPublicKey = request.OwnerPublicKey
 		}
@@ -1173,48 +1411,26 @@ func (sc *StorageSmartContract) updateAllocationRequestInternal(
 	if !isEnterprise {
 		cp, err := sc.getChallengePool(alloc.ID, balances)
 		if err != nil {
-			return "", common.NewError("allocation_updating_failed", err.Error())
+			return nil, nil, 0, common.NewError("allocation_updating_failed", err.Error())
 		}
 
 		cpBalance = cp.Balance
 	}
 
-	tokensRequiredToLock, err := alloc.requiredTokensForUpdateAllocation(cpBalance, request.Extend, isEnterprise, t.CreationDate)
-	if err != nil {
-		return "", common.NewError("allocation_updating_failed", err.Error())
-	}
-
-	if actErr = chainstate.WithActivation(balances, "electra", func() error {
-		if t.Value < tokensRequiredToLock {
-			return common.NewError("allocation_updating_failed",
-				fmt.Sprintf("not enough tokens to cover update allocation cost (locked : %d < required : %d)", t.Value, tokensRequiredToLock+t.Value))
-		}
-		return nil
-	}, func() error {
-		if tokensRequiredToLock > 0 {
-			return common.NewError("allocation_updating_failed",
-				fmt.Sprintf("not enough tokens to cover update allocation cost (locked : %d < required : %d)", t.Value, tokensRequiredToLock+t.Value))
+	// metered allocations pay for the used storage only
+	if mb == nil {
+		tokensRequiredToLock, err = alloc.requiredTokensForUpdateAllocation(cpBalance, request.Extend, isEnterprise, t.CreationDate)
+		if err != nil {
+			return nil, nil, 0, common.NewError("allocation_updating_failed", err.Error())
 		}
-		return nil
-	}); actErr != nil {
-		return "", actErr
 	}
 
-	// lock tokens if this transaction provides them
-
 	_ = sa.mustUpdateBase(func(base *storageAllocationBase) error {
 		alloc.deepCopy(base)
 		return nil
 	})
 
-	err = sa.saveUpdatedAllocation(blobbers, balances)
-	if err != nil {
-		return "", common.NewErrorf("allocation_reducing_failed", "%v", err)
-	}
-
-	emitAddOrOverwriteAllocationBlobberTerms(alloc, balances, t)
-
-	return string(sa.Encode()), nil
+	return sa, blobbers, tokensRequiredToLock, nil
 }
 
 //nolint:unused
@@ -1618,6 +1834,9 @@ func (sc *StorageSmartContract) finishAllocation(
 	balances chainstate.StateContextI,
 	conf *Config,
 ) (err error) {
+	// the challenge pool holds the escrow of the disputes, the enterprise
+	// allocations have none
+	var cp *challengePool
 	if isEnterprise {
 		var cost currency.Coin
 		if cost, err = alloc.payCostForDtuForEnterpriseAllocation(t, conf, sps, balances); err != nil {
@@ -1656,8 +1875,6 @@ func (sc *StorageSmartContract) finishAllocation(
 		}
 
 	} else {
-		var cp *challengePool
-
 		if cp, err = sc.getChallengePool(alloc.ID, balances); err != nil {
 			return fmt.Errorf("could not get challenge pool of alloc: %s, err: %v", alloc.ID, err)
 		}
@@ -1670,6 +1887,10 @@ func (sc *StorageSmartContract) finishAllocation(
 			return fmt.Errorf("4 error paying cancellation charge: %v", err)
 		}
 
+		if err = sc.closeMeteredBilling(alloc, sps, t.CreationDate, conf, balances); err != nil {
+			return fmt.Errorf("error closing metered billing: %v", err)
+		}
+
 		for i, d := range alloc.BlobberAllocs {
 			if d.Stats.UsedSize > 0 {
 				if err := removeAllocationFromBlobberPartitions(balances, d.BlobberID, d.AllocationID); err != nil {
@@ -1732,6 +1953,17 @@ func (sc *StorageSmartContract) finishAllocation(
 
 	}
 
+	// upheld after the payouts, the penalties of the finalization are
+	// escrowed by them
+	if err = sc.closeChallengeDisputes(alloc, cp, balances); err != nil {
+		return fmt.Errorf("could not close challenge disputes: %v", err)
+	}
+
+	_, err = balances.DeleteTrieNode(shardMigrationKey(sc.ID, alloc.ID))
+	if err != nil && err != util.ErrValueNotPresent {
+		return fmt.Errorf("could not delete shard migration: %v", err)
+	}
+
 	transfer := state.NewTransfer(sc.ID, alloc.Owner, alloc.WritePool)
 	if err = balances.AddTransfer(transfer); err != nil {
 		return fmt.Errorf("could not refund lock token: %v", err)
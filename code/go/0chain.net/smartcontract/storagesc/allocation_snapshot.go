@@ -0,0 +1,230 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/util"
+)
+
+//msgp:ignore freezeAllocationRequest
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+// freezeAllocationRequest is the input of the freeze_allocation function, the
+// signature is the owner's signature of the snapshot hash of the allocation
+// roots to freeze.
+type freezeAllocationRequest struct {
+	AllocationID string `json:"allocation_id"`
+	Signature    string `json:"signature"`
+}
+
+func (far *freezeAllocationRequest) decode(b []byte) error {
+	return json.Unmarshal(b, far)
+}
+
+func allocationSnapshotKey(sscKey, allocID string) datastore.Key {
+	return sscKey + ":allocationsnapshot:" + allocID
+}
+
+// blobberSnapshot is the allocation root of a blobber when the allocation
+// has been frozen.
+type blobberSnapshot struct {
+	BlobberID            string           `json:"blobber_id"`
+	AllocationRoot       string           `json:"allocation_root"`
+	WriteMarkerTimestamp common.Timestamp `json:"write_marker_timestamp"`
+}
+
+// allocationSnapshot is the signed snapshot of the allocation roots of a
+// frozen allocation. Its presence marks the allocation immutable.
+type allocationSnapshot struct {
+	AllocationID string             `json:"allocation_id"`
+	Owner        string             `json:"owner"`
+	Blobbers     []*blobberSnapshot `json:"blobbers"`
+	Hash         string             `json:"hash"`
+	Signature    string             `json:"signature"`
+	TxnHash      string             `json:"txn_hash"`
+	Round        int64              `json:"round"`
+	FrozenAt     common.Timestamp   `json:"frozen_at"`
+}
+
+func newAllocationSnapshot(alloc *storageAllocationBase) *allocationSnapshot {
+	snap := &allocationSnapshot{
+		AllocationID: alloc.ID,
+		Owner:        alloc.Owner,
+		Blobbers:     make([]*blobberSnapshot, 0, len(alloc.BlobberAllocs)),
+	}
+	for _, ba := range alloc.BlobberAllocs {
+		bs := &blobberSnapshot{
+			BlobberID:      ba.BlobberID,
+			AllocationRoot: ba.AllocationRoot,
+		}
+		if ba.LastWriteMarker != nil {
+			bs.WriteMarkerTimestamp = ba.LastWriteMarker.mustBase().Timestamp
+		}
+		snap.Blobbers = append(snap.Blobbers, bs)
+	}
+	sort.Slice(snap.Blobbers, func(i, j int) bool {
+		return snap.Blobbers[i].BlobberID < snap.Blobbers[j].BlobberID
+	})
+	snap.Hash = encryption.Hash(snap.hashData())
+	return snap
+}
+
+// hashData joins the allocation ID and the allocation roots of the blobbers
+// ordered by the blobber ID, the data the owner signs to freeze the
+// allocation
+func (as *allocationSnapshot) hashData() string {
+	var sb strings.Builder
+	sb.WriteString(as.AllocationID)
+	for _, bs := range as.Blobbers {
+		sb.WriteString(":")
+		sb.WriteString(bs.BlobberID)
+		sb.WriteString(":")
+		sb.WriteString(bs.AllocationRoot)
+	}
+	return sb.String()
+}
+
+func (as *allocationSnapshot) verifySignature(publicKey string, balances cstate.StateContextI) bool {
+	signatureScheme := balances.GetSignatureScheme()
+	if err := signatureScheme.SetPublicKey(publicKey); err != nil {
+		return false
+	}
+	ok, err := signatureScheme.Verify(as.Signature, as.Hash)
+	return err == nil && ok
+}
+
+func (as *allocationSnapshot) Encode() []byte {
+	var b, err = json.Marshal(as)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (as *allocationSnapshot) Decode(p []byte) error {
+	return json.Unmarshal(p, as)
+}
+
+func (as *allocationSnapshot) emitAddEvent(balances cstate.StateContextI) {
+	snapshots := make([]event.AllocationSnapshot, 0, len(as.Blobbers))
+	for _, bs := range as.Blobbers {
+		snapshots = append(snapshots, event.AllocationSnapshot{
+			AllocationID:         as.AllocationID,
+			BlobberID:            bs.BlobberID,
+			AllocationRoot:       bs.AllocationRoot,
+			WriteMarkerTimestamp: int64(bs.WriteMarkerTimestamp),
+			Owner:                as.Owner,
+			SnapshotHash:         as.Hash,
+			Signature:            as.Signature,
+			TransactionHash:      as.TxnHash,
+			Round:                as.Round,
+			FrozenAt:             int64(as.FrozenAt),
+		})
+	}
+	balances.EmitEvent(event.TypeStats, event.TagAddAllocationSnapshot, as.AllocationID, snapshots)
+}
+
+func (sc *StorageSmartContract) getAllocationSnapshot(allocID string,
+	balances cstate.CommonStateContextI) (*allocationSnapshot, error) {
+
+	as := new(allocationSnapshot)
+	if err := balances.GetTrieNode(allocationSnapshotKey(sc.ID, allocID), as); err != nil {
+		return nil, err
+	}
+	return as, nil
+}
+
+// isAllocationFrozen returns true if the allocation has a snapshot
+func (sc *StorageSmartContract) isAllocationFrozen(allocID string,
+	balances cstate.CommonStateContextI) (bool, error) {
+
+	_, err := sc.getAllocationSnapshot(allocID, balances)
+	switch err {
+	case nil:
+		return true, nil
+	case util.ErrValueNotPresent:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// freezeAllocation makes the allocation read-only, recording the current
+// allocation roots of its blobbers as a snapshot signed by the owner. No
+// write marker can be committed to a frozen allocation.
+func (sc *StorageSmartContract) freezeAllocation(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req freezeAllocationRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("freeze_allocation_failed",
+			"invalid request: "+err.Error())
+	}
+
+	sa, err := sc.getAllocation(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("freeze_allocation_failed", err.Error())
+	}
+
+	alloc := sa.mustBase()
+	if alloc.Owner != t.ClientID {
+		return "", common.NewError("freeze_allocation_failed",
+			"only owner can freeze an allocation")
+	}
+	if alloc.Finalized || alloc.Canceled {
+		return "", common.NewError("freeze_allocation_failed",
+			"allocation is finalized")
+	}
+	if alloc.Expiration < t.CreationDate {
+		return "", common.NewError("freeze_allocation_failed",
+			"allocation is expired")
+	}
+
+	frozen, err := sc.isAllocationFrozen(alloc.ID, balances)
+	if err != nil {
+		return "", common.NewError("freeze_allocation_failed", err.Error())
+	}
+	if frozen {
+		return "", common.NewError("freeze_allocation_failed",
+			"allocation is already frozen")
+	}
+
+	// the snapshot would mix the roots of both layouts
+	sm, err := sc.findShardMigration(alloc.ID, balances)
+	if err != nil {
+		return "", common.NewError("freeze_allocation_failed", err.Error())
+	}
+	if sm != nil {
+		return "", common.NewError("freeze_allocation_failed",
+			"allocation is migrating its shards")
+	}
+
+	snap := newAllocationSnapshot(alloc)
+	snap.Signature = req.Signature
+	if !snap.verifySignature(alloc.OwnerPublicKey, balances) {
+		return "", common.NewError("freeze_allocation_failed",
+			"invalid signature of the snapshot hash "+snap.Hash)
+	}
+	snap.TxnHash = t.Hash
+	snap.Round = balances.GetBlock().Round
+	snap.FrozenAt = t.CreationDate
+
+	if _, err := balances.InsertTrieNode(allocationSnapshotKey(sc.ID, alloc.ID), snap); err != nil {
+		return "", common.NewError("freeze_allocation_failed",
+			"saving snapshot: "+err.Error())
+	}
+
+	snap.emitAddEvent(balances)
+
+	return string(snap.Encode()), nil
+}
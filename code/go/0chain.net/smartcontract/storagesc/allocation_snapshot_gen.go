@@ -0,0 +1,287 @@
+package storagesc
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *allocationSnapshot) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 8
+	// string "AllocationID"
+	o = append(o, 0x88, 0xac, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44)
+	o = msgp.AppendString(o, z.AllocationID)
+	// string "Owner"
+	o = append(o, 0xa5, 0x4f, 0x77, 0x6e, 0x65, 0x72)
+	o = msgp.AppendString(o, z.Owner)
+	// string "Blobbers"
+	o = append(o, 0xa8, 0x42, 0x6c, 0x6f, 0x62, 0x62, 0x65, 0x72, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Blobbers)))
+	for za0001 := range z.Blobbers {
+		if z.Blobbers[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			// map header, size 3
+			// string "BlobberID"
+			o = append(o, 0x83, 0xa9, 0x42, 0x6c, 0x6f, 0x62, 0x62, 0x65, 0x72, 0x49, 0x44)
+			o = msgp.AppendString(o, z.Blobbers[za0001].BlobberID)
+			// string "AllocationRoot"
+			o = append(o, 0xae, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x6f, 0x6f, 0x74)
+			o = msgp.AppendString(o, z.Blobbers[za0001].AllocationRoot)
+			// string "WriteMarkerTimestamp"
+			o = append(o, 0xb4, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+			o, err = z.Blobbers[za0001].WriteMarkerTimestamp.MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Blobbers", za0001, "WriteMarkerTimestamp")
+				return
+			}
+		}
+	}
+	// string "Hash"
+	o = append(o, 0xa4, 0x48, 0x61, 0x73, 0x68)
+	o = msgp.AppendString(o, z.Hash)
+	// string "Signature"
+	o = append(o, 0xa9, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65)
+	o = msgp.AppendString(o, z.Signature)
+	// string "TxnHash"
+	o = append(o, 0xa7, 0x54, 0x78, 0x6e, 0x48, 0x61, 0x73, 0x68)
+	o = msgp.AppendString(o, z.TxnHash)
+	// string "Round"
+	o = append(o, 0xa5, 0x52, 0x6f, 0x75, 0x6e, 0x64)
+	o = msgp.AppendInt64(o, z.Round)
+	// string "FrozenAt"
+	o = append(o, 0xa8, 0x46, 0x72, 0x6f, 0x7a, 0x65, 0x6e, 0x41, 0x74)
+	o, err = z.FrozenAt.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "FrozenAt")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *allocationSnapshot) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "AllocationID":
+			z.AllocationID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AllocationID")
+				return
+			}
+		case "Owner":
+			z.Owner, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Owner")
+				return
+			}
+		case "Blobbers":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Blobbers")
+				return
+			}
+			if cap(z.Blobbers) >= int(zb0002) {
+				z.Blobbers = (z.Blobbers)[:zb0002]
+			} else {
+				z.Blobbers = make([]*blobberSnapshot, zb0002)
+			}
+			for za0001 := range z.Blobbers {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Blobbers[za0001] = nil
+				} else {
+					if z.Blobbers[za0001] == nil {
+						z.Blobbers[za0001] = new(blobberSnapshot)
+					}
+					var zb0003 uint32
+					zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Blobbers", za0001)
+						return
+					}
+					for zb0003 > 0 {
+						zb0003--
+						field, bts, err = msgp.ReadMapKeyZC(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Blobbers", za0001)
+							return
+						}
+						switch msgp.UnsafeString(field) {
+						case "BlobberID":
+							z.Blobbers[za0001].BlobberID, bts, err = msgp.ReadStringBytes(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Blobbers", za0001, "BlobberID")
+								return
+							}
+						case "AllocationRoot":
+							z.Blobbers[za0001].AllocationRoot, bts, err = msgp.ReadStringBytes(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Blobbers", za0001, "AllocationRoot")
+								return
+							}
+						case "WriteMarkerTimestamp":
+							bts, err = z.Blobbers[za0001].WriteMarkerTimestamp.UnmarshalMsg(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Blobbers", za0001, "WriteMarkerTimestamp")
+								return
+							}
+						default:
+							bts, err = msgp.Skip(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Blobbers", za0001)
+								return
+							}
+						}
+					}
+				}
+			}
+		case "Hash":
+			z.Hash, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Hash")
+				return
+			}
+		case "Signature":
+			z.Signature, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Signature")
+				return
+			}
+		case "TxnHash":
+			z.TxnHash, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TxnHash")
+				return
+			}
+		case "Round":
+			z.Round, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Round")
+				return
+			}
+		case "FrozenAt":
+			bts, err = z.FrozenAt.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "FrozenAt")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *allocationSnapshot) Msgsize() (s int) {
+	s = 1 + 13 + msgp.StringPrefixSize + len(z.AllocationID) + 6 + msgp.StringPrefixSize + len(z.Owner) + 9 + msgp.ArrayHeaderSize
+	for za0001 := range z.Blobbers {
+		if z.Blobbers[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += 1 + 10 + msgp.StringPrefixSize + len(z.Blobbers[za0001].BlobberID) + 15 + msgp.StringPrefixSize + len(z.Blobbers[za0001].AllocationRoot) + 21 + z.Blobbers[za0001].WriteMarkerTimestamp.Msgsize()
+		}
+	}
+	s += 5 + msgp.StringPrefixSize + len(z.Hash) + 10 + msgp.StringPrefixSize + len(z.Signature) + 8 + msgp.StringPrefixSize + len(z.TxnHash) + 6 + msgp.Int64Size + 9 + z.FrozenAt.Msgsize()
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *blobberSnapshot) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "BlobberID"
+	o = append(o, 0x83, 0xa9, 0x42, 0x6c, 0x6f, 0x62, 0x62, 0x65, 0x72, 0x49, 0x44)
+	o = msgp.AppendString(o, z.BlobberID)
+	// string "AllocationRoot"
+	o = append(o, 0xae, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x6f, 0x6f, 0x74)
+	o = msgp.AppendString(o, z.AllocationRoot)
+	// string "WriteMarkerTimestamp"
+	o = append(o, 0xb4, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	o, err = z.WriteMarkerTimestamp.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "WriteMarkerTimestamp")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *blobberSnapshot) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "BlobberID":
+			z.BlobberID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BlobberID")
+				return
+			}
+		case "AllocationRoot":
+			z.AllocationRoot, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AllocationRoot")
+				return
+			}
+		case "WriteMarkerTimestamp":
+			bts, err = z.WriteMarkerTimestamp.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "WriteMarkerTimestamp")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *blobberSnapshot) Msgsize() (s int) {
+	s = 1 + 10 + msgp.StringPrefixSize + len(z.BlobberID) + 15 + msgp.StringPrefixSize + len(z.AllocationRoot) + 21 + z.WriteMarkerTimestamp.Msgsize()
+	return
+}
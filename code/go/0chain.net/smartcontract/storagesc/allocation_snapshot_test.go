@@ -0,0 +1,159 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreezeAllocation(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		owner    = newClient(2000*x10, balances)
+		other    = newClient(100*x10, balances)
+		tp       = int64(100)
+	)
+
+	allocID, blobs := addAllocation(t, ssc, owner, tp, 0, 0, 0, 0, 0, balances, false, false, false)
+
+	sa, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	snapHash := newAllocationSnapshot(sa.mustBase()).Hash
+
+	freeze := func(c, signer *Client) error {
+		sig, err := signer.scheme.Sign(snapHash)
+		require.NoError(t, err)
+		input, err := json.Marshal(&freezeAllocationRequest{
+			AllocationID: allocID,
+			Signature:    sig,
+		})
+		require.NoError(t, err)
+		tx := newTransaction(c.id, ADDRESS, 0, tp)
+		balances.setTransaction(t, tx)
+		_, err = ssc.freezeAllocation(tx, input, balances)
+		return err
+	}
+
+	require.Error(t, freeze(other, other), "only owner can freeze")
+	require.Error(t, freeze(owner, other), "snapshot signed by other client")
+
+	frozen, err := ssc.isAllocationFrozen(allocID, balances)
+	require.NoError(t, err)
+	require.False(t, frozen)
+
+	require.NoError(t, freeze(owner, owner))
+	require.Error(t, freeze(owner, owner), "allocation frozen twice")
+
+	snap, err := ssc.getAllocationSnapshot(allocID, balances)
+	require.NoError(t, err)
+	require.Equal(t, snapHash, snap.Hash)
+	require.Equal(t, owner.id, snap.Owner)
+	require.Len(t, snap.Blobbers, len(sa.mustBase().BlobberAllocs))
+	for i := 1; i < len(snap.Blobbers); i++ {
+		require.Less(t, snap.Blobbers[i-1].BlobberID, snap.Blobbers[i].BlobberID)
+	}
+
+	// no write marker can be committed to a frozen allocation
+	b := blobs[0]
+	wm := &writeMarkerV1{
+		AllocationRoot: "alloc-root-1",
+		AllocationID:   allocID,
+		Size:           1024,
+		BlobberID:      b.id,
+		Timestamp:      common.Timestamp(tp),
+		ClientID:       owner.id,
+	}
+	wm.Signature, err = owner.scheme.Sign(encryption.Hash(wm.GetHashData()))
+	require.NoError(t, err)
+	cc := &BlobberCloseConnection{
+		AllocationRoot: "alloc-root-1",
+		WriteMarker:    &WriteMarker{},
+	}
+	cc.WriteMarker.SetEntity(wm)
+
+	tx := newTransaction(b.id, ssc.ID, 0, tp)
+	balances.setTransaction(t, tx)
+	_, err = ssc.commitBlobberConnection(tx, mustEncode(t, cc), balances)
+	require.EqualError(t, err, "commit_connection_failed: allocation is frozen")
+}
+
+func TestUpdateFrozenAllocation(t *testing.T) {
+	const frozenErr = "can't resize, change blobbers or migrate shards of a frozen allocation"
+
+	tests := []struct {
+		name    string
+		req     updateAllocationRequest
+		wantErr string
+	}{
+		{
+			name: "extend",
+			req:  updateAllocationRequest{Extend: true},
+		},
+		{
+			name:    "resize",
+			req:     updateAllocationRequest{Size: 1024},
+			wantErr: frozenErr,
+		},
+		{
+			name:    "extend and resize",
+			req:     updateAllocationRequest{Extend: true, Size: 1024},
+			wantErr: frozenErr,
+		},
+		{
+			name:    "add blobber",
+			req:     updateAllocationRequest{AddBlobberId: "new_blobber"},
+			wantErr: frozenErr,
+		},
+		{
+			name: "migrate shards",
+			req: updateAllocationRequest{ShardMigration: &shardMigrationRequest{
+				DataShards:   1,
+				ParityShards: 1,
+			}},
+			wantErr: frozenErr,
+		},
+		{
+			name: "rename",
+			req:  updateAllocationRequest{Name: "frozen"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ssc      = newTestStorageSC()
+				balances = newTestBalances(t, false)
+				owner    = newClient(2000*x10, balances)
+				tp       = int64(100)
+			)
+
+			allocID, _ := addAllocation(t, ssc, owner, tp, 0, 0, 0, 0, 0, balances, false, false, false)
+			sa, err := ssc.getAllocation(allocID, balances)
+			require.NoError(t, err)
+
+			sig, err := owner.scheme.Sign(newAllocationSnapshot(sa.mustBase()).Hash)
+			require.NoError(t, err)
+			tx := newTransaction(owner.id, ADDRESS, 0, tp)
+			balances.setTransaction(t, tx)
+			_, err = ssc.freezeAllocation(tx, mustEncode(t, &freezeAllocationRequest{
+				AllocationID: allocID,
+				Signature:    sig,
+			}), balances)
+			require.NoError(t, err)
+
+			req := tt.req
+			req.ID = allocID
+			req.OwnerID = owner.id
+			_, err = req.callUpdateAllocReq(t, owner.id, 0, tp+1, ssc, balances)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -812,6 +812,9 @@ func TestExtendAllocation(t *testing.T) {
 				return cp.Balance/10 == currency.Coin(newFunds/10) // ignore type cast errors
 			}),
 		).Return("", nil).Once()
+		balances.On(
+			"GetTrieNode", meteredBillingKey(ssc.ID, sa.ID),
+			mock.Anything).Return(util.ErrValueNotPresent).Maybe()
 
 		return ssc, &txn, &sa, blobbers, balances
 	}
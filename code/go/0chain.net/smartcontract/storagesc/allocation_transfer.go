@@ -0,0 +1,367 @@
+package storagesc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/util"
+)
+
+//msgp:ignore allocationTransferRequest
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+// allocationTransferRequest is the input of the offer, accept and cancel
+// allocation ownership transfer functions, NewOwnerID is used by the offer
+// and NewOwnerPublicKey by the accept.
+type allocationTransferRequest struct {
+	AllocationID      string `json:"allocation_id"`
+	NewOwnerID        string `json:"new_owner_id,omitempty"`
+	NewOwnerPublicKey string `json:"new_owner_public_key,omitempty"`
+}
+
+func (atr *allocationTransferRequest) decode(b []byte) error {
+	return json.Unmarshal(b, atr)
+}
+
+func allocationTransferKey(sscKey, allocID string) datastore.Key {
+	return sscKey + ":allocationtransfer:" + allocID
+}
+
+// allocationTransfer is a pending offer of the allocation owner to transfer
+// the allocation to another client, it's removed once accepted, cancelled,
+// rejected or found expired.
+type allocationTransfer struct {
+	AllocationID string           `json:"allocation_id"`
+	From         string           `json:"from"`
+	To           string           `json:"to"`
+	ExpiresAt    common.Timestamp `json:"expires_at"`
+}
+
+func (at *allocationTransfer) Encode() []byte {
+	var b, err = json.Marshal(at)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (at *allocationTransfer) Decode(p []byte) error {
+	return json.Unmarshal(p, at)
+}
+
+func (at *allocationTransfer) save(sscKey string, balances cstate.StateContextI) error {
+	_, err := balances.InsertTrieNode(allocationTransferKey(sscKey, at.AllocationID), at)
+	return err
+}
+
+func (sc *StorageSmartContract) deleteAllocationTransfer(allocID string,
+	balances cstate.StateContextI) error {
+
+	_, err := balances.DeleteTrieNode(allocationTransferKey(sc.ID, allocID))
+	return err
+}
+
+func (sc *StorageSmartContract) getAllocationTransfer(allocID string,
+	balances cstate.CommonStateContextI) (*allocationTransfer, error) {
+
+	at := new(allocationTransfer)
+	if err := balances.GetTrieNode(allocationTransferKey(sc.ID, allocID), at); err != nil {
+		return nil, err
+	}
+	return at, nil
+}
+
+// getTransferableAllocation returns the allocation if it's still active
+func (sc *StorageSmartContract) getTransferableAllocation(allocID string,
+	now common.Timestamp, balances cstate.StateContextI) (*StorageAllocation, error) {
+
+	sa, err := sc.getAllocation(allocID, balances)
+	if err != nil {
+		return nil, err
+	}
+
+	alloc := sa.mustBase()
+	if alloc.Finalized || alloc.Canceled {
+		return nil, errors.New("allocation is finalized")
+	}
+	if alloc.Expiration < now {
+		return nil, errors.New("allocation is expired")
+	}
+	return sa, nil
+}
+
+// offerAllocationTransfer offers the allocation to the given client, the
+// offer replaces a previous one and expires after the configured
+// ownership_transfer_expiry unless the recipient accepts it.
+func (sc *StorageSmartContract) offerAllocationTransfer(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	conf, err := sc.getConfig(balances, true)
+	if err != nil {
+		return "", common.NewErrorf("offer_allocation_transfer_failed",
+			"can't get config: %v", err)
+	}
+	if conf.OwnershipTransferExpiry <= 0 {
+		return "", common.NewError("offer_allocation_transfer_failed",
+			"allocation ownership transfers are disabled")
+	}
+
+	var req allocationTransferRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("offer_allocation_transfer_failed",
+			"invalid request: "+err.Error())
+	}
+	if req.NewOwnerID == "" {
+		return "", common.NewError("offer_allocation_transfer_failed",
+			"missing new owner id")
+	}
+
+	sa, err := sc.getTransferableAllocation(req.AllocationID, t.CreationDate, balances)
+	if err != nil {
+		return "", common.NewError("offer_allocation_transfer_failed", err.Error())
+	}
+
+	alloc := sa.mustBase()
+	if alloc.Owner != t.ClientID {
+		return "", common.NewError("offer_allocation_transfer_failed",
+			"only owner can transfer an allocation")
+	}
+	if req.NewOwnerID == alloc.Owner {
+		return "", common.NewError("offer_allocation_transfer_failed",
+			"allocation is already owned by the client")
+	}
+
+	at := &allocationTransfer{
+		AllocationID: alloc.ID,
+		From:         alloc.Owner,
+		To:           req.NewOwnerID,
+		ExpiresAt:    t.CreationDate + toSeconds(conf.OwnershipTransferExpiry),
+	}
+	if err := at.save(sc.ID, balances); err != nil {
+		return "", common.NewError("offer_allocation_transfer_failed",
+			"saving transfer offer: "+err.Error())
+	}
+
+	return string(at.Encode()), nil
+}
+
+// cancelAllocationTransfer removes the pending transfer offer of the
+// allocation, called by the owner. An expired offer can be removed by anyone.
+func (sc *StorageSmartContract) cancelAllocationTransfer(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req allocationTransferRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("cancel_allocation_transfer_failed",
+			"invalid request: "+err.Error())
+	}
+
+	at, err := sc.getAllocationTransfer(req.AllocationID, balances)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		return "", common.NewError("cancel_allocation_transfer_failed",
+			"no pending transfer of the allocation")
+	default:
+		return "", common.NewError("cancel_allocation_transfer_failed", err.Error())
+	}
+
+	if at.From != t.ClientID && at.ExpiresAt >= t.CreationDate {
+		return "", common.NewError("cancel_allocation_transfer_failed",
+			"only the offering owner can cancel the transfer")
+	}
+
+	if err := sc.deleteAllocationTransfer(at.AllocationID, balances); err != nil {
+		return "", common.NewError("cancel_allocation_transfer_failed",
+			"deleting transfer offer: "+err.Error())
+	}
+
+	return "allocation transfer cancelled", nil
+}
+
+// rejectAllocationTransfer removes the pending transfer offer of the
+// allocation, called by the recipient of the offer.
+func (sc *StorageSmartContract) rejectAllocationTransfer(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req allocationTransferRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("reject_allocation_transfer_failed",
+			"invalid request: "+err.Error())
+	}
+
+	at, err := sc.getAllocationTransfer(req.AllocationID, balances)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		return "", common.NewError("reject_allocation_transfer_failed",
+			"no pending transfer of the allocation")
+	default:
+		return "", common.NewError("reject_allocation_transfer_failed", err.Error())
+	}
+
+	if at.To != t.ClientID {
+		return "", common.NewError("reject_allocation_transfer_failed",
+			"transfer is not offered to the client")
+	}
+
+	if err := sc.deleteAllocationTransfer(at.AllocationID, balances); err != nil {
+		return "", common.NewError("reject_allocation_transfer_failed",
+			"deleting transfer offer: "+err.Error())
+	}
+
+	return "allocation transfer rejected", nil
+}
+
+// acceptAllocationTransfer makes the recipient of the pending offer the owner
+// of the allocation. The write pool belongs to the allocation and moves with
+// it, the read counters of the previous owner are carried over to the new
+// owner so the blobbers keep counting the owner reads from where they are.
+// An expired offer is removed instead, without an error, since a failed
+// transaction wouldn't remove it from the state.
+func (sc *StorageSmartContract) acceptAllocationTransfer(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req allocationTransferRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("accept_allocation_transfer_failed",
+			"invalid request: "+err.Error())
+	}
+
+	at, err := sc.getAllocationTransfer(req.AllocationID, balances)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		return "", common.NewError("accept_allocation_transfer_failed",
+			"no pending transfer of the allocation")
+	default:
+		return "", common.NewError("accept_allocation_transfer_failed", err.Error())
+	}
+
+	if at.To != t.ClientID {
+		return "", common.NewError("accept_allocation_transfer_failed",
+			"transfer is not offered to the client")
+	}
+	if at.ExpiresAt < t.CreationDate {
+		if err := sc.deleteAllocationTransfer(at.AllocationID, balances); err != nil {
+			return "", common.NewError("accept_allocation_transfer_failed",
+				"deleting expired transfer offer: "+err.Error())
+		}
+		return "allocation transfer offer expired", nil
+	}
+
+	pkb, err := hex.DecodeString(req.NewOwnerPublicKey)
+	if err != nil || encryption.Hash(pkb) != t.ClientID {
+		return "", common.NewError("accept_allocation_transfer_failed",
+			"public key doesn't match the client id")
+	}
+
+	sa, err := sc.getTransferableAllocation(at.AllocationID, t.CreationDate, balances)
+	if err != nil {
+		return "", common.NewError("accept_allocation_transfer_failed", err.Error())
+	}
+
+	alloc := sa.mustBase()
+	if alloc.Owner != at.From {
+		return "", common.NewError("accept_allocation_transfer_failed",
+			"allocation owner changed since the offer")
+	}
+
+	for _, ba := range alloc.BlobberAllocs {
+		if err := sc.transferReadMarker(ba.BlobberID, alloc.ID, at.From,
+			t.ClientID, req.NewOwnerPublicKey, balances); err != nil {
+			return "", common.NewError("accept_allocation_transfer_failed",
+				"transferring read marker: "+err.Error())
+		}
+	}
+
+	if err := sa.mustUpdateBase(func(base *storageAllocationBase) error {
+		base.Owner = t.ClientID
+		base.OwnerPublicKey = req.NewOwnerPublicKey
+		base.Tx = t.Hash
+		return nil
+	}); err != nil {
+		return "", common.NewError("accept_allocation_transfer_failed", err.Error())
+	}
+
+	if err := sc.deleteAllocationTransfer(at.AllocationID, balances); err != nil {
+		return "", common.NewError("accept_allocation_transfer_failed",
+			"deleting transfer offer: "+err.Error())
+	}
+
+	if err := sa.saveUpdatedAllocation(nil, balances); err != nil {
+		return "", common.NewError("accept_allocation_transfer_failed",
+			"saving allocation: "+err.Error())
+	}
+
+	balances.EmitEvent(event.TypeStats, event.TagTransferAllocationOwnership, alloc.ID,
+		event.AllocationOwnershipTransfer{
+			AllocationID:   alloc.ID,
+			PreviousOwner:  at.From,
+			Owner:          t.ClientID,
+			OwnerPublicKey: req.NewOwnerPublicKey,
+		})
+
+	return string(sa.Encode()), nil
+}
+
+// transferReadMarker carries the last read marker the blobber redeemed for
+// the previous owner over to the new owner. The previous owner's marker is
+// kept, so it can't be redeemed again, and a newer marker of the new owner
+// is kept over the previous owner's one.
+func (sc *StorageSmartContract) transferReadMarker(blobberID, allocID, from, to, toPublicKey string,
+	balances cstate.StateContextI) error {
+
+	prev := &ReadConnection{ReadMarker: &ReadMarker{
+		BlobberID:    blobberID,
+		ClientID:     from,
+		AllocationID: allocID,
+	}}
+	switch err := balances.GetTrieNode(prev.GetKey(sc.ID), prev); err {
+	case nil:
+	case util.ErrValueNotPresent:
+		return nil
+	default:
+		return err
+	}
+
+	next := &ReadConnection{ReadMarker: &ReadMarker{
+		BlobberID:    blobberID,
+		ClientID:     to,
+		AllocationID: allocID,
+	}}
+	switch err := balances.GetTrieNode(next.GetKey(sc.ID), next); err {
+	case nil:
+		if next.ReadMarker.ReadCounter >= prev.ReadMarker.ReadCounter {
+			return nil
+		}
+	case util.ErrValueNotPresent:
+	default:
+		return err
+	}
+
+	rm := *prev.ReadMarker
+	rm.ClientID = to
+	rm.ClientPublicKey = toPublicKey
+	rm.OwnerID = to
+	rm.Signature = ""
+	next.ReadMarker = &rm
+
+	_, err := balances.InsertTrieNode(next.GetKey(sc.ID), next)
+	return err
+}
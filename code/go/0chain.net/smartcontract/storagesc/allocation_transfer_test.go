@@ -0,0 +1,216 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocationTransfer(t *testing.T) {
+	var (
+		ssc       = newTestStorageSC()
+		balances  = newTestBalances(t, false)
+		owner     = newClient(2000*x10, balances)
+		recipient = newClient(100*x10, balances)
+		other     = newClient(100*x10, balances)
+		tp        = int64(100)
+	)
+
+	allocID, blobs := addAllocation(t, ssc, owner, tp, 0, 0, 0, 0, 0, balances, false, false, false)
+
+	transferRequest := func(newOwnerID, newOwnerPublicKey string) []byte {
+		b, err := json.Marshal(&allocationTransferRequest{
+			AllocationID:      allocID,
+			NewOwnerID:        newOwnerID,
+			NewOwnerPublicKey: newOwnerPublicKey,
+		})
+		require.NoError(t, err)
+		return b
+	}
+	offer := func(from *Client, to string) error {
+		tx := newTransaction(from.id, ADDRESS, 0, tp)
+		balances.setTransaction(t, tx)
+		_, err := ssc.offerAllocationTransfer(tx, transferRequest(to, ""), balances)
+		return err
+	}
+	accept := func(c *Client, now int64) error {
+		tx := newTransaction(c.id, ADDRESS, 0, now)
+		balances.setTransaction(t, tx)
+		_, err := ssc.acceptAllocationTransfer(tx, transferRequest("", c.pk), balances)
+		return err
+	}
+
+	// last read of the owner redeemed by the first blobber
+	rc := &ReadConnection{ReadMarker: &ReadMarker{
+		ClientID:        owner.id,
+		ClientPublicKey: owner.pk,
+		BlobberID:       blobs[0].id,
+		AllocationID:    allocID,
+		OwnerID:         owner.id,
+		ReadCounter:     42,
+	}}
+	mustSave(t, rc.GetKey(ssc.ID), rc, balances)
+
+	require.Error(t, offer(other, recipient.id), "only owner can offer")
+	require.Error(t, offer(owner, owner.id), "offer to the owner")
+	require.NoError(t, offer(owner, recipient.id))
+
+	require.Error(t, accept(other, tp), "accepted by other client")
+
+	sa, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	writePool := sa.mustBase().WritePool
+
+	require.NoError(t, accept(recipient, tp))
+
+	sa, err = ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	alloc := sa.mustBase()
+	require.Equal(t, recipient.id, alloc.Owner)
+	require.Equal(t, recipient.pk, alloc.OwnerPublicKey)
+	require.Equal(t, writePool, alloc.WritePool)
+
+	_, err = ssc.getAllocationTransfer(allocID, balances)
+	require.Equal(t, util.ErrValueNotPresent, err)
+	require.Error(t, accept(recipient, tp), "offer accepted twice")
+
+	// the owner read counter is carried over to the new owner
+	next := &ReadConnection{ReadMarker: &ReadMarker{
+		ClientID:     recipient.id,
+		BlobberID:    blobs[0].id,
+		AllocationID: allocID,
+	}}
+	require.NoError(t, balances.GetTrieNode(next.GetKey(ssc.ID), next))
+	require.EqualValues(t, 42, next.ReadMarker.ReadCounter)
+	require.Equal(t, recipient.pk, next.ReadMarker.ClientPublicKey)
+	require.Equal(t, recipient.id, next.ReadMarker.OwnerID)
+
+	var transfers int
+	for _, e := range balances.GetEvents() {
+		if e.Tag == event.TagTransferAllocationOwnership {
+			transfers++
+		}
+	}
+	require.Equal(t, 1, transfers)
+
+	// the previous owner can't offer the allocation anymore, the new owner
+	// can offer and cancel
+	require.Error(t, offer(owner, other.id))
+	require.NoError(t, offer(recipient, other.id))
+
+	tx := newTransaction(recipient.id, ADDRESS, 0, tp)
+	_, err = ssc.cancelAllocationTransfer(tx, transferRequest("", ""), balances)
+	require.NoError(t, err)
+	require.Error(t, accept(other, tp), "cancelled offer")
+}
+
+type transferCall func(*transaction.Transaction, []byte, cstate.StateContextI) (string, error)
+
+func TestAllocationTransferRemoval(t *testing.T) {
+	const tp = int64(100)
+	expired := tp + int64(toSeconds(2*time.Hour))
+
+	tests := []struct {
+		name    string
+		call    func(ssc *StorageSmartContract) transferCall
+		client  func(owner, recipient, other *Client) *Client
+		now     int64
+		wantErr string
+		removed bool
+	}{
+		{
+			name:    "reject by recipient",
+			call:    func(ssc *StorageSmartContract) transferCall { return ssc.rejectAllocationTransfer },
+			client:  func(_, recipient, _ *Client) *Client { return recipient },
+			now:     tp,
+			removed: true,
+		},
+		{
+			name:    "reject by other client",
+			call:    func(ssc *StorageSmartContract) transferCall { return ssc.rejectAllocationTransfer },
+			client:  func(_, _, other *Client) *Client { return other },
+			now:     tp,
+			wantErr: "transfer is not offered to the client",
+		},
+		{
+			name:    "reject by owner",
+			call:    func(ssc *StorageSmartContract) transferCall { return ssc.rejectAllocationTransfer },
+			client:  func(owner, _, _ *Client) *Client { return owner },
+			now:     tp,
+			wantErr: "transfer is not offered to the client",
+		},
+		{
+			name:    "accept expired offer",
+			call:    func(ssc *StorageSmartContract) transferCall { return ssc.acceptAllocationTransfer },
+			client:  func(_, recipient, _ *Client) *Client { return recipient },
+			now:     expired,
+			removed: true,
+		},
+		{
+			name:    "cancel by other client",
+			call:    func(ssc *StorageSmartContract) transferCall { return ssc.cancelAllocationTransfer },
+			client:  func(_, _, other *Client) *Client { return other },
+			now:     tp,
+			wantErr: "only the offering owner can cancel the transfer",
+		},
+		{
+			name:    "cancel expired offer by other client",
+			call:    func(ssc *StorageSmartContract) transferCall { return ssc.cancelAllocationTransfer },
+			client:  func(_, _, other *Client) *Client { return other },
+			now:     expired,
+			removed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ssc       = newTestStorageSC()
+				balances  = newTestBalances(t, false)
+				owner     = newClient(2000*x10, balances)
+				recipient = newClient(100*x10, balances)
+				other     = newClient(100*x10, balances)
+			)
+
+			allocID, _ := addAllocation(t, ssc, owner, tp, 0, 0, 0, 0, 0, balances, false, false, false)
+
+			tx := newTransaction(owner.id, ADDRESS, 0, tp)
+			balances.setTransaction(t, tx)
+			_, err := ssc.offerAllocationTransfer(tx, mustEncode(t, &allocationTransferRequest{
+				AllocationID: allocID,
+				NewOwnerID:   recipient.id,
+			}), balances)
+			require.NoError(t, err)
+
+			c := tt.client(owner, recipient, other)
+			tx = newTransaction(c.id, ADDRESS, 0, tt.now)
+			balances.setTransaction(t, tx)
+			_, err = tt.call(ssc)(tx, mustEncode(t, &allocationTransferRequest{
+				AllocationID:      allocID,
+				NewOwnerPublicKey: c.pk,
+			}), balances)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			_, err = ssc.getAllocationTransfer(allocID, balances)
+			if tt.removed {
+				require.Equal(t, util.ErrValueNotPresent, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			sa, err := ssc.getAllocation(allocID, balances)
+			require.NoError(t, err)
+			require.Equal(t, owner.id, sa.mustBase().Owner)
+		})
+	}
+}
@@ -0,0 +1,631 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/partitions"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/logging"
+	"github.com/0chain/common/core/util"
+	"go.uber.org/zap"
+)
+
+// maxInlineRepairs limits the number of allocations of a killed or shut
+// down blobber repaired within the same transaction, the other allocations
+// with auto-repair policy are logged and left to repair_allocation.
+const maxInlineRepairs = 50
+
+const (
+	repairReasonKilled           = "killed"
+	repairReasonShutdown         = "shutdown"
+	repairReasonFailedChallenges = "failed_challenges"
+)
+
+//msgp:ignore autoRepairPolicyRequest repairAllocationRequest
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+// autoRepairPolicyRequest is the input of set_auto_repair_policy. A zero
+// max_failed_challenges only replaces killed and shut down blobbers.
+type autoRepairPolicyRequest struct {
+	AllocationID        string `json:"allocation_id"`
+	Enabled             bool   `json:"enabled"`
+	MaxFailedChallenges int64  `json:"max_failed_challenges"`
+}
+
+func (arr *autoRepairPolicyRequest) decode(b []byte) error {
+	return json.Unmarshal(b, arr)
+}
+
+// repairAllocationRequest is the input of repair_allocation
+type repairAllocationRequest struct {
+	AllocationID string `json:"allocation_id"`
+}
+
+func (rar *repairAllocationRequest) decode(b []byte) error {
+	return json.Unmarshal(b, rar)
+}
+
+func autoRepairPolicyKey(sscKey, allocID string) datastore.Key {
+	return sscKey + ":autorepair:" + allocID
+}
+
+// autoRepairBlobber counts the challenges of an allocation blobber failed or
+// expired since its last passed challenge.
+type autoRepairBlobber struct {
+	BlobberID           string `json:"blobber_id"`
+	ConsecutiveFailures int64  `json:"consecutive_failures"`
+}
+
+// autoRepairPolicy opts an allocation in the replacement of its blobbers
+// when they are killed, shut down or fail MaxFailedChallenges consecutive
+// challenges.
+type autoRepairPolicy struct {
+	AllocationID        string               `json:"allocation_id"`
+	MaxFailedChallenges int64                `json:"max_failed_challenges"`
+	Blobbers            []*autoRepairBlobber `json:"blobbers"`
+}
+
+func (arp *autoRepairPolicy) Encode() []byte {
+	var b, err = json.Marshal(arp)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (arp *autoRepairPolicy) Decode(p []byte) error {
+	return json.Unmarshal(p, arp)
+}
+
+func (arp *autoRepairPolicy) save(sscKey string, balances cstate.StateContextI) error {
+	_, err := balances.InsertTrieNode(autoRepairPolicyKey(sscKey, arp.AllocationID), arp)
+	return err
+}
+
+func (arp *autoRepairPolicy) find(blobberID string) (int, bool) {
+	for i, b := range arp.Blobbers {
+		if b.BlobberID == blobberID {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// consecutiveFailures of the allocation blobber since its last passed
+// challenge
+func (arp *autoRepairPolicy) consecutiveFailures(blobberID string) int64 {
+	if i, ok := arp.find(blobberID); ok {
+		return arp.Blobbers[i].ConsecutiveFailures
+	}
+	return 0
+}
+
+// fail counts a failed or expired challenge of the allocation blobber and
+// returns whether the blobber must be replaced
+func (arp *autoRepairPolicy) fail(blobberID string) bool {
+	i, ok := arp.find(blobberID)
+	if !ok {
+		arp.Blobbers = append(arp.Blobbers, &autoRepairBlobber{BlobberID: blobberID})
+		i = len(arp.Blobbers) - 1
+	}
+	arp.Blobbers[i].ConsecutiveFailures++
+	return arp.MaxFailedChallenges > 0 &&
+		arp.Blobbers[i].ConsecutiveFailures >= arp.MaxFailedChallenges
+}
+
+// reset the consecutive failures of the allocation blobber, returns false if
+// there were none
+func (arp *autoRepairPolicy) reset(blobberID string) bool {
+	i, ok := arp.find(blobberID)
+	if !ok || arp.Blobbers[i].ConsecutiveFailures == 0 {
+		return false
+	}
+	arp.Blobbers[i].ConsecutiveFailures = 0
+	return true
+}
+
+// track the blobbers of the allocation, dropping the removed ones
+func (arp *autoRepairPolicy) track(alloc *storageAllocationBase) {
+	blobbers := make([]*autoRepairBlobber, 0, len(alloc.BlobberAllocs))
+	for _, ba := range alloc.BlobberAllocs {
+		b := &autoRepairBlobber{BlobberID: ba.BlobberID}
+		if i, ok := arp.find(ba.BlobberID); ok {
+			b.ConsecutiveFailures = arp.Blobbers[i].ConsecutiveFailures
+		}
+		blobbers = append(blobbers, b)
+	}
+	arp.Blobbers = blobbers
+}
+
+// repairReason returns why the blobber of the allocation must be replaced,
+// empty if it's healthy
+func (arp *autoRepairPolicy) repairReason(blobber *StorageNode) string {
+	switch {
+	case blobber.IsKilled():
+		return repairReasonKilled
+	case blobber.IsShutDown():
+		return repairReasonShutdown
+	case arp.MaxFailedChallenges > 0 && arp.consecutiveFailures(blobber.Id()) >= arp.MaxFailedChallenges:
+		return repairReasonFailedChallenges
+	default:
+		return ""
+	}
+}
+
+func (sc *StorageSmartContract) getAutoRepairPolicy(allocID string,
+	balances cstate.CommonStateContextI) (*autoRepairPolicy, error) {
+
+	arp := new(autoRepairPolicy)
+	if err := balances.GetTrieNode(autoRepairPolicyKey(sc.ID, allocID), arp); err != nil {
+		return nil, err
+	}
+	return arp, nil
+}
+
+func isEnterpriseAllocation(sa *StorageAllocation) bool {
+	if v2, ok := sa.Entity().(*storageAllocationV2); ok {
+		return v2.IsEnterprise != nil && *v2.IsEnterprise
+	}
+	return false
+}
+
+// needsAuthTicket reports whether the blobber can only be added to an
+// allocation with an auth ticket of its owner
+func needsAuthTicket(blobber *StorageNode) bool {
+	switch b := blobber.Entity().(type) {
+	case *storageNodeV2:
+		return b.IsRestricted != nil && *b.IsRestricted
+	case *storageNodeV3:
+		return (b.IsRestricted != nil && *b.IsRestricted) ||
+			(b.IsEnterprise != nil && *b.IsEnterprise)
+	default:
+		return false
+	}
+}
+
+// setAutoRepairPolicy enables or disables the automatic replacement of the
+// allocation blobbers. The consecutive failed challenges are counted from
+// the time the policy is enabled.
+func (sc *StorageSmartContract) setAutoRepairPolicy(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req autoRepairPolicyRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("set_auto_repair_policy_failed",
+			"invalid request: "+err.Error())
+	}
+	if req.MaxFailedChallenges < 0 {
+		return "", common.NewError("set_auto_repair_policy_failed",
+			"negative max failed challenges")
+	}
+
+	sa, err := sc.getTransferableAllocation(req.AllocationID, t.CreationDate, balances)
+	if err != nil {
+		return "", common.NewError("set_auto_repair_policy_failed", err.Error())
+	}
+	alloc := sa.mustBase()
+	if alloc.Owner != t.ClientID {
+		return "", common.NewError("set_auto_repair_policy_failed",
+			"only owner can set the allocation auto-repair policy")
+	}
+
+	if !req.Enabled {
+		_, err := balances.DeleteTrieNode(autoRepairPolicyKey(sc.ID, alloc.ID))
+		if err != nil && err != util.ErrValueNotPresent {
+			return "", common.NewError("set_auto_repair_policy_failed",
+				"deleting auto-repair policy: "+err.Error())
+		}
+		return "auto-repair disabled", nil
+	}
+
+	if isEnterpriseAllocation(sa) {
+		return "", common.NewError("set_auto_repair_policy_failed",
+			"enterprise allocations can't be repaired automatically")
+	}
+
+	arp, err := sc.getAutoRepairPolicy(alloc.ID, balances)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		arp = &autoRepairPolicy{AllocationID: alloc.ID}
+	default:
+		return "", common.NewError("set_auto_repair_policy_failed", err.Error())
+	}
+
+	arp.MaxFailedChallenges = req.MaxFailedChallenges
+	arp.track(alloc)
+
+	if err := arp.save(sc.ID, balances); err != nil {
+		return "", common.NewError("set_auto_repair_policy_failed",
+			"saving auto-repair policy: "+err.Error())
+	}
+	return string(arp.Encode()), nil
+}
+
+// resetAutoRepairFailures resets the consecutive failed challenges of a
+// blobber that passed a challenge of an allocation with auto-repair policy
+func (sc *StorageSmartContract) resetAutoRepairFailures(allocID, blobberID string,
+	balances cstate.StateContextI) error {
+
+	arp, err := sc.getAutoRepairPolicy(allocID, balances)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		return nil
+	default:
+		return fmt.Errorf("can't get auto-repair policy: %v", err)
+	}
+
+	if !arp.reset(blobberID) {
+		return nil
+	}
+	return arp.save(sc.ID, balances)
+}
+
+// countAutoRepairFailure counts a failed or expired challenge of a blobber of
+// an allocation with auto-repair policy, it returns true once the blobber
+// failed the max failed challenges of the policy in a row.
+func (sc *StorageSmartContract) countAutoRepairFailure(allocID, blobberID string,
+	balances cstate.StateContextI) (bool, error) {
+
+	arp, err := sc.getAutoRepairPolicy(allocID, balances)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		return false, nil
+	default:
+		return false, fmt.Errorf("can't get auto-repair policy: %v", err)
+	}
+
+	due := arp.fail(blobberID)
+	if err := arp.save(sc.ID, balances); err != nil {
+		return false, fmt.Errorf("saving auto-repair policy: %v", err)
+	}
+	return due, nil
+}
+
+// tryAutoRepair repairs the allocation if it has an auto-repair policy and
+// returns whether any blobber was replaced. An allocation that can't be
+// repaired is logged and skipped, it's left to repair_allocation, only the
+// errors of a state missing nodes are returned.
+func (sc *StorageSmartContract) tryAutoRepair(
+	t *transaction.Transaction,
+	allocID string,
+	balances cstate.StateContextI,
+) (bool, error) {
+	arp, err := sc.getAutoRepairPolicy(allocID, balances)
+	switch {
+	case err == nil:
+	case err == util.ErrValueNotPresent:
+		return false, nil
+	case cstate.ErrInvalidState(err):
+		return false, err
+	default:
+		logging.Logger.Error("auto-repair: can't get policy",
+			zap.String("allocation", allocID), zap.Error(err))
+		return false, nil
+	}
+
+	sa, err := sc.getTransferableAllocation(allocID, t.CreationDate, balances)
+	if err != nil {
+		if cstate.ErrInvalidState(err) {
+			return false, err
+		}
+		return false, nil
+	}
+
+	repairs, err := sc.autoRepair(t, sa, arp, balances)
+	if err != nil {
+		if cstate.ErrInvalidState(err) {
+			return false, err
+		}
+		logging.Logger.Error("auto-repair: can't repair allocation",
+			zap.String("allocation", allocID), zap.Error(err))
+		return false, nil
+	}
+	if len(repairs) == 0 {
+		logging.Logger.Info("auto-repair: no blobber replaced",
+			zap.String("allocation", allocID))
+		return false, nil
+	}
+	return true, nil
+}
+
+// repairAllocation replaces the unhealthy blobbers of an allocation with
+// auto-repair policy, any client can send it.
+func (sc *StorageSmartContract) repairAllocation(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req repairAllocationRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("repair_allocation_failed",
+			"invalid request: "+err.Error())
+	}
+
+	arp, err := sc.getAutoRepairPolicy(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("repair_allocation_failed",
+			"can't get auto-repair policy: "+err.Error())
+	}
+
+	sa, err := sc.getTransferableAllocation(req.AllocationID, t.CreationDate, balances)
+	if err != nil {
+		return "", common.NewError("repair_allocation_failed", err.Error())
+	}
+
+	repairs, err := sc.autoRepair(t, sa, arp, balances)
+	if err != nil {
+		return "", common.NewError("repair_allocation_failed", err.Error())
+	}
+	if len(repairs) == 0 {
+		return "", common.NewError("repair_allocation_failed",
+			"no blobber of the allocation can be replaced")
+	}
+
+	b, err := json.Marshal(repairs)
+	if err != nil {
+		return "", common.NewError("repair_allocation_failed", err.Error())
+	}
+	return string(b), nil
+}
+
+// repairBlobberAllocations replaces the killed or shut down blobber in its
+// allocations with auto-repair policy. All the allocations of the blobber
+// are looked up but at most maxInlineRepairs of them are repaired, the
+// others and the allocations that can't be repaired are logged and left to
+// repair_allocation.
+func (sc *StorageSmartContract) repairBlobberAllocations(
+	t *transaction.Transaction,
+	blobberID string,
+	balances cstate.StateContextI,
+) error {
+	parts, err := partitions.GetPartitions(balances, getBlobberAllocationsKey(blobberID))
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		return nil
+	default:
+		return fmt.Errorf("can't get blobber allocations: %v", err)
+	}
+
+	var allocIDs []string
+	if err := parts.ForEach(balances, func(_ int, id string, _ []byte) bool {
+		allocIDs = append(allocIDs, id)
+		return false
+	}); err != nil {
+		return fmt.Errorf("can't iterate blobber allocations: %v", err)
+	}
+
+	var repaired int
+	for _, allocID := range allocIDs {
+		if repaired >= maxInlineRepairs {
+			_, err := sc.getAutoRepairPolicy(allocID, balances)
+			switch {
+			case err == nil:
+				logging.Logger.Info("auto-repair: allocation left to repair_allocation",
+					zap.String("allocation", allocID),
+					zap.String("blobber", blobberID))
+			case cstate.ErrInvalidState(err):
+				return err
+			}
+			continue
+		}
+
+		ok, err := sc.tryAutoRepair(t, allocID, balances)
+		if err != nil {
+			return fmt.Errorf("repairing allocation %s: %v", allocID, err)
+		}
+		if ok {
+			repaired++
+		}
+	}
+	return nil
+}
+
+// autoRepair replaces the unhealthy blobbers of the allocation for which a
+// replacement is found. The replaced blobbers are removed the same way as
+// with update_allocation_request, the write pool funds moved to the
+// challenge pool for a killed or shut down blobber are moved back.
+func (sc *StorageSmartContract) autoRepair(
+	t *transaction.Transaction,
+	sa *StorageAllocation,
+	arp *autoRepairPolicy,
+	balances cstate.StateContextI,
+) ([]event.AllocationRepair, error) {
+	if isEnterpriseAllocation(sa) {
+		return nil, errors.New("enterprise allocations can't be repaired automatically")
+	}
+
+	alloc := sa.mustBase()
+	frozen, err := sc.isAllocationFrozen(alloc.ID, balances)
+	if err != nil {
+		return nil, fmt.Errorf("can't check allocation freeze: %v", err)
+	}
+	if frozen {
+		return nil, errors.New("frozen allocation can't be repaired")
+	}
+
+	conf, err := getConfig(balances)
+	if err != nil {
+		return nil, fmt.Errorf("can't get config: %v", err)
+	}
+
+	pc, err := sc.getPlacementConstraints(alloc.ID, balances)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		pc = nil
+	default:
+		return nil, fmt.Errorf("can't get placement constraints: %v", err)
+	}
+
+	blobbers, err := sc.getAllocationBlobbers(alloc, balances)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		repairs []event.AllocationRepair
+		r       = rand.New(rand.NewSource(balances.GetBlock().GetRoundRandomSeed()))
+	)
+	for i := range alloc.BlobberAllocs {
+		var (
+			ba     = alloc.BlobberAllocs[i]
+			reason = arp.repairReason(blobbers[i])
+		)
+		if reason == "" {
+			continue
+		}
+
+		addID, err := sc.selectReplacementBlobber(alloc, ba.BlobberID, pc, conf, t.CreationDate, r, balances)
+		if err != nil {
+			return nil, err
+		}
+		if addID == "" {
+			continue
+		}
+
+		removeID := ba.BlobberID
+		if blobbers, err = alloc.changeBlobbers(
+			conf, blobbers, addID, "", removeID, t.CreationDate, balances, sc, t, false,
+		); err != nil {
+			return nil, fmt.Errorf("replacing blobber %s with %s: %v", removeID, addID, err)
+		}
+
+		repairs = append(repairs, event.AllocationRepair{
+			AllocationID:     alloc.ID,
+			RemovedBlobberID: removeID,
+			AddedBlobberID:   addID,
+			Reason:           reason,
+			TransactionHash:  t.Hash,
+			Round:            balances.GetBlock().Round,
+		})
+	}
+
+	if len(repairs) == 0 {
+		return nil, nil
+	}
+
+	if err := sa.mustUpdateBase(func(base *storageAllocationBase) error {
+		alloc.deepCopy(base)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("updating allocation: %v", err)
+	}
+	if err := sa.saveUpdatedAllocation(blobbers, balances); err != nil {
+		return nil, fmt.Errorf("saving allocation: %v", err)
+	}
+
+	arp.track(alloc)
+	if err := arp.save(sc.ID, balances); err != nil {
+		return nil, fmt.Errorf("saving auto-repair policy: %v", err)
+	}
+
+	removed := make([]event.AllocationBlobberTerm, 0, len(repairs))
+	for _, rp := range repairs {
+		removed = append(removed, event.AllocationBlobberTerm{
+			AllocationIdHash: alloc.ID,
+			BlobberID:        rp.RemovedBlobberID,
+		})
+	}
+	balances.EmitEvent(event.TypeStats, event.TagDeleteAllocationBlobberTerm, t.Hash, removed)
+	emitAddOrOverwriteAllocationBlobberTerms(alloc, balances, t)
+	balances.EmitEvent(event.TypeStats, event.TagAddAllocationRepair, alloc.ID, repairs)
+
+	return repairs, nil
+}
+
+// selectReplacementBlobber picks a random challenge ready blobber that can
+// replace the blobber of the allocation: active, matching the allocation
+// price ranges and capacity, not requiring an auth ticket and keeping the
+// placement constraints met. Returns an empty ID if none is found.
+func (sc *StorageSmartContract) selectReplacementBlobber(
+	alloc *storageAllocationBase,
+	removeID string,
+	pc *placementConstraints,
+	conf *Config,
+	now common.Timestamp,
+	r *rand.Rand,
+	balances cstate.StateContextI,
+) (string, error) {
+	parts, err := partitions.GetPartitions(balances, ALL_CHALLENGE_READY_BLOBBERS_KEY)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		return "", nil
+	default:
+		return "", fmt.Errorf("can't get challenge ready blobbers: %v", err)
+	}
+
+	size, err := parts.Size(balances)
+	if err != nil {
+		return "", err
+	}
+	if size == 0 {
+		return "", nil
+	}
+
+	var candidates []ChallengeReadyBlobber
+	if err := parts.GetRandomItems(balances, r, &candidates); err != nil {
+		return "", fmt.Errorf("can't get challenge ready blobbers: %v", err)
+	}
+
+	for _, c := range candidates {
+		if _, ok := alloc.BlobberAllocsMap[c.BlobberID]; ok {
+			continue
+		}
+
+		blobber, err := sc.getBlobber(c.BlobberID, balances)
+		if err != nil {
+			continue
+		}
+		if needsAuthTicket(blobber) {
+			continue
+		}
+
+		sp, err := getStakePool(spenum.Blobber, c.BlobberID, balances)
+		if err != nil {
+			continue
+		}
+		staked, err := sp.stake()
+		if err != nil {
+			continue
+		}
+		stakedCapacity, err := sp.stakedCapacity(blobber.mustBase().Terms.WritePrice)
+		if err != nil {
+			continue
+		}
+		if err := alloc.isActive(blobber, staked, sp.TotalOffers, stakedCapacity, conf, now); err != nil {
+			continue
+		}
+
+		if pc != nil {
+			ids := make([]string, 0, len(alloc.BlobberAllocs))
+			for _, ba := range alloc.BlobberAllocs {
+				if ba.BlobberID == removeID {
+					ids = append(ids, c.BlobberID)
+				} else {
+					ids = append(ids, ba.BlobberID)
+				}
+			}
+			if pc.check(ids, balances) != nil {
+				continue
+			}
+		}
+
+		return c.BlobberID, nil
+	}
+	return "", nil
+}
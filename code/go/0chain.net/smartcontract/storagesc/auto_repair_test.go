@@ -0,0 +1,221 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"0chain.net/smartcontract/provider"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/stretchr/testify/require"
+)
+
+type autoRepairTestEnv struct {
+	t        *testing.T
+	ssc      *StorageSmartContract
+	balances *testBalances
+	owner    *Client
+	other    *Client
+	allocID  string
+	spares   []string
+	tp       int64
+}
+
+func newAutoRepairTestEnv(t *testing.T) *autoRepairTestEnv {
+	env := &autoRepairTestEnv{
+		t:        t,
+		ssc:      newTestStorageSC(),
+		balances: newTestBalances(t, false),
+		tp:       100,
+	}
+	env.owner = newClient(2000*x10, env.balances)
+	env.other = newClient(100*x10, env.balances)
+
+	setConfig(t, env.balances)
+	var blobs []*Client
+	env.allocID, blobs = addAllocation(t, env.ssc, env.owner, env.tp, 0, 0, 0, 0, 0,
+		env.balances, false, false, false)
+
+	alloc := env.alloc()
+	for _, b := range blobs {
+		if _, ok := alloc.BlobberAllocsMap[b.id]; !ok {
+			env.spares = append(env.spares, b.id)
+			require.NoError(t, PartitionsChallengeReadyBlobberAddOrUpdate(env.balances, b.id, 10*x10, 1))
+		}
+	}
+	require.NotEmpty(t, env.spares)
+	return env
+}
+
+func (env *autoRepairTestEnv) alloc() *storageAllocationBase {
+	sa, err := env.ssc.getAllocation(env.allocID, env.balances)
+	require.NoError(env.t, err)
+	return sa.mustBase()
+}
+
+func (env *autoRepairTestEnv) setPolicy(c *Client, req autoRepairPolicyRequest) error {
+	req.AllocationID = env.allocID
+	input, err := json.Marshal(&req)
+	require.NoError(env.t, err)
+	tx := newTransaction(c.id, ADDRESS, 0, env.tp)
+	env.balances.setTransaction(env.t, tx)
+	_, err = env.ssc.setAutoRepairPolicy(tx, input, env.balances)
+	return err
+}
+
+func (env *autoRepairTestEnv) repair() error {
+	env.tp += 100
+	input, err := json.Marshal(&repairAllocationRequest{AllocationID: env.allocID})
+	require.NoError(env.t, err)
+	tx := newTransaction(env.other.id, ADDRESS, 0, env.tp)
+	env.balances.setTransaction(env.t, tx)
+	_, err = env.ssc.repairAllocation(tx, input, env.balances)
+	return err
+}
+
+func (env *autoRepairTestEnv) shutdown(blobberID string) error {
+	require.NoError(env.t, partitionsBlobberAllocationsAdd(env.balances, blobberID, env.allocID))
+	sp, err := env.ssc.getStakePool(spenum.Blobber, blobberID, env.balances)
+	require.NoError(env.t, err)
+	env.tp += 100
+	tx := newTransaction(sp.Settings.DelegateWallet, ADDRESS, 0, env.tp)
+	env.balances.setTransaction(env.t, tx)
+	_, err = env.ssc.shutdownBlobber(tx, mustEncode(env.t, &provider.ProviderRequest{ID: blobberID}), env.balances)
+	return err
+}
+
+// fail counts a failed challenge of the blobber and runs the auto-repair if
+// it's due, the same way a failed or expired challenge does
+func (env *autoRepairTestEnv) fail(blobberID string) {
+	env.tp += 100
+	tx := newTransaction(env.other.id, ADDRESS, 0, env.tp)
+	env.balances.setTransaction(env.t, tx)
+	due, err := env.ssc.countAutoRepairFailure(env.allocID, blobberID, env.balances)
+	require.NoError(env.t, err)
+	if due {
+		_, err = env.ssc.tryAutoRepair(tx, env.allocID, env.balances)
+		require.NoError(env.t, err)
+	}
+}
+
+func TestAutoRepair(t *testing.T) {
+	enabled := autoRepairPolicyRequest{Enabled: true, MaxFailedChallenges: 2}
+
+	tests := []struct {
+		name string
+		run  func(env *autoRepairTestEnv)
+	}{
+		{
+			name: "no policy",
+			run: func(env *autoRepairTestEnv) {
+				require.ErrorContains(env.t, env.repair(), "auto-repair policy")
+			},
+		},
+		{
+			name: "policy set by other client",
+			run: func(env *autoRepairTestEnv) {
+				require.ErrorContains(env.t, env.setPolicy(env.other, enabled),
+					"only owner can set the allocation auto-repair policy")
+			},
+		},
+		{
+			name: "negative max failed challenges",
+			run: func(env *autoRepairTestEnv) {
+				require.ErrorContains(env.t, env.setPolicy(env.owner, autoRepairPolicyRequest{
+					Enabled:             true,
+					MaxFailedChallenges: -1,
+				}), "negative max failed challenges")
+			},
+		},
+		{
+			name: "healthy blobbers",
+			run: func(env *autoRepairTestEnv) {
+				require.NoError(env.t, env.setPolicy(env.owner, enabled))
+				require.ErrorContains(env.t, env.repair(), "no blobber")
+			},
+		},
+		{
+			name: "policy disabled",
+			run: func(env *autoRepairTestEnv) {
+				require.NoError(env.t, env.setPolicy(env.owner, enabled))
+				require.NoError(env.t, env.setPolicy(env.owner, autoRepairPolicyRequest{Enabled: false}))
+				require.ErrorContains(env.t, env.repair(), "auto-repair policy")
+			},
+		},
+		{
+			name: "shut down blobber replaced",
+			run: func(env *autoRepairTestEnv) {
+				require.NoError(env.t, env.setPolicy(env.owner, enabled))
+				shutdownID := env.alloc().BlobberAllocs[0].BlobberID
+				require.NoError(env.t, env.shutdown(shutdownID))
+
+				alloc := env.alloc()
+				require.NotContains(env.t, alloc.BlobberAllocsMap, shutdownID)
+				added := alloc.BlobberAllocs[0].BlobberID
+				require.Contains(env.t, env.spares, added)
+
+				arp, err := env.ssc.getAutoRepairPolicy(env.allocID, env.balances)
+				require.NoError(env.t, err)
+				_, ok := arp.find(shutdownID)
+				require.False(env.t, ok, "removed blobber is not tracked")
+				_, ok = arp.find(added)
+				require.True(env.t, ok, "added blobber is tracked")
+			},
+		},
+		{
+			name: "shut down blobber of an allocation that can't be repaired",
+			run: func(env *autoRepairTestEnv) {
+				require.NoError(env.t, env.setPolicy(env.owner, enabled))
+				_, err := env.balances.InsertTrieNode(allocationSnapshotKey(env.ssc.ID, env.allocID),
+					newAllocationSnapshot(env.alloc()))
+				require.NoError(env.t, err)
+
+				shutdownID := env.alloc().BlobberAllocs[0].BlobberID
+				require.NoError(env.t, env.shutdown(shutdownID), "the repair failure is skipped")
+				require.Contains(env.t, env.alloc().BlobberAllocsMap, shutdownID)
+			},
+		},
+		{
+			name: "consecutive failed challenges",
+			run: func(env *autoRepairTestEnv) {
+				require.NoError(env.t, env.setPolicy(env.owner, enabled))
+				failingID := env.alloc().BlobberAllocs[1].BlobberID
+				env.fail(failingID)
+				require.Contains(env.t, env.alloc().BlobberAllocsMap, failingID)
+				env.fail(failingID)
+
+				alloc := env.alloc()
+				require.NotContains(env.t, alloc.BlobberAllocsMap, failingID)
+				require.Len(env.t, alloc.BlobberAllocs, alloc.DataShards+alloc.ParityShards)
+			},
+		},
+		{
+			name: "passed challenge between failures",
+			run: func(env *autoRepairTestEnv) {
+				require.NoError(env.t, env.setPolicy(env.owner, enabled))
+				passingID := env.alloc().BlobberAllocs[2].BlobberID
+				env.fail(passingID)
+				require.NoError(env.t, env.ssc.resetAutoRepairFailures(env.allocID, passingID, env.balances))
+				env.fail(passingID)
+
+				require.Contains(env.t, env.alloc().BlobberAllocsMap, passingID)
+				require.ErrorContains(env.t, env.repair(), "no blobber")
+			},
+		},
+		{
+			name: "failures before the policy are not counted",
+			run: func(env *autoRepairTestEnv) {
+				blobberID := env.alloc().BlobberAllocs[1].BlobberID
+				env.fail(blobberID)
+				require.NoError(env.t, env.setPolicy(env.owner, enabled))
+				env.fail(blobberID)
+				require.Contains(env.t, env.alloc().BlobberAllocsMap, blobberID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(newAutoRepairTestEnv(t))
+		})
+	}
+}
@@ -126,6 +126,11 @@ func newTestBalances(t testing.TB, mpts bool) (tb *testBalances) {
 		t.Fatal(err)
 	}
 
+	h = cstate.NewHardFork("hermes", 0)
+	if _, err := tb.InsertTrieNode(h.GetKey(), h); err != nil {
+		t.Fatal(err)
+	}
+
 	bk := &block.Block{}
 	bk.Round = 2
 	tb.setBlock(t, bk)
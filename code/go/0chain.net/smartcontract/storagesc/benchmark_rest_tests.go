@@ -110,6 +110,112 @@ func BenchmarkRestTests(
 				},
 				Endpoint: srh.getAllocation,
 			},
+			{
+				FuncName: "allocation-snapshots",
+				Params: map[string]string{
+					"allocation_id": getMockAllocationId(0),
+				},
+				Endpoint: srh.getAllocationSnapshots,
+			},
+			{
+				FuncName: "allocation-repairs",
+				Params: map[string]string{
+					"allocation_id": getMockAllocationId(0),
+				},
+				Endpoint: srh.getAllocationRepairs,
+			},
+			{
+				FuncName: "auto-repair-policy",
+				Params: map[string]string{
+					"allocation_id": getMockAllocationId(0),
+				},
+				Endpoint: srh.getAutoRepairPolicy,
+			},
+			{
+				FuncName: "shard-migration",
+				Params: map[string]string{
+					"allocation_id": getMockAllocationId(0),
+				},
+				Endpoint: srh.getShardMigration,
+			},
+			{
+				FuncName: "read-sub-pools",
+				Params: map[string]string{
+					"allocation_id": getMockAllocationId(0),
+				},
+				Endpoint: srh.getReadSubPools,
+			},
+			{
+				FuncName: "read-sub-pool-consumption",
+				Params: map[string]string{
+					"allocation_id": getMockAllocationId(0),
+				},
+				Endpoint: srh.getReadSubPoolConsumption,
+			},
+			{
+				FuncName: "blobber-price-offers",
+				Params: map[string]string{
+					"blobber_id": getMockBlobberId(0),
+				},
+				Endpoint: srh.getBlobberPriceOffers,
+			},
+			{
+				FuncName: "challenge-disputes",
+				Params: map[string]string{
+					"allocation_id": getMockAllocationId(0),
+				},
+				Endpoint: srh.getChallengeDisputes,
+			},
+			{
+				FuncName: "blobber-challenge-disputes",
+				Params: map[string]string{
+					"blobber_id": getMockBlobberId(0),
+				},
+				Endpoint: srh.getBlobberChallengeDisputes,
+			},
+			{
+				FuncName: "provider-slashes",
+				Params: map[string]string{
+					"provider_id": getMockBlobberId(0),
+				},
+				Endpoint: srh.getProviderSlashes,
+			},
+			{
+				FuncName: "unbonding-entries",
+				Params: map[string]string{
+					"provider_id":   getMockBlobberId(0),
+					"provider_type": strconv.Itoa(int(spenum.Blobber)),
+				},
+				Endpoint: srh.getUnbondingEntries,
+			},
+			{
+				FuncName: "replay-challenge-selection",
+				Params: map[string]string{
+					"round": "1",
+				},
+				Endpoint: srh.replayChallengeSelection,
+			},
+			{
+				FuncName: "metered-billing",
+				Params: map[string]string{
+					"allocation_id": getMockAllocationId(1),
+				},
+				Endpoint: srh.getMeteredBilling,
+			},
+			{
+				FuncName: "metered-billing-alerts",
+				Params: map[string]string{
+					"owner": data.Clients[0],
+				},
+				Endpoint: srh.getMeteredBillingAlerts,
+			},
+			{
+				FuncName: "read-acl",
+				Params: map[string]string{
+					"allocation_id": getMockAllocationId(0),
+				},
+				Endpoint: srh.getReadACL,
+			},
 			{
 				FuncName: "allocations",
 				Params: map[string]string{
@@ -144,6 +250,50 @@ func BenchmarkRestTests(
 				},
 				Endpoint: srh.getAllocationUpdateMinLock,
 			},
+			{
+				FuncName: "allocation-create-quote",
+				Params: map[string]string{
+					"data": func() string {
+						blobbers := make([]string, 0, viper.GetInt(bk.NumBlobbersPerAllocation))
+						for i := 0; i < viper.GetInt(bk.NumBlobbersPerAllocation); i++ {
+							blobbers = append(blobbers, getMockBlobberId(i))
+						}
+						v, err := (&newAllocationRequest{
+							DataShards:      len(blobbers) / 2,
+							ParityShards:    len(blobbers) / 2,
+							Size:            10 * viper.GetInt64(bk.StorageMinAllocSize),
+							Owner:           data.Clients[0],
+							OwnerPublicKey:  data.PublicKeys[0],
+							Blobbers:        blobbers,
+							ReadPriceRange:  PriceRange{0, maxReadPrice},
+							WritePriceRange: PriceRange{0, maxWritePrice},
+						}).encode()
+						if err != nil {
+							log.Fatal(err)
+						}
+						return string(v)
+					}(),
+				},
+				Endpoint: srh.getAllocationCreateQuote,
+			},
+			{
+				FuncName: "allocation-update-quote",
+				Params: map[string]string{
+					"data": func() string {
+						v, err := json.Marshal(&updateAllocationRequest{
+							ID:     getMockAllocationId(0),
+							Size:   100000,
+							Extend: true,
+						})
+						if err != nil {
+							log.Fatal(err)
+						}
+						return string(v)
+					}(),
+					"client_id": data.Clients[0],
+				},
+				Endpoint: srh.getAllocationUpdateQuote,
+			},
 			{
 				FuncName: "openchallenges",
 				Params: map[string]string{
@@ -662,6 +662,193 @@ func AddMockFreeStorageAssigners(
 	}
 }
 
+func AddMockAllocationTransfers(
+	clients []string,
+	balances cstate.StateContextI,
+) {
+	var sscId = StorageSmartContract{
+		SmartContract: sci.NewSC(ADDRESS),
+	}.ID
+	at := &allocationTransfer{
+		AllocationID: getMockAllocationId(0),
+		From:         clients[getMockOwnerFromAllocationIndex(0, len(clients))],
+		To:           clients[len(clients)-1],
+		ExpiresAt:    benchAllocationExpire(balances.GetTransaction().CreationDate),
+	}
+	if err := at.save(sscId, balances); err != nil {
+		panic(err)
+	}
+}
+
+func AddMockReadACLs(
+	clients []string,
+	balances cstate.StateContextI,
+) {
+	var sscId = StorageSmartContract{
+		SmartContract: sci.NewSC(ADDRESS),
+	}.ID
+	acl := &readACL{
+		AllocationID: getMockAllocationId(0),
+		Entries: []*readACLEntry{
+			{
+				ClientID:  clients[1],
+				Quota:     viper.GetInt64(sc.StorageMinAllocSize),
+				ExpiresAt: benchAllocationExpire(balances.GetTransaction().CreationDate),
+			},
+		},
+	}
+	if err := acl.save(sscId, balances); err != nil {
+		panic(err)
+	}
+}
+
+func AddMockAutoRepairPolicies(
+	balances cstate.StateContextI,
+) {
+	var sscId = StorageSmartContract{
+		SmartContract: sci.NewSC(ADDRESS),
+	}.ID
+	arp := &autoRepairPolicy{
+		AllocationID:        getMockAllocationId(0),
+		MaxFailedChallenges: 1,
+	}
+	if err := arp.save(sscId, balances); err != nil {
+		panic(err)
+	}
+}
+
+func AddMockMeteredBillings(
+	balances cstate.StateContextI,
+) {
+	var sscId = StorageSmartContract{
+		SmartContract: sci.NewSC(ADDRESS),
+	}.ID
+	mb := &meteredBilling{
+		AllocationID:        getMockAllocationId(1),
+		Balance:             1e12,
+		LowBalanceThreshold: 1e10,
+		Blobbers: []*meteredBlobber{
+			{
+				BlobberID:  getMockBlobberId(0),
+				WritePrice: 1e10,
+				UsedSize:   GB,
+				SettledAt:  common.Now() - 3600,
+			},
+		},
+	}
+	if err := mb.save(sscId, balances); err != nil {
+		panic(err)
+	}
+}
+
+// AddMockReadSubPools adds a read sub-pool of the owner of the first
+// allocation paying the reads of any client.
+func AddMockReadSubPools(
+	clients []string,
+	balances cstate.StateContextI,
+) {
+	var sscId = StorageSmartContract{
+		SmartContract: sci.NewSC(ADDRESS),
+	}.ID
+	allocID := getMockAllocationId(0)
+	owner := clients[getMockOwnerFromAllocationIndex(0, len(clients))]
+	arsp := &allocationReadSubPools{
+		AllocationID: allocID,
+		Pools: []*readSubPool{{
+			ID:      readSubPoolID(allocID, owner, ""),
+			Name:    "mock read sub-pool",
+			Owner:   owner,
+			Balance: 100 * 1e10,
+		}},
+	}
+	if err := arsp.save(sscId, balances); err != nil {
+		panic(err)
+	}
+}
+
+// AddMockPriceOffers adds an expired price offer to the first blobbers.
+func AddMockPriceOffers(
+	balances cstate.StateContextI,
+) {
+	var sscId = StorageSmartContract{
+		SmartContract: sci.NewSC(ADDRESS),
+	}.ID
+	terms := getMockBlobberTerms()
+	for i := 0; i < viper.GetInt(sc.NumBlobbersPerAllocation); i++ {
+		bpo := &blobberPriceOffers{
+			BlobberID: getMockBlobberId(i),
+			Offers: []*blobberPriceOffer{{
+				ID:         getMockPriceOfferId(i),
+				ReadPrice:  terms.ReadPrice,
+				WritePrice: terms.WritePrice,
+				Capacity:   GB,
+				Remaining:  GB,
+				ValidUntil: 1,
+			}},
+		}
+		if err := bpo.save(sscId, balances); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// AddMockChallengeDisputes opens a dispute of a failed challenge of the first
+// blobber of the first allocation, validated by the last two validators.
+func AddMockChallengeDisputes(
+	validatorIds []string,
+	clients []string,
+	balances cstate.StateContextI,
+) {
+	var sscId = StorageSmartContract{
+		SmartContract: sci.NewSC(ADDRESS),
+	}.ID
+	cds := &challengeDisputes{
+		AllocationID: getMockAllocationId(0),
+		Disputes: []*challengeDispute{{
+			ChallengeID:        getMockDisputedChallengeId(0),
+			BlobberID:          getMockBlobberId(0),
+			ChallengeCreatedAt: common.Now() - 1,
+			Validators:         validatorIds[len(validatorIds)-2:],
+			TotalValidators:    2,
+			Deadline:           common.Now() + toSeconds(24*time.Hour),
+			Escrow:             10,
+			DelegateEscrow: map[string]currency.Coin{
+				getMockBlobberStakePoolId(0, 0, clients): 10,
+			},
+		}},
+	}
+	if err := cds.save(sscId, balances); err != nil {
+		panic(err)
+	}
+}
+
+// AddMockShardMigrations migrates the first two allocations to one more data
+// shard and one less parity shard. The first blobber of the first allocation
+// is yet to complete the migration, all the blobbers of the second one did.
+func AddMockShardMigrations(
+	balances cstate.StateContextI,
+) {
+	var sscId = StorageSmartContract{
+		SmartContract: sci.NewSC(ADDRESS),
+	}.ID
+	numBlobbers := viper.GetInt(sc.NumBlobbersPerAllocation)
+	for i, allocIndex := range []int{0, 1} {
+		sm := &shardMigration{
+			AllocationID: getMockAllocationId(allocIndex),
+			DataShards:   numBlobbers/2 + 1,
+			ParityShards: numBlobbers - numBlobbers/2 - 1,
+			StartedAt:    common.Now() - 3600,
+		}
+		startBlobbers := getMockBlobberBlockFromAllocationIndex(allocIndex)
+		for j := i ^ 1; j < numBlobbers; j++ {
+			sm.Completed = append(sm.Completed, getMockBlobberId(startBlobbers+j))
+		}
+		if err := sm.save(sscId, balances); err != nil {
+			panic(err)
+		}
+	}
+}
+
 func AddMockReadMarkers(
 	clients, publicKeys []string,
 	eventDb *event.EventDb,
@@ -809,6 +996,14 @@ func getMockBlobberBlockFromAllocationIndex(i int) int {
 	return i % (viper.GetInt(sc.NumBlobbers) - viper.GetInt(sc.NumBlobbersPerAllocation))
 }
 
+func getMockPriceOfferId(blobber int) string {
+	return encryption.Hash("mock price offer" + strconv.Itoa(blobber))
+}
+
+func getMockDisputedChallengeId(allocation int) string {
+	return encryption.Hash("mock disputed challenge" + strconv.Itoa(allocation))
+}
+
 func getMockChallengeId(blobberID, allocationId string) string {
 	return encryption.Hash("challenge" + allocationId + blobberID)
 }
@@ -827,6 +1022,10 @@ func SetMockConfig(
 	conf.HealthCheckPeriod = 1 * time.Hour
 	conf.BlobberSlash = 0.1
 	conf.CancellationCharge = 0.2
+	conf.OwnershipTransferExpiry = 24 * time.Hour
+	conf.MeteredBillingGracePeriod = 24 * time.Hour
+	conf.MeteredBillingMinPrepaidPeriod = 24 * time.Hour
+	conf.ChallengeDisputePeriod = 1 * time.Hour
 	conf.MaxReadPrice = 100e10  // 100 tokens per GB max allowed (by 64 KB)
 	conf.MaxWritePrice = 100e10 // 100 tokens per GB max allowed
 	conf.MinWritePrice = 0
@@ -273,6 +273,415 @@ func BenchmarkTests(
 				return bytes
 			}(),
 		},
+		{
+			name:     "storage.offer_allocation_transfer",
+			endpoint: ssc.offerAllocationTransfer,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&allocationTransferRequest{
+					AllocationID: getMockAllocationId(0),
+					NewOwnerID:   data.Clients[1],
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.accept_allocation_transfer",
+			endpoint: ssc.acceptAllocationTransfer,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     data.Clients[len(data.Clients)-1],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&allocationTransferRequest{
+					AllocationID:      getMockAllocationId(0),
+					NewOwnerPublicKey: data.PublicKeys[len(data.PublicKeys)-1],
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.cancel_allocation_transfer",
+			endpoint: ssc.cancelAllocationTransfer,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&allocationTransferRequest{
+					AllocationID: getMockAllocationId(0),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.reject_allocation_transfer",
+			endpoint: ssc.rejectAllocationTransfer,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     data.Clients[len(data.Clients)-1],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&allocationTransferRequest{
+					AllocationID: getMockAllocationId(0),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.freeze_allocation",
+			endpoint: ssc.freezeAllocation,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				alloc := &storageAllocationBase{ID: getMockAllocationId(0)}
+				for _, id := range blobbers {
+					alloc.BlobberAllocs = append(alloc.BlobberAllocs, &BlobberAllocation{
+						BlobberID:      id,
+						AllocationRoot: encryption.Hash("allocation root"),
+					})
+				}
+				_ = sigScheme.SetPublicKey(data.PublicKeys[0])
+				sigScheme.SetPrivateKey(data.PrivateKeys[0])
+				signature, _ := sigScheme.Sign(newAllocationSnapshot(alloc).Hash)
+				bytes, _ := json.Marshal(&freezeAllocationRequest{
+					AllocationID: getMockAllocationId(0),
+					Signature:    signature,
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.set_read_acl_entry",
+			endpoint: ssc.setReadACLEntry,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&readACLRequest{
+					AllocationID: getMockAllocationId(0),
+					PublicKey:    data.PublicKeys[2],
+					Quota:        viper.GetInt64(bk.StorageMinAllocSize),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.remove_read_acl_entry",
+			endpoint: ssc.removeReadACLEntry,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&readACLRequest{
+					AllocationID: getMockAllocationId(0),
+					ClientID:     data.Clients[1],
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.delete_read_acl",
+			endpoint: ssc.deleteReadACL,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&readACLRequest{
+					AllocationID: getMockAllocationId(0),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.set_auto_repair_policy",
+			endpoint: ssc.setAutoRepairPolicy,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&autoRepairPolicyRequest{
+					AllocationID:        getMockAllocationId(0),
+					Enabled:             true,
+					MaxFailedChallenges: 3,
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.repair_allocation",
+			endpoint: ssc.repairAllocation,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     data.Clients[1],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&repairAllocationRequest{
+					AllocationID: getMockAllocationId(0),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.top_up_metered_billing",
+			endpoint: ssc.topUpMeteredBilling,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     data.Clients[1],
+				ToClientID:   ADDRESS,
+				Value:        1e10,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&topUpMeteredBillingRequest{
+					AllocationID: getMockAllocationId(1),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.settle_metered_billing",
+			endpoint: ssc.settleMeteredBilling,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     data.Clients[2],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&settleMeteredBillingRequest{
+					AllocationID: getMockAllocationId(1),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.complete_shard_migration",
+			endpoint: ssc.completeShardMigration,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     getMockBlobberId(0),
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&shardMigrationActionRequest{
+					AllocationID: getMockAllocationId(0),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.finalize_shard_migration",
+			endpoint: ssc.finalizeShardMigration,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     data.Clients[getMockOwnerFromAllocationIndex(1, len(data.Clients))],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&shardMigrationActionRequest{
+					AllocationID: getMockAllocationId(1),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.cancel_shard_migration",
+			endpoint: ssc.cancelShardMigration,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     data.Clients[getMockOwnerFromAllocationIndex(0, len(data.Clients))],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&shardMigrationActionRequest{
+					AllocationID: getMockAllocationId(0),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.read_sub_pool_lock",
+			endpoint: ssc.readSubPoolLock,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				Value:        100 * 1e10,
+				CreationDate: creationTime - 1,
+				ClientID:     data.Clients[getMockOwnerFromAllocationIndex(0, len(data.Clients))],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&readSubPoolLockRequest{
+					AllocationID: getMockAllocationId(0),
+					Reader:       data.Clients[1],
+					Limit:        1e10,
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.read_sub_pool_unlock",
+			endpoint: ssc.readSubPoolUnlock,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     data.Clients[getMockOwnerFromAllocationIndex(0, len(data.Clients))],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&readSubPoolUnlockRequest{
+					AllocationID: getMockAllocationId(0),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.add_blobber_price_offer",
+			endpoint: ssc.addBlobberPriceOffer,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     getMockBlobberId(0),
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				terms := getMockBlobberTerms()
+				bytes, _ := json.Marshal(&addBlobberPriceOfferRequest{
+					BlobberID:  getMockBlobberId(0),
+					ReadPrice:  terms.ReadPrice,
+					WritePrice: terms.WritePrice,
+					Capacity:   GB,
+					ValidFrom:  creationTime - 1,
+					ValidUntil: creationTime + 3600,
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.remove_blobber_price_offer",
+			endpoint: ssc.removeBlobberPriceOffer,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&removeBlobberPriceOfferRequest{
+					BlobberID: getMockBlobberId(0),
+					OfferID:   getMockPriceOfferId(0),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.dispute_challenge",
+			endpoint: ssc.disputeChallenge,
+			txn: &transaction.Transaction{
+				ClientID:     getMockBlobberId(0),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				// the mock dispute excludes the last validators, the first ones
+				// validate the late proof
+				var validationTickets []*ValidationTicket
+				for i := 0; i < 2 && i < len(data.ValidatorIds); i++ {
+					vt := &ValidationTicket{
+						ChallengeID:  getMockDisputedChallengeId(0),
+						BlobberID:    getMockBlobberId(0),
+						ValidatorID:  data.ValidatorIds[i],
+						ValidatorKey: data.ValidatorPublicKeys[i],
+						Result:       true,
+						Message:      "mock message",
+						MessageCode:  "mock message code",
+						Timestamp:    creationTime,
+					}
+					hash := encryption.Hash(fmt.Sprintf("%v:%v:%v:%v:%v:%v", vt.ChallengeID, vt.BlobberID,
+						vt.ValidatorID, vt.ValidatorKey, vt.Result, vt.Timestamp))
+					_ = sigScheme.SetPublicKey(data.ValidatorPublicKeys[i])
+					sigScheme.SetPrivateKey(data.ValidatorPrivateKeys[i])
+					vt.Signature, _ = sigScheme.Sign(hash)
+					validationTickets = append(validationTickets, vt)
+				}
+				bytes, _ := json.Marshal(&challengeDisputeRequest{
+					AllocationID:      getMockAllocationId(0),
+					ChallengeID:       getMockDisputedChallengeId(0),
+					ValidationTickets: validationTickets,
+				})
+				return bytes
+			}(),
+		},
 		// free data.Allocations
 		{
 			name:     "storage.add_free_storage_assigner",
@@ -559,6 +968,65 @@ func BenchmarkTests(
 				return bytes
 			}(),
 		},
+		{
+			name:     "storage.stake_pool_redelegate",
+			endpoint: ssc.stakePoolRedelegate,
+			txn: &transaction.Transaction{
+				ClientID:     getMockBlobberStakePoolId(0, 0, data.Clients),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&stakepool.StakePoolRedelegateRequest{
+					FromProviderType: spenum.Blobber,
+					FromProviderID:   getMockBlobberId(0),
+					ToProviderType:   spenum.Blobber,
+					ToProviderID:     getMockBlobberId(1),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.stake_pool_auto_compound",
+			endpoint: ssc.stakePoolAutoCompound,
+			txn: &transaction.Transaction{
+				ClientID:     getMockBlobberStakePoolId(0, 0, data.Clients),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.StakePoolAutoCompoundRequest{
+				ProviderType: spenum.Blobber,
+				ProviderID:   getMockBlobberId(0),
+				AutoCompound: true,
+			}).Encode(),
+		},
+		{
+			name:     "storage.stake_pool_unbond",
+			endpoint: ssc.stakePoolUnbond,
+			txn: &transaction.Transaction{
+				ClientID:     getMockBlobberStakePoolId(0, 0, data.Clients),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.StakePoolUnbondRequest{
+				ProviderType: spenum.Blobber,
+				ProviderID:   getMockBlobberId(0),
+				Amount:       1,
+			}).Encode(),
+		},
+		{
+			name:     "storage.stake_pool_claim_unbonded",
+			endpoint: ssc.stakePoolClaimUnbonded,
+			txn: &transaction.Transaction{
+				ClientID:     getMockBlobberStakePoolId(0, 0, data.Clients),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.StakePoolRequest{
+				ProviderType: spenum.Blobber,
+				ProviderID:   getMockBlobberId(0),
+			}).Encode(),
+		},
 		{
 			name:     "storage.collect_reward",
 			endpoint: ssc.collectReward,
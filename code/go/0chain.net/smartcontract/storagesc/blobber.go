@@ -418,6 +418,14 @@ func (sc *StorageSmartContract) addBlobber(t *transaction.Transaction,
 		return "", err
 	}
 
+	var placement struct {
+		Placement *dto.BlobberPlacement `json:"placement"`
+	}
+	if err := json.Unmarshal(input, &placement); err != nil {
+		return "", common.NewError("add_or_update_blobber_failed",
+			"malformed request: "+err.Error())
+	}
+
 	// insert blobber
 	if err = sc.insertBlobber(t, conf, blobber, balances); err != nil {
 		return "", common.NewError("add_or_update_blobber_failed", err.Error())
@@ -430,6 +438,12 @@ func (sc *StorageSmartContract) addBlobber(t *transaction.Transaction,
 			"saving blobber: "+err.Error())
 	}
 
+	if placement.Placement != nil {
+		if err := setBlobberPlacement(t.ClientID, placement.Placement, balances); err != nil {
+			return "", common.NewError("add_or_update_blobber_failed", err.Error())
+		}
+	}
+
 	// Save url
 	if blobber.mustBase().BaseURL != "" {
 		_, err = balances.InsertTrieNode(blobber.GetUrlKey(sc.ID), &datastore.NOIDField{})
@@ -487,6 +501,12 @@ func (sc *StorageSmartContract) updateBlobberSettings(txn *transaction.Transacti
 		return "", common.NewError("update_blobber_settings_failed", err.Error())
 	}
 
+	if updatedBlobber.Placement != nil {
+		if err = setBlobberPlacement(updatedBlobber.ID, updatedBlobber.Placement, balances); err != nil {
+			return "", common.NewError("update_blobber_settings_failed", err.Error())
+		}
+	}
+
 	return string(blobber.Encode()), nil
 }
 
@@ -617,16 +637,33 @@ func (sc *StorageSmartContract) commitBlobberRead(t *transaction.Transaction,
 
 	commitRead.ReadMarker.ReadSize = sizeRead
 
-	// move tokens from read pool to blobber
-	rp, err := sc.getReadPool(commitRead.ReadMarker.ClientID, balances)
-	if err != nil && err != util.ErrValueNotPresent {
+	acl, err := sc.checkReadACL(alloc, commitRead.ReadMarker, numReads*CHUNK_SIZE, t.CreationDate, balances)
+	if err != nil {
 		return "", common.NewErrorf("commit_blobber_read",
-			"can't get related read pool: %v", err)
+			"read marker rejected by the allocation read ACL: %v", err)
 	}
-	if err == util.ErrValueNotPresent || rp == nil {
-		rp = new(readPool)
-		if err = rp.save(sc.ID, commitRead.ReadMarker.ClientID, balances); err != nil {
-			return "", common.NewError("new_read_pool_failed", err.Error())
+
+	// the most specific read sub-pool able to pay the read marker is debited
+	// instead of the read pool of the client
+	subPools, err := sc.getAllocationReadSubPools(alloc.ID, balances)
+	if err != nil {
+		return "", common.NewError("commit_blobber_read", err.Error())
+	}
+	subPool := subPools.match(commitRead.ReadMarker.ClientID, value, t.CreationDate)
+
+	// move tokens from read pool to blobber
+	var rp *readPool
+	if subPool == nil {
+		rp, err = sc.getReadPool(commitRead.ReadMarker.ClientID, balances)
+		if err != nil && err != util.ErrValueNotPresent {
+			return "", common.NewErrorf("commit_blobber_read",
+				"can't get related read pool: %v", err)
+		}
+		if err == util.ErrValueNotPresent || rp == nil {
+			rp = new(readPool)
+			if err = rp.save(sc.ID, commitRead.ReadMarker.ClientID, balances); err != nil {
+				return "", common.NewError("new_read_pool_failed", err.Error())
+			}
 		}
 	}
 
@@ -640,8 +677,13 @@ func (sc *StorageSmartContract) commitBlobberRead(t *transaction.Transaction,
 	details.Stats.NumReads++
 	alloc.Stats.NumReads++
 
-	resp, err = rp.moveToBlobber(commitRead.ReadMarker.AllocationID,
-		commitRead.ReadMarker.BlobberID, sp, value, balances)
+	if subPool != nil {
+		resp, err = subPool.moveToBlobber(commitRead.ReadMarker.AllocationID,
+			commitRead.ReadMarker.BlobberID, sp, value, balances)
+	} else {
+		resp, err = rp.moveToBlobber(commitRead.ReadMarker.AllocationID,
+			commitRead.ReadMarker.BlobberID, sp, value, balances)
+	}
 	if err != nil {
 		return "", common.NewErrorf("commit_blobber_read",
 			"can't transfer tokens from read pool to stake pool: %v", err)
@@ -702,16 +744,25 @@ func (sc *StorageSmartContract) commitBlobberRead(t *transaction.Transaction,
 			"can't save stake pool: %v", err)
 	}
 
-	if err = rp.save(sc.ID, commitRead.ReadMarker.ClientID, balances); err != nil {
-		return "", common.NewErrorf("commit_blobber_read",
-			"can't Save read pool: %v", err)
-	}
+	payment := readMarkerPayment{PayerID: commitRead.ReadMarker.ClientID, Cost: value}
+	if subPool != nil {
+		if err = subPools.save(sc.ID, balances); err != nil {
+			return "", common.NewErrorf("commit_blobber_read",
+				"can't save read sub-pools: %v", err)
+		}
+		payment.PayerID, payment.ReadSubPoolID = subPool.Owner, subPool.ID
+	} else {
+		if err = rp.save(sc.ID, commitRead.ReadMarker.ClientID, balances); err != nil {
+			return "", common.NewErrorf("commit_blobber_read",
+				"can't Save read pool: %v", err)
+		}
 
-	// updates the readpool table
-	balances.EmitEvent(event.TypeStats, event.TagUpdateReadpool, commitRead.ReadMarker.ClientID, event.ReadPool{
-		UserID:  commitRead.ReadMarker.ClientID,
-		Balance: rp.Balance,
-	})
+		// updates the readpool table
+		balances.EmitEvent(event.TypeStats, event.TagUpdateReadpool, commitRead.ReadMarker.ClientID, event.ReadPool{
+			UserID:  commitRead.ReadMarker.ClientID,
+			Balance: rp.Balance,
+		})
+	}
 
 	_, err = balances.InsertTrieNode(blobber.GetKey(), blobber)
 	if err != nil {
@@ -737,9 +788,16 @@ func (sc *StorageSmartContract) commitBlobberRead(t *transaction.Transaction,
 		return "", common.NewError("saving read marker", err.Error())
 	}
 
+	if acl != nil {
+		if err = acl.save(sc.ID, balances); err != nil {
+			return "", common.NewErrorf("commit_blobber_read",
+				"can't save read ACL: %v", err)
+		}
+	}
+
 	balances.EmitEvent(event.TypeStats, event.TagUpdateAllocation, alloc.ID, sa.buildDbUpdates(balances))
 
-	err = emitAddOrOverwriteReadMarker(commitRead.ReadMarker, balances, t)
+	err = emitAddOrOverwriteReadMarker(commitRead.ReadMarker, payment, balances, t)
 	if err != nil {
 		return "", common.NewError("saving read marker in db:", err.Error())
 	}
@@ -894,6 +952,16 @@ func (sc *StorageSmartContract) commitBlobberConnection(
 			" to be by the same client as owner of the allocation %s != %s", alloc.Owner, commitMarkerBase.ClientID))
 	}
 
+	frozen, err := sc.isAllocationFrozen(alloc.ID, balances)
+	if err != nil {
+		return "", common.NewError("commit_connection_failed",
+			"can't check allocation snapshot: "+err.Error())
+	}
+	if frozen {
+		return "", common.NewError("commit_connection_failed",
+			"allocation is frozen")
+	}
+
 	blobAlloc, ok := alloc.BlobberAllocsMap[t.ClientID]
 	if !ok {
 		return "", common.NewError("commit_connection_failed",
@@ -1014,8 +1082,18 @@ func (sc *StorageSmartContract) commitBlobberConnection(
 
 	blobberAllocSizeBefore := blobAlloc.Stats.UsedSize
 
+	// the write markers of both layouts are accepted during a shard migration
+	maxUsedSize := blobAlloc.Size
+	sm, err := sc.findShardMigration(alloc.ID, balances)
+	if err != nil {
+		return "", common.NewError("commit_connection_failed", err.Error())
+	}
+	if sm != nil {
+		maxUsedSize = sm.maxUsedSize(alloc, blobAlloc)
+	}
+
 	if blobAlloc.Stats.UsedSize+changeSize >
-		blobAlloc.Size {
+		maxUsedSize {
 
 		return "", common.NewError("commit_connection_failed",
 			"Size for blobber allocation exceeded maximum")
@@ -1046,11 +1124,27 @@ func (sc *StorageSmartContract) commitBlobberConnection(
 			"write marker time is after allocation expires")
 	}
 
-	movedTokens, err := sc.commitMoveTokens(conf, alloc, changeSize, blobAlloc,
-		commitMarkerBase.Timestamp, t.CreationDate, balances)
+	mb, err := sc.findMeteredBilling(alloc.ID, balances)
 	if err != nil {
-		return "", common.NewErrorf("commit_connection_failed",
-			"moving tokens: %v", err)
+		return "", common.NewErrorf("commit_connection_failed", "%v", err)
+	}
+
+	var movedTokens currency.Coin
+	if mb != nil {
+		// metered allocations pay per byte-time instead of moving tokens to
+		// the challenge pool
+		if err := sc.commitMeteredUsage(conf, alloc, mb, blobAlloc, blobberAllocSizeBefore,
+			changeSize, t.CreationDate, balances); err != nil {
+			return "", common.NewErrorf("commit_connection_failed",
+				"metered billing: %v", err)
+		}
+	} else {
+		movedTokens, err = sc.commitMoveTokens(conf, alloc, changeSize, blobAlloc,
+			commitMarkerBase.Timestamp, t.CreationDate, balances)
+		if err != nil {
+			return "", common.NewErrorf("commit_connection_failed",
+				"moving tokens: %v", err)
+		}
 	}
 
 	bb := blobber.mustBase()
@@ -0,0 +1,345 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+//msgp:ignore addBlobberPriceOfferRequest removeBlobberPriceOfferRequest
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+// maxPriceOffersPerBlobber bounds the open price offers of a blobber
+const maxPriceOffersPerBlobber = 10
+
+// addBlobberPriceOfferRequest is the input of add_blobber_price_offer, sent by
+// the delegate wallet of the blobber. The offered capacity is reserved on the
+// blobber until the allocations referencing the offer use it or the offer
+// expires and gets removed.
+type addBlobberPriceOfferRequest struct {
+	BlobberID  string           `json:"blobber_id"`
+	ReadPrice  currency.Coin    `json:"read_price"`
+	WritePrice currency.Coin    `json:"write_price"`
+	Capacity   int64            `json:"capacity"`
+	ValidFrom  common.Timestamp `json:"valid_from"`
+	ValidUntil common.Timestamp `json:"valid_until"`
+}
+
+func (r *addBlobberPriceOfferRequest) decode(b []byte) error {
+	return json.Unmarshal(b, r)
+}
+
+func (r *addBlobberPriceOfferRequest) validate(conf *Config, now common.Timestamp) error {
+	if r.Capacity <= 0 {
+		return errors.New("invalid capacity")
+	}
+	if err := validateReadPrice(r.ReadPrice, conf); err != nil {
+		return err
+	}
+	if err := validateWritePrice(r.WritePrice, conf); err != nil {
+		return err
+	}
+	if r.ValidUntil <= now || r.ValidUntil <= r.ValidFrom {
+		return errors.New("invalid validity window")
+	}
+	return nil
+}
+
+// removeBlobberPriceOfferRequest is the input of remove_blobber_price_offer,
+// any client can remove an expired offer to release its capacity
+type removeBlobberPriceOfferRequest struct {
+	BlobberID string `json:"blobber_id"`
+	OfferID   string `json:"offer_id"`
+}
+
+func (r *removeBlobberPriceOfferRequest) decode(b []byte) error {
+	return json.Unmarshal(b, r)
+}
+
+func blobberPriceOffersKey(sscKey, blobberID string) datastore.Key {
+	return sscKey + ":priceoffers:" + blobberID
+}
+
+// blobberPriceOffer is a price a blobber commits to for the allocations
+// created within its validity window, up to its capacity. The price holds
+// for the term of the allocation, an extension renews at the blobber terms.
+type blobberPriceOffer struct {
+	ID         string           `json:"id"`
+	ReadPrice  currency.Coin    `json:"read_price"`
+	WritePrice currency.Coin    `json:"write_price"`
+	Capacity   int64            `json:"capacity"`
+	Remaining  int64            `json:"remaining"`
+	ValidFrom  common.Timestamp `json:"valid_from"`
+	ValidUntil common.Timestamp `json:"valid_until"`
+}
+
+func (o *blobberPriceOffer) terms() Terms {
+	return Terms{ReadPrice: o.ReadPrice, WritePrice: o.WritePrice}
+}
+
+func (o *blobberPriceOffer) isValid(now common.Timestamp) bool {
+	return o.ValidFrom <= now && now <= o.ValidUntil
+}
+
+// blobberPriceOffers are the open price offers of a blobber
+type blobberPriceOffers struct {
+	BlobberID string               `json:"blobber_id"`
+	Offers    []*blobberPriceOffer `json:"offers"`
+}
+
+func (bpo *blobberPriceOffers) Encode() []byte {
+	var b, err = json.Marshal(bpo)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (bpo *blobberPriceOffers) Decode(p []byte) error {
+	return json.Unmarshal(p, bpo)
+}
+
+func (bpo *blobberPriceOffers) save(sscKey string, balances cstate.StateContextI) error {
+	if len(bpo.Offers) == 0 {
+		_, err := balances.DeleteTrieNode(blobberPriceOffersKey(sscKey, bpo.BlobberID))
+		if err == util.ErrValueNotPresent {
+			return nil
+		}
+		return err
+	}
+	_, err := balances.InsertTrieNode(blobberPriceOffersKey(sscKey, bpo.BlobberID), bpo)
+	return err
+}
+
+func (bpo *blobberPriceOffers) find(offerID string) (int, bool) {
+	for i, o := range bpo.Offers {
+		if o.ID == offerID {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// use the capacity of the offer, the offer is closed once fully used
+func (bpo *blobberPriceOffers) use(offerID string, size int64) error {
+	i, ok := bpo.find(offerID)
+	if !ok {
+		return fmt.Errorf("price offer %s of blobber %s not found", offerID, bpo.BlobberID)
+	}
+	o := bpo.Offers[i]
+	if o.Remaining < size {
+		return fmt.Errorf("price offer %s remaining capacity %d insufficient, wanted %d",
+			offerID, o.Remaining, size)
+	}
+	o.Remaining -= size
+	if o.Remaining == 0 {
+		bpo.Offers = append(bpo.Offers[:i], bpo.Offers[i+1:]...)
+	}
+	return nil
+}
+
+func (sc *StorageSmartContract) getBlobberPriceOffers(blobberID string,
+	balances cstate.CommonStateContextI) (*blobberPriceOffers, error) {
+
+	bpo := &blobberPriceOffers{BlobberID: blobberID}
+	err := balances.GetTrieNode(blobberPriceOffersKey(sc.ID, blobberID), bpo)
+	switch err {
+	case nil, util.ErrValueNotPresent:
+		return bpo, nil
+	default:
+		return nil, fmt.Errorf("can't get price offers: %v", err)
+	}
+}
+
+// applyPriceOffers makes the blobbers of a new allocation request offer the
+// prices of the referenced offers, with the offered capacity free for the
+// allocation. It returns the terms of the blobbers to restore once the
+// blobbers are selected.
+func (sc *StorageSmartContract) applyPriceOffers(
+	request *newAllocationRequest,
+	blobbers []*storageNodeResponse,
+	size int64,
+	now common.Timestamp,
+	balances cstate.CommonStateContextI,
+) (map[string]Terms, error) {
+	blobberTerms := make(map[string]Terms, len(request.PriceOffers))
+	for _, b := range blobbers {
+		offerID, ok := request.PriceOffers[b.ID]
+		if !ok {
+			continue
+		}
+
+		bpo, err := sc.getBlobberPriceOffers(b.ID, balances)
+		if err != nil {
+			return nil, err
+		}
+		i, ok := bpo.find(offerID)
+		if !ok {
+			return nil, fmt.Errorf("price offer %s of blobber %s not found", offerID, b.ID)
+		}
+		o := bpo.Offers[i]
+		if !o.isValid(now) {
+			return nil, fmt.Errorf("price offer %s of blobber %s is not valid", offerID, b.ID)
+		}
+		if o.Remaining < size {
+			return nil, fmt.Errorf("price offer %s remaining capacity %d insufficient, wanted %d",
+				offerID, o.Remaining, size)
+		}
+
+		blobberTerms[b.ID] = b.Terms
+		b.Terms = o.terms()
+		b.Allocated -= size
+	}
+	if len(blobberTerms) != len(request.PriceOffers) {
+		return nil, errors.New("price offers of blobbers not in the request")
+	}
+	return blobberTerms, nil
+}
+
+// addBlobberPriceOffer publishes a price offer of a blobber and reserves the
+// offered capacity
+func (sc *StorageSmartContract) addBlobberPriceOffer(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	conf, err := sc.getConfig(balances, true)
+	if err != nil {
+		return "", common.NewError("add_blobber_price_offer_failed",
+			"can't get config: "+err.Error())
+	}
+
+	var req addBlobberPriceOfferRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("add_blobber_price_offer_failed",
+			"invalid request: "+err.Error())
+	}
+	if err := req.validate(conf, t.CreationDate); err != nil {
+		return "", common.NewError("add_blobber_price_offer_failed", err.Error())
+	}
+
+	blobber, err := sc.getBlobber(req.BlobberID, balances)
+	if err != nil {
+		return "", common.NewError("add_blobber_price_offer_failed",
+			"can't get the blobber: "+err.Error())
+	}
+	sp, err := sc.getStakePool(spenum.Blobber, req.BlobberID, balances)
+	if err != nil {
+		return "", common.NewError("add_blobber_price_offer_failed",
+			"can't get related stake pool: "+err.Error())
+	}
+	if t.ClientID != sp.Settings.DelegateWallet {
+		return "", common.NewError("add_blobber_price_offer_failed",
+			"access denied, allowed for delegate_wallet owner only")
+	}
+
+	bpo, err := sc.getBlobberPriceOffers(req.BlobberID, balances)
+	if err != nil {
+		return "", common.NewError("add_blobber_price_offer_failed", err.Error())
+	}
+	if len(bpo.Offers) >= maxPriceOffersPerBlobber {
+		return "", common.NewError("add_blobber_price_offer_failed",
+			fmt.Sprintf("blobber reached the max %d price offers", maxPriceOffersPerBlobber))
+	}
+
+	if err := blobber.mustUpdateBase(func(snb *storageNodeBase) error {
+		if snb.IsShutDown() || snb.IsKilled() {
+			return errors.New("blobber is not active")
+		}
+		stakedCapacity, err := sp.stakedCapacity(snb.Terms.WritePrice)
+		if err != nil {
+			return fmt.Errorf("can't get staked capacity: %v", err)
+		}
+		if snb.Capacity-snb.Allocated < req.Capacity || stakedCapacity-snb.Allocated < req.Capacity {
+			return fmt.Errorf("blobber free capacity insufficient for the offer, wanted %d", req.Capacity)
+		}
+		snb.Allocated += req.Capacity
+		return nil
+	}); err != nil {
+		return "", common.NewError("add_blobber_price_offer_failed", err.Error())
+	}
+
+	o := &blobberPriceOffer{
+		ID:         t.Hash,
+		ReadPrice:  req.ReadPrice,
+		WritePrice: req.WritePrice,
+		Capacity:   req.Capacity,
+		Remaining:  req.Capacity,
+		ValidFrom:  req.ValidFrom,
+		ValidUntil: req.ValidUntil,
+	}
+	bpo.Offers = append(bpo.Offers, o)
+	if err := bpo.save(sc.ID, balances); err != nil {
+		return "", common.NewError("add_blobber_price_offer_failed",
+			"saving price offers: "+err.Error())
+	}
+
+	if _, err := balances.InsertTrieNode(blobber.GetKey(), blobber); err != nil {
+		return "", common.NewError("add_blobber_price_offer_failed",
+			"saving blobber: "+err.Error())
+	}
+	emitUpdateBlobberAllocatedSavedHealth(blobber, balances)
+
+	return toJson(o), nil
+}
+
+// removeBlobberPriceOffer removes an expired price offer and releases its
+// remaining capacity
+func (sc *StorageSmartContract) removeBlobberPriceOffer(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req removeBlobberPriceOfferRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("remove_blobber_price_offer_failed",
+			"invalid request: "+err.Error())
+	}
+
+	bpo, err := sc.getBlobberPriceOffers(req.BlobberID, balances)
+	if err != nil {
+		return "", common.NewError("remove_blobber_price_offer_failed", err.Error())
+	}
+	i, ok := bpo.find(req.OfferID)
+	if !ok {
+		return "", common.NewError("remove_blobber_price_offer_failed",
+			"price offer not found")
+	}
+	o := bpo.Offers[i]
+	if t.CreationDate <= o.ValidUntil {
+		return "", common.NewError("remove_blobber_price_offer_failed",
+			"price offer is not expired")
+	}
+	bpo.Offers = append(bpo.Offers[:i], bpo.Offers[i+1:]...)
+
+	blobber, err := sc.getBlobber(req.BlobberID, balances)
+	if err != nil {
+		return "", common.NewError("remove_blobber_price_offer_failed",
+			"can't get the blobber: "+err.Error())
+	}
+	//nolint:errcheck
+	blobber.mustUpdateBase(func(snb *storageNodeBase) error {
+		snb.Allocated -= o.Remaining
+		return nil
+	})
+
+	if err := bpo.save(sc.ID, balances); err != nil {
+		return "", common.NewError("remove_blobber_price_offer_failed",
+			"saving price offers: "+err.Error())
+	}
+	if _, err := balances.InsertTrieNode(blobber.GetKey(), blobber); err != nil {
+		return "", common.NewError("remove_blobber_price_offer_failed",
+			"saving blobber: "+err.Error())
+	}
+	emitUpdateBlobberAllocatedSavedHealth(blobber, balances)
+
+	return toJson(o), nil
+}
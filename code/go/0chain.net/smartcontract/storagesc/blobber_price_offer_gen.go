@@ -0,0 +1,232 @@
+package storagesc
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *blobberPriceOffer) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 7
+	// string "ID"
+	o = append(o, 0x87, 0xa2, 0x49, 0x44)
+	o = msgp.AppendString(o, z.ID)
+	// string "ReadPrice"
+	o = append(o, 0xa9, 0x52, 0x65, 0x61, 0x64, 0x50, 0x72, 0x69, 0x63, 0x65)
+	o, err = z.ReadPrice.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "ReadPrice")
+		return
+	}
+	// string "WritePrice"
+	o = append(o, 0xaa, 0x57, 0x72, 0x69, 0x74, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65)
+	o, err = z.WritePrice.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "WritePrice")
+		return
+	}
+	// string "Capacity"
+	o = append(o, 0xa8, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79)
+	o = msgp.AppendInt64(o, z.Capacity)
+	// string "Remaining"
+	o = append(o, 0xa9, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67)
+	o = msgp.AppendInt64(o, z.Remaining)
+	// string "ValidFrom"
+	o = append(o, 0xa9, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x46, 0x72, 0x6f, 0x6d)
+	o, err = z.ValidFrom.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "ValidFrom")
+		return
+	}
+	// string "ValidUntil"
+	o = append(o, 0xaa, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x55, 0x6e, 0x74, 0x69, 0x6c)
+	o, err = z.ValidUntil.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "ValidUntil")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *blobberPriceOffer) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ID":
+			z.ID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ID")
+				return
+			}
+		case "ReadPrice":
+			bts, err = z.ReadPrice.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ReadPrice")
+				return
+			}
+		case "WritePrice":
+			bts, err = z.WritePrice.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "WritePrice")
+				return
+			}
+		case "Capacity":
+			z.Capacity, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Capacity")
+				return
+			}
+		case "Remaining":
+			z.Remaining, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Remaining")
+				return
+			}
+		case "ValidFrom":
+			bts, err = z.ValidFrom.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ValidFrom")
+				return
+			}
+		case "ValidUntil":
+			bts, err = z.ValidUntil.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ValidUntil")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *blobberPriceOffer) Msgsize() (s int) {
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 10 + z.ReadPrice.Msgsize() + 11 + z.WritePrice.Msgsize() + 9 + msgp.Int64Size + 10 + msgp.Int64Size + 10 + z.ValidFrom.Msgsize() + 11 + z.ValidUntil.Msgsize()
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *blobberPriceOffers) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "BlobberID"
+	o = append(o, 0x82, 0xa9, 0x42, 0x6c, 0x6f, 0x62, 0x62, 0x65, 0x72, 0x49, 0x44)
+	o = msgp.AppendString(o, z.BlobberID)
+	// string "Offers"
+	o = append(o, 0xa6, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Offers)))
+	for za0001 := range z.Offers {
+		if z.Offers[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Offers[za0001].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Offers", za0001)
+				return
+			}
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *blobberPriceOffers) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "BlobberID":
+			z.BlobberID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BlobberID")
+				return
+			}
+		case "Offers":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Offers")
+				return
+			}
+			if cap(z.Offers) >= int(zb0002) {
+				z.Offers = (z.Offers)[:zb0002]
+			} else {
+				z.Offers = make([]*blobberPriceOffer, zb0002)
+			}
+			for za0001 := range z.Offers {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Offers[za0001] = nil
+				} else {
+					if z.Offers[za0001] == nil {
+						z.Offers[za0001] = new(blobberPriceOffer)
+					}
+					bts, err = z.Offers[za0001].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Offers", za0001)
+						return
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *blobberPriceOffers) Msgsize() (s int) {
+	s = 1 + 10 + msgp.StringPrefixSize + len(z.BlobberID) + 7 + msgp.ArrayHeaderSize
+	for za0001 := range z.Offers {
+		if z.Offers[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Offers[za0001].Msgsize()
+		}
+	}
+	return
+}
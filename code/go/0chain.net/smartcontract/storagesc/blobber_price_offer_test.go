@@ -0,0 +1,177 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobberPriceOffer(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		owner    = newClient(5000*x10, balances)
+		other    = newClient(100*x10, balances)
+		tp       = int64(100)
+	)
+
+	_, blobs := addAllocation(t, ssc, owner, tp, 0, 0, 0, 0, 0, balances, false, false, false)
+	blobberID := blobs[0].id
+	sp, err := ssc.getStakePool(spenum.Blobber, blobberID, balances)
+	require.NoError(t, err)
+	getBlobber := func() *storageNodeBase {
+		b, err := ssc.getBlobber(blobberID, balances)
+		require.NoError(t, err)
+		return b.mustBase()
+	}
+	before := getBlobber()
+
+	nar := &newAllocationRequest{
+		DataShards:      10,
+		ParityShards:    10,
+		Size:            GB,
+		Owner:           owner.id,
+		OwnerPublicKey:  owner.pk,
+		ReadPriceRange:  PriceRange{1 * x10, 10 * x10},
+		WritePriceRange: PriceRange{0 * x10, 20 * x10},
+	}
+	for _, b := range blobs {
+		nar.Blobbers = append(nar.Blobbers, b.id)
+		nar.BlobberAuthTickets = append(nar.BlobberAuthTickets, "")
+	}
+	size := bSize(nar.Size, nar.DataShards)
+
+	addOffer := func(clientID string, req addBlobberPriceOfferRequest) (string, error) {
+		tp += 100
+		req.BlobberID = blobberID
+		tx := newTransaction(clientID, ssc.ID, 0, tp)
+		balances.setTransaction(t, tx)
+		_, err := ssc.addBlobberPriceOffer(tx, mustEncode(t, &req), balances)
+		return tx.Hash, err
+	}
+	removeOffer := func(offerID string) error {
+		tx := newTransaction(other.id, ssc.ID, 0, tp)
+		balances.setTransaction(t, tx)
+		_, err := ssc.removeBlobberPriceOffer(tx, mustEncode(t, &removeBlobberPriceOfferRequest{
+			BlobberID: blobberID,
+			OfferID:   offerID,
+		}), balances)
+		return err
+	}
+	offer := addBlobberPriceOfferRequest{
+		ReadPrice:  before.Terms.ReadPrice,
+		WritePrice: before.Terms.WritePrice / 2,
+		Capacity:   2 * size,
+		ValidUntil: common.Timestamp(tp + 1000),
+	}
+
+	_, err = addOffer(other.id, offer)
+	require.ErrorContains(t, err, "access denied")
+	_, err = addOffer(sp.Settings.DelegateWallet, addBlobberPriceOfferRequest{Capacity: size, ValidUntil: 1})
+	require.ErrorContains(t, err, "validity window")
+	offerID, err := addOffer(sp.Settings.DelegateWallet, offer)
+	require.NoError(t, err)
+	require.Equal(t, before.Allocated+offer.Capacity, getBlobber().Allocated, "the offered capacity is reserved")
+
+	tp += 100
+	nar.PriceOffers = map[string]string{blobberID: "unknown"}
+	_, err = nar.callNewAllocReq(t, owner.id, 1000*x10, ssc, tp, balances)
+	require.ErrorContains(t, err, "not found")
+
+	nar.PriceOffers = map[string]string{blobberID: offerID}
+	resp, err := nar.callNewAllocReq(t, owner.id, 1000*x10, ssc, tp, balances)
+	require.NoError(t, err)
+	var out NewAllocationTxnOutput
+	require.NoError(t, json.Unmarshal([]byte(resp), &out))
+	sa, err := ssc.getAllocation(out.ID, balances)
+	require.NoError(t, err)
+	ba, ok := sa.mustBase().BlobberAllocsMap[blobberID]
+	require.True(t, ok)
+	require.Equal(t, offer.WritePrice, ba.Terms.WritePrice, "the allocation gets the offered price")
+
+	after := getBlobber()
+	require.Equal(t, before.Terms, after.Terms, "the blobber keeps its terms")
+	require.Equal(t, before.Allocated+offer.Capacity, after.Allocated, "the allocation uses the reserved capacity")
+	bpo, err := ssc.getBlobberPriceOffers(blobberID, balances)
+	require.NoError(t, err)
+	require.Len(t, bpo.Offers, 1)
+	require.Equal(t, offer.Capacity-size, bpo.Offers[0].Remaining)
+
+	require.ErrorContains(t, removeOffer(offerID), "not expired")
+	tp += 1000
+	require.NoError(t, removeOffer(offerID))
+	require.Equal(t, before.Allocated+size, getBlobber().Allocated, "the remaining capacity is released")
+	bpo, err = ssc.getBlobberPriceOffers(blobberID, balances)
+	require.NoError(t, err)
+	require.Empty(t, bpo.Offers)
+}
+
+func TestAddBlobberPriceOfferRequestValidate(t *testing.T) {
+	conf := &Config{
+		MaxReadPrice:  10 * x10,
+		MinWritePrice: 1 * x10,
+		MaxWritePrice: 10 * x10,
+	}
+	valid := addBlobberPriceOfferRequest{
+		Capacity:   GB,
+		ReadPrice:  1 * x10,
+		WritePrice: 2 * x10,
+		ValidUntil: 200,
+	}
+
+	tests := []struct {
+		name    string
+		update  func(r *addBlobberPriceOfferRequest)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			update: func(r *addBlobberPriceOfferRequest) {},
+		},
+		{
+			name:    "no capacity",
+			update:  func(r *addBlobberPriceOfferRequest) { r.Capacity = 0 },
+			wantErr: "invalid capacity",
+		},
+		{
+			name:    "read price above the max",
+			update:  func(r *addBlobberPriceOfferRequest) { r.ReadPrice = 11 * x10 },
+			wantErr: "greater than max_read_price",
+		},
+		{
+			name:    "write price above the max",
+			update:  func(r *addBlobberPriceOfferRequest) { r.WritePrice = 11 * x10 },
+			wantErr: "greater than max_write_price",
+		},
+		{
+			name:    "write price below the min",
+			update:  func(r *addBlobberPriceOfferRequest) { r.WritePrice = 1*x10 - 1 },
+			wantErr: "less than min_write_price",
+		},
+		{
+			name:   "write price at the min",
+			update: func(r *addBlobberPriceOfferRequest) { r.WritePrice = 1 * x10 },
+		},
+		{
+			name:    "expired",
+			update:  func(r *addBlobberPriceOfferRequest) { r.ValidUntil = 100 },
+			wantErr: "invalid validity window",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := valid
+			tt.update(&r)
+			err := r.validate(conf, 100)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
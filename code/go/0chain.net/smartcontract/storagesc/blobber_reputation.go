@@ -0,0 +1,108 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/core/datastore"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/util"
+)
+
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+// reputationHardFork is the hard fork the blobbers challenge reputation is
+// tracked from
+const reputationHardFork = "hermes"
+
+const (
+	// reputationScale is the score of a blobber passing all its challenges,
+	// the score is kept in basis points to keep floats out of the state
+	reputationScale int64 = 10000
+	// reputationDecay is the weight, in percent, of the score before a
+	// challenge result, the last ~20 challenges of a blobber weigh the most
+	reputationDecay int64 = 95
+	// initialReputation of a blobber without challenges is neutral, it has
+	// to pass challenges to be trusted more than an unknown blobber
+	initialReputation = reputationScale / 2
+)
+
+func blobberReputationKey(sscKey, blobberID string) datastore.Key {
+	return sscKey + ":reputation:" + blobberID
+}
+
+// blobberReputation is the exponentially decayed rate of the challenges
+// passed by a blobber, from 0 (all recent challenges failed) to
+// reputationScale.
+type blobberReputation struct {
+	Score  int64 `json:"score"`
+	Passed int64 `json:"passed"`
+	Failed int64 `json:"failed"`
+}
+
+func (br *blobberReputation) Encode() []byte {
+	var b, err = json.Marshal(br)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (br *blobberReputation) Decode(p []byte) error {
+	return json.Unmarshal(p, br)
+}
+
+// update the score with the result of a challenge
+func (br *blobberReputation) update(passed bool) {
+	var result int64
+	if passed {
+		result = reputationScale
+		br.Passed++
+	} else {
+		br.Failed++
+	}
+	br.Score = (reputationDecay*br.Score + (100-reputationDecay)*result) / 100
+}
+
+// rate of the score, from 0 to 1
+func (br *blobberReputation) rate() float64 {
+	return float64(br.Score) / float64(reputationScale)
+}
+
+// getBlobberReputation returns the reputation of the blobber, the initial
+// one if the blobber has no challenge result yet
+func getBlobberReputation(sscKey, blobberID string, balances cstate.CommonStateContextI) (*blobberReputation, error) {
+	br := &blobberReputation{}
+	err := balances.GetTrieNode(blobberReputationKey(sscKey, blobberID), br)
+	switch err {
+	case nil:
+		return br, nil
+	case util.ErrValueNotPresent:
+		return &blobberReputation{Score: initialReputation}, nil
+	default:
+		return nil, err
+	}
+}
+
+// updateBlobberReputation records the result of a challenge of the blobber,
+// on response or on expiration
+func updateBlobberReputation(sscKey, blobberID string, passed bool, balances cstate.StateContextI) error {
+	return cstate.WithActivation(balances, reputationHardFork, func() error {
+		return nil
+	}, func() error {
+		br, err := getBlobberReputation(sscKey, blobberID, balances)
+		if err != nil {
+			return fmt.Errorf("can't get blobber reputation: %v", err)
+		}
+		br.update(passed)
+		if _, err := balances.InsertTrieNode(blobberReputationKey(sscKey, blobberID), br); err != nil {
+			return fmt.Errorf("saving blobber reputation: %v", err)
+		}
+		balances.EmitEvent(event.TypeStats, event.TagUpdateBlobberReputation, blobberID, event.BlobberReputation{
+			BlobberID:  blobberID,
+			Reputation: br.rate(),
+		})
+		return nil
+	})
+}
@@ -0,0 +1,109 @@
+package storagesc
+
+import (
+	"testing"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobberReputation(t *testing.T) {
+	// the results of the challenges, oldest first
+	failing30 := make([]bool, 100)
+	for i := range failing30 {
+		failing30[i] = i%10 >= 3
+	}
+	passing := make([]bool, 100)
+	for i := range passing {
+		passing[i] = true
+	}
+	recentlyFailing := append(append([]bool{}, passing...), make([]bool, 20)...)
+
+	tests := []struct {
+		name     string
+		results  []bool
+		minScore int64
+		maxScore int64
+	}{
+		{
+			name:     "no challenge result",
+			minScore: initialReputation,
+			maxScore: initialReputation,
+		},
+		{
+			name:     "fails 30% of the challenges",
+			results:  failing30,
+			minScore: 6000,
+			maxScore: 8000,
+		},
+		{
+			name:     "passes all challenges",
+			results:  passing,
+			minScore: 9900,
+			maxScore: reputationScale,
+		},
+		{
+			name:     "recent failures weigh the most",
+			results:  recentlyFailing,
+			minScore: 0,
+			maxScore: 4000,
+		},
+		{
+			name:     "single failure of a new blobber",
+			results:  []bool{false},
+			minScore: initialReputation * reputationDecay / 100,
+			maxScore: initialReputation * reputationDecay / 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			balances := newTestBalances(t, false)
+			var passed, failed int64
+			for _, r := range tt.results {
+				require.NoError(t, updateBlobberReputation(ADDRESS, "b1", r, balances))
+				if r {
+					passed++
+				} else {
+					failed++
+				}
+			}
+
+			br, err := getBlobberReputation(ADDRESS, "b1", balances)
+			require.NoError(t, err)
+			require.Equal(t, passed, br.Passed)
+			require.Equal(t, failed, br.Failed)
+			require.GreaterOrEqual(t, br.Score, tt.minScore)
+			require.LessOrEqual(t, br.Score, tt.maxScore)
+		})
+	}
+}
+
+func TestBlobberReputationBeforeHardFork(t *testing.T) {
+	balances := newTestBalances(t, false)
+	_, err := balances.DeleteTrieNode(cstate.NewHardFork(reputationHardFork, 0).GetKey())
+	require.NoError(t, err)
+
+	require.NoError(t, updateBlobberReputation(ADDRESS, "b1", false, balances))
+	err = balances.GetTrieNode(blobberReputationKey(ADDRESS, "b1"), &blobberReputation{})
+	require.Equal(t, util.ErrValueNotPresent, err, "not tracked before the hard fork")
+}
+
+func TestNewAllocationRequestMinReputation(t *testing.T) {
+	conf := &Config{MinAllocSize: 1}
+	nar := newAllocationRequest{
+		DataShards:      1,
+		ParityShards:    1,
+		Size:            1,
+		Blobbers:        []string{"b1", "b2"},
+		ReadPriceRange:  PriceRange{Max: 1},
+		WritePriceRange: PriceRange{Max: 1},
+	}
+	nar.MinReputation = 0.8
+	require.NoError(t, nar.validate(conf))
+	nar.MinReputation = 1.5
+	require.Error(t, nar.validate(conf))
+	nar.MinReputation = -0.1
+	require.Error(t, nar.validate(conf))
+}
@@ -244,6 +244,16 @@ func (ssc *StorageSmartContract) saveStakePools(validators []datastore.Key,
 	return
 }
 
+// failedChallengesEvidence is the evidence of the slash of a blobber for the
+// challenges failed over the period: the latest failed challenge if the caller
+// knows it or the period otherwise
+func failedChallengesEvidence(failedChallengeID string, from, to common.Timestamp) string {
+	if failedChallengeID != "" {
+		return failedChallengeID
+	}
+	return fmt.Sprintf("challenges_failed:%d-%d", from, to)
+}
+
 // move tokens from challenge pool back to write pool
 func (sc *StorageSmartContract) blobberPenalty(
 	alloc *storageAllocationBase,
@@ -253,6 +263,7 @@ func (sc *StorageSmartContract) blobberPenalty(
 	validators []string,
 	balances cstate.StateContextI,
 	allocationID string,
+	failedChallengeID string,
 ) (err error) {
 	if latestSuccessfulChallTime >= latestFinalizedChallTime {
 		return nil
@@ -356,11 +367,19 @@ func (sc *StorageSmartContract) blobberPenalty(
 			return fmt.Errorf("can't get blobber's stake pool: %v", err)
 		}
 
-		dpMove, err := sp.slash(blobAlloc.BlobberID, blobAlloc.Offer(), slash, balances, allocationID)
+		dpMove, penalties, err := sp.slash(blobAlloc.BlobberID, blobAlloc.Offer(), slash, balances, allocationID,
+			failedChallengesEvidence(failedChallengeID, latestSuccessfulChallTime, latestFinalizedChallTime))
 		if err != nil {
 			return fmt.Errorf("can't slash tokens: %v", err)
 		}
 
+		// the slash is held in escrow while the failures are disputed
+		err = sc.escrowChallengeSlash(alloc.ID, blobAlloc.BlobberID, latestSuccessfulChallTime,
+			latestFinalizedChallTime, penalties, cp, balances)
+		if err != nil {
+			return fmt.Errorf("can't escrow slashed tokens: %v", err)
+		}
+
 		penalty, err := currency.AddCoin(blobAlloc.Penalty, dpMove) // penalty statistic
 		if err != nil {
 			return err
@@ -589,7 +608,7 @@ func (sc *StorageSmartContract) processChallengePassed(
 
 	alloc := cab.alloc.mustBase()
 
-	err := alloc.removeOldChallenges(cab.allocChallenges, balances, cab.challenge, sc)
+	failedChallengeID, err := alloc.removeOldChallenges(cab.allocChallenges, balances, cab.challenge, sc)
 	if err != nil {
 		return "failed to remove old allocation challenges", common.NewError("challenge_reward_error",
 			"error removing old challenges: "+err.Error())
@@ -671,6 +690,14 @@ func (sc *StorageSmartContract) processChallengePassed(
 	cab.blobAlloc.Stats.SuccessChallenges++
 	cab.blobAlloc.Stats.OpenChallenges--
 
+	if err := sc.resetAutoRepairFailures(alloc.ID, cab.blobAlloc.BlobberID, balances); err != nil {
+		return "", common.NewError("verify_challenge", err.Error())
+	}
+
+	if err := updateBlobberReputation(sc.ID, cab.challenge.BlobberID, true, balances); err != nil {
+		return "", common.NewError("verify_challenge", err.Error())
+	}
+
 	if err := cab.challenge.Save(balances, sc.ID); err != nil {
 		return "", common.NewError("verify_challenge_error", err.Error())
 	}
@@ -703,6 +730,7 @@ func (sc *StorageSmartContract) processChallengePassed(
 			alloc, cab.latestSuccessfulChallTime, cab.latestFinalizedChallTime, cab.blobAlloc, validators,
 			balances,
 			cab.challenge.AllocationID,
+			failedChallengeID,
 		)
 		if err != nil {
 			return "", common.NewError("challenge_penalty_error", err.Error())
@@ -760,7 +788,21 @@ func (sc *StorageSmartContract) processChallengeFailed(
 	cab.blobAlloc.Stats.FailedChallenges++
 	cab.blobAlloc.Stats.OpenChallenges--
 
-	err := emitUpdateChallenge(cab.challenge, false, ChallengeRespondedInvalid, balances, alloc.Stats)
+	if err := updateBlobberReputation(sc.ID, cab.challenge.BlobberID, false, balances); err != nil {
+		return "", common.NewError("challenge_penalty_error", err.Error())
+	}
+
+	repairDue, err := sc.countAutoRepairFailure(alloc.ID, cab.challenge.BlobberID, balances)
+	if err != nil {
+		return "", common.NewError("challenge_penalty_error", err.Error())
+	}
+
+	err = sc.openChallengeDispute(alloc.ID, cab.challenge.ID, cab.challenge, balances)
+	if err != nil {
+		return "", common.NewError("challenge_penalty_error", err.Error())
+	}
+
+	err = emitUpdateChallenge(cab.challenge, false, ChallengeRespondedInvalid, balances, alloc.Stats)
 	if err != nil {
 		return "", err
 	}
@@ -785,6 +827,12 @@ func (sc *StorageSmartContract) processChallengeFailed(
 		return "", common.NewError("challenge_reward_error", err.Error())
 	}
 
+	if repairDue {
+		if _, err := sc.tryAutoRepair(balances.GetTransaction(), alloc.ID, balances); err != nil {
+			return "", common.NewError("challenge_penalty_error", err.Error())
+		}
+	}
+
 	return "Challenge Failed by Blobber", nil
 }
 
@@ -1115,6 +1163,17 @@ func (sc *StorageSmartContract) populateGenerateChallenge(
 		Timestamp:        lwm.Timestamp,
 	}
 
+	sm, err := sc.findShardMigration(alloc.mustBase().ID, balances)
+	if err != nil {
+		return nil, err
+	}
+	if sm != nil {
+		if root := sm.sourceRoot(blobberID); root != nil && root.AllocationRoot != challInfo.AllocationRoot {
+			challInfo.SourceAllocationRoot = root.AllocationRoot
+			challInfo.SourceTimestamp = root.Timestamp
+		}
+	}
+
 	allocChallenges, err := sc.getAllocationChallenges(alloc.mustBase().ID, balances)
 	if err != nil {
 		if err == util.ErrValueNotPresent {
@@ -1192,20 +1251,33 @@ func (sc *StorageSmartContract) genChal(
 		return nil
 	}
 
-	hashSeed := encryption.Hash(t.Hash + b.PrevHash)
-	// the "1" was the index when generating multiple challenges.
-	// keep it in case we need to generate more than 1 challenge at once.
-	challengeID := encryption.Hash(hashSeed + "1")
+	var (
+		challengeID string
+		seed        int64
+	)
+	if actErr := cstate.WithActivation(balances, vrfChallengeHardFork, func() error {
+		hashSeed := encryption.Hash(t.Hash + b.PrevHash)
+		// the "1" was the index when generating multiple challenges.
+		// keep it in case we need to generate more than 1 challenge at once.
+		challengeID = encryption.Hash(hashSeed + "1")
 
-	seedSource, err := strconv.ParseUint(challengeID[0:16], 16, 64)
-	if err != nil {
+		seedSource, err := strconv.ParseUint(challengeID[0:16], 16, 64)
+		if err != nil {
+			return err
+		}
+		seed = int64(seedSource)
+		return nil
+	}, func() (err error) {
+		challengeID, seed, err = deriveChallenge(b.Round, b.GetRoundRandomSeed())
+		return err
+	}); actErr != nil {
 		return common.NewErrorf("generate_challenge",
-			"Error in creating challenge seed: %v", err)
+			"Error in creating challenge seed: %v", actErr)
 	}
 
 	result, err := sc.populateGenerateChallenge(
 		partsWeight,
-		int64(seedSource),
+		seed,
 		validators,
 		t,
 		challengeID,
@@ -1297,7 +1369,19 @@ func (sc *StorageSmartContract) addChallenge(sa *StorageAllocation,
 	}
 
 	beforeEmitAddChallenge(challInfo)
-	return emitAddChallenge(challInfo, lenExpired, balances, alloc.Stats)
+	if err := emitAddChallenge(challInfo, lenExpired, balances, alloc.Stats); err != nil {
+		return err
+	}
+
+	// the blobbers that failed too many challenges in a row are replaced
+	// once the expired challenges are counted
+	if lenExpired > 0 {
+		if _, err := sc.tryAutoRepair(balances.GetTransaction(), alloc.ID, balances); err != nil {
+			return common.NewErrorf("add_challenge",
+				"error repairing allocation: %v", err)
+		}
+	}
+	return nil
 }
 
 func isChallengeExpired(currentRound, roundCreatedAt, maxChallengeCompletionRounds int64) bool {
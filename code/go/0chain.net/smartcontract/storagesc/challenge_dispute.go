@@ -0,0 +1,632 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/logging"
+	"github.com/0chain/common/core/util"
+	"go.uber.org/zap"
+)
+
+//msgp:ignore challengeDisputeRequest
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+// maxChallengeDisputesPerAllocation bounds the open disputes of an
+// allocation, the challenges failed over it can't be disputed
+const maxChallengeDisputesPerAllocation = 50
+
+const (
+	challengeDisputeOpen       = "open"
+	challengeDisputeOverturned = "overturned"
+	challengeDisputeUpheld     = "upheld"
+)
+
+// challengeDisputeRequest is the input of dispute_challenge, the late proof
+// of a failed challenge validated by validators other than the ones of the
+// challenge
+type challengeDisputeRequest struct {
+	AllocationID      string              `json:"allocation_id"`
+	ChallengeID       string              `json:"challenge_id"`
+	ValidationTickets []*ValidationTicket `json:"validation_tickets"`
+}
+
+func (cdr *challengeDisputeRequest) decode(b []byte) error {
+	return json.Unmarshal(b, cdr)
+}
+
+func (cdr *challengeDisputeRequest) validate() error {
+	switch {
+	case cdr.AllocationID == "":
+		return errors.New("missing allocation_id")
+	case cdr.ChallengeID == "":
+		return errors.New("missing challenge_id")
+	case len(cdr.ValidationTickets) == 0:
+		return errors.New("missing validation_tickets")
+	}
+	return nil
+}
+
+func challengeDisputesKey(sscKey, allocID string) datastore.Key {
+	return sscKey + ":challengedisputes:" + allocID
+}
+
+// challengeDispute is a failed challenge the blobber can dispute until the
+// deadline. The slash of the blobber for the failure is held in escrow in the
+// challenge pool of the allocation meanwhile: it's given back to the
+// delegates when the dispute is overturned and dropped when the dispute is
+// upheld at the deadline.
+type challengeDispute struct {
+	ChallengeID        string           `json:"challenge_id"`
+	BlobberID          string           `json:"blobber_id"`
+	ChallengeCreatedAt common.Timestamp `json:"challenge_created_at"`
+	// Validators of the challenge, they can't validate the late proof
+	Validators      []string `json:"validators"`
+	TotalValidators int      `json:"total_validators"`
+	// DisputeValidators are picked by the SC to validate the late proof
+	DisputeValidators []string         `json:"dispute_validators"`
+	Deadline          common.Timestamp `json:"deadline"`
+	Escrow            currency.Coin    `json:"escrow"`
+	// DelegateEscrow is the escrow slashed from each delegate pool
+	DelegateEscrow map[string]currency.Coin `json:"delegate_escrow"`
+}
+
+func (cd *challengeDispute) isOpen(now common.Timestamp) bool {
+	return now <= cd.Deadline
+}
+
+func (cd *challengeDispute) addEscrow(delegateID string, value currency.Coin) error {
+	escrow, err := currency.AddCoin(cd.Escrow, value)
+	if err != nil {
+		return err
+	}
+	delegateEscrow, err := currency.AddCoin(cd.DelegateEscrow[delegateID], value)
+	if err != nil {
+		return err
+	}
+	if cd.DelegateEscrow == nil {
+		cd.DelegateEscrow = make(map[string]currency.Coin)
+	}
+	cd.Escrow, cd.DelegateEscrow[delegateID] = escrow, delegateEscrow
+	return nil
+}
+
+// challengeDisputes are the disputes of the failed challenges of an
+// allocation
+type challengeDisputes struct {
+	AllocationID string              `json:"allocation_id"`
+	Disputes     []*challengeDispute `json:"disputes"`
+}
+
+func (cds *challengeDisputes) Encode() []byte {
+	var b, err = json.Marshal(cds)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (cds *challengeDisputes) Decode(p []byte) error {
+	return json.Unmarshal(p, cds)
+}
+
+func (cds *challengeDisputes) save(sscKey string, balances cstate.StateContextI) error {
+	if len(cds.Disputes) == 0 {
+		_, err := balances.DeleteTrieNode(challengeDisputesKey(sscKey, cds.AllocationID))
+		if err == util.ErrValueNotPresent {
+			return nil
+		}
+		return err
+	}
+	_, err := balances.InsertTrieNode(challengeDisputesKey(sscKey, cds.AllocationID), cds)
+	return err
+}
+
+func (cds *challengeDisputes) find(challengeID string) (int, bool) {
+	for i, cd := range cds.Disputes {
+		if cd.ChallengeID == challengeID {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func (cds *challengeDisputes) remove(i int) {
+	cds.Disputes = append(cds.Disputes[:i], cds.Disputes[i+1:]...)
+}
+
+// release takes the escrow of the dispute out of the challenge pool, there's
+// no challenge pool to release from when nothing is escrowed
+func (cd *challengeDispute) release(cp *challengePool) error {
+	if cd.Escrow == 0 {
+		return nil
+	}
+	return cp.releaseEscrow(cd.Escrow)
+}
+
+// uphold the disputes closed at the given time, their escrow is dropped from
+// the challenge pool
+func (cds *challengeDisputes) uphold(cp *challengePool, now common.Timestamp,
+	balances cstate.StateContextI) error {
+
+	var open []*challengeDispute
+	for _, cd := range cds.Disputes {
+		if cd.isOpen(now) {
+			open = append(open, cd)
+			continue
+		}
+		if err := cd.release(cp); err != nil {
+			return err
+		}
+		emitChallengeDispute(cds.AllocationID, cd, challengeDisputeUpheld, balances)
+	}
+	cds.Disputes = open
+	return nil
+}
+
+// escrow the slash of the delegates of the blobber for the challenges failed
+// over the period in the challenge pool, it's split between the open disputes
+// of the failures. It returns false if none of the failures is disputable,
+// the slash is final then.
+func (cds *challengeDisputes) escrow(cp *challengePool, blobberID string,
+	from, to common.Timestamp, penalties map[string]currency.Coin,
+	now common.Timestamp) (bool, error) {
+
+	var disputed []*challengeDispute
+	for _, cd := range cds.Disputes {
+		if cd.BlobberID == blobberID && cd.isOpen(now) &&
+			cd.ChallengeCreatedAt > from && cd.ChallengeCreatedAt <= to {
+			disputed = append(disputed, cd)
+		}
+	}
+	if len(disputed) == 0 {
+		return false, nil
+	}
+
+	delegates := make([]string, 0, len(penalties))
+	for id := range penalties {
+		delegates = append(delegates, id)
+	}
+	sort.Strings(delegates)
+
+	var total currency.Coin
+	for _, id := range delegates {
+		penalty := penalties[id]
+		share, rest, err := currency.DistributeCoin(penalty, int64(len(disputed)))
+		if err != nil {
+			return false, err
+		}
+		for i, cd := range disputed {
+			value := share
+			if i == 0 {
+				value += rest
+			}
+			if err := cd.addEscrow(id, value); err != nil {
+				return false, err
+			}
+		}
+		if total, err = currency.AddCoin(total, penalty); err != nil {
+			return false, err
+		}
+	}
+
+	if err := cp.holdEscrow(total); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (sc *StorageSmartContract) getChallengeDisputes(allocID string,
+	balances cstate.CommonStateContextI) (*challengeDisputes, error) {
+
+	cds := &challengeDisputes{AllocationID: allocID}
+	err := balances.GetTrieNode(challengeDisputesKey(sc.ID, allocID), cds)
+	switch err {
+	case nil, util.ErrValueNotPresent:
+		return cds, nil
+	default:
+		return nil, fmt.Errorf("can't get challenge disputes: %v", err)
+	}
+}
+
+// openChallengeDispute lets the blobber dispute the failed challenge, the
+// challenge is loaded when not given. Nothing is done when the disputes are
+// disabled or the challenge is gone.
+func (sc *StorageSmartContract) openChallengeDispute(allocID, challengeID string,
+	challenge *StorageChallenge, balances cstate.StateContextI) error {
+
+	conf, err := sc.getConfig(balances, true)
+	if err != nil {
+		return fmt.Errorf("can't get SC configurations: %v", err)
+	}
+	if conf.ChallengeDisputePeriod <= 0 {
+		return nil
+	}
+
+	if challenge == nil {
+		challenge, err = sc.getStorageChallenge(challengeID, balances)
+		switch err {
+		case nil:
+		case util.ErrValueNotPresent:
+			return nil
+		default:
+			return fmt.Errorf("can't get challenge: %v", err)
+		}
+	}
+
+	cds, err := sc.getChallengeDisputes(allocID, balances)
+	if err != nil {
+		return err
+	}
+	if _, ok := cds.find(challenge.ID); ok {
+		return nil
+	}
+	if len(cds.Disputes) >= maxChallengeDisputesPerAllocation {
+		logging.Logger.Info("challenge not disputable, too many open disputes",
+			zap.String("allocation_id", allocID),
+			zap.String("challenge_id", challenge.ID))
+		return nil
+	}
+
+	disputeValidators, err := sc.selectDisputeValidators(challenge, conf, balances)
+	if err != nil {
+		return fmt.Errorf("selecting dispute validators: %v", err)
+	}
+	if len(disputeValidators) < challenge.TotalValidators {
+		logging.Logger.Info("challenge not disputable, not enough validators",
+			zap.String("allocation_id", allocID),
+			zap.String("challenge_id", challenge.ID),
+			zap.Int("validators", len(disputeValidators)))
+		return nil
+	}
+
+	cd := &challengeDispute{
+		ChallengeID:        challenge.ID,
+		BlobberID:          challenge.BlobberID,
+		ChallengeCreatedAt: challenge.Created,
+		Validators:         challenge.ValidatorIDs,
+		TotalValidators:    challenge.TotalValidators,
+		DisputeValidators:  disputeValidators,
+		Deadline:           balances.GetTransaction().CreationDate + toSeconds(conf.ChallengeDisputePeriod),
+	}
+	cds.Disputes = append(cds.Disputes, cd)
+	if err := cds.save(sc.ID, balances); err != nil {
+		return fmt.Errorf("saving challenge disputes: %v", err)
+	}
+	emitChallengeDispute(allocID, cd, challengeDisputeOpen, balances)
+	return nil
+}
+
+// selectDisputeValidators picks the validators of the late proof of the
+// failed challenge. The pick is seeded by the challenge, so every node makes
+// the same one, and leaves out the blobber and the validators of the
+// challenge.
+func (sc *StorageSmartContract) selectDisputeValidators(challenge *StorageChallenge,
+	conf *Config, balances cstate.StateContextI) ([]string, error) {
+
+	validators, err := getValidatorsList(balances)
+	if err != nil {
+		return nil, fmt.Errorf("can't get validators list: %v", err)
+	}
+	size, err := validators.Size(balances)
+	if err != nil {
+		return nil, err
+	}
+	if size == 0 {
+		return nil, nil
+	}
+
+	seed, err := strconv.ParseUint(encryption.Hash("dispute:" + challenge.ID)[0:16], 16, 64)
+	if err != nil {
+		return nil, err
+	}
+	r := rand.New(rand.NewSource(int64(seed)))
+	var randValidators []ValidationPartitionNode
+	if err := validators.GetRandomItems(balances, r, &randValidators); err != nil {
+		return nil, fmt.Errorf("error getting validators random slice: %v", err)
+	}
+
+	excluded := make(map[string]struct{}, len(challenge.ValidatorIDs)+1)
+	excluded[challenge.BlobberID] = struct{}{}
+	for _, id := range challenge.ValidatorIDs {
+		excluded[id] = struct{}{}
+	}
+
+	var (
+		selected        = make([]string, 0, challenge.TotalValidators)
+		filterValidator = filterHealthyValidators(balances.GetTransaction().CreationDate)
+	)
+	for _, i := range r.Perm(len(randValidators)) {
+		if len(selected) == challenge.TotalValidators {
+			break
+		}
+		id := randValidators[i].Id
+		if _, ok := excluded[id]; ok {
+			continue
+		}
+		validator, err := getValidator(id, balances)
+		if err != nil {
+			if cstate.ErrInvalidState(err) {
+				return nil, err
+			}
+			continue
+		}
+		if validator.IsKilled() || validator.IsShutDown() {
+			continue
+		}
+		if kick, err := filterValidator(validator); err != nil {
+			return nil, err
+		} else if kick {
+			continue
+		}
+		sp, err := sc.getStakePool(spenum.Validator, id, balances)
+		if err != nil {
+			return nil, fmt.Errorf("can't get validator %s stake pool: %v", id, err)
+		}
+		stake, err := sp.stake()
+		if err != nil {
+			return nil, err
+		}
+		if stake < conf.MinStake {
+			continue
+		}
+		selected = append(selected, id)
+	}
+	return selected, nil
+}
+
+// escrowChallengeSlash holds the slash of the blobber in escrow in the
+// challenge pool while the challenges failed over the period are disputable.
+// The caller saves the challenge pool.
+func (sc *StorageSmartContract) escrowChallengeSlash(allocID, blobberID string,
+	from, to common.Timestamp, penalties map[string]currency.Coin,
+	cp *challengePool, balances cstate.StateContextI) error {
+
+	cds, err := sc.getChallengeDisputes(allocID, balances)
+	if err != nil {
+		return err
+	}
+	if len(cds.Disputes) == 0 {
+		return nil
+	}
+
+	now := balances.GetTransaction().CreationDate
+	if err := cds.uphold(cp, now, balances); err != nil {
+		return fmt.Errorf("upholding challenge disputes: %v", err)
+	}
+	escrowed, err := cds.escrow(cp, blobberID, from, to, penalties, now)
+	if err != nil {
+		return fmt.Errorf("escrowing slash: %v", err)
+	}
+	if escrowed {
+		for _, cd := range cds.Disputes {
+			if cd.BlobberID == blobberID {
+				emitChallengeDispute(allocID, cd, challengeDisputeOpen, balances)
+			}
+		}
+	}
+	return cds.save(sc.ID, balances)
+}
+
+// closeChallengeDisputes upholds the disputes of a finishing allocation,
+// including the ones escrowing the penalties of its finalization. Their
+// escrow is dropped from the challenge pool, which is nil for the allocations
+// without one.
+func (sc *StorageSmartContract) closeChallengeDisputes(alloc *storageAllocationBase,
+	cp *challengePool, balances cstate.StateContextI) error {
+
+	cds, err := sc.getChallengeDisputes(alloc.ID, balances)
+	if err != nil {
+		return err
+	}
+	if len(cds.Disputes) == 0 {
+		return nil
+	}
+
+	// all the disputes close with the allocation
+	if err := cds.uphold(cp, common.Timestamp(math.MaxInt64), balances); err != nil {
+		return err
+	}
+	return cds.save(sc.ID, balances)
+}
+
+// disputeChallenge overturns a failed challenge of the blobber with a late
+// proof validated by the validators picked for the dispute. The
+// stats of the allocation count the challenge as passed and the slash
+// escrowed in the challenge pool goes back to the delegates of the blobber.
+func (sc *StorageSmartContract) disputeChallenge(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req challengeDisputeRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("dispute_challenge_failed",
+			"invalid request: "+err.Error())
+	}
+	if err := req.validate(); err != nil {
+		return "", common.NewError("dispute_challenge_failed", err.Error())
+	}
+
+	sa, err := sc.getAllocation(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("dispute_challenge_failed", err.Error())
+	}
+	alloc := sa.mustBase()
+	if alloc.Finalized || alloc.Canceled {
+		return "", common.NewError("dispute_challenge_failed",
+			"allocation is finalized")
+	}
+
+	cds, err := sc.getChallengeDisputes(alloc.ID, balances)
+	if err != nil {
+		return "", common.NewError("dispute_challenge_failed", err.Error())
+	}
+	i, ok := cds.find(req.ChallengeID)
+	if !ok {
+		return "", common.NewError("dispute_challenge_failed",
+			"no dispute of the challenge")
+	}
+	cd := cds.Disputes[i]
+	if cd.BlobberID != t.ClientID {
+		return "", common.NewError("dispute_challenge_failed",
+			"only the challenged blobber can dispute the challenge")
+	}
+	if !cd.isOpen(t.CreationDate) {
+		return "", common.NewError("dispute_challenge_failed",
+			"dispute window is closed")
+	}
+	ba, ok := alloc.BlobberAllocsMap[cd.BlobberID]
+	if !ok {
+		return "", common.NewError("dispute_challenge_failed",
+			"blobber is not part of the allocation")
+	}
+
+	// the late proof is verified like a challenge response, by the
+	// validators picked for the dispute
+	challenge := &StorageChallenge{
+		ID:              cd.ChallengeID,
+		AllocationID:    alloc.ID,
+		BlobberID:       cd.BlobberID,
+		TotalValidators: len(cd.DisputeValidators),
+		ValidatorIDs:    cd.DisputeValidators,
+		ValidatorIDMap:  make(map[string]struct{}, len(cd.DisputeValidators)),
+	}
+	for _, id := range cd.DisputeValidators {
+		challenge.ValidatorIDMap[id] = struct{}{}
+	}
+	for _, vt := range req.ValidationTickets {
+		if vt == nil {
+			continue
+		}
+		if _, ok := challenge.ValidatorIDMap[vt.ValidatorID]; !ok {
+			return "", common.NewError("dispute_challenge_failed",
+				"validator "+vt.ValidatorID+" is not picked for the dispute")
+		}
+	}
+	result, err := verifyChallengeTickets(balances, challenge, &ChallengeResponse{
+		ID:                cd.ChallengeID,
+		ValidationTickets: req.ValidationTickets,
+	})
+	if err != nil {
+		return "", common.NewError("dispute_challenge_failed",
+			"invalid late proof: "+err.Error())
+	}
+	if !result.pass {
+		return "", common.NewError("dispute_challenge_failed",
+			"late proof rejected by the validators")
+	}
+
+	cp, err := sc.getChallengePool(alloc.ID, balances)
+	if err != nil {
+		return "", common.NewError("dispute_challenge_failed",
+			"can't get allocation's challenge pool: "+err.Error())
+	}
+	sp, err := sc.getStakePool(spenum.Blobber, cd.BlobberID, balances)
+	if err != nil {
+		return "", common.NewError("dispute_challenge_failed",
+			"can't get blobber's stake pool: "+err.Error())
+	}
+	if err := cds.refund(alloc.ID, cd, cp, sp, balances); err != nil {
+		return "", common.NewError("dispute_challenge_failed", err.Error())
+	}
+	if err := sp.Save(spenum.Blobber, cd.BlobberID, balances); err != nil {
+		return "", common.NewError("dispute_challenge_failed",
+			"can't save blobber's stake pool: "+err.Error())
+	}
+
+	cds.remove(i)
+	if err := cds.uphold(cp, t.CreationDate, balances); err != nil {
+		return "", common.NewError("dispute_challenge_failed", err.Error())
+	}
+	if err := cp.save(sc.ID, alloc, balances); err != nil {
+		return "", common.NewError("dispute_challenge_failed",
+			"can't save allocation's challenge pool: "+err.Error())
+	}
+	if err := cds.save(sc.ID, balances); err != nil {
+		return "", common.NewError("dispute_challenge_failed",
+			"saving challenge disputes: "+err.Error())
+	}
+	emitChallengeDispute(alloc.ID, cd, challengeDisputeOverturned, balances)
+
+	ba.Stats.FailedChallenges--
+	ba.Stats.SuccessChallenges++
+	alloc.Stats.FailedChallenges--
+	alloc.Stats.SuccessChallenges++
+	_ = sa.mustUpdateBase(func(base *storageAllocationBase) error {
+		alloc.deepCopy(base)
+		return nil
+	})
+	if _, err := balances.InsertTrieNode(sa.GetKey(sc.ID), sa); err != nil {
+		return "", common.NewError("dispute_challenge_failed",
+			"saving allocation: "+err.Error())
+	}
+	emitUpdateAllocationStatEvent(alloc, balances)
+
+	return toJson(cd), nil
+}
+
+// refund gives the escrow of the dispute back from the challenge pool to the
+// delegate pools still staking on the blobber, the rest of it is dropped like
+// an upheld one
+func (cds *challengeDisputes) refund(allocID string, cd *challengeDispute,
+	cp *challengePool, sp *stakePool, balances cstate.StateContextI) error {
+
+	if err := cd.release(cp); err != nil {
+		return err
+	}
+	escrow := cd.Escrow
+
+	refund := stakepool.NewStakePoolReward(cd.BlobberID, spenum.Blobber,
+		spenum.ChallengeDisputeRefund, sp.Settings.DelegateWallet, allocID)
+	delegates := make([]string, 0, len(cd.DelegateEscrow))
+	for id := range cd.DelegateEscrow {
+		delegates = append(delegates, id)
+	}
+	sort.Strings(delegates)
+	for _, id := range delegates {
+		dp, ok := sp.Pools[id]
+		if !ok {
+			continue
+		}
+		value := cd.DelegateEscrow[id]
+		var err error
+		if escrow, err = currency.MinusCoin(escrow, value); err != nil {
+			return fmt.Errorf("escrow of delegate %s exceeds the escrow of the dispute: %v", id, err)
+		}
+		if dp.Balance, err = currency.AddCoin(dp.Balance, value); err != nil {
+			return err
+		}
+		refund.DelegateRewards[id] = value
+	}
+	return refund.Emit(event.TagStakePoolReward, balances)
+}
+
+func emitChallengeDispute(allocID string, cd *challengeDispute, status string,
+	balances cstate.StateContextI) {
+
+	balances.EmitEvent(event.TypeStats, event.TagUpdateChallengeDispute, cd.ChallengeID,
+		event.ChallengeDispute{
+			ChallengeID:  cd.ChallengeID,
+			AllocationID: allocID,
+			BlobberID:    cd.BlobberID,
+			Status:       status,
+			Escrow:       cd.Escrow,
+			Deadline:     int64(cd.Deadline),
+			Round:        balances.GetBlock().Round,
+		})
+}
@@ -0,0 +1,331 @@
+package storagesc
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/0chain/common/core/currency"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *challengeDispute) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 9
+	// string "ChallengeID"
+	o = append(o, 0x89, 0xab, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x49, 0x44)
+	o = msgp.AppendString(o, z.ChallengeID)
+	// string "BlobberID"
+	o = append(o, 0xa9, 0x42, 0x6c, 0x6f, 0x62, 0x62, 0x65, 0x72, 0x49, 0x44)
+	o = msgp.AppendString(o, z.BlobberID)
+	// string "ChallengeCreatedAt"
+	o = append(o, 0xb2, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o, err = z.ChallengeCreatedAt.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "ChallengeCreatedAt")
+		return
+	}
+	// string "Validators"
+	o = append(o, 0xaa, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Validators)))
+	for za0001 := range z.Validators {
+		o = msgp.AppendString(o, z.Validators[za0001])
+	}
+	// string "TotalValidators"
+	o = append(o, 0xaf, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73)
+	o = msgp.AppendInt(o, z.TotalValidators)
+	// string "DisputeValidators"
+	o = append(o, 0xb1, 0x44, 0x69, 0x73, 0x70, 0x75, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.DisputeValidators)))
+	for za0002 := range z.DisputeValidators {
+		o = msgp.AppendString(o, z.DisputeValidators[za0002])
+	}
+	// string "Deadline"
+	o = append(o, 0xa8, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65)
+	o, err = z.Deadline.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Deadline")
+		return
+	}
+	// string "Escrow"
+	o = append(o, 0xa6, 0x45, 0x73, 0x63, 0x72, 0x6f, 0x77)
+	o, err = z.Escrow.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Escrow")
+		return
+	}
+	// string "DelegateEscrow"
+	o = append(o, 0xae, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x45, 0x73, 0x63, 0x72, 0x6f, 0x77)
+	o = msgp.AppendMapHeader(o, uint32(len(z.DelegateEscrow)))
+	keys_za0003 := make([]string, 0, len(z.DelegateEscrow))
+	for k := range z.DelegateEscrow {
+		keys_za0003 = append(keys_za0003, k)
+	}
+	msgp.Sort(keys_za0003)
+	for _, k := range keys_za0003 {
+		za0004 := z.DelegateEscrow[k]
+		o = msgp.AppendString(o, k)
+		o, err = za0004.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "DelegateEscrow", k)
+			return
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *challengeDispute) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ChallengeID":
+			z.ChallengeID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ChallengeID")
+				return
+			}
+		case "BlobberID":
+			z.BlobberID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BlobberID")
+				return
+			}
+		case "ChallengeCreatedAt":
+			bts, err = z.ChallengeCreatedAt.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ChallengeCreatedAt")
+				return
+			}
+		case "Validators":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Validators")
+				return
+			}
+			if cap(z.Validators) >= int(zb0002) {
+				z.Validators = (z.Validators)[:zb0002]
+			} else {
+				z.Validators = make([]string, zb0002)
+			}
+			for za0001 := range z.Validators {
+				z.Validators[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Validators", za0001)
+					return
+				}
+			}
+		case "TotalValidators":
+			z.TotalValidators, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TotalValidators")
+				return
+			}
+		case "DisputeValidators":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DisputeValidators")
+				return
+			}
+			if cap(z.DisputeValidators) >= int(zb0003) {
+				z.DisputeValidators = (z.DisputeValidators)[:zb0003]
+			} else {
+				z.DisputeValidators = make([]string, zb0003)
+			}
+			for za0002 := range z.DisputeValidators {
+				z.DisputeValidators[za0002], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "DisputeValidators", za0002)
+					return
+				}
+			}
+		case "Deadline":
+			bts, err = z.Deadline.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Deadline")
+				return
+			}
+		case "Escrow":
+			bts, err = z.Escrow.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Escrow")
+				return
+			}
+		case "DelegateEscrow":
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DelegateEscrow")
+				return
+			}
+			if z.DelegateEscrow == nil {
+				z.DelegateEscrow = make(map[string]currency.Coin, zb0004)
+			} else if len(z.DelegateEscrow) > 0 {
+				for key := range z.DelegateEscrow {
+					delete(z.DelegateEscrow, key)
+				}
+			}
+			for zb0004 > 0 {
+				var za0003 string
+				var za0004 currency.Coin
+				zb0004--
+				za0003, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "DelegateEscrow")
+					return
+				}
+				bts, err = za0004.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "DelegateEscrow", za0003)
+					return
+				}
+				z.DelegateEscrow[za0003] = za0004
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *challengeDispute) Msgsize() (s int) {
+	s = 1 + 12 + msgp.StringPrefixSize + len(z.ChallengeID) + 10 + msgp.StringPrefixSize + len(z.BlobberID) + 19 + z.ChallengeCreatedAt.Msgsize() + 11 + msgp.ArrayHeaderSize
+	for za0001 := range z.Validators {
+		s += msgp.StringPrefixSize + len(z.Validators[za0001])
+	}
+	s += 16 + msgp.IntSize + 18 + msgp.ArrayHeaderSize
+	for za0002 := range z.DisputeValidators {
+		s += msgp.StringPrefixSize + len(z.DisputeValidators[za0002])
+	}
+	s += 9 + z.Deadline.Msgsize() + 7 + z.Escrow.Msgsize() + 15 + msgp.MapHeaderSize
+	if z.DelegateEscrow != nil {
+		for za0003, za0004 := range z.DelegateEscrow {
+			_ = za0004
+			s += msgp.StringPrefixSize + len(za0003) + za0004.Msgsize()
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *challengeDisputes) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "AllocationID"
+	o = append(o, 0x82, 0xac, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44)
+	o = msgp.AppendString(o, z.AllocationID)
+	// string "Disputes"
+	o = append(o, 0xa8, 0x44, 0x69, 0x73, 0x70, 0x75, 0x74, 0x65, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Disputes)))
+	for za0001 := range z.Disputes {
+		if z.Disputes[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Disputes[za0001].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Disputes", za0001)
+				return
+			}
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *challengeDisputes) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "AllocationID":
+			z.AllocationID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AllocationID")
+				return
+			}
+		case "Disputes":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Disputes")
+				return
+			}
+			if cap(z.Disputes) >= int(zb0002) {
+				z.Disputes = (z.Disputes)[:zb0002]
+			} else {
+				z.Disputes = make([]*challengeDispute, zb0002)
+			}
+			for za0001 := range z.Disputes {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Disputes[za0001] = nil
+				} else {
+					if z.Disputes[za0001] == nil {
+						z.Disputes[za0001] = new(challengeDispute)
+					}
+					bts, err = z.Disputes[za0001].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Disputes", za0001)
+						return
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *challengeDisputes) Msgsize() (s int) {
+	s = 1 + 13 + msgp.StringPrefixSize + len(z.AllocationID) + 9 + msgp.ArrayHeaderSize
+	for za0001 := range z.Disputes {
+		if z.Disputes[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Disputes[za0001].Msgsize()
+		}
+	}
+	return
+}
@@ -0,0 +1,319 @@
+package storagesc
+
+import (
+	"testing"
+	"time"
+
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/require"
+)
+
+type challengeDisputeTestEnv struct {
+	t          *testing.T
+	ssc        *StorageSmartContract
+	balances   *testBalances
+	owner      *Client
+	allocID    string
+	blobberID  string
+	validators map[string]*Client
+	// original are the validators of the challenges
+	original []*Client
+	tp       int64
+}
+
+func newChallengeDisputeTestEnv(t *testing.T) *challengeDisputeTestEnv {
+	env := &challengeDisputeTestEnv{
+		t:          t,
+		ssc:        newTestStorageSC(),
+		balances:   newTestBalances(t, false),
+		validators: make(map[string]*Client),
+		tp:         100,
+	}
+	env.owner = newClient(1000*x10, env.balances)
+	env.allocID, _ = addAllocation(t, env.ssc, env.owner, env.tp, 0, 0, 0, 0, 0,
+		env.balances, false, false, false)
+	conf := setConfig(t, env.balances)
+	conf.ChallengeDisputePeriod = time.Hour
+	_, err := env.balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
+	require.NoError(t, err)
+
+	env.blobberID = env.alloc().BlobberAllocs[0].BlobberID
+	for i := 0; i < 4; i++ {
+		v := addValidator(t, env.ssc, env.tp, env.balances)
+		env.validators[v.id] = v
+		if i < 2 {
+			env.original = append(env.original, v)
+		}
+	}
+	return env
+}
+
+func (env *challengeDisputeTestEnv) alloc() *storageAllocationBase {
+	sa, err := env.ssc.getAllocation(env.allocID, env.balances)
+	require.NoError(env.t, err)
+	return sa.mustBase()
+}
+
+func (env *challengeDisputeTestEnv) disputes() *challengeDisputes {
+	cds, err := env.ssc.getChallengeDisputes(env.allocID, env.balances)
+	require.NoError(env.t, err)
+	return cds
+}
+
+func (env *challengeDisputeTestEnv) stake() currency.Coin {
+	sp, err := env.ssc.getStakePool(spenum.Blobber, env.blobberID, env.balances)
+	require.NoError(env.t, err)
+	staked, err := sp.stake()
+	require.NoError(env.t, err)
+	return staked
+}
+
+func (env *challengeDisputeTestEnv) cp() *challengePool {
+	cp, err := env.ssc.getChallengePool(env.allocID, env.balances)
+	require.NoError(env.t, err)
+	return cp
+}
+
+func (env *challengeDisputeTestEnv) cpBalance() currency.Coin {
+	return env.cp().Balance
+}
+
+// open fails a challenge validated by the original validators
+func (env *challengeDisputeTestEnv) open(challengeID string, totalValidators int) {
+	env.tp += 100
+	env.balances.setTransaction(env.t, newTransaction(env.owner.id, env.ssc.ID, 0, env.tp))
+	challenge := &StorageChallenge{
+		ID:              challengeID,
+		AllocationID:    env.allocID,
+		BlobberID:       env.blobberID,
+		TotalValidators: totalValidators,
+		ValidatorIDs:    []string{env.original[0].id, env.original[1].id},
+		Created:         common.Timestamp(env.tp),
+	}
+	require.NoError(env.t, challenge.Save(env.balances, env.ssc.ID))
+	require.NoError(env.t, env.ssc.openChallengeDispute(env.allocID, challengeID, nil, env.balances))
+}
+
+// slash escrows the slash of the blobber for the failure of the challenge
+func (env *challengeDisputeTestEnv) slash(challengeID string) currency.Coin {
+	sp, err := env.ssc.getStakePool(spenum.Blobber, env.blobberID, env.balances)
+	require.NoError(env.t, err)
+	staked, err := sp.stake()
+	require.NoError(env.t, err)
+	ba := env.alloc().BlobberAllocsMap[env.blobberID]
+	moved, penalties, err := sp.slash(env.blobberID, ba.Offer(), staked/10, env.balances,
+		env.allocID, challengeID)
+	require.NoError(env.t, err)
+	cp := env.cp()
+	require.NoError(env.t, env.ssc.escrowChallengeSlash(env.allocID, env.blobberID,
+		common.Timestamp(env.tp-1), common.Timestamp(env.tp), penalties, cp, env.balances))
+	require.NoError(env.t, cp.save(env.ssc.ID, env.alloc(), env.balances))
+	require.NoError(env.t, sp.Save(spenum.Blobber, env.blobberID, env.balances))
+	return moved
+}
+
+// picked returns the validators picked for the dispute of the challenge
+func (env *challengeDisputeTestEnv) picked(challengeID string) []*Client {
+	cds := env.disputes()
+	i, ok := cds.find(challengeID)
+	require.True(env.t, ok)
+	var vs []*Client
+	for _, id := range cds.Disputes[i].DisputeValidators {
+		vs = append(vs, env.validators[id])
+	}
+	return vs
+}
+
+func (env *challengeDisputeTestEnv) dispute(clientID, challengeID string, pass bool, vs ...*Client) error {
+	env.tp += 100
+	tx := newTransaction(clientID, env.ssc.ID, 0, env.tp)
+	env.balances.setTransaction(env.t, tx)
+	req := challengeDisputeRequest{AllocationID: env.allocID, ChallengeID: challengeID}
+	for _, v := range vs {
+		req.ValidationTickets = append(req.ValidationTickets,
+			v.validTicket(env.t, challengeID, env.blobberID, pass, env.tp))
+	}
+	_, err := env.ssc.disputeChallenge(tx, mustEncode(env.t, &req), env.balances)
+	return err
+}
+
+func TestChallengeDispute(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(env *challengeDisputeTestEnv)
+	}{
+		{
+			name: "overturned",
+			run: func(env *challengeDisputeTestEnv) {
+				staked, cpBefore := env.stake(), env.cpBalance()
+				env.open("overturned", 2)
+				escrow := env.slash("overturned")
+				require.NotZero(env.t, escrow)
+
+				var slashed currency.Coin
+				for _, e := range env.balances.events {
+					if e.Tag != event.TagAddSlashRecords {
+						continue
+					}
+					for _, r := range e.Data.([]event.SlashRecord) {
+						require.Equal(env.t, "overturned", r.Evidence, "the failed challenge is the evidence")
+						require.Equal(env.t, spenum.SlashChallengeFailed.String(), r.Reason)
+						slashed += r.Amount
+					}
+				}
+				require.Equal(env.t, escrow, slashed)
+				require.Equal(env.t, staked-escrow, env.stake())
+				require.Equal(env.t, cpBefore, env.cpBalance(), "the escrow is not paid out of the challenge pool")
+				require.Equal(env.t, escrow, env.cp().Escrow, "the escrow is held in the challenge pool")
+				cds := env.disputes()
+				require.Len(env.t, cds.Disputes, 1)
+				require.Equal(env.t, escrow, cds.Disputes[0].Escrow)
+
+				before := env.alloc()
+				require.NoError(env.t, env.dispute(env.blobberID, "overturned", true, env.picked("overturned")...))
+				require.Equal(env.t, staked, env.stake(), "the escrow is given back to the delegates")
+				require.Equal(env.t, cpBefore, env.cpBalance())
+				require.Zero(env.t, env.cp().Escrow)
+				require.Empty(env.t, env.disputes().Disputes)
+				after := env.alloc()
+				require.Equal(env.t, before.Stats.SuccessChallenges+1, after.Stats.SuccessChallenges)
+				require.Equal(env.t, before.Stats.FailedChallenges-1, after.Stats.FailedChallenges)
+				require.Equal(env.t, before.BlobberAllocsMap[env.blobberID].LatestSuccessfulChallCreatedAt,
+					after.BlobberAllocsMap[env.blobberID].LatestSuccessfulChallCreatedAt,
+					"the penalty period of the blobber is left as is")
+			},
+		},
+		{
+			name: "not the challenged blobber",
+			run: func(env *challengeDisputeTestEnv) {
+				env.open("challenge", 2)
+				env.slash("challenge")
+				require.ErrorContains(env.t, env.dispute(env.owner.id, "challenge", true, env.picked("challenge")...),
+					"only the challenged blobber")
+			},
+		},
+		{
+			name: "unknown challenge",
+			run: func(env *challengeDisputeTestEnv) {
+				env.open("challenge", 2)
+				require.ErrorContains(env.t, env.dispute(env.blobberID, "unknown", true, env.picked("challenge")...),
+					"no dispute")
+			},
+		},
+		{
+			name: "validator not picked for the dispute",
+			run: func(env *challengeDisputeTestEnv) {
+				env.open("challenge", 2)
+				env.slash("challenge")
+				picked := env.picked("challenge")
+				require.ErrorContains(env.t, env.dispute(env.blobberID, "challenge", true, env.original[0], picked[0]),
+					"is not picked for the dispute")
+			},
+		},
+		{
+			name: "late proof rejected",
+			run: func(env *challengeDisputeTestEnv) {
+				env.open("challenge", 2)
+				env.slash("challenge")
+				require.ErrorContains(env.t, env.dispute(env.blobberID, "challenge", false, env.picked("challenge")...),
+					"rejected")
+			},
+		},
+		{
+			name: "dispute validators picked by the SC",
+			run: func(env *challengeDisputeTestEnv) {
+				env.open("challenge", 2)
+				picked := env.picked("challenge")
+				require.Len(env.t, picked, 2)
+				for _, v := range picked {
+					require.NotContains(env.t, env.original, v, "the validators of the challenge are left out")
+				}
+
+				challenge, err := env.ssc.getStorageChallenge("challenge", env.balances)
+				require.NoError(env.t, err)
+				conf, err := env.ssc.getConfig(env.balances, true)
+				require.NoError(env.t, err)
+				again, err := env.ssc.selectDisputeValidators(challenge, conf, env.balances)
+				require.NoError(env.t, err)
+				require.Equal(env.t, env.disputes().Disputes[0].DisputeValidators, again, "the pick is deterministic")
+			},
+		},
+		{
+			name: "not enough validators",
+			run: func(env *challengeDisputeTestEnv) {
+				env.open("challenge", 3)
+				require.Empty(env.t, env.disputes().Disputes, "the challenge is not disputable")
+			},
+		},
+		{
+			name: "upheld",
+			run: func(env *challengeDisputeTestEnv) {
+				staked, cpBefore := env.stake(), env.cpBalance()
+				env.open("upheld", 2)
+				escrow := env.slash("upheld")
+				env.tp += int64(toSeconds(time.Hour))
+				require.ErrorContains(env.t, env.dispute(env.blobberID, "upheld", true, env.picked("upheld")...),
+					"dispute window is closed")
+				cp := env.cp()
+				require.NoError(env.t, env.ssc.closeChallengeDisputes(env.alloc(), cp, env.balances))
+				require.Equal(env.t, staked-escrow, env.stake())
+				require.Equal(env.t, cpBefore, cp.Balance)
+				require.Zero(env.t, cp.Escrow, "the escrow is dropped")
+				require.Empty(env.t, env.disputes().Disputes)
+			},
+		},
+		{
+			name: "escrow exceeding the challenge pool escrow",
+			run: func(env *challengeDisputeTestEnv) {
+				env.open("challenge", 2)
+				env.slash("challenge")
+				cp := env.cp()
+				cp.Escrow--
+				require.ErrorContains(env.t, env.disputes().uphold(cp,
+					common.Timestamp(env.tp+int64(toSeconds(time.Hour))+1), env.balances),
+					"exceeds the escrow of the challenge pool")
+			},
+		},
+		{
+			name: "finalization penalty escrowed",
+			run: func(env *challengeDisputeTestEnv) {
+				staked := env.stake()
+				env.open("challenge", 2)
+
+				alloc := env.alloc()
+				ba := alloc.BlobberAllocsMap[env.blobberID]
+				ba.LatestSuccessfulChallCreatedAt = common.Timestamp(env.tp - 1)
+				ba.LatestFinalizedChallCreatedAt = common.Timestamp(env.tp)
+				ba.ChallengePoolIntegralValue = 100 * x10
+				conf, err := env.ssc.getConfig(env.balances, true)
+				require.NoError(env.t, err)
+				sp, err := env.ssc.getStakePool(spenum.Blobber, env.blobberID, env.balances)
+				require.NoError(env.t, err)
+				cp := env.cp()
+				_, err = ba.challengePenaltyOnFinalization(conf, alloc, env.balances, sp, cp, env.ssc)
+				require.NoError(env.t, err)
+				require.NoError(env.t, sp.Save(spenum.Blobber, env.blobberID, env.balances))
+
+				require.NotZero(env.t, ba.Penalty)
+				require.Equal(env.t, staked-ba.Penalty, env.stake())
+				cds := env.disputes()
+				require.Len(env.t, cds.Disputes, 1)
+				require.Equal(env.t, ba.Penalty, cds.Disputes[0].Escrow)
+				require.Equal(env.t, ba.Penalty, cp.Escrow)
+
+				require.NoError(env.t, env.ssc.closeChallengeDisputes(alloc, cp, env.balances))
+				require.Zero(env.t, cp.Escrow)
+				require.Empty(env.t, env.disputes().Disputes)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(newChallengeDisputeTestEnv(t))
+		})
+	}
+}
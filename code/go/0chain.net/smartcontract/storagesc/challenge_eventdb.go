@@ -27,6 +27,9 @@ func storageChallengeToChallengeTable(ch *StorageChallengeResponse, expiredN int
 		ExpiredN:       expiredN,
 		Timestamp:      ch.Timestamp,
 		RoundCreatedAt: ch.RoundCreatedAt,
+
+		SourceAllocationRoot: ch.SourceAllocationRoot,
+		SourceTimestamp:      ch.SourceTimestamp,
 	}
 }
 
@@ -53,6 +56,9 @@ func challengeTableToStorageChallengeInfo(ch *event.Challenge, edb *event.EventD
 		AllocationRoot: ch.AllocationRoot,
 		Validators:     validators,
 		Timestamp:      ch.Timestamp,
+
+		SourceAllocationRoot: ch.SourceAllocationRoot,
+		SourceTimestamp:      ch.SourceTimestamp,
 	}, nil
 }
 
@@ -0,0 +1,47 @@
+package storagesc
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/util"
+)
+
+// vrfChallengeHardFork is the hard fork deriving the challenges from the
+// round random seed instead of the generate challenge transaction hash
+const vrfChallengeHardFork = "hermes"
+
+// deriveChallenge returns the ID and the selection seed of the challenge
+// generated in the round. The round random seed is the aggregated VRF output
+// of the round, the block proposer can't grind on it to steer the blobber,
+// allocation and validators selection, and anyone can derive it again. The
+// selection is checked as the rest of the block, by the nodes executing the
+// generate challenge transaction again.
+func deriveChallenge(round, roundRandomSeed int64) (challengeID string, seed int64, err error) {
+	// the "1" is the index of the challenge in the round, kept in case more
+	// than one challenge is generated at once.
+	challengeID = encryption.Hash(fmt.Sprintf("challenge:%d:%d:1", round, roundRandomSeed))
+
+	seedSource, err := strconv.ParseUint(challengeID[0:16], 16, 64)
+	if err != nil {
+		return "", 0, err
+	}
+	return challengeID, int64(seedSource), nil
+}
+
+// isVRFChallenge reports whether the challenge created in the round is
+// derived from the round random seed
+func isVRFChallenge(roundCreatedAt int64, balances cstate.CommonStateContextI) (bool, error) {
+	round, err := cstate.GetRoundByName(balances, vrfChallengeHardFork)
+	switch {
+	case err == nil:
+		return roundCreatedAt >= round, nil
+	case errors.Is(err, util.ErrValueNotPresent):
+		return false, nil
+	default:
+		return false, err
+	}
+}
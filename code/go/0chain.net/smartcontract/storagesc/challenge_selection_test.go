@@ -0,0 +1,52 @@
+package storagesc
+
+import (
+	"testing"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveChallenge(t *testing.T) {
+	id, seed, err := deriveChallenge(100, 12345)
+	require.NoError(t, err)
+
+	id2, seed2, err := deriveChallenge(100, 12345)
+	require.NoError(t, err)
+	require.Equal(t, id, id2)
+	require.Equal(t, seed, seed2)
+
+	other, otherSeed, err := deriveChallenge(100, 12346)
+	require.NoError(t, err)
+	require.NotEqual(t, id, other, "depends on the round random seed")
+	require.NotEqual(t, seed, otherSeed)
+
+	other, _, err = deriveChallenge(101, 12345)
+	require.NoError(t, err)
+	require.NotEqual(t, id, other, "depends on the round")
+}
+
+func TestIsVRFChallenge(t *testing.T) {
+	tests := []struct {
+		name  string
+		round int64
+		want  bool
+	}{
+		{name: "created after the hard fork", round: 20, want: true},
+		{name: "created at the hard fork", round: 10, want: true},
+		{name: "created before the hard fork", round: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			balances := newTestBalances(t, false)
+			h := cstate.NewHardFork(vrfChallengeHardFork, 10)
+			_, err := balances.InsertTrieNode(h.GetKey(), h)
+			require.NoError(t, err)
+
+			vrf, err := isVRFChallenge(tt.round, balances)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, vrf)
+		})
+	}
+}
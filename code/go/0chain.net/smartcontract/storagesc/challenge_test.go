@@ -1879,7 +1879,7 @@ func testBlobberPenalty(
 		validatorStakes, wpBalance, challengePoolIntegralValue, challengePoolBalance, thisChallange, thisExpires, now, size)
 
 	alloc := allocation.mustBase()
-	err = ssc.blobberPenalty(alloc, 0, previous, details, validators, ctx, allocationId)
+	err = ssc.blobberPenalty(alloc, 0, previous, details, validators, ctx, allocationId, "")
 	if err != nil {
 		return err
 	}
@@ -2010,7 +2010,7 @@ func setupChallengeMocks(
 	}
 	var ctx = &mockStateContext{
 		StateContext: *cstate.NewStateContext(
-			nil,
+			&block.Block{},
 			&util.MerklePatriciaTrie{},
 			txn,
 			nil,
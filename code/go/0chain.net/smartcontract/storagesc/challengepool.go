@@ -19,12 +19,16 @@ import (
 )
 
 //msgp:ignore challengePoolStat
+//msgp:shim currency.Coin as:uint64 using:uint64/currency.Coin mode:cast
 //go:generate msgp -io=false -tests=false -unexported=true -v
 
 // challenge pool is a locked tokens for a duration for an allocation
 
 type challengePool struct {
 	*tokenpool.ZcnPool `json:"pool"`
+	// Escrow is the slash of the blobbers held while their failed challenges
+	// are disputed, it's not part of the balance paid out of the pool
+	Escrow currency.Coin `json:"escrow,omitempty" msg:"Escrow,omitempty"`
 }
 
 func newChallengePool() *challengePool {
@@ -48,13 +52,15 @@ func (cp *challengePool) Encode() (b []byte) {
 func (cp *challengePool) Decode(input []byte) (err error) {
 
 	type challengePoolJSON struct {
-		Pool json.RawMessage `json:"pool"`
+		Pool   json.RawMessage `json:"pool"`
+		Escrow currency.Coin   `json:"escrow"`
 	}
 
 	var challengePoolVal challengePoolJSON
 	if err = json.Unmarshal(input, &challengePoolVal); err != nil {
 		return
 	}
+	cp.Escrow = challengePoolVal.Escrow
 
 	if len(challengePoolVal.Pool) == 0 {
 		return // no data given
@@ -156,6 +162,27 @@ func (cp *challengePool) moveToBlobbers(sscKey string, reward currency.Coin,
 	return nil
 }
 
+// holdEscrow adds the slashed tokens to the escrow of the pool
+func (cp *challengePool) holdEscrow(value currency.Coin) error {
+	escrow, err := currency.AddCoin(cp.Escrow, value)
+	if err != nil {
+		return err
+	}
+	cp.Escrow = escrow
+	return nil
+}
+
+// releaseEscrow takes the tokens out of the escrow of the pool, the caller
+// gives them back or drops them
+func (cp *challengePool) releaseEscrow(value currency.Coin) error {
+	if value > cp.Escrow {
+		return fmt.Errorf("escrow %v exceeds the escrow of the challenge pool %v",
+			value, cp.Escrow)
+	}
+	cp.Escrow -= value
+	return nil
+}
+
 func toChallengePoolStat(cp *event.ChallengePool) *challengePoolStat {
 	stat := challengePoolStat{
 		ID:         cp.ID,
@@ -4,15 +4,27 @@ package storagesc
 
 import (
 	"0chain.net/chaincore/tokenpool"
+	"github.com/0chain/common/core/currency"
 	"github.com/tinylib/msgp/msgp"
 )
 
 // MarshalMsg implements msgp.Marshaler
 func (z *challengePool) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 1
+	// omitempty: check for empty values
+	zb0001Len := uint32(2)
+	var zb0001Mask uint8 /* 2 bits */
+	if z.Escrow == 0 {
+		zb0001Len--
+		zb0001Mask |= 0x2
+	}
+	// variable map header, size zb0001Len
+	o = append(o, 0x80|uint8(zb0001Len))
+	if zb0001Len == 0 {
+		return
+	}
 	// string "ZcnPool"
-	o = append(o, 0x81, 0xa7, 0x5a, 0x63, 0x6e, 0x50, 0x6f, 0x6f, 0x6c)
+	o = append(o, 0xa7, 0x5a, 0x63, 0x6e, 0x50, 0x6f, 0x6f, 0x6c)
 	if z.ZcnPool == nil {
 		o = msgp.AppendNil(o)
 	} else {
@@ -22,6 +34,11 @@ func (z *challengePool) MarshalMsg(b []byte) (o []byte, err error) {
 			return
 		}
 	}
+	if (zb0001Mask & 0x2) == 0 { // if not empty
+		// string "Escrow"
+		o = append(o, 0xa6, 0x45, 0x73, 0x63, 0x72, 0x6f, 0x77)
+		o = msgp.AppendUint64(o, uint64(z.Escrow))
+	}
 	return
 }
 
@@ -60,6 +77,16 @@ func (z *challengePool) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					return
 				}
 			}
+		case "Escrow":
+			{
+				var zb0002 uint64
+				zb0002, bts, err = msgp.ReadUint64Bytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Escrow")
+					return
+				}
+				z.Escrow = currency.Coin(zb0002)
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -80,5 +107,6 @@ func (z *challengePool) Msgsize() (s int) {
 	} else {
 		s += z.ZcnPool.Msgsize()
 	}
+	s += 7 + msgp.Uint64Size
 	return
 }
@@ -30,8 +30,11 @@ type freeAllocationSettings struct {
 }
 
 type stakePoolConfig struct {
-	MinLockPeriod time.Duration `json:"min_lock_period"`
-	KillSlash     float64       `json:"kill_slash"`
+	MinLockPeriod       time.Duration `json:"min_lock_period"`
+	KillSlash           float64       `json:"kill_slash"`
+	MinRedelegatePeriod time.Duration `json:"min_redelegate_period"`
+	// UnbondingPeriod is the number of rounds before unbonded tokens can be claimed.
+	UnbondingPeriod int64 `json:"unbonding_period"`
 }
 
 type readPoolConfig struct {
@@ -118,6 +121,18 @@ type Config struct {
 
 	// allocation cancellation
 	CancellationCharge float64 `json:"cancellation_charge"`
+	// OwnershipTransferExpiry is the time an allocation ownership transfer
+	// offer can be accepted within, zero disables the transfers.
+	OwnershipTransferExpiry time.Duration `json:"ownership_transfer_expiry"`
+	// MeteredBillingGracePeriod is the time a metered allocation keeps
+	// accepting writes after its prepaid balance is exhausted.
+	MeteredBillingGracePeriod time.Duration `json:"metered_billing_grace_period"`
+	// MeteredBillingMinPrepaidPeriod is the time the initial prepaid balance
+	// of a metered allocation must pay for with the whole allocation size used.
+	MeteredBillingMinPrepaidPeriod time.Duration `json:"metered_billing_min_prepaid_period"`
+	// ChallengeDisputePeriod is the time a blobber can dispute a failed
+	// challenge with a late proof, zero disables the disputes.
+	ChallengeDisputePeriod time.Duration `json:"challenge_dispute_period"`
 	// free allocations
 	MaxTotalFreeAllocation      currency.Coin          `json:"max_total_free_allocation"`
 	MaxIndividualFreeAllocation currency.Coin          `json:"max_individual_free_allocation"`
@@ -168,6 +183,22 @@ func (conf *Config) validate() (err error) {
 		return fmt.Errorf("cancellation_charge not in [0, 1] range: %v",
 			conf.CancellationCharge)
 	}
+	if conf.OwnershipTransferExpiry < 0 {
+		return fmt.Errorf("negative ownership_transfer_expiry: %v",
+			conf.OwnershipTransferExpiry)
+	}
+	if conf.MeteredBillingGracePeriod < 0 {
+		return fmt.Errorf("negative metered_billing_grace_period: %v",
+			conf.MeteredBillingGracePeriod)
+	}
+	if conf.MeteredBillingMinPrepaidPeriod < 0 {
+		return fmt.Errorf("negative metered_billing_min_prepaid_period: %v",
+			conf.MeteredBillingMinPrepaidPeriod)
+	}
+	if conf.ChallengeDisputePeriod < 0 {
+		return fmt.Errorf("negative challenge_dispute_period: %v",
+			conf.ChallengeDisputePeriod)
+	}
 	if conf.MaxBlobbersPerAllocation <= 0 {
 		return fmt.Errorf("invalid max_blobber_per_allocation <= 0: %v",
 			conf.MaxBlobbersPerAllocation)
@@ -194,6 +225,12 @@ func (conf *Config) validate() (err error) {
 	if conf.StakePool.KillSlash < 0 || conf.StakePool.KillSlash > 1 {
 		return fmt.Errorf("stakepool.kill_slash, %v must be in interval [0.1]", conf.StakePool.KillSlash)
 	}
+	if conf.StakePool.MinRedelegatePeriod < 0 {
+		return fmt.Errorf("negative stakepool.min_redelegate_period: %v", conf.StakePool.MinRedelegatePeriod)
+	}
+	if conf.StakePool.UnbondingPeriod < 0 {
+		return fmt.Errorf("negative stakepool.unbonding_period: %v", conf.StakePool.UnbondingPeriod)
+	}
 
 	if conf.FreeAllocationSettings.DataShards < 0 {
 		return fmt.Errorf("negative free_allocation_settings.data_shards: %v",
@@ -370,6 +407,10 @@ func getConfiguredConfig() (conf *Config, err error) {
 	conf.ValidatorReward = scc.GetFloat64(pfx + "validator_reward")
 	conf.BlobberSlash = scc.GetFloat64(pfx + "blobber_slash")
 	conf.CancellationCharge = scc.GetFloat64(pfx + "cancellation_charge")
+	conf.OwnershipTransferExpiry = scc.GetDuration(pfx + "ownership_transfer_expiry")
+	conf.MeteredBillingGracePeriod = scc.GetDuration(pfx + "metered_billing_grace_period")
+	conf.MeteredBillingMinPrepaidPeriod = scc.GetDuration(pfx + "metered_billing_min_prepaid_period")
+	conf.ChallengeDisputePeriod = scc.GetDuration(pfx + "challenge_dispute_period")
 	conf.MaxBlobbersPerAllocation = scc.GetInt(pfx + "max_blobbers_per_allocation")
 	conf.MaxReadPrice, err = currency.ParseZCN(scc.GetFloat64(pfx + "max_read_price"))
 	if err != nil {
@@ -401,6 +442,8 @@ func getConfiguredConfig() (conf *Config, err error) {
 	conf.StakePool = new(stakePoolConfig)
 	conf.StakePool.MinLockPeriod = scc.GetDuration(pfx + "stakepool.min_lock_period")
 	conf.StakePool.KillSlash = scc.GetFloat64(pfx + "stakepool.kill_slash")
+	conf.StakePool.MinRedelegatePeriod = scc.GetDuration(pfx + "stakepool.min_redelegate_period")
+	conf.StakePool.UnbondingPeriod = scc.GetInt64(pfx + "stakepool.unbonding_period")
 
 	conf.MaxTotalFreeAllocation, err = currency.MultFloat64(1e10, scc.GetFloat64(pfx+"max_total_free_allocation"))
 	if err != nil {
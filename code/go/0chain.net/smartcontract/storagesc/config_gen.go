@@ -9,9 +9,9 @@ import (
 // MarshalMsg implements msgp.Marshaler
 func (z *Config) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 33
+	// map header, size 37
 	// string "TimeUnit"
-	o = append(o, 0xde, 0x0, 0x21, 0xa8, 0x54, 0x69, 0x6d, 0x65, 0x55, 0x6e, 0x69, 0x74)
+	o = append(o, 0xde, 0x0, 0x25, 0xa8, 0x54, 0x69, 0x6d, 0x65, 0x55, 0x6e, 0x69, 0x74)
 	o = msgp.AppendDuration(o, z.TimeUnit)
 	// string "Minted"
 	o = append(o, 0xa6, 0x4d, 0x69, 0x6e, 0x74, 0x65, 0x64)
@@ -62,13 +62,11 @@ func (z *Config) MarshalMsg(b []byte) (o []byte, err error) {
 	if z.StakePool == nil {
 		o = msgp.AppendNil(o)
 	} else {
-		// map header, size 2
-		// string "MinLockPeriod"
-		o = append(o, 0x82, 0xad, 0x4d, 0x69, 0x6e, 0x4c, 0x6f, 0x63, 0x6b, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
-		o = msgp.AppendDuration(o, z.StakePool.MinLockPeriod)
-		// string "KillSlash"
-		o = append(o, 0xa9, 0x4b, 0x69, 0x6c, 0x6c, 0x53, 0x6c, 0x61, 0x73, 0x68)
-		o = msgp.AppendFloat64(o, z.StakePool.KillSlash)
+		o, err = z.StakePool.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "StakePool")
+			return
+		}
 	}
 	// string "ValidatorReward"
 	o = append(o, 0xaf, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64)
@@ -109,6 +107,18 @@ func (z *Config) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "CancellationCharge"
 	o = append(o, 0xb2, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x72, 0x67, 0x65)
 	o = msgp.AppendFloat64(o, z.CancellationCharge)
+	// string "OwnershipTransferExpiry"
+	o = append(o, 0xb7, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x45, 0x78, 0x70, 0x69, 0x72, 0x79)
+	o = msgp.AppendDuration(o, z.OwnershipTransferExpiry)
+	// string "MeteredBillingGracePeriod"
+	o = append(o, 0xb9, 0x4d, 0x65, 0x74, 0x65, 0x72, 0x65, 0x64, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x47, 0x72, 0x61, 0x63, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o = msgp.AppendDuration(o, z.MeteredBillingGracePeriod)
+	// string "MeteredBillingMinPrepaidPeriod"
+	o = append(o, 0xbe, 0x4d, 0x65, 0x74, 0x65, 0x72, 0x65, 0x64, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x4d, 0x69, 0x6e, 0x50, 0x72, 0x65, 0x70, 0x61, 0x69, 0x64, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o = msgp.AppendDuration(o, z.MeteredBillingMinPrepaidPeriod)
+	// string "ChallengeDisputePeriod"
+	o = append(o, 0xb6, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x44, 0x69, 0x73, 0x70, 0x75, 0x74, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o = msgp.AppendDuration(o, z.ChallengeDisputePeriod)
 	// string "MaxTotalFreeAllocation"
 	o = append(o, 0xb6, 0x4d, 0x61, 0x78, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x46, 0x72, 0x65, 0x65, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e)
 	o, err = z.MaxTotalFreeAllocation.MarshalMsg(o)
@@ -341,40 +351,11 @@ func (z *Config) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				if z.StakePool == nil {
 					z.StakePool = new(stakePoolConfig)
 				}
-				var zb0004 uint32
-				zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+				bts, err = z.StakePool.UnmarshalMsg(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "StakePool")
 					return
 				}
-				for zb0004 > 0 {
-					zb0004--
-					field, bts, err = msgp.ReadMapKeyZC(bts)
-					if err != nil {
-						err = msgp.WrapError(err, "StakePool")
-						return
-					}
-					switch msgp.UnsafeString(field) {
-					case "MinLockPeriod":
-						z.StakePool.MinLockPeriod, bts, err = msgp.ReadDurationBytes(bts)
-						if err != nil {
-							err = msgp.WrapError(err, "StakePool", "MinLockPeriod")
-							return
-						}
-					case "KillSlash":
-						z.StakePool.KillSlash, bts, err = msgp.ReadFloat64Bytes(bts)
-						if err != nil {
-							err = msgp.WrapError(err, "StakePool", "KillSlash")
-							return
-						}
-					default:
-						bts, err = msgp.Skip(bts)
-						if err != nil {
-							err = msgp.WrapError(err, "StakePool")
-							return
-						}
-					}
-				}
 			}
 		case "ValidatorReward":
 			z.ValidatorReward, bts, err = msgp.ReadFloat64Bytes(bts)
@@ -430,6 +411,30 @@ func (z *Config) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "CancellationCharge")
 				return
 			}
+		case "OwnershipTransferExpiry":
+			z.OwnershipTransferExpiry, bts, err = msgp.ReadDurationBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "OwnershipTransferExpiry")
+				return
+			}
+		case "MeteredBillingGracePeriod":
+			z.MeteredBillingGracePeriod, bts, err = msgp.ReadDurationBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MeteredBillingGracePeriod")
+				return
+			}
+		case "MeteredBillingMinPrepaidPeriod":
+			z.MeteredBillingMinPrepaidPeriod, bts, err = msgp.ReadDurationBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MeteredBillingMinPrepaidPeriod")
+				return
+			}
+		case "ChallengeDisputePeriod":
+			z.ChallengeDisputePeriod, bts, err = msgp.ReadDurationBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ChallengeDisputePeriod")
+				return
+			}
 		case "MaxTotalFreeAllocation":
 			bts, err = z.MaxTotalFreeAllocation.UnmarshalMsg(bts)
 			if err != nil {
@@ -532,23 +537,23 @@ func (z *Config) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				return
 			}
 		case "Cost":
-			var zb0005 uint32
-			zb0005, bts, err = msgp.ReadMapHeaderBytes(bts)
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "Cost")
 				return
 			}
 			if z.Cost == nil {
-				z.Cost = make(map[string]int, zb0005)
+				z.Cost = make(map[string]int, zb0004)
 			} else if len(z.Cost) > 0 {
 				for key := range z.Cost {
 					delete(z.Cost, key)
 				}
 			}
-			for zb0005 > 0 {
+			for zb0004 > 0 {
 				var za0001 string
 				var za0002 int
-				zb0005--
+				zb0004--
 				za0001, bts, err = msgp.ReadStringBytes(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "Cost")
@@ -591,9 +596,9 @@ func (z *Config) Msgsize() (s int) {
 	if z.StakePool == nil {
 		s += msgp.NilSize
 	} else {
-		s += 1 + 14 + msgp.DurationSize + 10 + msgp.Float64Size
+		s += z.StakePool.Msgsize()
 	}
-	s += 16 + msgp.Float64Size + 13 + msgp.Float64Size + 18 + msgp.DurationSize + 25 + msgp.IntSize + 13 + z.MaxReadPrice.Msgsize() + 14 + z.MaxWritePrice.Msgsize() + 14 + z.MinWritePrice.Msgsize() + 12 + msgp.Int64Size + 19 + msgp.Float64Size + 23 + z.MaxTotalFreeAllocation.Msgsize() + 28 + z.MaxIndividualFreeAllocation.Msgsize() + 23 + z.FreeAllocationSettings.Msgsize() + 17 + msgp.BoolSize + 23 + msgp.Int64Size + 23 + msgp.IntSize + 22 + msgp.IntSize + 29 + msgp.IntSize + 9 + z.MinStake.Msgsize() + 9 + z.MaxStake.Msgsize() + 20 + z.MinStakePerDelegate.Msgsize() + 13 + msgp.IntSize + 10 + msgp.Float64Size + 12
+	s += 16 + msgp.Float64Size + 13 + msgp.Float64Size + 18 + msgp.DurationSize + 25 + msgp.IntSize + 13 + z.MaxReadPrice.Msgsize() + 14 + z.MaxWritePrice.Msgsize() + 14 + z.MinWritePrice.Msgsize() + 12 + msgp.Int64Size + 19 + msgp.Float64Size + 24 + msgp.DurationSize + 26 + msgp.DurationSize + 31 + msgp.DurationSize + 23 + msgp.DurationSize + 23 + z.MaxTotalFreeAllocation.Msgsize() + 28 + z.MaxIndividualFreeAllocation.Msgsize() + 23 + z.FreeAllocationSettings.Msgsize() + 17 + msgp.BoolSize + 23 + msgp.Int64Size + 23 + msgp.IntSize + 22 + msgp.IntSize + 29 + msgp.IntSize + 9 + z.MinStake.Msgsize() + 9 + z.MaxStake.Msgsize() + 20 + z.MinStakePerDelegate.Msgsize() + 13 + msgp.IntSize + 10 + msgp.Float64Size + 12
 	if z.BlockReward == nil {
 		s += msgp.NilSize
 	} else {
@@ -1113,15 +1118,21 @@ func (z *readPoolConfig) Msgsize() (s int) {
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z stakePoolConfig) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *stakePoolConfig) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 2
+	// map header, size 4
 	// string "MinLockPeriod"
-	o = append(o, 0x82, 0xad, 0x4d, 0x69, 0x6e, 0x4c, 0x6f, 0x63, 0x6b, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o = append(o, 0x84, 0xad, 0x4d, 0x69, 0x6e, 0x4c, 0x6f, 0x63, 0x6b, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
 	o = msgp.AppendDuration(o, z.MinLockPeriod)
 	// string "KillSlash"
 	o = append(o, 0xa9, 0x4b, 0x69, 0x6c, 0x6c, 0x53, 0x6c, 0x61, 0x73, 0x68)
 	o = msgp.AppendFloat64(o, z.KillSlash)
+	// string "MinRedelegatePeriod"
+	o = append(o, 0xb3, 0x4d, 0x69, 0x6e, 0x52, 0x65, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o = msgp.AppendDuration(o, z.MinRedelegatePeriod)
+	// string "UnbondingPeriod"
+	o = append(o, 0xaf, 0x55, 0x6e, 0x62, 0x6f, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o = msgp.AppendInt64(o, z.UnbondingPeriod)
 	return
 }
 
@@ -1155,6 +1166,18 @@ func (z *stakePoolConfig) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "KillSlash")
 				return
 			}
+		case "MinRedelegatePeriod":
+			z.MinRedelegatePeriod, bts, err = msgp.ReadDurationBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MinRedelegatePeriod")
+				return
+			}
+		case "UnbondingPeriod":
+			z.UnbondingPeriod, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "UnbondingPeriod")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -1168,8 +1191,8 @@ func (z *stakePoolConfig) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z stakePoolConfig) Msgsize() (s int) {
-	s = 1 + 14 + msgp.DurationSize + 10 + msgp.Float64Size
+func (z *stakePoolConfig) Msgsize() (s int) {
+	s = 1 + 14 + msgp.DurationSize + 10 + msgp.Float64Size + 20 + msgp.DurationSize + 16 + msgp.Int64Size
 	return
 }
 
@@ -43,9 +43,15 @@ const (
 
 	StakePoolMinLockPeriod
 	StakePoolKillSlash
+	StakePoolMinRedelegatePeriod
+	StakePoolUnbondingPeriod
 	MaxTotalFreeAllocation
 	MaxIndividualFreeAllocation
 	CancellationCharge
+	OwnershipTransferExpiry
+	MeteredBillingGracePeriod
+	MeteredBillingMinPrepaidPeriod
+	ChallengeDisputePeriod
 
 	FreeAllocationDataShards
 	FreeAllocationParityShards
@@ -111,6 +117,30 @@ const (
 	CostKillValidator
 	CostShutdownBlobber
 	CostShutdownValidator
+	CostOfferAllocationTransfer
+	CostAcceptAllocationTransfer
+	CostCancelAllocationTransfer
+	CostRejectAllocationTransfer
+	CostFreezeAllocation
+	CostSetReadACLEntry
+	CostRemoveReadACLEntry
+	CostDeleteReadACL
+	CostSetAutoRepairPolicy
+	CostRepairAllocation
+	CostTopUpMeteredBilling
+	CostSettleMeteredBilling
+	CostCompleteShardMigration
+	CostFinalizeShardMigration
+	CostCancelShardMigration
+	CostReadSubPoolLock
+	CostReadSubPoolUnlock
+	CostAddBlobberPriceOffer
+	CostRemoveBlobberPriceOffer
+	CostDisputeChallenge
+	CostStakePoolRedelegate
+	CostStakePoolAutoCompound
+	CostStakePoolUnbond
+	CostStakePoolClaimUnbonded
 	MaxCharge
 	NumberOfSettings
 )
@@ -148,9 +178,15 @@ func initSettingName() {
 	SettingName[WritePoolMinLock] = "writepool.min_lock"
 	SettingName[StakePoolKillSlash] = "stakepool.kill_slash"
 	SettingName[StakePoolMinLockPeriod] = "stakepool.min_lock_period"
+	SettingName[StakePoolMinRedelegatePeriod] = "stakepool.min_redelegate_period"
+	SettingName[StakePoolUnbondingPeriod] = "stakepool.unbonding_period"
 	SettingName[MaxTotalFreeAllocation] = "max_total_free_allocation"
 	SettingName[MaxIndividualFreeAllocation] = "max_individual_free_allocation"
 	SettingName[CancellationCharge] = "cancellation_charge"
+	SettingName[OwnershipTransferExpiry] = "ownership_transfer_expiry"
+	SettingName[MeteredBillingGracePeriod] = "metered_billing_grace_period"
+	SettingName[MeteredBillingMinPrepaidPeriod] = "metered_billing_min_prepaid_period"
+	SettingName[ChallengeDisputePeriod] = "challenge_dispute_period"
 	SettingName[FreeAllocationDataShards] = "free_allocation_settings.data_shards"
 	SettingName[FreeAllocationParityShards] = "free_allocation_settings.parity_shards"
 	SettingName[FreeAllocationSize] = "free_allocation_settings.size"
@@ -210,6 +246,30 @@ func initSettingName() {
 	SettingName[CostKillValidator] = "cost.kill_validator"
 	SettingName[CostShutdownBlobber] = "cost.shutdown_blobber"
 	SettingName[CostShutdownValidator] = "cost.shutdown_validator"
+	SettingName[CostOfferAllocationTransfer] = "cost.offer_allocation_transfer"
+	SettingName[CostAcceptAllocationTransfer] = "cost.accept_allocation_transfer"
+	SettingName[CostCancelAllocationTransfer] = "cost.cancel_allocation_transfer"
+	SettingName[CostRejectAllocationTransfer] = "cost.reject_allocation_transfer"
+	SettingName[CostFreezeAllocation] = "cost.freeze_allocation"
+	SettingName[CostSetReadACLEntry] = "cost.set_read_acl_entry"
+	SettingName[CostRemoveReadACLEntry] = "cost.remove_read_acl_entry"
+	SettingName[CostDeleteReadACL] = "cost.delete_read_acl"
+	SettingName[CostSetAutoRepairPolicy] = "cost.set_auto_repair_policy"
+	SettingName[CostRepairAllocation] = "cost.repair_allocation"
+	SettingName[CostTopUpMeteredBilling] = "cost.top_up_metered_billing"
+	SettingName[CostSettleMeteredBilling] = "cost.settle_metered_billing"
+	SettingName[CostCompleteShardMigration] = "cost.complete_shard_migration"
+	SettingName[CostFinalizeShardMigration] = "cost.finalize_shard_migration"
+	SettingName[CostCancelShardMigration] = "cost.cancel_shard_migration"
+	SettingName[CostReadSubPoolLock] = "cost.read_sub_pool_lock"
+	SettingName[CostReadSubPoolUnlock] = "cost.read_sub_pool_unlock"
+	SettingName[CostAddBlobberPriceOffer] = "cost.add_blobber_price_offer"
+	SettingName[CostRemoveBlobberPriceOffer] = "cost.remove_blobber_price_offer"
+	SettingName[CostDisputeChallenge] = "cost.dispute_challenge"
+	SettingName[CostStakePoolRedelegate] = "cost.stake_pool_redelegate"
+	SettingName[CostStakePoolAutoCompound] = "cost.stake_pool_auto_compound"
+	SettingName[CostStakePoolUnbond] = "cost.stake_pool_unbond"
+	SettingName[CostStakePoolClaimUnbonded] = "cost.stake_pool_claim_unbonded"
 }
 
 func initSettings() {
@@ -229,9 +289,15 @@ func initSettings() {
 		WritePoolMinLock.String():                 {WritePoolMinLock, config.CurrencyCoin},
 		StakePoolMinLockPeriod.String():           {StakePoolMinLockPeriod, config.Duration},
 		StakePoolKillSlash.String():               {StakePoolKillSlash, config.Float64},
+		StakePoolMinRedelegatePeriod.String():     {StakePoolMinRedelegatePeriod, config.Duration},
+		StakePoolUnbondingPeriod.String():         {StakePoolUnbondingPeriod, config.Int64},
 		MaxTotalFreeAllocation.String():           {MaxTotalFreeAllocation, config.CurrencyCoin},
 		MaxIndividualFreeAllocation.String():      {MaxIndividualFreeAllocation, config.CurrencyCoin},
 		CancellationCharge.String():               {CancellationCharge, config.Float64},
+		OwnershipTransferExpiry.String():          {OwnershipTransferExpiry, config.Duration},
+		MeteredBillingGracePeriod.String():        {MeteredBillingGracePeriod, config.Duration},
+		MeteredBillingMinPrepaidPeriod.String():   {MeteredBillingMinPrepaidPeriod, config.Duration},
+		ChallengeDisputePeriod.String():           {ChallengeDisputePeriod, config.Duration},
 		FreeAllocationDataShards.String():         {FreeAllocationDataShards, config.Int},
 		FreeAllocationParityShards.String():       {FreeAllocationParityShards, config.Int},
 		FreeAllocationSize.String():               {FreeAllocationSize, config.Int64},
@@ -291,6 +357,30 @@ func initSettings() {
 		CostKillValidator.String():                {CostKillValidator, config.Cost},
 		CostShutdownBlobber.String():              {CostShutdownBlobber, config.Cost},
 		CostShutdownValidator.String():            {CostShutdownValidator, config.Cost},
+		CostOfferAllocationTransfer.String():      {CostOfferAllocationTransfer, config.Cost},
+		CostAcceptAllocationTransfer.String():     {CostAcceptAllocationTransfer, config.Cost},
+		CostCancelAllocationTransfer.String():     {CostCancelAllocationTransfer, config.Cost},
+		CostRejectAllocationTransfer.String():     {CostRejectAllocationTransfer, config.Cost},
+		CostFreezeAllocation.String():             {CostFreezeAllocation, config.Cost},
+		CostSetReadACLEntry.String():              {CostSetReadACLEntry, config.Cost},
+		CostRemoveReadACLEntry.String():           {CostRemoveReadACLEntry, config.Cost},
+		CostDeleteReadACL.String():                {CostDeleteReadACL, config.Cost},
+		CostSetAutoRepairPolicy.String():          {CostSetAutoRepairPolicy, config.Cost},
+		CostRepairAllocation.String():             {CostRepairAllocation, config.Cost},
+		CostTopUpMeteredBilling.String():          {CostTopUpMeteredBilling, config.Cost},
+		CostSettleMeteredBilling.String():         {CostSettleMeteredBilling, config.Cost},
+		CostCompleteShardMigration.String():       {CostCompleteShardMigration, config.Cost},
+		CostFinalizeShardMigration.String():       {CostFinalizeShardMigration, config.Cost},
+		CostCancelShardMigration.String():         {CostCancelShardMigration, config.Cost},
+		CostReadSubPoolLock.String():              {CostReadSubPoolLock, config.Cost},
+		CostReadSubPoolUnlock.String():            {CostReadSubPoolUnlock, config.Cost},
+		CostAddBlobberPriceOffer.String():         {CostAddBlobberPriceOffer, config.Cost},
+		CostRemoveBlobberPriceOffer.String():      {CostRemoveBlobberPriceOffer, config.Cost},
+		CostDisputeChallenge.String():             {CostDisputeChallenge, config.Cost},
+		CostStakePoolRedelegate.String():          {CostStakePoolRedelegate, config.Cost},
+		CostStakePoolAutoCompound.String():        {CostStakePoolAutoCompound, config.Cost},
+		CostStakePoolUnbond.String():              {CostStakePoolUnbond, config.Cost},
+		CostStakePoolClaimUnbonded.String():       {CostStakePoolClaimUnbonded, config.Cost},
 	}
 }
 
@@ -439,6 +529,11 @@ func (conf *Config) setInt64(key string, change int64) error {
 		conf.FreeAllocationSettings.Size = change
 	case MaxChallengeCompletionRounds:
 		conf.MaxChallengeCompletionRounds = change
+	case StakePoolUnbondingPeriod:
+		if conf.StakePool == nil {
+			conf.StakePool = &stakePoolConfig{}
+		}
+		conf.StakePool.UnbondingPeriod = change
 	default:
 		return fmt.Errorf("key: %v not implemented as int64", key)
 	}
@@ -505,8 +600,21 @@ func (conf *Config) setDuration(key string, change time.Duration) error {
 			conf.StakePool = &stakePoolConfig{}
 		}
 		conf.StakePool.MinLockPeriod = change
+	case StakePoolMinRedelegatePeriod:
+		if conf.StakePool == nil {
+			conf.StakePool = &stakePoolConfig{}
+		}
+		conf.StakePool.MinRedelegatePeriod = change
 	case HealthCheckPeriod:
 		conf.HealthCheckPeriod = change
+	case OwnershipTransferExpiry:
+		conf.OwnershipTransferExpiry = change
+	case MeteredBillingGracePeriod:
+		conf.MeteredBillingGracePeriod = change
+	case MeteredBillingMinPrepaidPeriod:
+		conf.MeteredBillingMinPrepaidPeriod = change
+	case ChallengeDisputePeriod:
+		conf.ChallengeDisputePeriod = change
 	default:
 		return fmt.Errorf("key: %v not implemented as duration", key)
 	}
@@ -641,12 +749,24 @@ func (conf *Config) get(key Setting) interface{} {
 		return conf.WritePool.MinLock
 	case StakePoolMinLockPeriod:
 		return conf.StakePool.MinLockPeriod
+	case StakePoolMinRedelegatePeriod:
+		return conf.StakePool.MinRedelegatePeriod
+	case StakePoolUnbondingPeriod:
+		return conf.StakePool.UnbondingPeriod
 	case MaxTotalFreeAllocation:
 		return conf.MaxTotalFreeAllocation
 	case MaxIndividualFreeAllocation:
 		return conf.MaxIndividualFreeAllocation
 	case CancellationCharge:
 		return conf.CancellationCharge
+	case OwnershipTransferExpiry:
+		return conf.OwnershipTransferExpiry
+	case MeteredBillingGracePeriod:
+		return conf.MeteredBillingGracePeriod
+	case MeteredBillingMinPrepaidPeriod:
+		return conf.MeteredBillingMinPrepaidPeriod
+	case ChallengeDisputePeriod:
+		return conf.ChallengeDisputePeriod
 	case FreeAllocationDataShards:
 		return conf.FreeAllocationSettings.DataShards
 	case FreeAllocationParityShards:
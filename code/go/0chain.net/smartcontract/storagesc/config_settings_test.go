@@ -144,9 +144,16 @@ func TestUpdateSettings(t *testing.T) {
 					"readpool.min_lock":  "10",
 					"writepool.min_lock": "10",
 
-					"max_total_free_allocation":      "10000",
-					"max_individual_free_allocation": "100",
-					"cancellation_charge":            "0.2",
+					"max_total_free_allocation":          "10000",
+					"max_individual_free_allocation":     "100",
+					"cancellation_charge":                "0.2",
+					"ownership_transfer_expiry":          "1h",
+					"metered_billing_grace_period":       "2h",
+					"metered_billing_min_prepaid_period": "24h",
+					"challenge_dispute_period":           "1h",
+
+					"stakepool.min_redelegate_period": "168h",
+					"stakepool.unbonding_period":      "1000",
 
 					"free_allocation_settings.data_shards":           "10",
 					"free_allocation_settings.parity_shards":         "5",
@@ -552,6 +559,18 @@ func getConfField(conf Config, field string) interface{} {
 		return conf.BlockReward.Zeta.K
 	case BlockRewardZetaMu:
 		return conf.BlockReward.Zeta.Mu
+	case OwnershipTransferExpiry:
+		return conf.OwnershipTransferExpiry
+	case MeteredBillingGracePeriod:
+		return conf.MeteredBillingGracePeriod
+	case MeteredBillingMinPrepaidPeriod:
+		return conf.MeteredBillingMinPrepaidPeriod
+	case ChallengeDisputePeriod:
+		return conf.ChallengeDisputePeriod
+	case StakePoolMinRedelegatePeriod:
+		return conf.StakePool.MinRedelegatePeriod
+	case StakePoolUnbondingPeriod:
+		return conf.StakePool.UnbondingPeriod
 	case OwnerId:
 		return conf.OwnerId
 	default:
@@ -19,6 +19,9 @@ import (
 	"github.com/0chain/common/core/currency"
 
 	cstate "0chain.net/chaincore/chain/state"
+	sci "0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/encryption"
 	"0chain.net/smartcontract/stakepool"
 	"github.com/0chain/common/core/logging"
 	"go.uber.org/zap"
@@ -26,6 +29,7 @@ import (
 	"0chain.net/smartcontract/stakepool/spenum"
 
 	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/dto"
 
 	"0chain.net/core/datastore"
 	"github.com/0chain/common/core/util"
@@ -63,8 +67,24 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 		rest.MakeEndpoint(storage+"/allocations", common.UserRateLimit(srh.getAllocations)),
 		rest.MakeEndpoint(storage+"/expired-allocations", common.UserRateLimit(srh.getExpiredAllocations)),
 		rest.MakeEndpoint(storage+"/allocation-update-min-lock", common.UserRateLimit(srh.getAllocationUpdateMinLock)),
+		rest.MakeEndpoint(storage+"/allocation-create-quote", common.UserRateLimit(srh.getAllocationCreateQuote)),
+		rest.MakeEndpoint(storage+"/allocation-update-quote", common.UserRateLimit(srh.getAllocationUpdateQuote)),
 		rest.MakeEndpoint(storage+"/allocation", common.UserRateLimit(srh.getAllocation)),
+		rest.MakeEndpoint(storage+"/allocation-snapshots", common.UserRateLimit(srh.getAllocationSnapshots)),
+		rest.MakeEndpoint(storage+"/allocation-repairs", common.UserRateLimit(srh.getAllocationRepairs)),
+		rest.MakeEndpoint(storage+"/auto-repair-policy", common.UserRateLimit(srh.getAutoRepairPolicy)),
+		rest.MakeEndpoint(storage+"/shard-migration", common.UserRateLimit(srh.getShardMigration)),
+		rest.MakeEndpoint(storage+"/read-sub-pools", common.UserRateLimit(srh.getReadSubPools)),
+		rest.MakeEndpoint(storage+"/read-sub-pool-consumption", common.UserRateLimit(srh.getReadSubPoolConsumption)),
+		rest.MakeEndpoint(storage+"/blobber-price-offers", common.UserRateLimit(srh.getBlobberPriceOffers)),
+		rest.MakeEndpoint(storage+"/challenge-disputes", common.UserRateLimit(srh.getChallengeDisputes)),
+		rest.MakeEndpoint(storage+"/blobber-challenge-disputes", common.UserRateLimit(srh.getBlobberChallengeDisputes)),
+		rest.MakeEndpoint(storage+"/provider-slashes", common.UserRateLimit(srh.getProviderSlashes)),
+		rest.MakeEndpoint(storage+"/unbonding-entries", common.UserRateLimit(srh.getUnbondingEntries)),
+		rest.MakeEndpoint(storage+"/metered-billing", common.UserRateLimit(srh.getMeteredBilling)),
+		rest.MakeEndpoint(storage+"/metered-billing-alerts", common.UserRateLimit(srh.getMeteredBillingAlerts)),
 		rest.MakeEndpoint(storage+"/latestreadmarker", common.UserRateLimit(srh.getLatestReadMarker)),
+		rest.MakeEndpoint(storage+"/read-acl", common.UserRateLimit(srh.getReadACL)),
 		rest.MakeEndpoint(storage+"/readmarkers", common.UserRateLimit(srh.getReadMarkers)),
 		rest.MakeEndpoint(storage+"/count_readmarkers", common.UserRateLimit(srh.getReadMarkersCount)),
 		rest.MakeEndpoint(storage+"/getWriteMarkers", common.UserRateLimit(srh.getWriteMarkers)),
@@ -73,6 +93,7 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 		rest.MakeEndpoint(storage+"/openchallenges", common.UserRateLimit(srh.getOpenChallenges)),
 		rest.MakeEndpoint(storage+"/getchallenge", common.UserRateLimit(srh.getChallenge)),
 		rest.MakeEndpoint(storage+"/blobber-challenges", common.UserRateLimit(srh.getBlobberChallenges)),
+		rest.MakeEndpoint(storage+"/replay-challenge-selection", common.UserRateLimit(srh.replayChallengeSelection)),
 		rest.MakeEndpoint(storage+"/getStakePoolStat", common.UserRateLimit(srh.getStakePoolStat)),
 		rest.MakeEndpoint(storage+"/getUserStakePoolStat", common.UserRateLimit(srh.getUserStakePoolStat)),
 		rest.MakeEndpoint(storage+"/block", common.UserRateLimit(srh.getBlock)),
@@ -280,6 +301,10 @@ type allocationBlobbersRequest struct {
 	WritePriceRange PriceRange `json:"write_price_range"`
 	Size            int64      `json:"size"`
 	IsRestricted    int        `json:"is_restricted"`
+
+	Placement *placementConstraints `json:"placement,omitempty"`
+
+	MinReputation float64 `json:"min_reputation,omitempty"`
 }
 
 func (nar *allocationBlobbersRequest) decode(b []byte) error {
@@ -300,6 +325,11 @@ func (nar *allocationBlobbersRequest) decode(b []byte) error {
 //
 //   - Restricted status
 //
+//   - Placement constraints: region minimums, excluded jurisdictions and distinct operators.
+//     The blobbers meeting them are listed first.
+//
+//   - Minimum challenge reputation
+//
 // parameters:
 //
 //	+name: allocation_data
@@ -390,6 +420,14 @@ func getBlobbersForRequest(request allocationBlobbersRequest, edb *event.EventDb
 			"invalid data shards:%v or parity shards:%v", request.DataShards, request.ParityShards)
 	}
 
+	if request.Placement != nil {
+		request.Placement.normalize()
+		if err := request.Placement.validate(numberOfBlobbers); err != nil {
+			return nil, common.NewErrorf("allocation_creation_failed",
+				"invalid placement constraints: %v", err)
+		}
+	}
+
 	var allocationSize = bSize(request.Size, request.DataShards)
 
 	allocation := event.AllocationQuery{
@@ -411,6 +449,10 @@ func getBlobbersForRequest(request allocationBlobbersRequest, edb *event.EventDb
 		AllocationSizeInGB: sizeInGB(allocationSize),
 		NumberOfDataShards: request.DataShards,
 		IsRestricted:       request.IsRestricted,
+		MinReputation:      request.MinReputation,
+	}
+	if request.Placement != nil {
+		allocation.ExcludedJurisdictions = request.Placement.ExcludedJurisdictions
 	}
 
 	logging.Logger.Debug("alloc_blobbers", zap.Int64("ReadPriceRange.Min", allocation.ReadPriceRange.Min),
@@ -430,6 +472,22 @@ func getBlobbersForRequest(request allocationBlobbersRequest, edb *event.EventDb
 		return nil, fmt.Errorf("not enough blobbers to honor the allocation : %d < %d", len(blobberIDs), numberOfBlobbers)
 	}
 
+	// the blobbers meeting the placement constraints come first
+	if request.Placement != nil {
+		selected, others, err := request.Placement.selectBlobbers(blobberIDs, numberOfBlobbers, balances)
+		if err != nil {
+			if !isForce {
+				return nil, err
+			}
+			return blobberIDs, nil
+		}
+		placed := make([]string, 0, len(selected)+len(others))
+		for _, i := range append(selected, others...) {
+			placed = append(placed, blobberIDs[i])
+		}
+		blobberIDs = placed
+	}
+
 	return blobberIDs, nil
 }
 
@@ -906,6 +964,90 @@ func (srh *StorageRestHandler) getBlock(w http.ResponseWriter, r *http.Request)
 	return
 }
 
+// swagger:model challengeSelectionReplay
+type challengeSelectionReplay struct {
+	Round           int64  `json:"round"`
+	RoundRandomSeed int64  `json:"round_random_seed"`
+	VRF             bool   `json:"vrf"` // derived from the round random seed
+	ChallengeID     string `json:"challenge_id,omitempty"`
+	Seed            int64  `json:"seed,omitempty"`
+	// Challenges generated in the round, with the blobber, allocation and
+	// validators selected with their seed
+	Challenges []event.Challenge `json:"challenges"`
+	// Derived is set when the challenge generated in the round has the ID
+	// and the seed derived from the round random seed
+	Derived bool `json:"derived"`
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/replay-challenge-selection storage-sc ReplayChallengeSelection
+// Replay challenge selection.
+//
+// Derives again the challenge ID and the seed selecting the challenged blobber, allocation and
+// validators from the random seed of the round, the aggregated VRF output of the round, and compares
+// them with the challenge generated in the round. The selection itself depends on the state of the
+// previous block and is not replayed, the challenges are returned with the blobber, allocation and
+// validators recorded for them. Rounds before the VRF challenges are never derived.
+//
+// parameters:
+//
+//	+name: round
+//	 description: round the challenge was generated in
+//	 required: true
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: challengeSelectionReplay
+//	400:
+//	500:
+func (srh *StorageRestHandler) replayChallengeSelection(w http.ResponseWriter, r *http.Request) {
+	round, err := strconv.ParseInt(r.URL.Query().Get("round"), 10, 64)
+	if err != nil || round <= 0 {
+		common.Respond(w, r, nil, common.NewErrBadRequest("invalid round"))
+		return
+	}
+
+	sctx := srh.GetQueryStateContext()
+	edb := sctx.GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+
+	block, err := edb.GetBlockByRound(round)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrBadRequest("can't get block of the round", err.Error()))
+		return
+	}
+
+	challenges, err := edb.GetChallengesCreatedInRound(round)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get challenges", err.Error()))
+		return
+	}
+
+	replay := challengeSelectionReplay{
+		Round:           round,
+		RoundRandomSeed: block.RoundRandomSeed,
+		Challenges:      challenges,
+	}
+	if replay.VRF, err = isVRFChallenge(round, sctx); err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't check challenge derivation", err.Error()))
+		return
+	}
+	if replay.VRF {
+		if replay.ChallengeID, replay.Seed, err = deriveChallenge(round, block.RoundRandomSeed); err != nil {
+			common.Respond(w, r, nil, common.NewErrInternal("can't derive challenge", err.Error()))
+			return
+		}
+		replay.Derived = len(challenges) == 1 &&
+			challenges[0].ChallengeID == replay.ChallengeID &&
+			challenges[0].Seed == replay.Seed
+	}
+	common.Respond(w, r, replay, nil)
+}
+
 // swagger:model stakePoolStat
 type StakePoolStat struct {
 	ID           string             `json:"pool_id"` // pool ID
@@ -932,11 +1074,15 @@ type DelegatePoolStat struct {
 	Status       string           `json:"status"`
 	RoundCreated int64            `json:"round_created"`
 	StakedAt     common.Timestamp `json:"staked_at"`
+	AutoCompound bool             `json:"auto_compound"`
 }
 
 // swagger:model userPoolStat
 type UserPoolStat struct {
 	Pools map[datastore.Key][]*DelegatePoolStat `json:"pools"`
+	// Slashes is the penalty history of the delegate pools of the user
+	// per provider
+	Slashes map[datastore.Key][]event.SlashRecord `json:"slashes,omitempty"`
 }
 
 func ToProviderStakePoolStats(provider *event.Provider, delegatePools []event.DelegatePool) (*StakePoolStat, error) {
@@ -969,6 +1115,7 @@ func ToProviderStakePoolStats(provider *event.Provider, delegatePools []event.De
 			Rewards:      dp.Reward,
 			TotalPenalty: dp.TotalPenalty,
 			TotalReward:  dp.TotalReward,
+			AutoCompound: dp.AutoCompound,
 		}
 
 		newBal, err := currency.AddCoin(spStat.Balance, dpStats.Balance)
@@ -986,7 +1133,8 @@ func ToProviderStakePoolStats(provider *event.Provider, delegatePools []event.De
 // swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/getUserStakePoolStat storage-sc GetUserStakePoolStat
 // Get user stake pool statistics.
 //
-// Retrieve statistic for a user's stake pools given the user's id.
+// Retrieve statistic for a user's stake pools given the user's id, with the slashes of
+// the pools per provider.
 //
 // parameters:
 //
@@ -1053,6 +1201,7 @@ func (srh *StorageRestHandler) getUserStakePoolStat(w http.ResponseWriter, r *ht
 			Status:       pool.Status.String(),
 			RoundCreated: pool.RoundCreated,
 			StakedAt:     pool.StakedAt,
+			AutoCompound: pool.AutoCompound,
 		}
 		dps.Balance = pool.Balance
 
@@ -1065,6 +1214,18 @@ func (srh *StorageRestHandler) getUserStakePoolStat(w http.ResponseWriter, r *ht
 		ups.Pools[pool.ProviderID] = append(ups.Pools[pool.ProviderID], &dps)
 	}
 
+	ups.Slashes = make(map[datastore.Key][]event.SlashRecord)
+	for _, pType := range []spenum.Provider{spenum.Blobber, spenum.Validator} {
+		slashes, err := edb.GetDelegateSlashRecords(clientID, pType, pagination)
+		if err != nil {
+			common.Respond(w, r, nil, common.NewErrInternal("can't get slashes", err.Error()))
+			return
+		}
+		for _, slash := range slashes {
+			ups.Slashes[slash.ProviderID] = append(ups.Slashes[slash.ProviderID], slash)
+		}
+	}
+
 	common.Respond(w, r, ups, nil)
 }
 
@@ -1277,6 +1438,10 @@ type StorageChallengeResponse struct {
 	Seed              int64             `json:"seed"`
 	AllocationRoot    string            `json:"allocation_root"`
 	Timestamp         common.Timestamp  `json:"timestamp"`
+	// SourceAllocationRoot is the root of the blobber in the source layout
+	// of a shard migration, a proof against either root is valid
+	SourceAllocationRoot string           `json:"source_allocation_root,omitempty"`
+	SourceTimestamp      common.Timestamp `json:"source_timestamp,omitempty"`
 }
 
 // swagger:model ChallengesResponse
@@ -1808,6 +1973,120 @@ func (srh *StorageRestHandler) getLatestReadMarker(w http.ResponseWriter, r *htt
 	}
 }
 
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/read-acl storage-sc GetReadACL
+// Get allocation read ACL.
+//
+// Gets the clients allowed to redeem read markers of an allocation, along with their quota,
+// expiration and redeemed read size. Allocations without ACL can be read by any client.
+//
+// parameters:
+//
+//	+name: allocation_id
+//	 description: allocation ID
+//	 required: true
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: readACL
+//	400:
+//	500:
+func (srh *StorageRestHandler) getReadACL(w http.ResponseWriter, r *http.Request) {
+	allocationID := r.URL.Query().Get("allocation_id")
+	if allocationID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing allocation_id"))
+		return
+	}
+
+	acl := new(readACL)
+	err := srh.GetQueryStateContext().GetTrieNode(readACLKey(ADDRESS, allocationID), acl)
+	switch err {
+	case nil:
+		common.Respond(w, r, acl, nil)
+	case util.ErrValueNotPresent:
+		common.Respond(w, r, make(map[string]string), nil)
+	default:
+		common.Respond(w, r, nil, common.NewErrInternal("can't get read ACL", err.Error()))
+	}
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/auto-repair-policy storage-sc GetAutoRepairPolicy
+// Get allocation auto-repair policy.
+//
+// Gets the auto-repair policy of an allocation, along with the failed challenges of its blobbers
+// at their last passed challenge. Allocations without policy are never repaired automatically.
+//
+// parameters:
+//
+//	+name: allocation_id
+//	 description: allocation ID
+//	 required: true
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: autoRepairPolicy
+//	400:
+//	500:
+func (srh *StorageRestHandler) getAutoRepairPolicy(w http.ResponseWriter, r *http.Request) {
+	allocationID := r.URL.Query().Get("allocation_id")
+	if allocationID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing allocation_id"))
+		return
+	}
+
+	arp := new(autoRepairPolicy)
+	err := srh.GetQueryStateContext().GetTrieNode(autoRepairPolicyKey(ADDRESS, allocationID), arp)
+	switch err {
+	case nil:
+		common.Respond(w, r, arp, nil)
+	case util.ErrValueNotPresent:
+		common.Respond(w, r, make(map[string]string), nil)
+	default:
+		common.Respond(w, r, nil, common.NewErrInternal("can't get auto-repair policy", err.Error()))
+	}
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/shard-migration storage-sc GetShardMigration
+// Get allocation shard migration.
+//
+// Gets the ongoing migration of an allocation to other data and parity shards, along with
+// the blobbers which completed it. Returns an empty object if the allocation isn't migrating.
+//
+// parameters:
+//
+//	+name: allocation_id
+//	 description: allocation ID
+//	 required: true
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: shardMigration
+//	400:
+//	500:
+func (srh *StorageRestHandler) getShardMigration(w http.ResponseWriter, r *http.Request) {
+	allocationID := r.URL.Query().Get("allocation_id")
+	if allocationID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing allocation_id"))
+		return
+	}
+
+	sm := new(shardMigration)
+	err := srh.GetQueryStateContext().GetTrieNode(shardMigrationKey(ADDRESS, allocationID), sm)
+	switch err {
+	case nil:
+		common.Respond(w, r, sm, nil)
+	case util.ErrValueNotPresent:
+		common.Respond(w, r, make(map[string]string), nil)
+	default:
+		common.Respond(w, r, nil, common.NewErrInternal("can't get shard migration", err.Error()))
+	}
+}
+
 // swagger:model AllocationUpdateMinLockResponse
 type AllocationUpdateMinLockResponse struct {
 	MinLockDemand int64 `json:"min_lock_demand"`
@@ -1961,6 +2240,206 @@ func (srh *StorageRestHandler) getAllocationUpdateMinLock(w http.ResponseWriter,
 	}, nil)
 }
 
+// swagger:model AllocationQuote
+type AllocationQuote struct {
+	// Blobbers selected for the allocation
+	Blobbers []BlobberQuote `json:"blobbers"`
+	// MinLockDemand is the write pool lock required by the transaction
+	MinLockDemand currency.Coin `json:"min_lock_demand"`
+	// Error is the error the transaction would fail with, if any
+	Error string `json:"error,omitempty"`
+}
+
+// swagger:model BlobberQuote
+type BlobberQuote struct {
+	BlobberID  string        `json:"blobber_id"`
+	Size       int64         `json:"size"`
+	ReadPrice  currency.Coin `json:"read_price"`
+	WritePrice currency.Coin `json:"write_price"`
+	// Cost of the blobber for a time unit
+	Cost currency.Coin `json:"cost"`
+}
+
+func newAllocationQuote(alloc *storageAllocationBase, minLockDemand currency.Coin) (*AllocationQuote, error) {
+	quote := &AllocationQuote{
+		Blobbers:      make([]BlobberQuote, 0, len(alloc.BlobberAllocs)),
+		MinLockDemand: minLockDemand,
+	}
+	for _, ba := range alloc.BlobberAllocs {
+		cost, err := currency.MultFloat64(ba.Terms.WritePrice, sizeInGB(ba.Size))
+		if err != nil {
+			return nil, err
+		}
+		quote.Blobbers = append(quote.Blobbers, BlobberQuote{
+			BlobberID:  ba.BlobberID,
+			Size:       ba.Size,
+			ReadPrice:  ba.Terms.ReadPrice,
+			WritePrice: ba.Terms.WritePrice,
+			Cost:       cost,
+		})
+	}
+	return quote, nil
+}
+
+// dryRunContext returns a copy of the latest finalized state the storage
+// smart contract functions can run against, the changes are dropped.
+func (srh *StorageRestHandler) dryRunContext(data, clientID string) (
+	*StorageSmartContract, cstate.StateContextI, *transaction.Transaction, error) {
+
+	qsc := srh.GetQueryStateContext()
+	balances, ok := qsc.(cstate.StateContextI)
+	if !ok {
+		return nil, nil, nil, common.NewErrInternal("dry run is not supported")
+	}
+
+	now := qsc.Now()
+	txn := &transaction.Transaction{
+		ClientID:     clientID,
+		ToClientID:   ADDRESS,
+		CreationDate: now,
+	}
+	txn.Hash = encryption.Hash(fmt.Sprintf("%s:%s:%d", data, clientID, now))
+
+	return &StorageSmartContract{SmartContract: sci.NewSC(ADDRESS)}, balances, txn, nil
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/allocation-create-quote storage-sc GetAllocationCreateQuote
+// Dry runs a new allocation request.
+//
+// Runs the new allocation request against the latest finalized state, without submitting a transaction.
+// Returns the selected blobbers, their costs, the write pool lock required and the error the transaction would fail with.
+//
+// parameters:
+//
+//	+name: data
+//	 description: New allocation request data, in valid JSON format, following the newAllocationRequest struct.
+//	 in: query
+//	 type: string
+//	 required: true
+//	+name: client_id
+//	 description: Client sending the transaction, the owner of the allocation by default.
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: AllocationQuote
+//	400:
+//	500:
+func (srh *StorageRestHandler) getAllocationCreateQuote(w http.ResponseWriter, r *http.Request) {
+	data := r.URL.Query().Get("data")
+	if data == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing data"))
+		return
+	}
+
+	var req newAllocationRequest
+	if err := req.decode([]byte(data)); err != nil {
+		common.Respond(w, r, nil, common.NewErrBadRequest("can't decode allocation request", err.Error()))
+		return
+	}
+	clientID := r.URL.Query().Get("client_id")
+	if clientID == "" {
+		clientID = req.Owner
+	}
+
+	sc, balances, txn, err := srh.dryRunContext(data, clientID)
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+	conf, err := getConfig(balances)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal(err.Error()))
+		return
+	}
+
+	request, sa, _, _, err := sc.prepareNewAllocation(txn, []byte(data), conf, balances, Timings{})
+	if err != nil {
+		common.Respond(w, r, &AllocationQuote{Error: err.Error()}, nil)
+		return
+	}
+
+	alloc := sa.mustBase()
+	var minLockDemand currency.Coin
+	// metered allocations are paid from a prepaid balance of any amount
+	if request.Metered == nil {
+		if minLockDemand, err = alloc.cost(); err != nil {
+			common.Respond(w, r, nil, common.NewErrInternal(err.Error()))
+			return
+		}
+	}
+
+	quote, err := newAllocationQuote(alloc, minLockDemand)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal(err.Error()))
+		return
+	}
+	common.Respond(w, r, quote, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/allocation-update-quote storage-sc GetAllocationUpdateQuote
+// Dry runs an update allocation request.
+//
+// Runs the update allocation request against the latest finalized state, without submitting a transaction.
+// Returns the blobbers of the updated allocation, their costs, the write pool lock required and the error the
+// transaction would fail with. It supersedes allocation-update-min-lock.
+//
+// parameters:
+//
+//	+name: data
+//	 description: Update allocation request data, in valid JSON format, following the updateAllocationRequest struct.
+//	 in: query
+//	 type: string
+//	 required: true
+//	+name: client_id
+//	 description: Client sending the transaction.
+//	 in: query
+//	 type: string
+//	 required: true
+//
+// responses:
+//
+//	200: AllocationQuote
+//	400:
+//	500:
+func (srh *StorageRestHandler) getAllocationUpdateQuote(w http.ResponseWriter, r *http.Request) {
+	data := r.URL.Query().Get("data")
+	if data == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing data"))
+		return
+	}
+	clientID := r.URL.Query().Get("client_id")
+	if clientID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing client_id"))
+		return
+	}
+
+	sc, balances, txn, err := srh.dryRunContext(data, clientID)
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+	conf, err := getConfig(balances)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal(err.Error()))
+		return
+	}
+
+	sa, _, minLockDemand, err := sc.applyAllocationUpdate(txn, []byte(data), conf, balances)
+	if err != nil {
+		common.Respond(w, r, &AllocationQuote{Error: err.Error()}, nil)
+		return
+	}
+
+	quote, err := newAllocationQuote(sa.mustBase(), minLockDemand)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal(err.Error()))
+		return
+	}
+	common.Respond(w, r, quote, nil)
+}
+
 func changeBlobbersEventDB(
 	edb *event.EventDb,
 	saBase *storageAllocationBase,
@@ -2114,8 +2593,560 @@ func (srh *StorageRestHandler) getAllocations(w http.ResponseWriter, r *http.Req
 	common.Respond(w, r, allocations, nil)
 }
 
-// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/getExpiredAllocations storage-sc GetExpiredAllocations
-// Get expired allocations.
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/allocation-snapshots storage-sc GetAllocationSnapshots
+// Get allocation snapshots.
+//
+// Gets the blobber allocation roots recorded when allocations were frozen, either of
+// a single allocation or of all the allocations of an owner. Supports pagination.
+//
+// parameters:
+//
+//	+name: allocation_id
+//	 description: allocation to get the snapshot of
+//	 in: query
+//	 type: string
+//	+name: owner
+//	 description: owner of the frozen allocations, used if allocation_id is not set
+//	 in: query
+//	 type: string
+//	+name: offset
+//	 description: offset
+//	 in: query
+//	 type: string
+//	+name: limit
+//	 description: limit
+//	 in: query
+//	 type: string
+//	+name: sort
+//	 description: desc or asc
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []AllocationSnapshot
+//	400:
+//	500:
+func (srh *StorageRestHandler) getAllocationSnapshots(w http.ResponseWriter, r *http.Request) {
+	var (
+		allocationID = r.URL.Query().Get("allocation_id")
+		owner        = r.URL.Query().Get("owner")
+	)
+	if allocationID == "" && owner == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("allocation_id or owner is required"))
+		return
+	}
+
+	limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := srh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+
+	var snapshots []event.AllocationSnapshot
+	if allocationID != "" {
+		snapshots, err = edb.GetAllocationSnapshots(allocationID, limit)
+	} else {
+		snapshots, err = edb.GetAllocationSnapshotsByOwner(owner, limit)
+	}
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get allocation snapshots", err.Error()))
+		return
+	}
+	common.Respond(w, r, snapshots, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/metered-billing storage-sc GetMeteredBilling
+// Get allocation metered billing.
+//
+// Gets the prepaid balance of a metered allocation and the data size of its blobbers
+// since their last settlement. Empty for allocations paying with the write pool.
+//
+// parameters:
+//
+//	+name: allocation_id
+//	 description: allocation ID
+//	 required: true
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: meteredBilling
+//	400:
+//	500:
+func (srh *StorageRestHandler) getMeteredBilling(w http.ResponseWriter, r *http.Request) {
+	allocationID := r.URL.Query().Get("allocation_id")
+	if allocationID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing allocation_id"))
+		return
+	}
+
+	mb := new(meteredBilling)
+	err := srh.GetQueryStateContext().GetTrieNode(meteredBillingKey(ADDRESS, allocationID), mb)
+	switch err {
+	case nil:
+		common.Respond(w, r, mb, nil)
+	case util.ErrValueNotPresent:
+		common.Respond(w, r, make(map[string]string), nil)
+	default:
+		common.Respond(w, r, nil, common.NewErrInternal("can't get metered billing", err.Error()))
+	}
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/read-sub-pools storage-sc GetReadSubPools
+// Get allocation read sub-pools.
+//
+// Gets the read sub-pools of an allocation with their balance, limit, spent tokens and expiration.
+//
+// parameters:
+//
+//	+name: allocation_id
+//	 description: allocation ID
+//	 required: true
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: allocationReadSubPools
+//	400:
+//	500:
+func (srh *StorageRestHandler) getReadSubPools(w http.ResponseWriter, r *http.Request) {
+	allocationID := r.URL.Query().Get("allocation_id")
+	if allocationID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing allocation_id"))
+		return
+	}
+
+	arsp := &allocationReadSubPools{AllocationID: allocationID}
+	err := srh.GetQueryStateContext().GetTrieNode(allocationReadSubPoolsKey(ADDRESS, allocationID), arsp)
+	if err != nil && err != util.ErrValueNotPresent {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get read sub-pools", err.Error()))
+		return
+	}
+	common.Respond(w, r, arsp, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/read-sub-pool-consumption storage-sc GetReadSubPoolConsumption
+// Get read sub-pool consumption.
+//
+// Gets the read markers, the read size and the tokens spent by the read sub-pools of an allocation,
+// per sub-pool and reader.
+//
+// parameters:
+//
+//	+name: allocation_id
+//	 description: allocation ID
+//	 required: true
+//	 in: query
+//	 type: string
+//	+name: payer_id
+//	 description: owner of the read sub-pools, all of them if empty
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []ReadSubPoolConsumption
+//	400:
+//	500:
+func (srh *StorageRestHandler) getReadSubPoolConsumption(w http.ResponseWriter, r *http.Request) {
+	allocationID := r.URL.Query().Get("allocation_id")
+	if allocationID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing allocation_id"))
+		return
+	}
+
+	edb := srh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+
+	consumption, err := edb.GetReadSubPoolConsumption(allocationID, r.URL.Query().Get("payer_id"))
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get read sub-pool consumption", err.Error()))
+		return
+	}
+	common.Respond(w, r, consumption, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/blobber-price-offers storage-sc GetBlobberPriceOffers
+// Get blobber price offers.
+//
+// Gets the open price offers of a blobber with their prices, remaining capacity and validity window.
+// New allocations reference an offer to get its prices.
+//
+// parameters:
+//
+//	+name: blobber_id
+//	 description: blobber ID
+//	 required: true
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: blobberPriceOffers
+//	400:
+//	500:
+func (srh *StorageRestHandler) getBlobberPriceOffers(w http.ResponseWriter, r *http.Request) {
+	blobberID := r.URL.Query().Get("blobber_id")
+	if blobberID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing blobber_id"))
+		return
+	}
+
+	bpo := &blobberPriceOffers{BlobberID: blobberID}
+	err := srh.GetQueryStateContext().GetTrieNode(blobberPriceOffersKey(ADDRESS, blobberID), bpo)
+	if err != nil && err != util.ErrValueNotPresent {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get price offers", err.Error()))
+		return
+	}
+	common.Respond(w, r, bpo, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/challenge-disputes storage-sc GetChallengeDisputes
+// Get challenge disputes.
+//
+// Gets the open disputes of the failed challenges of an allocation, with their deadline and
+// the blobber slash held in escrow until the deadline.
+//
+// parameters:
+//
+//	+name: allocation_id
+//	 description: allocation ID
+//	 required: true
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: challengeDisputes
+//	400:
+//	500:
+func (srh *StorageRestHandler) getChallengeDisputes(w http.ResponseWriter, r *http.Request) {
+	allocationID := r.URL.Query().Get("allocation_id")
+	if allocationID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing allocation_id"))
+		return
+	}
+
+	cds := &challengeDisputes{AllocationID: allocationID}
+	err := srh.GetQueryStateContext().GetTrieNode(challengeDisputesKey(ADDRESS, allocationID), cds)
+	if err != nil && err != util.ErrValueNotPresent {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get challenge disputes", err.Error()))
+		return
+	}
+	common.Respond(w, r, cds, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/blobber-challenge-disputes storage-sc GetBlobberChallengeDisputes
+// Get blobber challenge disputes.
+//
+// Gets the disputes of the failed challenges of a blobber, open, overturned by a late proof
+// or upheld at the deadline. Supports pagination.
+//
+// parameters:
+//
+//	+name: blobber_id
+//	 description: blobber ID
+//	 required: true
+//	 in: query
+//	 type: string
+//	+name: offset
+//	 description: offset
+//	 in: query
+//	 type: string
+//	+name: limit
+//	 description: limit
+//	 in: query
+//	 type: string
+//	+name: sort
+//	 description: desc or asc
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []ChallengeDispute
+//	400:
+//	500:
+func (srh *StorageRestHandler) getBlobberChallengeDisputes(w http.ResponseWriter, r *http.Request) {
+	blobberID := r.URL.Query().Get("blobber_id")
+	if blobberID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing blobber_id"))
+		return
+	}
+
+	limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := srh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+
+	disputes, err := edb.GetChallengeDisputesByBlobber(blobberID, limit)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get challenge disputes", err.Error()))
+		return
+	}
+	common.Respond(w, r, disputes, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/provider-slashes storage-sc GetProviderSlashes
+// Get provider slashes.
+//
+// Gets the slashing history of a provider, one record per delegate pool slashed with the reason,
+// the evidence, the fraction slashed and the amount the delegate lost. Supports pagination.
+//
+// parameters:
+//
+//	+name: provider_id
+//	 description: provider ID
+//	 required: true
+//	 in: query
+//	 type: string
+//	+name: offset
+//	 description: offset
+//	 in: query
+//	 type: string
+//	+name: limit
+//	 description: limit
+//	 in: query
+//	 type: string
+//	+name: sort
+//	 description: desc or asc
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []SlashRecord
+//	400:
+//	500:
+func (srh *StorageRestHandler) getProviderSlashes(w http.ResponseWriter, r *http.Request) {
+	providerID := r.URL.Query().Get("provider_id")
+	if providerID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing provider_id"))
+		return
+	}
+
+	limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := srh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+
+	slashes, err := edb.GetProviderSlashRecords(providerID, limit)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get provider slashes", err.Error()))
+		return
+	}
+	common.Respond(w, r, slashes, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/unbonding-entries storage-sc GetUnbondingEntries
+// Get unbonding entries.
+//
+// Gets the pending unbonding entries of the delegate pools of a blobber or a validator, per delegate,
+// with the amount and the round they can be claimed at.
+//
+// parameters:
+//
+//	+name: provider_id
+//	 description: id of a provider
+//	 required: true
+//	 in: query
+//	 type: string
+//	+name: provider_type
+//	 description: type of the provider, possible values are 3 (blobber), 4 (validator)
+//	 required: true
+//	 in: query
+//	 type: string
+//	+name: client_id
+//	 description: delegate to get the unbonding entries of, all the delegates if not set
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: map[string][]UnbondingEntry
+//	400:
+//	500:
+func (srh *StorageRestHandler) getUnbondingEntries(w http.ResponseWriter, r *http.Request) {
+	var (
+		providerID = r.URL.Query().Get("provider_id")
+		clientID   = r.URL.Query().Get("client_id")
+	)
+	providerType, err := strconv.Atoi(r.URL.Query().Get("provider_type"))
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrBadRequest("invalid provider_type: "+err.Error()))
+		return
+	}
+
+	sp, err := getStakePool(spenum.Provider(providerType), providerID, srh.GetQueryStateContext())
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrBadRequest("can't get stake pool: "+err.Error()))
+		return
+	}
+
+	entries := make(map[string][]*stakepool.UnbondingEntry)
+	for id, dp := range sp.Pools {
+		if len(dp.Unbonding) == 0 || (clientID != "" && id != clientID) {
+			continue
+		}
+		entries[id] = dp.Unbonding
+	}
+	common.Respond(w, r, entries, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/metered-billing-alerts storage-sc GetMeteredBillingAlerts
+// Get metered billing alerts.
+//
+// Gets the low balance and exhaustion alerts of the metered allocations of an owner. Supports pagination.
+//
+// parameters:
+//
+//	+name: owner
+//	 description: owner of the metered allocations
+//	 required: true
+//	 in: query
+//	 type: string
+//	+name: offset
+//	 description: offset
+//	 in: query
+//	 type: string
+//	+name: limit
+//	 description: limit
+//	 in: query
+//	 type: string
+//	+name: sort
+//	 description: desc or asc
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []MeteredBillingAlert
+//	400:
+//	500:
+func (srh *StorageRestHandler) getMeteredBillingAlerts(w http.ResponseWriter, r *http.Request) {
+	owner := r.URL.Query().Get("owner")
+	if owner == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing owner"))
+		return
+	}
+
+	limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := srh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+
+	alerts, err := edb.GetMeteredBillingAlerts(owner, limit)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get metered billing alerts", err.Error()))
+		return
+	}
+	common.Respond(w, r, alerts, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/allocation-repairs storage-sc GetAllocationRepairs
+// Get allocation repairs.
+//
+// Gets the blobbers replaced by the auto-repair policy, either of a single allocation or
+// the ones added to a blobber, which has to repair the allocation data. Supports pagination.
+//
+// parameters:
+//
+//	+name: allocation_id
+//	 description: allocation to get the repairs of
+//	 in: query
+//	 type: string
+//	+name: blobber_id
+//	 description: blobber added by the repairs, used if allocation_id is not set
+//	 in: query
+//	 type: string
+//	+name: offset
+//	 description: offset
+//	 in: query
+//	 type: string
+//	+name: limit
+//	 description: limit
+//	 in: query
+//	 type: string
+//	+name: sort
+//	 description: desc or asc
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []AllocationRepair
+//	400:
+//	500:
+func (srh *StorageRestHandler) getAllocationRepairs(w http.ResponseWriter, r *http.Request) {
+	var (
+		allocationID = r.URL.Query().Get("allocation_id")
+		blobberID    = r.URL.Query().Get("blobber_id")
+	)
+	if allocationID == "" && blobberID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("allocation_id or blobber_id is required"))
+		return
+	}
+
+	limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := srh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+
+	var repairs []event.AllocationRepair
+	if allocationID != "" {
+		repairs, err = edb.GetAllocationRepairs(allocationID, limit)
+	} else {
+		repairs, err = edb.GetAllocationRepairsByBlobber(blobberID, limit)
+	}
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get allocation repairs", err.Error()))
+		return
+	}
+	common.Respond(w, r, repairs, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/getExpiredAllocations storage-sc GetExpiredAllocations
+// Get expired allocations.
 //
 // Retrieves a list of expired allocations associated with a specified blobber.
 //
@@ -2606,9 +3637,14 @@ type storageNodeResponse struct {
 
 	IsRestricted bool `json:"is_restricted"`
 	IsEnterprise bool `json:"is_enterprise"`
+
+	Placement *dto.BlobberPlacement `json:"placement,omitempty"`
+
+	// Reputation is the exponentially decayed rate of the challenges passed
+	Reputation float64 `json:"reputation"`
 }
 
-func StoragNodeToStorageNodeResponse(balances cstate.StateContextI, sn StorageNode) (storageNodeResponse, error) {
+func StoragNodeToStorageNodeResponse(sscKey string, balances cstate.StateContextI, sn StorageNode) (storageNodeResponse, error) {
 	b := sn.mustBase()
 	sr := storageNodeResponse{
 		ID:                      b.ID,
@@ -2658,6 +3694,19 @@ func StoragNodeToStorageNodeResponse(balances cstate.StateContextI, sn StorageNo
 		return storageNodeResponse{}, err
 	}
 
+	if err := cstate.WithActivation(balances, reputationHardFork, func() error {
+		return nil
+	}, func() error {
+		br, err := getBlobberReputation(sscKey, b.ID, balances)
+		if err != nil {
+			return fmt.Errorf("can't get blobber reputation: %v", err)
+		}
+		sr.Reputation = br.rate()
+		return nil
+	}); err != nil {
+		return storageNodeResponse{}, err
+	}
+
 	return sr, nil
 }
 
@@ -2746,6 +3795,13 @@ func blobberTableToStorageNode(blobber event.Blobber) storageNodeResponse {
 		CreatedAt:                blobber.CreatedAt,
 		IsRestricted:             blobber.IsRestricted,
 		IsEnterprise:             blobber.IsEnterprise,
+		Placement: &dto.BlobberPlacement{
+			Region:       blobber.Region,
+			Country:      blobber.Country,
+			Jurisdiction: blobber.Jurisdiction,
+			Operator:     blobber.Operator,
+		},
+		Reputation: blobber.Reputation,
 	}
 }
 
@@ -0,0 +1,89 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/rest"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocationQuote(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		owner    = newClient(2000*x10, balances)
+		other    = newClient(100*x10, balances)
+		tp       = int64(100)
+	)
+
+	allocID, blobs := addAllocation(t, ssc, owner, tp, 0, 0, 0, 0, 0, balances, false, false, false)
+
+	srh := NewStorageRestHandler(rest.NewRestHandler(&rest.TestQueryChainer{}))
+	srh.SetQueryStateContext(cstate.NewTimedQueryStateContext(balances, func() common.Timestamp {
+		return common.Timestamp(tp + 1)
+	}))
+
+	quote := func(handler http.HandlerFunc, req interface{}, clientID string) *AllocationQuote {
+		data, err := json.Marshal(req)
+		require.NoError(t, err)
+		query := url.Values{}
+		query.Add("data", string(data))
+		query.Add("client_id", clientID)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/?"+query.Encode(), nil))
+		require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+		var q AllocationQuote
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&q))
+		return &q
+	}
+
+	nar := &newAllocationRequest{
+		DataShards:      10,
+		ParityShards:    10,
+		Size:            GB,
+		Owner:           owner.id,
+		OwnerPublicKey:  owner.pk,
+		ReadPriceRange:  PriceRange{1 * x10, 10 * x10},
+		WritePriceRange: PriceRange{0 * x10, 20 * x10},
+	}
+	for _, b := range blobs {
+		nar.Blobbers = append(nar.Blobbers, b.id)
+		nar.BlobberAuthTickets = append(nar.BlobberAuthTickets, "")
+	}
+
+	q := quote(srh.getAllocationCreateQuote, nar, owner.id)
+	require.Empty(t, q.Error)
+	require.Len(t, q.Blobbers, nar.DataShards+nar.ParityShards)
+	var total currency.Coin
+	for _, b := range q.Blobbers {
+		require.NotZero(t, b.Cost)
+		total += b.Cost
+	}
+	require.Equal(t, total, q.MinLockDemand)
+
+	nar.Size = 1
+	q = quote(srh.getAllocationCreateQuote, nar, owner.id)
+	require.Contains(t, q.Error, "insufficient allocation size")
+	require.Empty(t, q.Blobbers)
+
+	uar := &updateAllocationRequest{ID: allocID, Size: GB}
+	q = quote(srh.getAllocationUpdateQuote, uar, owner.id)
+	require.Empty(t, q.Error)
+	require.Len(t, q.Blobbers, 20)
+	sa, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	require.Equal(t, 2*sa.mustBase().BlobberAllocs[0].Size, q.Blobbers[0].Size,
+		"the allocation isn't updated")
+
+	q = quote(srh.getAllocationUpdateQuote, uar, other.id)
+	require.Contains(t, q.Error, "only owner can update the allocation")
+}
@@ -508,6 +508,9 @@ func setConfig(t testing.TB, balances chainState.StateContextI) (
 	}
 
 	conf.CancellationCharge = 0.2
+	conf.OwnershipTransferExpiry = time.Hour
+	conf.MeteredBillingGracePeriod = time.Hour
+	conf.MeteredBillingMinPrepaidPeriod = time.Hour
 	conf.MaxIndividualFreeAllocation = 1000000
 	conf.MaxTotalFreeAllocation = 100000000000000000
 
@@ -18,7 +18,7 @@ import (
 // killBlobber
 // punitively disables a blobber. it will no longer be used for new allocations
 // or receive further rewards. Stakeholders will have their stakes slashed.
-func (_ *StorageSmartContract) killBlobber(
+func (sc *StorageSmartContract) killBlobber(
 	tx *transaction.Transaction,
 	input []byte,
 	balances cstate.StateContextI,
@@ -83,6 +83,15 @@ func (_ *StorageSmartContract) killBlobber(
 	}
 	bb := blobber.mustBase()
 
+	_, err = balances.InsertTrieNode(blobber.GetKey(), blobber)
+	if err != nil {
+		return "", common.NewError("kill_blobber_failed", "saving blobber: "+err.Error())
+	}
+
+	if err := sc.repairBlobberAllocations(tx, bb.ID, balances); err != nil {
+		return "", common.NewError("kill_blobber_failed", err.Error())
+	}
+
 	// delete the blobber from MPT if it's empty and has no stake pools
 	if bb.SavedData <= 0 && len(sp.GetPools()) == 0 {
 		// remove the blobber from MPT
@@ -98,10 +107,6 @@ func (_ *StorageSmartContract) killBlobber(
 		return "", nil
 	}
 
-	_, err = balances.InsertTrieNode(blobber.GetKey(), blobber)
-	if err != nil {
-		return "", common.NewError("kill_blobber_failed", "saving blobber: "+err.Error())
-	}
 	return "", nil
 }
 
@@ -0,0 +1,514 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+//msgp:ignore meteredBillingRequest topUpMeteredBillingRequest settleMeteredBillingRequest
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+// meteredBillingRequest opts a new allocation in the metered billing, the
+// transaction value is the initial prepaid balance instead of the write pool.
+type meteredBillingRequest struct {
+	LowBalanceThreshold currency.Coin `json:"low_balance_threshold"`
+}
+
+// topUpMeteredBillingRequest is the input of top_up_metered_billing, the
+// transaction value is added to the prepaid balance. Only the owner can
+// change the low balance threshold.
+type topUpMeteredBillingRequest struct {
+	AllocationID        string         `json:"allocation_id"`
+	LowBalanceThreshold *currency.Coin `json:"low_balance_threshold,omitempty"`
+}
+
+func (tur *topUpMeteredBillingRequest) decode(b []byte) error {
+	return json.Unmarshal(b, tur)
+}
+
+// settleMeteredBillingRequest is the input of settle_metered_billing
+type settleMeteredBillingRequest struct {
+	AllocationID string `json:"allocation_id"`
+}
+
+func (smr *settleMeteredBillingRequest) decode(b []byte) error {
+	return json.Unmarshal(b, smr)
+}
+
+func meteredBillingKey(sscKey, allocID string) datastore.Key {
+	return sscKey + ":metered:" + allocID
+}
+
+// meteredBlobber is the data size stored by a blobber of a metered
+// allocation since its last settlement, and the write price it's paid at.
+type meteredBlobber struct {
+	BlobberID  string           `json:"blobber_id"`
+	WritePrice currency.Coin    `json:"write_price"`
+	UsedSize   int64            `json:"used_size"`
+	SettledAt  common.Timestamp `json:"settled_at"`
+}
+
+// cost of the data stored by the blobber from its last settlement to now
+func (mbb *meteredBlobber) cost(now common.Timestamp, timeUnit time.Duration) (currency.Coin, error) {
+	if now <= mbb.SettledAt || mbb.UsedSize <= 0 {
+		return 0, nil
+	}
+	dtu := float64((now - mbb.SettledAt).Duration()) / float64(timeUnit)
+	return currency.MultFloat64(mbb.WritePrice, sizeInGB(mbb.UsedSize)*dtu)
+}
+
+// meteredBilling is the prepaid balance of an allocation paying its blobbers
+// per committed byte-time. Once the balance is exhausted the allocation
+// becomes read-only after the metered_billing_grace_period, usage not
+// covered by the balance is not paid.
+type meteredBilling struct {
+	AllocationID        string            `json:"allocation_id"`
+	Balance             currency.Coin     `json:"balance"`
+	LowBalanceThreshold currency.Coin     `json:"low_balance_threshold"`
+	Paid                currency.Coin     `json:"paid"`
+	ExhaustedAt         common.Timestamp  `json:"exhausted_at"`
+	LowBalanceNotified  bool              `json:"low_balance_notified"`
+	ExhaustedNotified   bool              `json:"exhausted_notified"`
+	Blobbers            []*meteredBlobber `json:"blobbers"`
+}
+
+func (mb *meteredBilling) Encode() []byte {
+	var b, err = json.Marshal(mb)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (mb *meteredBilling) Decode(p []byte) error {
+	return json.Unmarshal(p, mb)
+}
+
+func (mb *meteredBilling) save(sscKey string, balances cstate.StateContextI) error {
+	_, err := balances.InsertTrieNode(meteredBillingKey(sscKey, mb.AllocationID), mb)
+	return err
+}
+
+func (mb *meteredBilling) find(blobberID string) (int, bool) {
+	for i, b := range mb.Blobbers {
+		if b.BlobberID == blobberID {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// track returns the metered blobber of the allocation blobber, starting the
+// metering at the given size if it's not tracked yet
+func (mb *meteredBilling) track(ba *BlobberAllocation, usedSize int64, now common.Timestamp) *meteredBlobber {
+	if i, ok := mb.find(ba.BlobberID); ok {
+		return mb.Blobbers[i]
+	}
+	mbb := &meteredBlobber{
+		BlobberID:  ba.BlobberID,
+		WritePrice: ba.Terms.WritePrice,
+		UsedSize:   usedSize,
+		SettledAt:  now,
+	}
+	mb.Blobbers = append(mb.Blobbers, mbb)
+	return mbb
+}
+
+func (mb *meteredBilling) untrack(blobberID string) {
+	if i, ok := mb.find(blobberID); ok {
+		mb.Blobbers = append(mb.Blobbers[:i], mb.Blobbers[i+1:]...)
+	}
+}
+
+// isReadOnly reports whether the grace period after the balance exhaustion
+// is over
+func (mb *meteredBilling) isReadOnly(now common.Timestamp, gracePeriod time.Duration) bool {
+	return mb.ExhaustedAt > 0 && now >= mb.ExhaustedAt+toSeconds(gracePeriod)
+}
+
+func (mb *meteredBilling) topUp(value currency.Coin) error {
+	balance, err := currency.AddCoin(mb.Balance, value)
+	if err != nil {
+		return err
+	}
+	mb.Balance = balance
+	if mb.Balance > 0 {
+		mb.ExhaustedAt = 0
+		mb.ExhaustedNotified = false
+	}
+	if mb.Balance >= mb.LowBalanceThreshold {
+		mb.LowBalanceNotified = false
+	}
+	return nil
+}
+
+// settle pays the blobber from the balance for the data stored since its
+// last settlement. The stake pool of the blobber must be saved by the
+// caller.
+func (mb *meteredBilling) settle(mbb *meteredBlobber, sp *stakePool, now common.Timestamp,
+	timeUnit time.Duration, balances cstate.StateContextI) (currency.Coin, error) {
+
+	cost, err := mbb.cost(now, timeUnit)
+	if err != nil {
+		return 0, err
+	}
+	if now > mbb.SettledAt {
+		mbb.SettledAt = now
+	}
+
+	// the balance is exhausted once it's used up, even exactly
+	pay := cost
+	if cost > 0 && pay >= mb.Balance {
+		pay = mb.Balance
+		if mb.ExhaustedAt == 0 {
+			mb.ExhaustedAt = now
+		}
+	}
+	if pay == 0 {
+		return 0, nil
+	}
+
+	if err := sp.DistributeRewards(pay, mbb.BlobberID, spenum.Blobber,
+		spenum.MeteredStorageReward, balances, mb.AllocationID); err != nil {
+		return 0, fmt.Errorf("paying blobber %s: %v", mbb.BlobberID, err)
+	}
+	if mb.Balance, err = currency.MinusCoin(mb.Balance, pay); err != nil {
+		return 0, err
+	}
+	if mb.Paid, err = currency.AddCoin(mb.Paid, pay); err != nil {
+		return 0, err
+	}
+	return pay, nil
+}
+
+// settleBlobber settles the blobber loading and saving its stake pool
+func (mb *meteredBilling) settleBlobber(mbb *meteredBlobber, now common.Timestamp,
+	timeUnit time.Duration, balances cstate.StateContextI) error {
+
+	sp, err := getStakePool(spenum.Blobber, mbb.BlobberID, balances)
+	if err != nil {
+		return fmt.Errorf("can't get stake pool of %s: %v", mbb.BlobberID, err)
+	}
+	paid, err := mb.settle(mbb, sp, now, timeUnit, balances)
+	if err != nil {
+		return err
+	}
+	if paid == 0 {
+		return nil
+	}
+	return sp.Save(spenum.Blobber, mbb.BlobberID, balances)
+}
+
+// notify emits the billing update and the low balance or exhaustion alerts
+// not sent yet
+func (mb *meteredBilling) notify(owner string, closed bool, balances cstate.StateContextI) {
+	balances.EmitEvent(event.TypeStats, event.TagUpdateMeteredBilling, mb.AllocationID, event.MeteredBilling{
+		AllocationID:        mb.AllocationID,
+		Owner:               owner,
+		Balance:             mb.Balance,
+		LowBalanceThreshold: mb.LowBalanceThreshold,
+		Paid:                mb.Paid,
+		ExhaustedAt:         int64(mb.ExhaustedAt),
+		Closed:              closed,
+	})
+	if closed {
+		return
+	}
+
+	var alert bool
+	if mb.ExhaustedAt > 0 && !mb.ExhaustedNotified {
+		mb.ExhaustedNotified = true
+		mb.LowBalanceNotified = true
+		alert = true
+	}
+	if mb.Balance < mb.LowBalanceThreshold && !mb.LowBalanceNotified {
+		mb.LowBalanceNotified = true
+		alert = true
+	}
+	if !alert {
+		return
+	}
+	balances.EmitEvent(event.TypeStats, event.TagMeteredBillingLowBalance, mb.AllocationID, event.MeteredBillingAlert{
+		AllocationID:        mb.AllocationID,
+		Owner:               owner,
+		Balance:             mb.Balance,
+		LowBalanceThreshold: mb.LowBalanceThreshold,
+		Exhausted:           mb.ExhaustedAt > 0,
+		Round:               balances.GetBlock().Round,
+	})
+}
+
+func (sc *StorageSmartContract) getMeteredBilling(allocID string,
+	balances cstate.CommonStateContextI) (*meteredBilling, error) {
+
+	mb := new(meteredBilling)
+	if err := balances.GetTrieNode(meteredBillingKey(sc.ID, allocID), mb); err != nil {
+		return nil, err
+	}
+	return mb, nil
+}
+
+// findMeteredBilling returns nil for the allocations without metered billing
+func (sc *StorageSmartContract) findMeteredBilling(allocID string,
+	balances cstate.CommonStateContextI) (*meteredBilling, error) {
+
+	mb, err := sc.getMeteredBilling(allocID, balances)
+	switch err {
+	case nil:
+		return mb, nil
+	case util.ErrValueNotPresent:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("can't get metered billing: %v", err)
+	}
+}
+
+// newMeteredBilling funds the metered billing of a new allocation with the
+// transfer, it's saved once the allocation is added
+func newMeteredBilling(alloc *storageAllocationBase, req *meteredBillingRequest,
+	transfer *Transfer, balances cstate.StateContextI) (*meteredBilling, error) {
+
+	value, err := transfer.transfer(balances)
+	if err != nil {
+		return nil, err
+	}
+	return &meteredBilling{
+		AllocationID:        alloc.ID,
+		Balance:             value,
+		LowBalanceThreshold: req.LowBalanceThreshold,
+	}, nil
+}
+
+// checkFunding checks the initial prepaid balance pays for the whole size of
+// the allocation used during the given period
+func (mb *meteredBilling) checkFunding(alloc *storageAllocationBase, period time.Duration) error {
+	cost, err := alloc.cost()
+	if err != nil {
+		return fmt.Errorf("failed to get allocation cost: %v", err)
+	}
+	minPrepaid, err := currency.MultFloat64(cost, float64(period)/float64(alloc.TimeUnit))
+	if err != nil {
+		return fmt.Errorf("failed to get minimum prepaid balance: %v", err)
+	}
+	if mb.Balance == 0 || mb.Balance < minPrepaid {
+		return fmt.Errorf("not enough prepaid tokens for the metered allocation %v < %v",
+			mb.Balance, minPrepaid)
+	}
+	return nil
+}
+
+// commitMeteredUsage settles the blobber of a metered allocation for the
+// data it stored before the write marker and meters the new size. Writes
+// growing the allocation are rejected once it's read-only.
+func (sc *StorageSmartContract) commitMeteredUsage(
+	conf *Config,
+	alloc *storageAllocationBase,
+	mb *meteredBilling,
+	ba *BlobberAllocation,
+	sizeBefore, changeSize int64,
+	now common.Timestamp,
+	balances cstate.StateContextI,
+) error {
+	mbb := mb.track(ba, sizeBefore, now)
+	if err := mb.settleBlobber(mbb, now, conf.TimeUnit, balances); err != nil {
+		return err
+	}
+	if changeSize > 0 && mb.isReadOnly(now, conf.MeteredBillingGracePeriod) {
+		return errors.New("allocation is read-only, metered balance exhausted")
+	}
+
+	mbb.WritePrice = ba.Terms.WritePrice
+	mbb.UsedSize = ba.Stats.UsedSize
+	mb.notify(alloc.Owner, false, balances)
+	return mb.save(sc.ID, balances)
+}
+
+// settleRemovedMeteredBlobber pays the blobber removed from a metered
+// allocation up to now and stops metering it
+func (sc *StorageSmartContract) settleRemovedMeteredBlobber(alloc *storageAllocationBase,
+	blobberID string, now common.Timestamp, balances cstate.StateContextI) error {
+
+	mb, err := sc.findMeteredBilling(alloc.ID, balances)
+	if err != nil || mb == nil {
+		return err
+	}
+	i, ok := mb.find(blobberID)
+	if !ok {
+		return nil
+	}
+
+	conf, err := getConfig(balances)
+	if err != nil {
+		return fmt.Errorf("can't get config: %v", err)
+	}
+	if err := mb.settleBlobber(mb.Blobbers[i], now, conf.TimeUnit, balances); err != nil {
+		return err
+	}
+	mb.untrack(blobberID)
+	mb.notify(alloc.Owner, false, balances)
+	return mb.save(sc.ID, balances)
+}
+
+// closeMeteredBilling settles the blobbers of a finished metered allocation
+// up to its expiration and refunds the rest of the balance to the owner.
+// The given stake pools of the allocation blobbers are saved by the caller.
+func (sc *StorageSmartContract) closeMeteredBilling(
+	alloc *storageAllocationBase,
+	sps []*stakePool,
+	now common.Timestamp,
+	conf *Config,
+	balances cstate.StateContextI,
+) error {
+	mb, err := sc.findMeteredBilling(alloc.ID, balances)
+	if err != nil || mb == nil {
+		return err
+	}
+
+	if now > alloc.Expiration {
+		now = alloc.Expiration
+	}
+	for i, ba := range alloc.BlobberAllocs {
+		mbb := mb.track(ba, ba.Stats.UsedSize, now)
+		if _, err := mb.settle(mbb, sps[i], now, conf.TimeUnit, balances); err != nil {
+			return err
+		}
+		mb.untrack(ba.BlobberID)
+	}
+	for _, mbb := range mb.Blobbers {
+		if err := mb.settleBlobber(mbb, now, conf.TimeUnit, balances); err != nil {
+			return err
+		}
+	}
+	mb.Blobbers = nil
+
+	if mb.Balance > 0 {
+		if err := balances.AddTransfer(state.NewTransfer(sc.ID, alloc.Owner, mb.Balance)); err != nil {
+			return fmt.Errorf("could not refund metered balance: %v", err)
+		}
+		mb.Balance = 0
+	}
+	mb.notify(alloc.Owner, true, balances)
+
+	_, err = balances.DeleteTrieNode(meteredBillingKey(sc.ID, alloc.ID))
+	return err
+}
+
+// topUpMeteredBilling adds the transaction value to the prepaid balance of a
+// metered allocation, any client can top it up. An exhausted allocation
+// accepts writes again once topped up.
+func (sc *StorageSmartContract) topUpMeteredBilling(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req topUpMeteredBillingRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("top_up_metered_billing_failed",
+			"invalid request: "+err.Error())
+	}
+
+	mb, err := sc.getMeteredBilling(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("top_up_metered_billing_failed",
+			"can't get metered billing: "+err.Error())
+	}
+
+	alloc, err := sc.getAllocation(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("top_up_metered_billing_failed", err.Error())
+	}
+	owner := alloc.mustBase().Owner
+
+	if req.LowBalanceThreshold != nil {
+		if t.ClientID != owner {
+			return "", common.NewError("top_up_metered_billing_failed",
+				"only owner can change the low balance threshold")
+		}
+		mb.LowBalanceThreshold = *req.LowBalanceThreshold
+	}
+	if t.Value == 0 && req.LowBalanceThreshold == nil {
+		return "", common.NewError("top_up_metered_billing_failed",
+			"no tokens to top up")
+	}
+
+	if _, err := NewTokenTransfer(t.Value, t.ClientID, t.ToClientID, false).transfer(balances); err != nil {
+		return "", common.NewError("top_up_metered_billing_failed", err.Error())
+	}
+	if err := mb.topUp(t.Value); err != nil {
+		return "", common.NewError("top_up_metered_billing_failed", err.Error())
+	}
+
+	mb.notify(owner, false, balances)
+	if err := mb.save(sc.ID, balances); err != nil {
+		return "", common.NewError("top_up_metered_billing_failed",
+			"saving metered billing: "+err.Error())
+	}
+	return string(mb.Encode()), nil
+}
+
+// settleMeteredBilling pays the blobbers of a metered allocation up to now,
+// any client can send it. It makes the exhaustion of the balance effective
+// for the allocations without writes.
+func (sc *StorageSmartContract) settleMeteredBilling(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req settleMeteredBillingRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("settle_metered_billing_failed",
+			"invalid request: "+err.Error())
+	}
+
+	mb, err := sc.getMeteredBilling(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("settle_metered_billing_failed",
+			"can't get metered billing: "+err.Error())
+	}
+
+	sa, err := sc.getAllocation(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("settle_metered_billing_failed", err.Error())
+	}
+	alloc := sa.mustBase()
+
+	conf, err := getConfig(balances)
+	if err != nil {
+		return "", common.NewError("settle_metered_billing_failed",
+			"can't get config: "+err.Error())
+	}
+
+	now := t.CreationDate
+	if now > alloc.Expiration {
+		now = alloc.Expiration
+	}
+	for _, mbb := range mb.Blobbers {
+		if err := mb.settleBlobber(mbb, now, conf.TimeUnit, balances); err != nil {
+			return "", common.NewError("settle_metered_billing_failed", err.Error())
+		}
+	}
+	for i := len(mb.Blobbers) - 1; i >= 0; i-- {
+		if _, ok := alloc.BlobberAllocsMap[mb.Blobbers[i].BlobberID]; !ok {
+			mb.untrack(mb.Blobbers[i].BlobberID)
+		}
+	}
+
+	mb.notify(alloc.Owner, false, balances)
+	if err := mb.save(sc.ID, balances); err != nil {
+		return "", common.NewError("settle_metered_billing_failed",
+			"saving metered billing: "+err.Error())
+	}
+	return string(mb.Encode()), nil
+}
@@ -0,0 +1,267 @@
+package storagesc
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *meteredBilling) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 8
+	// string "AllocationID"
+	o = append(o, 0x88, 0xac, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44)
+	o = msgp.AppendString(o, z.AllocationID)
+	// string "Balance"
+	o = append(o, 0xa7, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65)
+	o, err = z.Balance.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Balance")
+		return
+	}
+	// string "LowBalanceThreshold"
+	o = append(o, 0xb3, 0x4c, 0x6f, 0x77, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64)
+	o, err = z.LowBalanceThreshold.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "LowBalanceThreshold")
+		return
+	}
+	// string "Paid"
+	o = append(o, 0xa4, 0x50, 0x61, 0x69, 0x64)
+	o, err = z.Paid.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Paid")
+		return
+	}
+	// string "ExhaustedAt"
+	o = append(o, 0xab, 0x45, 0x78, 0x68, 0x61, 0x75, 0x73, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o, err = z.ExhaustedAt.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "ExhaustedAt")
+		return
+	}
+	// string "LowBalanceNotified"
+	o = append(o, 0xb2, 0x4c, 0x6f, 0x77, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.LowBalanceNotified)
+	// string "ExhaustedNotified"
+	o = append(o, 0xb1, 0x45, 0x78, 0x68, 0x61, 0x75, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.ExhaustedNotified)
+	// string "Blobbers"
+	o = append(o, 0xa8, 0x42, 0x6c, 0x6f, 0x62, 0x62, 0x65, 0x72, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Blobbers)))
+	for za0001 := range z.Blobbers {
+		if z.Blobbers[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Blobbers[za0001].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Blobbers", za0001)
+				return
+			}
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *meteredBilling) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "AllocationID":
+			z.AllocationID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AllocationID")
+				return
+			}
+		case "Balance":
+			bts, err = z.Balance.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Balance")
+				return
+			}
+		case "LowBalanceThreshold":
+			bts, err = z.LowBalanceThreshold.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LowBalanceThreshold")
+				return
+			}
+		case "Paid":
+			bts, err = z.Paid.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Paid")
+				return
+			}
+		case "ExhaustedAt":
+			bts, err = z.ExhaustedAt.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ExhaustedAt")
+				return
+			}
+		case "LowBalanceNotified":
+			z.LowBalanceNotified, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LowBalanceNotified")
+				return
+			}
+		case "ExhaustedNotified":
+			z.ExhaustedNotified, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ExhaustedNotified")
+				return
+			}
+		case "Blobbers":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Blobbers")
+				return
+			}
+			if cap(z.Blobbers) >= int(zb0002) {
+				z.Blobbers = (z.Blobbers)[:zb0002]
+			} else {
+				z.Blobbers = make([]*meteredBlobber, zb0002)
+			}
+			for za0001 := range z.Blobbers {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Blobbers[za0001] = nil
+				} else {
+					if z.Blobbers[za0001] == nil {
+						z.Blobbers[za0001] = new(meteredBlobber)
+					}
+					bts, err = z.Blobbers[za0001].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Blobbers", za0001)
+						return
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *meteredBilling) Msgsize() (s int) {
+	s = 1 + 13 + msgp.StringPrefixSize + len(z.AllocationID) + 8 + z.Balance.Msgsize() + 20 + z.LowBalanceThreshold.Msgsize() + 5 + z.Paid.Msgsize() + 12 + z.ExhaustedAt.Msgsize() + 19 + msgp.BoolSize + 18 + msgp.BoolSize + 9 + msgp.ArrayHeaderSize
+	for za0001 := range z.Blobbers {
+		if z.Blobbers[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Blobbers[za0001].Msgsize()
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *meteredBlobber) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "BlobberID"
+	o = append(o, 0x84, 0xa9, 0x42, 0x6c, 0x6f, 0x62, 0x62, 0x65, 0x72, 0x49, 0x44)
+	o = msgp.AppendString(o, z.BlobberID)
+	// string "WritePrice"
+	o = append(o, 0xaa, 0x57, 0x72, 0x69, 0x74, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65)
+	o, err = z.WritePrice.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "WritePrice")
+		return
+	}
+	// string "UsedSize"
+	o = append(o, 0xa8, 0x55, 0x73, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65)
+	o = msgp.AppendInt64(o, z.UsedSize)
+	// string "SettledAt"
+	o = append(o, 0xa9, 0x53, 0x65, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x41, 0x74)
+	o, err = z.SettledAt.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "SettledAt")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *meteredBlobber) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "BlobberID":
+			z.BlobberID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BlobberID")
+				return
+			}
+		case "WritePrice":
+			bts, err = z.WritePrice.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "WritePrice")
+				return
+			}
+		case "UsedSize":
+			z.UsedSize, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "UsedSize")
+				return
+			}
+		case "SettledAt":
+			bts, err = z.SettledAt.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SettledAt")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *meteredBlobber) Msgsize() (s int) {
+	s = 1 + 10 + msgp.StringPrefixSize + len(z.BlobberID) + 11 + z.WritePrice.Msgsize() + 9 + msgp.Int64Size + 10 + z.SettledAt.Msgsize()
+	return
+}
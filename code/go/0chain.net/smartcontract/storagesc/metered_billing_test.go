@@ -0,0 +1,251 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeteredBilling(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		owner    = newClient(2000*x10, balances)
+		other    = newClient(100*x10, balances)
+		tp       = int64(100)
+	)
+
+	setConfig(t, balances)
+	allocID, _ := addAllocation(t, ssc, owner, tp, 0, 0, 0, 0, 0, balances, false, false, false)
+	conf, err := getConfig(balances)
+	require.NoError(t, err)
+
+	sa, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	alloc := sa.mustBase()
+	ba := alloc.BlobberAllocs[0]
+
+	// cost of 1 GB stored by the blobber for a time unit
+	perTimeUnit := ba.Terms.WritePrice
+	require.NotZero(t, perTimeUnit)
+	require.NoError(t, (&meteredBilling{
+		AllocationID:        allocID,
+		Balance:             perTimeUnit + perTimeUnit/2,
+		LowBalanceThreshold: perTimeUnit,
+	}).save(ssc.ID, balances))
+
+	getBilling := func() *meteredBilling {
+		mb, err := ssc.getMeteredBilling(allocID, balances)
+		require.NoError(t, err)
+		return mb
+	}
+	commit := func(size int64, now common.Timestamp) error {
+		before := ba.Stats.UsedSize
+		ba.Stats.UsedSize += size
+		return ssc.commitMeteredUsage(conf, alloc, getBilling(), ba, before, size, now, balances)
+	}
+	topUp := func(c *Client, value currency.Coin, threshold *currency.Coin) error {
+		input, err := json.Marshal(&topUpMeteredBillingRequest{
+			AllocationID:        allocID,
+			LowBalanceThreshold: threshold,
+		})
+		require.NoError(t, err)
+		tx := newTransaction(c.id, ADDRESS, value, tp)
+		balances.setTransaction(t, tx)
+		_, err = ssc.topUpMeteredBilling(tx, input, balances)
+		return err
+	}
+
+	var (
+		now   = common.Timestamp(tp)
+		unit  = toSeconds(conf.TimeUnit)
+		grace = toSeconds(conf.MeteredBillingGracePeriod)
+	)
+
+	require.NoError(t, commit(GB, now))
+	mb := getBilling()
+	require.Len(t, mb.Blobbers, 1)
+	require.Zero(t, mb.Paid, "the first write marker starts the metering")
+
+	// 1 GB for a time unit is paid, the balance is below the threshold
+	require.NoError(t, commit(0, now+unit))
+	mb = getBilling()
+	require.EqualValues(t, perTimeUnit, mb.Paid)
+	require.EqualValues(t, perTimeUnit/2, mb.Balance)
+	require.Zero(t, mb.ExhaustedAt)
+	require.True(t, mb.LowBalanceNotified)
+
+	// the balance is exhausted, writes are accepted within the grace period
+	require.NoError(t, commit(MB, now+2*unit))
+	mb = getBilling()
+	require.Zero(t, mb.Balance)
+	require.EqualValues(t, now+2*unit, mb.ExhaustedAt)
+	require.ErrorContains(t, commit(MB, now+2*unit+grace), "read-only")
+	ba.Stats.UsedSize -= MB
+	require.NoError(t, commit(-MB, now+2*unit+grace), "deletes are accepted")
+
+	require.Error(t, topUp(other, 0, &perTimeUnit), "only owner can change the threshold")
+	require.NoError(t, topUp(other, 2*perTimeUnit, nil))
+	mb = getBilling()
+	require.Zero(t, mb.ExhaustedAt)
+	require.False(t, mb.LowBalanceNotified)
+	require.NoError(t, commit(MB, now+2*unit+grace))
+
+	// the rest of the balance is refunded on finalization
+	sps := make([]*stakePool, 0, len(alloc.BlobberAllocs))
+	for _, ba := range alloc.BlobberAllocs {
+		sp, err := ssc.getStakePool(spenum.Blobber, ba.BlobberID, balances)
+		require.NoError(t, err)
+		sps = append(sps, sp)
+	}
+	balances.setTransaction(t, newTransaction(owner.id, ADDRESS, 0, tp))
+	ownerBalance := balances.balances[owner.id]
+	rest := getBilling().Balance
+	require.NoError(t, ssc.closeMeteredBilling(alloc, sps, alloc.Expiration, conf, balances))
+	require.Equal(t, ownerBalance+rest, balances.balances[owner.id])
+	_, err = ssc.getMeteredBilling(allocID, balances)
+	require.Equal(t, util.ErrValueNotPresent, err)
+}
+
+func TestNewMeteredAllocation(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   currency.Coin
+		wantErr string
+	}{
+		{
+			name:    "unfunded",
+			value:   0,
+			wantErr: "not enough prepaid tokens",
+		},
+		{
+			name:    "below the minimum prepaid balance",
+			value:   x10 / 100,
+			wantErr: "not enough prepaid tokens",
+		},
+		{
+			name:  "minimum prepaid balance",
+			value: x10 / 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ssc      = newTestStorageSC()
+				balances = newTestBalances(t, false)
+				owner    = newClient(2000*x10, balances)
+				tp       = int64(100)
+			)
+			setConfig(t, balances)
+
+			nar := &newAllocationRequest{
+				DataShards:      10,
+				ParityShards:    10,
+				Owner:           owner.id,
+				OwnerPublicKey:  owner.pk,
+				ReadPriceRange:  PriceRange{1 * x10, 10 * x10},
+				WritePriceRange: PriceRange{0 * x10, 20 * x10},
+				Size:            GB,
+				Metered:         &meteredBillingRequest{},
+			}
+			for i := 0; i < 20; i++ {
+				b := addBlobber(t, ssc, 2*GB, tp, avgTerms, 50*x10, balances, false, false)
+				nar.Blobbers = append(nar.Blobbers, b.id)
+				nar.BlobberAuthTickets = append(nar.BlobberAuthTickets, "")
+			}
+
+			resp, err := nar.callNewAllocReq(t, owner.id, tt.value, ssc, tp, balances)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var sa StorageAllocation
+			require.NoError(t, sa.Decode([]byte(resp)))
+			mb, err := ssc.getMeteredBilling(sa.mustBase().ID, balances)
+			require.NoError(t, err)
+			require.Equal(t, tt.value, mb.Balance)
+			require.Zero(t, sa.mustBase().WritePool)
+		})
+	}
+}
+
+func TestMeteredBillingSettle(t *testing.T) {
+	const price = currency.Coin(10 * x10)
+
+	tests := []struct {
+		name          string
+		balance       currency.Coin
+		wantPaid      currency.Coin
+		wantExhausted bool
+	}{
+		{
+			name:     "balance above the cost",
+			balance:  2 * price,
+			wantPaid: price,
+		},
+		{
+			name:          "balance equal to the cost",
+			balance:       price,
+			wantPaid:      price,
+			wantExhausted: true,
+		},
+		{
+			name:          "balance below the cost",
+			balance:       price / 2,
+			wantPaid:      price / 2,
+			wantExhausted: true,
+		},
+		{
+			name:          "empty balance",
+			balance:       0,
+			wantExhausted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ssc      = newTestStorageSC()
+				balances = newTestBalances(t, false)
+				owner    = newClient(2000*x10, balances)
+				tp       = int64(100)
+			)
+			allocID, _ := addAllocation(t, ssc, owner, tp, 0, 0, 0, 0, 0, balances, false, false, false)
+			conf, err := getConfig(balances)
+			require.NoError(t, err)
+			sa, err := ssc.getAllocation(allocID, balances)
+			require.NoError(t, err)
+			ba := sa.mustBase().BlobberAllocs[0]
+			sp, err := ssc.getStakePool(spenum.Blobber, ba.BlobberID, balances)
+			require.NoError(t, err)
+
+			// 1 GB stored for a time unit
+			now := common.Timestamp(tp)
+			mb := &meteredBilling{AllocationID: allocID, Balance: tt.balance}
+			mbb := &meteredBlobber{
+				BlobberID:  ba.BlobberID,
+				WritePrice: price,
+				UsedSize:   GB,
+				SettledAt:  now,
+			}
+			end := now + toSeconds(conf.TimeUnit)
+			paid, err := mb.settle(mbb, sp, end, conf.TimeUnit, balances)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantPaid, paid)
+			require.Equal(t, tt.balance-tt.wantPaid, mb.Balance)
+			if tt.wantExhausted {
+				require.Equal(t, end, mb.ExhaustedAt)
+			} else {
+				require.Zero(t, mb.ExhaustedAt)
+			}
+		})
+	}
+}
@@ -533,6 +533,10 @@ func (d *BlobberAllocation) removeBlobberPassRates(alloc *storageAllocationBase,
 				return 0.0, err
 			}
 
+			if err := updateBlobberReputation(sc.ID, oc.BlobberID, false, balances); err != nil {
+				return 0.0, err
+			}
+
 		} else {
 			d.Stats.SuccessChallenges++
 			alloc.Stats.SuccessChallenges++
@@ -593,7 +597,7 @@ func (d *BlobberAllocation) payChallengePoolPassPayments(alloc *storageAllocatio
 		return 0, 0, nil
 	}
 
-	challengePenaltyPaid, err := d.challengePenaltyOnFinalization(conf, alloc, balances, sp)
+	challengePenaltyPaid, err := d.challengePenaltyOnFinalization(conf, alloc, balances, sp, cp, sc)
 	if err != nil {
 		return 0, 0, common.NewError("challenge_penalty_on_finalization_error", err.Error())
 	}
@@ -663,7 +667,7 @@ func (d *BlobberAllocation) challengeRewardOnFinalization(timeUnit time.Duration
 	return payment, nil
 }
 
-func (d *BlobberAllocation) challengePenaltyOnFinalization(conf *Config, alloc *storageAllocationBase, balances chainstate.StateContextI, sp *stakePool) (currency.Coin, error) {
+func (d *BlobberAllocation) challengePenaltyOnFinalization(conf *Config, alloc *storageAllocationBase, balances chainstate.StateContextI, sp *stakePool, cp *challengePool, sc *StorageSmartContract) (currency.Coin, error) {
 	if d.LatestSuccessfulChallCreatedAt >= d.LatestFinalizedChallCreatedAt {
 		return 0, nil
 	}
@@ -702,11 +706,20 @@ func (d *BlobberAllocation) challengePenaltyOnFinalization(conf *Config, alloc *
 	if conf.BlobberSlash > 0 && move > 0 &&
 		slash > 0 {
 
-		dpMove, err := sp.slash(d.BlobberID, d.Offer(), slash, balances, alloc.ID)
+		// the failed challenges are not known at finalization
+		evidence := failedChallengesEvidence("", d.LatestSuccessfulChallCreatedAt, d.LatestFinalizedChallCreatedAt)
+		dpMove, penalties, err := sp.slash(d.BlobberID, d.Offer(), slash, balances, alloc.ID, evidence)
 		if err != nil {
 			return 0, fmt.Errorf("can't slash tokens: %v", err)
 		}
 
+		// the slash is held in escrow like the one of a failed challenge
+		err = sc.escrowChallengeSlash(alloc.ID, d.BlobberID, d.LatestSuccessfulChallCreatedAt,
+			d.LatestFinalizedChallCreatedAt, penalties, cp, balances)
+		if err != nil {
+			return 0, fmt.Errorf("can't escrow slashed tokens: %v", err)
+		}
+
 		penalty, err := currency.AddCoin(d.Penalty, dpMove) // penalty statistic
 		if err != nil {
 			return 0, err
@@ -1249,8 +1262,7 @@ func (sab *storageAllocationBase) replaceBlobber(blobberID string, sc *StorageSm
 			}
 
 			if blobberIsKilled {
-				sab.BlobberAllocs[i] = addedBlobberAllocation
-				sab.BlobberAllocsMap[addedBlobberAllocation.BlobberID] = addedBlobberAllocation
+				sab.swapBlobberAllocation(i, addedBlobberAllocation)
 				break
 			}
 
@@ -1329,8 +1341,7 @@ func (sab *storageAllocationBase) replaceBlobber(blobberID string, sc *StorageSm
 			emitUpdateBlobberAllocatedSavedHealth(blobber, balances)
 
 			sab.Stats.UsedSize += -d.Stats.UsedSize
-			sab.BlobberAllocs[i] = addedBlobberAllocation
-			sab.BlobberAllocsMap[addedBlobberAllocation.BlobberID] = addedBlobberAllocation
+			sab.swapBlobberAllocation(i, addedBlobberAllocation)
 			break
 		}
 	}
@@ -1338,6 +1349,17 @@ func (sab *storageAllocationBase) replaceBlobber(blobberID string, sc *StorageSm
 	return nil
 }
 
+// swapBlobberAllocation puts the added blobber allocation in place of the
+// i-th one, or drops the i-th one when there's none to add
+func (sab *storageAllocationBase) swapBlobberAllocation(i int, added *BlobberAllocation) {
+	if added == nil {
+		sab.BlobberAllocs = append(sab.BlobberAllocs[:i], sab.BlobberAllocs[i+1:]...)
+		return
+	}
+	sab.BlobberAllocs[i] = added
+	sab.BlobberAllocsMap[added.BlobberID] = added
+}
+
 func replaceBlobber(
 	sa *storageAllocationBase,
 	blobbers []*StorageNode,
@@ -1346,6 +1368,10 @@ func replaceBlobber(
 	sc *StorageSmartContract,
 	txn *transaction.Transaction,
 	addedBlobber *StorageNode, addedBlobberAllocation *BlobberAllocation, now common.Timestamp, isEnterpriseBlobber bool) ([]*StorageNode, error) {
+	if err := sc.settleRemovedMeteredBlobber(sa, blobberID, now, balances); err != nil {
+		return nil, fmt.Errorf("settling metered blobber %s: %v", blobberID, err)
+	}
+
 	if err := sa.replaceBlobber(blobberID, sc, balances, txn, addedBlobberAllocation, now, isEnterpriseBlobber); err != nil {
 		return nil, err
 	}
@@ -1366,46 +1392,46 @@ func replaceBlobber(
 	return blobbers, nil
 }
 
-func (sab *storageAllocationBase) changeBlobbers(
+// newAllocationBlobber checks the blobber can be added to the allocation and
+// reserves the size on it
+func (sab *storageAllocationBase) newAllocationBlobber(
 	conf *Config,
-	blobbers []*StorageNode,
-	addId, authTicket, removeId string,
+	addId, authTicket string,
+	size int64,
 	now common.Timestamp,
 	balances cstate.StateContextI,
-	sc *StorageSmartContract,
-	txn *transaction.Transaction,
 	isEnterpriseBlobber bool,
-) ([]*StorageNode, error) {
+) (*StorageNode, *BlobberAllocation, *stakePool, error) {
 	var err error
 
 	_, found := sab.BlobberAllocsMap[addId]
 	if found {
-		return nil, fmt.Errorf("allocation already has blobber %s", addId)
+		return nil, nil, nil, fmt.Errorf("allocation already has blobber %s", addId)
 	}
 
 	addedBlobber, err := getBlobber(addId, balances)
 	if err != nil {
-		return nil, fmt.Errorf("can't get blobber %s to add : %v", addId, err)
+		return nil, nil, nil, fmt.Errorf("can't get blobber %s to add : %v", addId, err)
 	}
 
 	bb := addedBlobber.mustBase()
 
 	var sp *stakePool
 	if sp, err = getStakePool(spenum.Blobber, bb.ID, balances); err != nil {
-		return nil, fmt.Errorf("can't get blobber's stake pool: %v", err)
+		return nil, nil, nil, fmt.Errorf("can't get blobber's stake pool: %v", err)
 	}
 	staked, err := sp.stake()
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	stakedCapacity, err := sp.stakedCapacity(bb.Terms.WritePrice)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	if err := sab.isActive(addedBlobber, staked, sp.TotalOffers, stakedCapacity, conf, now); err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	if actErr := cstate.WithActivation(balances, "electra",
@@ -1446,17 +1472,32 @@ func (sab *storageAllocationBase) changeBlobbers(
 				return nil
 			})
 		}); actErr != nil {
-		return nil, actErr
+		return nil, nil, nil, actErr
 	}
 
 	//nolint:errcheck
 	addedBlobber.mustUpdateBase(func(b *storageNodeBase) error {
-		b.Allocated += sab.bSize() // Why increase allocation then check if the free capacity is enough?
+		b.Allocated += size // Why increase allocation then check if the free capacity is enough?
 		return nil
 	})
-	afterSize := sab.bSize()
 
-	ba := newBlobberAllocation(afterSize, sab, addedBlobber.mustBase(), conf, now)
+	return addedBlobber, newBlobberAllocation(size, sab, addedBlobber.mustBase(), conf, now), sp, nil
+}
+
+func (sab *storageAllocationBase) changeBlobbers(
+	conf *Config,
+	blobbers []*StorageNode,
+	addId, authTicket, removeId string,
+	now common.Timestamp,
+	balances cstate.StateContextI,
+	sc *StorageSmartContract,
+	txn *transaction.Transaction,
+	isEnterpriseBlobber bool,
+) ([]*StorageNode, error) {
+	addedBlobber, ba, sp, err := sab.newAllocationBlobber(conf, addId, authTicket, sab.bSize(), now, balances, isEnterpriseBlobber)
+	if err != nil {
+		return nil, err
+	}
 
 	if len(removeId) > 0 {
 		if blobbers, err = replaceBlobber(sab, blobbers, removeId, balances, sc, txn, addedBlobber, ba, now, isEnterpriseBlobber); err != nil {
@@ -1549,7 +1590,17 @@ func (sab *storageAllocationBase) validateEachBlobber(
 	var (
 		errs     = make([]string, 0, len(blobbers))
 		filtered = make([]*StorageNode, 0, len(blobbers))
+		// the reputation is not tracked before its hard fork
+		minReputation float64
 	)
+	if actErr := cstate.WithActivation(balances, reputationHardFork, func() error {
+		return nil
+	}, func() error {
+		minReputation = request.MinReputation
+		return nil
+	}); actErr != nil {
+		return nil, []string{actErr.Error()}
+	}
 	for i, b := range blobbers {
 		sn := StorageNode{}
 
@@ -1595,6 +1646,12 @@ func (sab *storageAllocationBase) validateEachBlobber(
 			continue
 		}
 
+		if minReputation > 0 && b.Reputation < minReputation {
+			errs = append(errs, fmt.Sprintf("blobber %s reputation %.3f is below %.3f",
+				b.ID, b.Reputation, minReputation))
+			continue
+		}
+
 		filtered = append(filtered, &sn)
 	}
 	return filtered, errs
@@ -1772,6 +1829,18 @@ func (sab *storageAllocationBase) removeExpiredChallenges(
 			if err != nil {
 				return 0, err
 			}
+
+			if err := updateBlobberReputation(sc.ID, oc.BlobberID, false, balances); err != nil {
+				return 0, err
+			}
+
+			if _, err := sc.countAutoRepairFailure(sab.ID, oc.BlobberID, balances); err != nil {
+				return 0, err
+			}
+
+			if err := sc.openChallengeDispute(sab.ID, oc.ID, nil, balances); err != nil {
+				return 0, err
+			}
 		}
 	}
 
@@ -1793,15 +1862,17 @@ func (sab *storageAllocationBase) removeExpiredChallenges(
 	return len(expChalIDs), nil
 }
 
-// removeOldChallenges removes all open challenges from the allocation that are old
+// removeOldChallenges removes all open challenges from the allocation that are old,
+// it returns the latest of them, the evidence of the slash of the blobber for them
 func (sab *storageAllocationBase) removeOldChallenges(
 	allocChallenges *AllocationChallenges,
 	balances cstate.StateContextI,
 	currentChallenge *StorageChallenge,
 	sc *StorageSmartContract,
-) error {
+) (latestRemovedID string, err error) {
 	var nonRemovedChallenges []*AllocOpenChallenge
 	var expChalIDs []string
+	var latestRemovedAt common.Timestamp
 
 	for _, oc := range allocChallenges.OpenChallenges {
 		if oc.RoundCreatedAt >= currentChallenge.RoundCreatedAt || oc.BlobberID != currentChallenge.BlobberID {
@@ -1828,6 +1899,9 @@ func (sab *storageAllocationBase) removeOldChallenges(
 			if ba.LatestFinalizedChallCreatedAt < oc.CreatedAt {
 				ba.LatestFinalizedChallCreatedAt = oc.CreatedAt
 			}
+			if latestRemovedID == "" || latestRemovedAt < oc.CreatedAt {
+				latestRemovedID, latestRemovedAt = oc.ID, oc.CreatedAt
+			}
 
 			err := emitUpdateChallenge(&StorageChallenge{
 				ID:           oc.ID,
@@ -1836,7 +1910,15 @@ func (sab *storageAllocationBase) removeOldChallenges(
 			}, false, ChallengeOldRemoved, balances, sab.Stats)
 
 			if err != nil {
-				return err
+				return "", err
+			}
+
+			if err := updateBlobberReputation(sc.ID, oc.BlobberID, false, balances); err != nil {
+				return "", err
+			}
+
+			if err := sc.openChallengeDispute(sab.ID, oc.ID, nil, balances); err != nil {
+				return "", err
 			}
 		}
 	}
@@ -1848,11 +1930,11 @@ func (sab *storageAllocationBase) removeOldChallenges(
 	for _, challengeID := range expChalIDs {
 		_, err := balances.DeleteTrieNode(storageChallengeKey(sc.ID, challengeID))
 		if err != nil {
-			return common.NewErrorf("remove_old_challenges", "could not delete challenge node: %v", err)
+			return "", common.NewErrorf("remove_old_challenges", "could not delete challenge node: %v", err)
 		}
 	}
 
-	return nil
+	return latestRemovedID, nil
 }
 
 // Clone implements statecache.Value interface
@@ -0,0 +1,373 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/core/datastore"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/dto"
+	"github.com/0chain/common/core/util"
+)
+
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+const (
+	maxPlacementFieldLength = 64
+	maxPlacementRules       = 32
+)
+
+func blobberPlacementKey(sscKey, blobberID string) datastore.Key {
+	return sscKey + ":blobberplacement:" + blobberID
+}
+
+func placementConstraintsKey(sscKey, allocID string) datastore.Key {
+	return sscKey + ":placement:" + allocID
+}
+
+// blobberPlacement is the location and the operator of a blobber declared on
+// registration or with update_blobber_settings. All the fields are optional
+// and stored lower case.
+type blobberPlacement struct {
+	Region       string `json:"region"`
+	Country      string `json:"country"`
+	Jurisdiction string `json:"jurisdiction"`
+	Operator     string `json:"operator"`
+}
+
+func newBlobberPlacement(p *dto.BlobberPlacement) *blobberPlacement {
+	return &blobberPlacement{
+		Region:       p.Region,
+		Country:      p.Country,
+		Jurisdiction: p.Jurisdiction,
+		Operator:     p.Operator,
+	}
+}
+
+func normalizePlacementField(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+func (bp *blobberPlacement) normalize() {
+	bp.Region = normalizePlacementField(bp.Region)
+	bp.Country = normalizePlacementField(bp.Country)
+	bp.Jurisdiction = normalizePlacementField(bp.Jurisdiction)
+	bp.Operator = normalizePlacementField(bp.Operator)
+}
+
+func (bp *blobberPlacement) validate() error {
+	for _, f := range []struct{ name, value string }{
+		{"region", bp.Region},
+		{"country", bp.Country},
+		{"jurisdiction", bp.Jurisdiction},
+		{"operator", bp.Operator},
+	} {
+		if len(f.value) > maxPlacementFieldLength {
+			return fmt.Errorf("%s is longer than %d characters", f.name, maxPlacementFieldLength)
+		}
+	}
+	return nil
+}
+
+// operator of the blobber, blobbers without declared operator are considered
+// run by distinct operators
+func (bp *blobberPlacement) operator(blobberID string) string {
+	if bp.Operator == "" {
+		return "blobber:" + blobberID
+	}
+	return bp.Operator
+}
+
+func (bp *blobberPlacement) Encode() []byte {
+	var b, err = json.Marshal(bp)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (bp *blobberPlacement) Decode(p []byte) error {
+	return json.Unmarshal(p, bp)
+}
+
+func (bp *blobberPlacement) emitUpdate(blobberID string, balances cstate.StateContextI) {
+	balances.EmitEvent(event.TypeStats, event.TagUpdateBlobberPlacement, blobberID, event.BlobberPlacement{
+		BlobberID:    blobberID,
+		Region:       bp.Region,
+		Country:      bp.Country,
+		Jurisdiction: bp.Jurisdiction,
+		Operator:     bp.Operator,
+	})
+}
+
+// getBlobberPlacement returns the placement of the blobber, empty if the
+// blobber didn't declare it
+func getBlobberPlacement(blobberID string, balances cstate.CommonStateContextI) (*blobberPlacement, error) {
+	bp := new(blobberPlacement)
+	err := balances.GetTrieNode(blobberPlacementKey(ADDRESS, blobberID), bp)
+	switch err {
+	case nil, util.ErrValueNotPresent:
+		return bp, nil
+	default:
+		return nil, err
+	}
+}
+
+// setBlobberPlacement saves the blobber placement and updates it in the
+// event db
+func setBlobberPlacement(blobberID string, p *dto.BlobberPlacement, balances cstate.StateContextI) error {
+	bp := newBlobberPlacement(p)
+	bp.normalize()
+	if err := bp.validate(); err != nil {
+		return fmt.Errorf("invalid placement: %v", err)
+	}
+	if _, err := balances.InsertTrieNode(blobberPlacementKey(ADDRESS, blobberID), bp); err != nil {
+		return fmt.Errorf("saving placement: %v", err)
+	}
+	bp.emitUpdate(blobberID, balances)
+	return nil
+}
+
+// regionMinimum requires at least Min blobbers of an allocation in the region
+type regionMinimum struct {
+	Region string `json:"region"`
+	Min    int    `json:"min"`
+}
+
+// placementConstraints restrict the blobbers of an allocation by their
+// declared placement. Blobbers without region never count in the region
+// minimums and blobbers without jurisdiction are excluded as soon as any
+// jurisdiction is excluded or any region is required.
+type placementConstraints struct {
+	RegionMinimums        []regionMinimum `json:"region_minimums,omitempty"`
+	ExcludedJurisdictions []string        `json:"excluded_jurisdictions,omitempty"`
+	DistinctOperators     bool            `json:"distinct_operators,omitempty"`
+}
+
+func (pc *placementConstraints) normalize() {
+	for i := range pc.RegionMinimums {
+		pc.RegionMinimums[i].Region = normalizePlacementField(pc.RegionMinimums[i].Region)
+	}
+	for i := range pc.ExcludedJurisdictions {
+		pc.ExcludedJurisdictions[i] = normalizePlacementField(pc.ExcludedJurisdictions[i])
+	}
+}
+
+// validate the constraints can be met by an allocation of the given number
+// of blobbers
+func (pc *placementConstraints) validate(numBlobbers int) error {
+	if len(pc.RegionMinimums)+len(pc.ExcludedJurisdictions) > maxPlacementRules {
+		return fmt.Errorf("too many placement rules, max %d", maxPlacementRules)
+	}
+
+	var (
+		total   int
+		regions = make(map[string]struct{}, len(pc.RegionMinimums))
+	)
+	for _, rm := range pc.RegionMinimums {
+		if rm.Region == "" {
+			return errors.New("missing region of region minimum")
+		}
+		if rm.Min <= 0 {
+			return fmt.Errorf("invalid minimum %d of region %s", rm.Min, rm.Region)
+		}
+		if _, ok := regions[rm.Region]; ok {
+			return fmt.Errorf("duplicate region %s", rm.Region)
+		}
+		regions[rm.Region] = struct{}{}
+		total += rm.Min
+	}
+	if total > numBlobbers {
+		return fmt.Errorf("region minimums require %d blobbers, allocation has %d",
+			total, numBlobbers)
+	}
+
+	for _, j := range pc.ExcludedJurisdictions {
+		if j == "" {
+			return errors.New("empty excluded jurisdiction")
+		}
+	}
+	return nil
+}
+
+// isExcluded by the jurisdiction constraints, a blobber with no jurisdiction
+// can't prove it's outside the excluded ones
+func (pc *placementConstraints) isExcluded(bp *blobberPlacement) bool {
+	if bp.Jurisdiction == "" {
+		return len(pc.ExcludedJurisdictions) > 0
+	}
+	for _, j := range pc.ExcludedJurisdictions {
+		if j == bp.Jurisdiction {
+			return true
+		}
+	}
+	return false
+}
+
+// selectBlobbers chooses n blobbers meeting the constraints, first the ones
+// required by the region minimums then the others in the given order. The
+// indexes of the selected blobbers are returned in the given order, followed
+// by the indexes of the other eligible blobbers.
+func (pc *placementConstraints) selectBlobbers(ids []string, n int,
+	balances cstate.CommonStateContextI) (selected, others []int, err error) {
+
+	placements := make([]*blobberPlacement, len(ids))
+	for i, id := range ids {
+		if placements[i], err = getBlobberPlacement(id, balances); err != nil {
+			return nil, nil, fmt.Errorf("can't get placement of blobber %s: %v", id, err)
+		}
+	}
+
+	var (
+		picked    = make([]bool, len(ids))
+		operators = make(map[string]struct{})
+	)
+	pick := func(i int) bool {
+		if picked[i] || pc.isExcluded(placements[i]) {
+			return false
+		}
+		if pc.DistinctOperators {
+			op := placements[i].operator(ids[i])
+			if _, ok := operators[op]; ok {
+				return false
+			}
+			operators[op] = struct{}{}
+		}
+		picked[i] = true
+		selected = append(selected, i)
+		return true
+	}
+
+	for _, rm := range pc.RegionMinimums {
+		var count int
+		for i := 0; i < len(ids) && count < rm.Min; i++ {
+			if placements[i].Region == rm.Region && pick(i) {
+				count++
+			}
+		}
+		if count < rm.Min {
+			return nil, nil, fmt.Errorf("not enough blobbers in region %s: %d < %d",
+				rm.Region, count, rm.Min)
+		}
+	}
+
+	for i := 0; i < len(ids) && len(selected) < n; i++ {
+		pick(i)
+	}
+	if len(selected) < n {
+		return nil, nil, fmt.Errorf("not enough blobbers meeting the placement constraints: %d < %d",
+			len(selected), n)
+	}
+	sort.Ints(selected)
+
+	for i := range ids {
+		if !picked[i] && !pc.isExcluded(placements[i]) {
+			others = append(others, i)
+		}
+	}
+	return selected, others, nil
+}
+
+// check the blobbers of an allocation meet the constraints
+func (pc *placementConstraints) check(ids []string, balances cstate.CommonStateContextI) error {
+	var (
+		regions   = make(map[string]int)
+		operators = make(map[string]string)
+	)
+	for _, id := range ids {
+		bp, err := getBlobberPlacement(id, balances)
+		if err != nil {
+			return fmt.Errorf("can't get placement of blobber %s: %v", id, err)
+		}
+		if pc.isExcluded(bp) {
+			if bp.Jurisdiction == "" {
+				return fmt.Errorf("blobber %s has no declared jurisdiction", id)
+			}
+			return fmt.Errorf("blobber %s is in excluded jurisdiction %s", id, bp.Jurisdiction)
+		}
+		if pc.DistinctOperators {
+			op := bp.operator(id)
+			if other, ok := operators[op]; ok {
+				return fmt.Errorf("blobbers %s and %s have the same operator %s", other, id, op)
+			}
+			operators[op] = id
+		}
+		regions[bp.Region]++
+	}
+
+	for _, rm := range pc.RegionMinimums {
+		if regions[rm.Region] < rm.Min {
+			return fmt.Errorf("not enough blobbers in region %s: %d < %d",
+				rm.Region, regions[rm.Region], rm.Min)
+		}
+	}
+	return nil
+}
+
+func (pc *placementConstraints) Encode() []byte {
+	var b, err = json.Marshal(pc)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (pc *placementConstraints) Decode(p []byte) error {
+	return json.Unmarshal(p, pc)
+}
+
+func (sc *StorageSmartContract) getPlacementConstraints(allocID string,
+	balances cstate.CommonStateContextI) (*placementConstraints, error) {
+
+	pc := new(placementConstraints)
+	if err := balances.GetTrieNode(placementConstraintsKey(sc.ID, allocID), pc); err != nil {
+		return nil, err
+	}
+	return pc, nil
+}
+
+// updatePlacementConstraints replaces the placement constraints of the
+// allocation if given and checks the allocation blobbers meet the current
+// constraints
+func (sc *StorageSmartContract) updatePlacementConstraints(alloc *storageAllocationBase,
+	pc *placementConstraints, balances cstate.StateContextI) error {
+
+	saved, err := sc.getPlacementConstraints(alloc.ID, balances)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		if pc == nil {
+			return nil
+		}
+	default:
+		return fmt.Errorf("can't get placement constraints: %v", err)
+	}
+
+	if pc != nil {
+		pc.normalize()
+		if err := pc.validate(alloc.DataShards + alloc.ParityShards); err != nil {
+			return fmt.Errorf("invalid placement constraints: %v", err)
+		}
+	} else {
+		pc = saved
+	}
+
+	ids := make([]string, 0, len(alloc.BlobberAllocs))
+	for _, ba := range alloc.BlobberAllocs {
+		ids = append(ids, ba.BlobberID)
+	}
+	if err := pc.check(ids, balances); err != nil {
+		return fmt.Errorf("allocation blobbers don't meet the placement constraints: %v", err)
+	}
+
+	if pc != saved {
+		if _, err := balances.InsertTrieNode(placementConstraintsKey(sc.ID, alloc.ID), pc); err != nil {
+			return fmt.Errorf("saving placement constraints: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+package storagesc
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *blobberPlacement) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "Region"
+	o = append(o, 0x84, 0xa6, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendString(o, z.Region)
+	// string "Country"
+	o = append(o, 0xa7, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79)
+	o = msgp.AppendString(o, z.Country)
+	// string "Jurisdiction"
+	o = append(o, 0xac, 0x4a, 0x75, 0x72, 0x69, 0x73, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendString(o, z.Jurisdiction)
+	// string "Operator"
+	o = append(o, 0xa8, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72)
+	o = msgp.AppendString(o, z.Operator)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *blobberPlacement) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Region":
+			z.Region, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Region")
+				return
+			}
+		case "Country":
+			z.Country, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Country")
+				return
+			}
+		case "Jurisdiction":
+			z.Jurisdiction, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Jurisdiction")
+				return
+			}
+		case "Operator":
+			z.Operator, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Operator")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *blobberPlacement) Msgsize() (s int) {
+	s = 1 + 7 + msgp.StringPrefixSize + len(z.Region) + 8 + msgp.StringPrefixSize + len(z.Country) + 13 + msgp.StringPrefixSize + len(z.Jurisdiction) + 9 + msgp.StringPrefixSize + len(z.Operator)
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *placementConstraints) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "RegionMinimums"
+	o = append(o, 0x83, 0xae, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.RegionMinimums)))
+	for za0001 := range z.RegionMinimums {
+		// map header, size 2
+		// string "Region"
+		o = append(o, 0x82, 0xa6, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e)
+		o = msgp.AppendString(o, z.RegionMinimums[za0001].Region)
+		// string "Min"
+		o = append(o, 0xa3, 0x4d, 0x69, 0x6e)
+		o = msgp.AppendInt(o, z.RegionMinimums[za0001].Min)
+	}
+	// string "ExcludedJurisdictions"
+	o = append(o, 0xb5, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x4a, 0x75, 0x72, 0x69, 0x73, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.ExcludedJurisdictions)))
+	for za0002 := range z.ExcludedJurisdictions {
+		o = msgp.AppendString(o, z.ExcludedJurisdictions[za0002])
+	}
+	// string "DistinctOperators"
+	o = append(o, 0xb1, 0x44, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73)
+	o = msgp.AppendBool(o, z.DistinctOperators)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *placementConstraints) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "RegionMinimums":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "RegionMinimums")
+				return
+			}
+			if cap(z.RegionMinimums) >= int(zb0002) {
+				z.RegionMinimums = (z.RegionMinimums)[:zb0002]
+			} else {
+				z.RegionMinimums = make([]regionMinimum, zb0002)
+			}
+			for za0001 := range z.RegionMinimums {
+				var zb0003 uint32
+				zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "RegionMinimums", za0001)
+					return
+				}
+				for zb0003 > 0 {
+					zb0003--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "RegionMinimums", za0001)
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "Region":
+						z.RegionMinimums[za0001].Region, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "RegionMinimums", za0001, "Region")
+							return
+						}
+					case "Min":
+						z.RegionMinimums[za0001].Min, bts, err = msgp.ReadIntBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "RegionMinimums", za0001, "Min")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "RegionMinimums", za0001)
+							return
+						}
+					}
+				}
+			}
+		case "ExcludedJurisdictions":
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ExcludedJurisdictions")
+				return
+			}
+			if cap(z.ExcludedJurisdictions) >= int(zb0004) {
+				z.ExcludedJurisdictions = (z.ExcludedJurisdictions)[:zb0004]
+			} else {
+				z.ExcludedJurisdictions = make([]string, zb0004)
+			}
+			for za0002 := range z.ExcludedJurisdictions {
+				z.ExcludedJurisdictions[za0002], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "ExcludedJurisdictions", za0002)
+					return
+				}
+			}
+		case "DistinctOperators":
+			z.DistinctOperators, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DistinctOperators")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *placementConstraints) Msgsize() (s int) {
+	s = 1 + 15 + msgp.ArrayHeaderSize
+	for za0001 := range z.RegionMinimums {
+		s += 1 + 7 + msgp.StringPrefixSize + len(z.RegionMinimums[za0001].Region) + 4 + msgp.IntSize
+	}
+	s += 22 + msgp.ArrayHeaderSize
+	for za0002 := range z.ExcludedJurisdictions {
+		s += msgp.StringPrefixSize + len(z.ExcludedJurisdictions[za0002])
+	}
+	s += 18 + msgp.BoolSize
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z regionMinimum) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Region"
+	o = append(o, 0x82, 0xa6, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendString(o, z.Region)
+	// string "Min"
+	o = append(o, 0xa3, 0x4d, 0x69, 0x6e)
+	o = msgp.AppendInt(o, z.Min)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *regionMinimum) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Region":
+			z.Region, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Region")
+				return
+			}
+		case "Min":
+			z.Min, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Min")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z regionMinimum) Msgsize() (s int) {
+	s = 1 + 7 + msgp.StringPrefixSize + len(z.Region) + 4 + msgp.IntSize
+	return
+}
@@ -0,0 +1,165 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"0chain.net/smartcontract/dto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlacementConstraints(t *testing.T) {
+	balances := newTestBalances(t, false)
+
+	placements := []dto.BlobberPlacement{
+		{Region: "EU", Jurisdiction: "DE", Operator: "op1"},
+		{Region: "eu ", Jurisdiction: "fr", Operator: "OP1"},
+		{Region: "us", Jurisdiction: "us", Operator: "op2"},
+		{Region: "eu", Jurisdiction: "x", Operator: "op3"},
+		{},
+		{Region: "eu", Operator: "op4"},
+		{Region: "eu", Jurisdiction: "ch", Operator: "op5"},
+		{Jurisdiction: "jp", Operator: "op6"},
+	}
+	ids := make([]string, 0, len(placements))
+	for i := range placements {
+		id := getMockBlobberId(i)
+		require.NoError(t, setBlobberPlacement(id, &placements[i], balances))
+		ids = append(ids, id)
+	}
+
+	bp, err := getBlobberPlacement(ids[1], balances)
+	require.NoError(t, err)
+	require.Equal(t, blobberPlacement{Region: "eu", Jurisdiction: "fr", Operator: "op1"}, *bp)
+
+	pc := &placementConstraints{
+		RegionMinimums:        []regionMinimum{{Region: "EU", Min: 2}},
+		ExcludedJurisdictions: []string{"X"},
+		DistinctOperators:     true,
+	}
+	pc.normalize()
+	require.NoError(t, pc.validate(4))
+	require.Error(t, pc.validate(1), "region minimums above the number of blobbers")
+	require.Error(t, (&placementConstraints{
+		RegionMinimums: []regionMinimum{{Region: "eu", Min: 1}, {Region: "eu", Min: 1}},
+	}).validate(4), "duplicate region")
+
+	selected, others, err := pc.selectBlobbers(ids, 4, balances)
+	require.NoError(t, err)
+	// the excluded blobber 3, the blobbers 4 and 5 without jurisdiction and
+	// the second blobber of op1 are left out
+	require.Equal(t, []int{0, 2, 6, 7}, selected)
+	require.Equal(t, []int{1}, others)
+
+	_, _, err = pc.selectBlobbers(ids, 5, balances)
+	require.Error(t, err, "not enough blobbers with distinct operators")
+
+	_, _, err = (&placementConstraints{
+		RegionMinimums: []regionMinimum{{Region: "us", Min: 2}},
+	}).selectBlobbers(ids, 4, balances)
+	require.Error(t, err, "not enough blobbers in region")
+
+	require.NoError(t, pc.check([]string{ids[0], ids[2], ids[6]}, balances))
+	require.ErrorContains(t, pc.check([]string{ids[0], ids[2], ids[5]}, balances),
+		"no declared jurisdiction")
+	require.Error(t, pc.check([]string{ids[0], ids[1], ids[2]}, balances), "same operator")
+	require.Error(t, pc.check([]string{ids[0], ids[3], ids[5]}, balances), "excluded jurisdiction")
+	require.Error(t, pc.check([]string{ids[0], ids[2], ids[4]}, balances), "one blobber in eu")
+}
+
+func TestPlacementExcludedJurisdiction(t *testing.T) {
+	tests := []struct {
+		name     string
+		pc       placementConstraints
+		bp       blobberPlacement
+		excluded bool
+	}{
+		{
+			name: "no constraints, no jurisdiction",
+			pc:   placementConstraints{DistinctOperators: true},
+			bp:   blobberPlacement{Region: "eu"},
+		},
+		{
+			name:     "excluded jurisdictions, no jurisdiction",
+			pc:       placementConstraints{ExcludedJurisdictions: []string{"x"}},
+			bp:       blobberPlacement{Region: "eu"},
+			excluded: true,
+		},
+		{
+			name: "region minimums, no jurisdiction",
+			pc:   placementConstraints{RegionMinimums: []regionMinimum{{Region: "eu", Min: 1}}},
+			bp:   blobberPlacement{Region: "eu"},
+		},
+		{
+			name:     "excluded jurisdiction",
+			pc:       placementConstraints{ExcludedJurisdictions: []string{"x"}},
+			bp:       blobberPlacement{Jurisdiction: "x"},
+			excluded: true,
+		},
+		{
+			name: "other jurisdiction",
+			pc:   placementConstraints{ExcludedJurisdictions: []string{"x"}},
+			bp:   blobberPlacement{Jurisdiction: "y"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.excluded, tt.pc.isExcluded(&tt.bp))
+		})
+	}
+}
+
+func TestUpdateAllocationPlacement(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		owner    = newClient(2000*x10, balances)
+		tp       = int64(100)
+	)
+
+	setConfig(t, balances)
+	allocID, _ := addAllocation(t, ssc, owner, tp, 0, 0, 0, 0, 0, balances, false, false, false)
+
+	sa, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	alloc := sa.mustBase()
+	for i, ba := range alloc.BlobberAllocs {
+		region := "eu"
+		if i%2 == 1 {
+			region = "us"
+		}
+		require.NoError(t, setBlobberPlacement(ba.BlobberID, &dto.BlobberPlacement{
+			Region:       region,
+			Jurisdiction: region,
+		}, balances))
+	}
+
+	update := func(pc *placementConstraints) error {
+		input, err := json.Marshal(&updateAllocationRequest{
+			ID:        allocID,
+			OwnerID:   owner.id,
+			Placement: pc,
+		})
+		require.NoError(t, err)
+		tp += 100
+		tx := newTransaction(owner.id, ssc.ID, 0, tp)
+		balances.setTransaction(t, tx)
+		_, err = ssc.updateAllocationRequest(tx, input, balances)
+		return err
+	}
+
+	numEU := (len(alloc.BlobberAllocs) + 1) / 2
+	require.ErrorContains(t, update(&placementConstraints{
+		RegionMinimums: []regionMinimum{{Region: "eu", Min: numEU + 1}},
+	}), "placement constraints")
+	_, err = ssc.getPlacementConstraints(allocID, balances)
+	require.Error(t, err, "constraints not saved on failure")
+
+	require.NoError(t, update(&placementConstraints{
+		RegionMinimums: []regionMinimum{{Region: "EU", Min: numEU}},
+	}))
+	pc, err := ssc.getPlacementConstraints(allocID, balances)
+	require.NoError(t, err)
+	require.Equal(t, []regionMinimum{{Region: "eu", Min: numEU}}, pc.RegionMinimums)
+}
@@ -0,0 +1,310 @@
+package storagesc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/util"
+)
+
+// MAX_READ_ACL_ENTRIES limits the number of readers of an allocation ACL
+const MAX_READ_ACL_ENTRIES = 1000
+
+//msgp:ignore readACLRequest
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+// readACLRequest is the input of the read ACL functions. The reader is given
+// either by the client ID or by the public key. The quota in bytes and the
+// expiration are only used to set an entry, zero means no limit.
+type readACLRequest struct {
+	AllocationID string           `json:"allocation_id"`
+	ClientID     string           `json:"client_id,omitempty"`
+	PublicKey    string           `json:"public_key,omitempty"`
+	Quota        int64            `json:"quota,omitempty"`
+	ExpiresAt    common.Timestamp `json:"expires_at,omitempty"`
+}
+
+func (rar *readACLRequest) decode(b []byte) error {
+	return json.Unmarshal(b, rar)
+}
+
+// readerID returns the client ID of the reader, derived from the public key
+// if given.
+func (rar *readACLRequest) readerID() (string, error) {
+	if rar.PublicKey == "" {
+		if rar.ClientID == "" {
+			return "", errors.New("missing client id or public key")
+		}
+		return rar.ClientID, nil
+	}
+
+	pkb, err := hex.DecodeString(rar.PublicKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid public key: %v", err)
+	}
+	id := encryption.Hash(pkb)
+	if rar.ClientID != "" && rar.ClientID != id {
+		return "", errors.New("public key doesn't match the client id")
+	}
+	return id, nil
+}
+
+func readACLKey(sscKey, allocID string) datastore.Key {
+	return sscKey + ":readacl:" + allocID
+}
+
+// readACLEntry allows a client to redeem read markers of an allocation,
+// ReadSize is the number of bytes already redeemed by the client.
+type readACLEntry struct {
+	ClientID  string           `json:"client_id"`
+	PublicKey string           `json:"public_key,omitempty"`
+	Quota     int64            `json:"quota"`
+	ExpiresAt common.Timestamp `json:"expires_at"`
+	ReadSize  int64            `json:"read_size"`
+}
+
+// readACL is the list of clients allowed to read from an allocation besides
+// its owner. Allocations without ACL can be read by any client.
+type readACL struct {
+	AllocationID string          `json:"allocation_id"`
+	Entries      []*readACLEntry `json:"entries"`
+}
+
+func (acl *readACL) Encode() []byte {
+	var b, err = json.Marshal(acl)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (acl *readACL) Decode(p []byte) error {
+	return json.Unmarshal(p, acl)
+}
+
+func (acl *readACL) save(sscKey string, balances cstate.StateContextI) error {
+	_, err := balances.InsertTrieNode(readACLKey(sscKey, acl.AllocationID), acl)
+	return err
+}
+
+func (acl *readACL) find(clientID string) (int, bool) {
+	for i, e := range acl.Entries {
+		if e.ClientID == clientID {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// redeem checks the client can read the given number of bytes at the given
+// time and accounts them in its quota
+func (acl *readACL) redeem(clientID string, size int64, now common.Timestamp) error {
+	i, ok := acl.find(clientID)
+	if !ok {
+		return errors.New("client is not allowed to read from the allocation")
+	}
+
+	e := acl.Entries[i]
+	if e.ExpiresAt > 0 && e.ExpiresAt < now {
+		return errors.New("client read access expired")
+	}
+	if e.Quota > 0 && e.ReadSize+size > e.Quota {
+		return fmt.Errorf("client read quota exceeded: %d + %d > %d",
+			e.ReadSize, size, e.Quota)
+	}
+	e.ReadSize += size
+	return nil
+}
+
+func (sc *StorageSmartContract) getReadACL(allocID string,
+	balances cstate.CommonStateContextI) (*readACL, error) {
+
+	acl := new(readACL)
+	if err := balances.GetTrieNode(readACLKey(sc.ID, allocID), acl); err != nil {
+		return nil, err
+	}
+	return acl, nil
+}
+
+// getOwnedAllocation returns the active allocation if it's owned by the client
+func (sc *StorageSmartContract) getOwnedAllocation(allocID, clientID string,
+	now common.Timestamp, balances cstate.StateContextI) (*storageAllocationBase, error) {
+
+	sa, err := sc.getTransferableAllocation(allocID, now, balances)
+	if err != nil {
+		return nil, err
+	}
+
+	alloc := sa.mustBase()
+	if alloc.Owner != clientID {
+		return nil, errors.New("only owner can manage the allocation read ACL")
+	}
+	return alloc, nil
+}
+
+// setReadACLEntry adds a reader to the allocation ACL or updates the quota
+// and the expiration of an existing one, keeping its redeemed read size.
+// Once an allocation has an ACL, only the owner and the listed clients can
+// redeem read markers.
+func (sc *StorageSmartContract) setReadACLEntry(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req readACLRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("set_read_acl_entry_failed",
+			"invalid request: "+err.Error())
+	}
+	readerID, err := req.readerID()
+	if err != nil {
+		return "", common.NewError("set_read_acl_entry_failed", err.Error())
+	}
+	if req.Quota < 0 {
+		return "", common.NewError("set_read_acl_entry_failed",
+			"negative read quota")
+	}
+	if req.ExpiresAt > 0 && req.ExpiresAt < t.CreationDate {
+		return "", common.NewError("set_read_acl_entry_failed",
+			"expiration is in the past")
+	}
+
+	alloc, err := sc.getOwnedAllocation(req.AllocationID, t.ClientID, t.CreationDate, balances)
+	if err != nil {
+		return "", common.NewError("set_read_acl_entry_failed", err.Error())
+	}
+
+	acl, err := sc.getReadACL(alloc.ID, balances)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		acl = &readACL{AllocationID: alloc.ID}
+	default:
+		return "", common.NewError("set_read_acl_entry_failed", err.Error())
+	}
+
+	if i, ok := acl.find(readerID); ok {
+		acl.Entries[i].Quota = req.Quota
+		acl.Entries[i].ExpiresAt = req.ExpiresAt
+		if req.PublicKey != "" {
+			acl.Entries[i].PublicKey = req.PublicKey
+		}
+	} else {
+		if len(acl.Entries) >= MAX_READ_ACL_ENTRIES {
+			return "", common.NewError("set_read_acl_entry_failed",
+				fmt.Sprintf("read ACL can't have more than %d entries", MAX_READ_ACL_ENTRIES))
+		}
+		acl.Entries = append(acl.Entries, &readACLEntry{
+			ClientID:  readerID,
+			PublicKey: req.PublicKey,
+			Quota:     req.Quota,
+			ExpiresAt: req.ExpiresAt,
+		})
+	}
+
+	if err := acl.save(sc.ID, balances); err != nil {
+		return "", common.NewError("set_read_acl_entry_failed",
+			"saving read ACL: "+err.Error())
+	}
+	return string(acl.Encode()), nil
+}
+
+// removeReadACLEntry removes a reader from the allocation ACL, the ACL is
+// kept even if empty so that only the owner can read.
+func (sc *StorageSmartContract) removeReadACLEntry(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req readACLRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("remove_read_acl_entry_failed",
+			"invalid request: "+err.Error())
+	}
+	readerID, err := req.readerID()
+	if err != nil {
+		return "", common.NewError("remove_read_acl_entry_failed", err.Error())
+	}
+
+	alloc, err := sc.getOwnedAllocation(req.AllocationID, t.ClientID, t.CreationDate, balances)
+	if err != nil {
+		return "", common.NewError("remove_read_acl_entry_failed", err.Error())
+	}
+
+	acl, err := sc.getReadACL(alloc.ID, balances)
+	if err != nil {
+		return "", common.NewError("remove_read_acl_entry_failed",
+			"can't get read ACL: "+err.Error())
+	}
+
+	i, ok := acl.find(readerID)
+	if !ok {
+		return "", common.NewError("remove_read_acl_entry_failed",
+			"client is not in the read ACL")
+	}
+	acl.Entries = append(acl.Entries[:i], acl.Entries[i+1:]...)
+
+	if err := acl.save(sc.ID, balances); err != nil {
+		return "", common.NewError("remove_read_acl_entry_failed",
+			"saving read ACL: "+err.Error())
+	}
+	return string(acl.Encode()), nil
+}
+
+// deleteReadACL removes the allocation ACL, any client can read again
+func (sc *StorageSmartContract) deleteReadACL(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req readACLRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("delete_read_acl_failed",
+			"invalid request: "+err.Error())
+	}
+
+	alloc, err := sc.getOwnedAllocation(req.AllocationID, t.ClientID, t.CreationDate, balances)
+	if err != nil {
+		return "", common.NewError("delete_read_acl_failed", err.Error())
+	}
+
+	if _, err := balances.DeleteTrieNode(readACLKey(sc.ID, alloc.ID)); err != nil {
+		return "", common.NewError("delete_read_acl_failed",
+			"deleting read ACL: "+err.Error())
+	}
+	return "read ACL deleted", nil
+}
+
+// checkReadACL enforces the allocation ACL on a read marker redeemed at the
+// given time, the owner is always allowed to read. The expiry is checked at
+// the redeem time rather than the marker timestamp, which is chosen by the
+// client. The updated ACL is returned to be saved with the redeem, nil if
+// the allocation has no ACL.
+func (sc *StorageSmartContract) checkReadACL(alloc *storageAllocationBase,
+	rm *ReadMarker, size int64, now common.Timestamp, balances cstate.StateContextI) (*readACL, error) {
+
+	if rm.ClientID == alloc.Owner {
+		return nil, nil
+	}
+
+	acl, err := sc.getReadACL(alloc.ID, balances)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("can't get read ACL: %v", err)
+	}
+
+	if err := acl.redeem(rm.ClientID, size, now); err != nil {
+		return nil, err
+	}
+	return acl, nil
+}
@@ -0,0 +1,139 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"testing"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadACL(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		owner    = newClient(2000*x10, balances)
+		reader   = newClient(100*x10, balances)
+		other    = newClient(100*x10, balances)
+		tp       = int64(100)
+	)
+
+	setConfig(t, balances)
+	allocID, blobs := addAllocation(t, ssc, owner, tp, 0, 0, 0, 0, 0, balances, false, false, false)
+
+	sa, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	var blobber *Client
+	for _, b := range blobs {
+		if b.id == sa.mustBase().BlobberAllocs[0].BlobberID {
+			blobber = b
+		}
+	}
+	require.NotNil(t, blobber)
+
+	for _, c := range []*Client{reader, other} {
+		tp += 100
+		tx := newTransaction(c.id, ssc.ID, 10*x10, tp)
+		balances.setTransaction(t, tx)
+		_, err = ssc.readPoolLock(tx, mustEncode(t, &readPoolLockRequest{
+			TargetId: c.id,
+		}), balances)
+		require.NoError(t, err)
+	}
+
+	aclRequest := func(req readACLRequest) []byte {
+		req.AllocationID = allocID
+		b, err := json.Marshal(&req)
+		require.NoError(t, err)
+		return b
+	}
+	type aclFunc func(*transaction.Transaction, []byte, cstate.StateContextI) (string, error)
+	manage := func(c *Client, f aclFunc, req readACLRequest) error {
+		tx := newTransaction(c.id, ADDRESS, 0, tp)
+		balances.setTransaction(t, tx)
+		_, err := f(tx, aclRequest(req), balances)
+		return err
+	}
+	set, remove, deleteACL := aclFunc(ssc.setReadACLEntry), aclFunc(ssc.removeReadACLEntry), aclFunc(ssc.deleteReadACL)
+	readAt := func(c *Client, counter, markedAt int64) error {
+		rc := &ReadConnection{ReadMarker: &ReadMarker{
+			ClientID:        c.id,
+			ClientPublicKey: c.pk,
+			BlobberID:       blobber.id,
+			AllocationID:    allocID,
+			OwnerID:         owner.id,
+			Timestamp:       common.Timestamp(markedAt),
+			ReadCounter:     counter,
+		}}
+		rc.ReadMarker.Signature, err = c.scheme.Sign(
+			encryption.Hash(rc.ReadMarker.GetHashData()))
+		require.NoError(t, err)
+		tx := newTransaction(blobber.id, ssc.ID, 0, tp)
+		balances.setTransaction(t, tx)
+		_, err := ssc.commitBlobberRead(tx, mustEncode(t, rc), balances)
+		return err
+	}
+	read := func(c *Client, counter int64) error {
+		tp += 100
+		return readAt(c, counter, tp)
+	}
+
+	// no ACL, any client can read
+	require.NoError(t, read(other, 1))
+
+	require.Error(t, manage(other, set, readACLRequest{ClientID: reader.id}),
+		"only owner can manage the ACL")
+	require.Error(t, manage(owner, set, readACLRequest{ClientID: other.id, PublicKey: reader.pk}),
+		"public key doesn't match the client id")
+	require.NoError(t, manage(owner, set, readACLRequest{
+		PublicKey: reader.pk,
+		Quota:     2 * CHUNK_SIZE,
+	}))
+
+	acl, err := ssc.getReadACL(allocID, balances)
+	require.NoError(t, err)
+	require.Len(t, acl.Entries, 1)
+	require.Equal(t, reader.id, acl.Entries[0].ClientID)
+
+	require.ErrorContains(t, read(other, 2), "read ACL", "client not in the ACL")
+	require.NoError(t, read(reader, 1))
+	require.NoError(t, read(reader, 2))
+	require.ErrorContains(t, read(reader, 3), "read ACL", "quota exceeded")
+
+	acl, err = ssc.getReadACL(allocID, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 2*CHUNK_SIZE, acl.Entries[0].ReadSize)
+
+	// the redeemed read size is kept when the quota is raised
+	require.NoError(t, manage(owner, set, readACLRequest{
+		ClientID:  reader.id,
+		Quota:     3 * CHUNK_SIZE,
+		ExpiresAt: common.Timestamp(tp + 150),
+	}))
+	require.NoError(t, read(reader, 3))
+	require.ErrorContains(t, read(reader, 4), "read ACL", "quota exceeded")
+	require.NoError(t, manage(owner, set, readACLRequest{ClientID: reader.id}))
+
+	require.NoError(t, manage(owner, set, readACLRequest{
+		ClientID:  other.id,
+		ExpiresAt: common.Timestamp(tp + 50),
+	}))
+	tp += 100
+	require.ErrorContains(t, readAt(other, 2, tp-90), "read ACL",
+		"marker from before the expiry redeemed after it")
+	require.ErrorContains(t, read(other, 2), "read ACL", "access expired")
+
+	require.NoError(t, manage(owner, remove, readACLRequest{ClientID: reader.id}))
+	require.Error(t, manage(owner, remove, readACLRequest{ClientID: reader.id}),
+		"client removed twice")
+	require.ErrorContains(t, read(reader, 4), "read ACL", "client removed from the ACL")
+
+	require.NoError(t, manage(owner, deleteACL, readACLRequest{}))
+	_, err = ssc.getReadACL(allocID, balances)
+	require.Equal(t, util.ErrValueNotPresent, err)
+	require.NoError(t, read(reader, 4))
+}
@@ -0,0 +1,330 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+//msgp:ignore readSubPoolLockRequest readSubPoolUnlockRequest
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+// maxReadSubPoolsPerAllocation bounds the read sub-pools funded by the owner
+// of an allocation. The readers fund their own sub-pools only once the owner
+// added them to the read ACL, so they're bounded by the ACL and can't take
+// the place of the owner's sub-pools.
+const maxReadSubPoolsPerAllocation = 20
+
+// readSubPoolLockRequest is the input of read_sub_pool_lock, the transaction
+// value is added to the sub-pool of the sender for the allocation and the
+// reader. The name, the limit and the expiration of the sub-pool are replaced.
+type readSubPoolLockRequest struct {
+	AllocationID string `json:"allocation_id"`
+	// Reader paid by the sub-pool, any reader of the allocation if empty
+	Reader string `json:"reader,omitempty"`
+	Name   string `json:"name,omitempty"`
+	// Limit of the tokens spent by the sub-pool, zero for no limit
+	Limit currency.Coin `json:"limit,omitempty"`
+	// ExpiresAt is the time the sub-pool stops paying reads, zero never
+	ExpiresAt common.Timestamp `json:"expires_at,omitempty"`
+}
+
+func (lr *readSubPoolLockRequest) decode(b []byte) error {
+	return json.Unmarshal(b, lr)
+}
+
+func (lr *readSubPoolLockRequest) validate(now common.Timestamp) error {
+	if lr.AllocationID == "" {
+		return errors.New("missing allocation_id")
+	}
+	if lr.ExpiresAt != 0 && lr.ExpiresAt <= now {
+		return errors.New("expiration in the past")
+	}
+	return nil
+}
+
+// readSubPoolUnlockRequest is the input of read_sub_pool_unlock
+type readSubPoolUnlockRequest struct {
+	AllocationID string `json:"allocation_id"`
+	Reader       string `json:"reader,omitempty"`
+}
+
+func (ur *readSubPoolUnlockRequest) decode(b []byte) error {
+	return json.Unmarshal(b, ur)
+}
+
+func allocationReadSubPoolsKey(sscKey, allocID string) datastore.Key {
+	return sscKey + ":readsubpools:" + allocID
+}
+
+func readSubPoolID(allocID, owner, reader string) string {
+	return encryption.Hash(allocID + ":" + owner + ":" + reader)
+}
+
+// readSubPool is a part of the read tokens of its owner reserved for the
+// reads of an allocation, by any reader or by a single one.
+type readSubPool struct {
+	ID        string           `json:"id"`
+	Name      string           `json:"name"`
+	Owner     string           `json:"owner"`
+	Reader    string           `json:"reader"`
+	Balance   currency.Coin    `json:"balance"`
+	Limit     currency.Coin    `json:"limit"`
+	Spent     currency.Coin    `json:"spent"`
+	ExpiresAt common.Timestamp `json:"expires_at"`
+}
+
+// canPay the value of a read marker of the reader at the given time
+func (rsp *readSubPool) canPay(reader string, value currency.Coin, now common.Timestamp) bool {
+	if rsp.Reader != "" && rsp.Reader != reader {
+		return false
+	}
+	if rsp.ExpiresAt != 0 && now > rsp.ExpiresAt {
+		return false
+	}
+	if value > rsp.Balance {
+		return false
+	}
+	return rsp.Limit == 0 || rsp.Spent+value <= rsp.Limit
+}
+
+func (rsp *readSubPool) moveToBlobber(allocID, blobID string,
+	sp *stakePool, value currency.Coin, balances cstate.StateContextI) (string, error) {
+
+	rp := &readPool{Balance: rsp.Balance}
+	resp, err := rp.moveToBlobber(allocID, blobID, sp, value, balances)
+	if err != nil {
+		return "", err
+	}
+	spent, err := currency.AddCoin(rsp.Spent, value)
+	if err != nil {
+		return "", err
+	}
+	rsp.Balance, rsp.Spent = rp.Balance, spent
+	return resp, nil
+}
+
+// allocationReadSubPools are the read sub-pools of an allocation, they're
+// created by the allocation owner or by a reader for its own reads.
+type allocationReadSubPools struct {
+	AllocationID string         `json:"allocation_id"`
+	Pools        []*readSubPool `json:"pools"`
+}
+
+func (arsp *allocationReadSubPools) Encode() []byte {
+	var b, err = json.Marshal(arsp)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (arsp *allocationReadSubPools) Decode(p []byte) error {
+	return json.Unmarshal(p, arsp)
+}
+
+func (arsp *allocationReadSubPools) save(sscKey string, balances cstate.StateContextI) error {
+	if len(arsp.Pools) == 0 {
+		_, err := balances.DeleteTrieNode(allocationReadSubPoolsKey(sscKey, arsp.AllocationID))
+		if err == util.ErrValueNotPresent {
+			return nil
+		}
+		return err
+	}
+	_, err := balances.InsertTrieNode(allocationReadSubPoolsKey(sscKey, arsp.AllocationID), arsp)
+	return err
+}
+
+func (arsp *allocationReadSubPools) find(owner, reader string) (int, bool) {
+	for i, rsp := range arsp.Pools {
+		if rsp.Owner == owner && rsp.Reader == reader {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// ownerPools is the number of sub-pools funded by the allocation owner
+func (arsp *allocationReadSubPools) ownerPools(owner string) (n int) {
+	for _, rsp := range arsp.Pools {
+		if rsp.Owner == owner {
+			n++
+		}
+	}
+	return
+}
+
+// match returns the most specific sub-pool able to pay the read marker: one
+// of the reader before one of any reader, nil if none can.
+func (arsp *allocationReadSubPools) match(reader string, value currency.Coin,
+	now common.Timestamp) *readSubPool {
+
+	var anyReader *readSubPool
+	for _, rsp := range arsp.Pools {
+		if !rsp.canPay(reader, value, now) {
+			continue
+		}
+		if rsp.Reader == reader {
+			return rsp
+		}
+		if anyReader == nil {
+			anyReader = rsp
+		}
+	}
+	return anyReader
+}
+
+func (sc *StorageSmartContract) getAllocationReadSubPools(allocID string,
+	balances cstate.CommonStateContextI) (*allocationReadSubPools, error) {
+
+	arsp := &allocationReadSubPools{AllocationID: allocID}
+	err := balances.GetTrieNode(allocationReadSubPoolsKey(sc.ID, allocID), arsp)
+	switch err {
+	case nil, util.ErrValueNotPresent:
+		return arsp, nil
+	default:
+		return nil, fmt.Errorf("can't get read sub-pools: %v", err)
+	}
+}
+
+// checkReadSubPoolReader checks the reader funding its own sub-pool is in
+// the read ACL of the allocation
+func (sc *StorageSmartContract) checkReadSubPoolReader(allocID, reader string,
+	balances cstate.CommonStateContextI) error {
+
+	acl, err := sc.getReadACL(allocID, balances)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		return errors.New("the reader is not in the read ACL of the allocation")
+	default:
+		return fmt.Errorf("can't get read ACL: %v", err)
+	}
+	if _, ok := acl.find(reader); !ok {
+		return errors.New("the reader is not in the read ACL of the allocation")
+	}
+	return nil
+}
+
+// readSubPoolLock creates or tops up the read sub-pool of the sender
+func (sc *StorageSmartContract) readSubPoolLock(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req readSubPoolLockRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("read_sub_pool_lock_failed",
+			"invalid request: "+err.Error())
+	}
+	if err := req.validate(t.CreationDate); err != nil {
+		return "", common.NewError("read_sub_pool_lock_failed", err.Error())
+	}
+
+	conf, err := sc.getReadPoolConfig(balances, true)
+	if err != nil {
+		return "", common.NewError("read_sub_pool_lock_failed",
+			"can't get configs: "+err.Error())
+	}
+	if t.Value < conf.MinLock {
+		return "", common.NewError("read_sub_pool_lock_failed",
+			"insufficient amount to lock")
+	}
+
+	sa, err := sc.getAllocation(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("read_sub_pool_lock_failed", err.Error())
+	}
+	alloc := sa.mustBase()
+	if t.ClientID != alloc.Owner && t.ClientID != req.Reader {
+		return "", common.NewError("read_sub_pool_lock_failed",
+			"only the allocation owner or the reader can fund a read sub-pool")
+	}
+	if t.ClientID != alloc.Owner {
+		if err := sc.checkReadSubPoolReader(alloc.ID, t.ClientID, balances); err != nil {
+			return "", common.NewError("read_sub_pool_lock_failed", err.Error())
+		}
+	}
+
+	arsp, err := sc.getAllocationReadSubPools(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("read_sub_pool_lock_failed", err.Error())
+	}
+	var rsp *readSubPool
+	if i, ok := arsp.find(t.ClientID, req.Reader); ok {
+		rsp = arsp.Pools[i]
+	} else {
+		if t.ClientID == alloc.Owner && arsp.ownerPools(alloc.Owner) >= maxReadSubPoolsPerAllocation {
+			return "", common.NewError("read_sub_pool_lock_failed",
+				fmt.Sprintf("allocation reached the max %d read sub-pools", maxReadSubPoolsPerAllocation))
+		}
+		rsp = &readSubPool{
+			ID:     readSubPoolID(req.AllocationID, t.ClientID, req.Reader),
+			Owner:  t.ClientID,
+			Reader: req.Reader,
+		}
+		arsp.Pools = append(arsp.Pools, rsp)
+	}
+
+	if _, err := NewTokenTransfer(t.Value, t.ClientID, t.ToClientID, false).transfer(balances); err != nil {
+		return "", common.NewError("read_sub_pool_lock_failed", err.Error())
+	}
+	balance, err := currency.AddCoin(rsp.Balance, t.Value)
+	if err != nil {
+		return "", common.NewError("read_sub_pool_lock_failed", err.Error())
+	}
+	rsp.Balance = balance
+	rsp.Name, rsp.Limit, rsp.ExpiresAt = req.Name, req.Limit, req.ExpiresAt
+
+	if err := arsp.save(sc.ID, balances); err != nil {
+		return "", common.NewError("read_sub_pool_lock_failed",
+			"saving read sub-pools: "+err.Error())
+	}
+	return toJson(rsp), nil
+}
+
+// readSubPoolUnlock gives the balance of a read sub-pool back to its owner
+// and removes the sub-pool
+func (sc *StorageSmartContract) readSubPoolUnlock(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req readSubPoolUnlockRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("read_sub_pool_unlock_failed",
+			"invalid request: "+err.Error())
+	}
+
+	arsp, err := sc.getAllocationReadSubPools(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("read_sub_pool_unlock_failed", err.Error())
+	}
+	i, ok := arsp.find(t.ClientID, req.Reader)
+	if !ok {
+		return "", common.NewError("read_sub_pool_unlock_failed",
+			"no read sub-pool of the client for the allocation and the reader")
+	}
+	rsp := arsp.Pools[i]
+	arsp.Pools = append(arsp.Pools[:i], arsp.Pools[i+1:]...)
+
+	if rsp.Balance > 0 {
+		if err := balances.AddTransfer(state.NewTransfer(sc.ID, t.ClientID, rsp.Balance)); err != nil {
+			return "", common.NewError("read_sub_pool_unlock_failed", err.Error())
+		}
+	}
+	if err := arsp.save(sc.ID, balances); err != nil {
+		return "", common.NewError("read_sub_pool_unlock_failed",
+			"saving read sub-pools: "+err.Error())
+	}
+	return toJson(rsp), nil
+}
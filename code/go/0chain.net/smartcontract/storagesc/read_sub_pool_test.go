@@ -0,0 +1,212 @@
+package storagesc
+
+import (
+	"fmt"
+	"testing"
+
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSubPools(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		owner    = newClient(2000*x10, balances)
+		other    = newClient(100*x10, balances)
+		tp       = int64(100)
+		reads    int64
+	)
+
+	allocID, _ := addAllocation(t, ssc, owner, tp, 0, 0, 0, 0, 0, balances, false, false, false)
+	sa, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	blobberID := sa.mustBase().BlobberAllocs[0].BlobberID
+
+	lock := func(c *Client, value currency.Coin, req readSubPoolLockRequest) error {
+		tp += 100
+		req.AllocationID = allocID
+		tx := newTransaction(c.id, ssc.ID, value, tp)
+		balances.setTransaction(t, tx)
+		_, err := ssc.readSubPoolLock(tx, mustEncode(t, &req), balances)
+		return err
+	}
+	// read reads 1 GB for 1 token
+	read := func() error {
+		tp += 100
+		reads++
+		rm := &ReadConnection{ReadMarker: &ReadMarker{
+			ClientID:        owner.id,
+			ClientPublicKey: owner.pk,
+			BlobberID:       blobberID,
+			AllocationID:    allocID,
+			OwnerID:         owner.id,
+			Timestamp:       common.Timestamp(tp),
+			ReadCounter:     reads * GB / (64 * KB),
+		}}
+		rm.ReadMarker.Signature, err = owner.scheme.Sign(
+			encryption.Hash(rm.ReadMarker.GetHashData()))
+		require.NoError(t, err)
+		tx := newTransaction(blobberID, ssc.ID, 0, tp)
+		balances.setTransaction(t, tx)
+		_, err := ssc.commitBlobberRead(tx, mustEncode(t, rm), balances)
+		if err != nil {
+			reads--
+		}
+		return err
+	}
+	subPool := func(reader string) *readSubPool {
+		arsp, err := ssc.getAllocationReadSubPools(allocID, balances)
+		require.NoError(t, err)
+		i, ok := arsp.find(owner.id, reader)
+		require.True(t, ok)
+		return arsp.Pools[i]
+	}
+
+	require.ErrorContains(t, lock(other, 5*x10, readSubPoolLockRequest{}), "only the allocation owner or the reader")
+	require.ErrorContains(t, lock(other, 5*x10, readSubPoolLockRequest{Reader: other.id}), "read ACL")
+	setReadACL(t, ssc, balances, owner, allocID, other.id, tp)
+	require.NoError(t, lock(other, 5*x10, readSubPoolLockRequest{Reader: other.id}),
+		"a reader in the ACL funds its own reads")
+	require.ErrorContains(t, lock(owner, 5*x10, readSubPoolLockRequest{ExpiresAt: 1}), "expiration")
+
+	require.NoError(t, lock(owner, 5*x10, readSubPoolLockRequest{Name: "any"}))
+	require.NoError(t, lock(owner, 5*x10, readSubPoolLockRequest{Name: "owner reads", Reader: owner.id, Limit: 1 * x10}))
+
+	// the sub-pool of the reader pays first, up to its limit
+	require.NoError(t, read())
+	require.EqualValues(t, 4*x10, subPool(owner.id).Balance)
+	require.EqualValues(t, 1*x10, subPool(owner.id).Spent)
+	require.EqualValues(t, 5*x10, subPool("").Balance)
+
+	require.NoError(t, read())
+	require.EqualValues(t, 4*x10, subPool(owner.id).Balance)
+	require.EqualValues(t, 4*x10, subPool("").Balance)
+
+	var rm *event.ReadMarker
+	for _, e := range balances.events {
+		if e.Tag == event.TagAddReadMarker {
+			rm = e.Data.(*event.ReadMarker)
+		}
+	}
+	require.NotNil(t, rm)
+	require.Equal(t, subPool("").ID, rm.ReadSubPoolID)
+	require.Equal(t, owner.id, rm.PayerID)
+	require.EqualValues(t, 1*x10, rm.Cost)
+
+	// the expired sub-pools don't pay, the read pool of the reader is empty
+	require.NoError(t, lock(owner, 10, readSubPoolLockRequest{Name: "any", ExpiresAt: common.Timestamp(tp + 101)}))
+	tp += 100
+	require.ErrorContains(t, read(), "not enough tokens in read pool")
+
+	before := balances.balances[owner.id]
+	tp += 100
+	tx := newTransaction(owner.id, ssc.ID, 0, tp)
+	balances.setTransaction(t, tx)
+	_, err = ssc.readSubPoolUnlock(tx, mustEncode(t, &readSubPoolUnlockRequest{
+		AllocationID: allocID,
+		Reader:       owner.id,
+	}), balances)
+	require.NoError(t, err)
+	require.EqualValues(t, before+4*x10, balances.balances[owner.id])
+
+	_, err = ssc.readSubPoolUnlock(tx, mustEncode(t, &readSubPoolUnlockRequest{
+		AllocationID: allocID,
+		Reader:       owner.id,
+	}), balances)
+	require.ErrorContains(t, err, "no read sub-pool")
+}
+
+func setReadACL(t *testing.T, ssc *StorageSmartContract, balances *testBalances,
+	owner *Client, allocID, reader string, tp int64) {
+
+	tx := newTransaction(owner.id, ADDRESS, 0, tp)
+	balances.setTransaction(t, tx)
+	_, err := ssc.setReadACLEntry(tx, mustEncode(t, &readACLRequest{
+		AllocationID: allocID,
+		ClientID:     reader,
+	}), balances)
+	require.NoError(t, err)
+}
+
+func TestReadSubPoolLockLimits(t *testing.T) {
+	tests := []struct {
+		name string
+		// ownerPools are the sub-pools funded by the owner beforehand
+		ownerPools int
+		inACL      bool
+		byOwner    bool
+		wantErr    string
+	}{
+		{
+			name:    "reader without read ACL",
+			wantErr: "not in the read ACL",
+		},
+		{
+			name:  "reader in the read ACL",
+			inACL: true,
+		},
+		{
+			name:       "reader sub-pools don't count towards the owner cap",
+			ownerPools: maxReadSubPoolsPerAllocation,
+			inACL:      true,
+		},
+		{
+			name:       "owner below the cap",
+			ownerPools: maxReadSubPoolsPerAllocation - 1,
+			byOwner:    true,
+		},
+		{
+			name:       "owner at the cap",
+			ownerPools: maxReadSubPoolsPerAllocation,
+			byOwner:    true,
+			wantErr:    "max 20 read sub-pools",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ssc      = newTestStorageSC()
+				balances = newTestBalances(t, false)
+				owner    = newClient(2000*x10, balances)
+				reader   = newClient(100*x10, balances)
+				tp       = int64(100)
+			)
+			allocID, _ := addAllocation(t, ssc, owner, tp, 0, 0, 0, 0, 0, balances, false, false, false)
+
+			lock := func(c *Client, reader string) error {
+				tp += 100
+				tx := newTransaction(c.id, ssc.ID, 1*x10, tp)
+				balances.setTransaction(t, tx)
+				_, err := ssc.readSubPoolLock(tx, mustEncode(t, &readSubPoolLockRequest{
+					AllocationID: allocID,
+					Reader:       reader,
+				}), balances)
+				return err
+			}
+
+			if tt.inACL {
+				setReadACL(t, ssc, balances, owner, allocID, reader.id, tp)
+			}
+			for i := 0; i < tt.ownerPools; i++ {
+				require.NoError(t, lock(owner, encryption.Hash(fmt.Sprintf("reader%d", i))))
+			}
+
+			var err error
+			if tt.byOwner {
+				err = lock(owner, reader.id)
+			} else {
+				err = lock(reader, reader.id)
+			}
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
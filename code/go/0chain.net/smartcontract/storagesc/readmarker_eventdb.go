@@ -5,8 +5,17 @@ import (
 	"0chain.net/chaincore/transaction"
 	"0chain.net/smartcontract/dbs/event"
 	"0chain.net/smartcontract/dbs/model"
+	"github.com/0chain/common/core/currency"
 )
 
+// readMarkerPayment is the pool which paid a read marker
+type readMarkerPayment struct {
+	PayerID string
+	// ReadSubPoolID is empty for the read pool of the payer
+	ReadSubPoolID string
+	Cost          currency.Coin
+}
+
 func readMarkerToReadMarkerTable(rm *ReadMarker, txnHash string) *event.ReadMarker {
 
 	readMarker := &event.ReadMarker{
@@ -25,9 +34,13 @@ func readMarkerToReadMarkerTable(rm *ReadMarker, txnHash string) *event.ReadMark
 	return readMarker
 }
 
-func emitAddOrOverwriteReadMarker(rm *ReadMarker, balances cstate.StateContextI, t *transaction.Transaction) error {
+func emitAddOrOverwriteReadMarker(rm *ReadMarker, payment readMarkerPayment, balances cstate.StateContextI, t *transaction.Transaction) error {
 
-	balances.EmitEvent(event.TypeStats, event.TagAddReadMarker, t.Hash, readMarkerToReadMarkerTable(rm, t.Hash))
+	readMarker := readMarkerToReadMarkerTable(rm, t.Hash)
+	readMarker.PayerID = payment.PayerID
+	readMarker.ReadSubPoolID = payment.ReadSubPoolID
+	readMarker.Cost = payment.Cost
+	balances.EmitEvent(event.TypeStats, event.TagAddReadMarker, t.Hash, readMarker)
 	emitUpdateBlobberReadStatEvent(rm, balances)
 	return nil
 }
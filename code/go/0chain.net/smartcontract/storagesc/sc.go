@@ -70,6 +70,26 @@ func (ssc *StorageSmartContract) setSC(sc *sci.SmartContract, _ sci.BCContextI)
 	ssc.SmartContractExecutionStats["finalize_allocation"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "finalize_allocation"), nil)
 	ssc.SmartContractExecutionStats["cancel_allocation"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "cancel_allocation"), nil)
 	ssc.SmartContractExecutionStats["free_allocation_request"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "free_allocation_request"), nil)
+	ssc.SmartContractExecutionStats["offer_allocation_transfer"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "offer_allocation_transfer"), nil)
+	ssc.SmartContractExecutionStats["accept_allocation_transfer"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "accept_allocation_transfer"), nil)
+	ssc.SmartContractExecutionStats["cancel_allocation_transfer"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "cancel_allocation_transfer"), nil)
+	ssc.SmartContractExecutionStats["reject_allocation_transfer"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "reject_allocation_transfer"), nil)
+	ssc.SmartContractExecutionStats["freeze_allocation"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "freeze_allocation"), nil)
+	ssc.SmartContractExecutionStats["set_read_acl_entry"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "set_read_acl_entry"), nil)
+	ssc.SmartContractExecutionStats["remove_read_acl_entry"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "remove_read_acl_entry"), nil)
+	ssc.SmartContractExecutionStats["delete_read_acl"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "delete_read_acl"), nil)
+	ssc.SmartContractExecutionStats["set_auto_repair_policy"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "set_auto_repair_policy"), nil)
+	ssc.SmartContractExecutionStats["repair_allocation"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "repair_allocation"), nil)
+	ssc.SmartContractExecutionStats["top_up_metered_billing"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "top_up_metered_billing"), nil)
+	ssc.SmartContractExecutionStats["settle_metered_billing"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "settle_metered_billing"), nil)
+	ssc.SmartContractExecutionStats["complete_shard_migration"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "complete_shard_migration"), nil)
+	ssc.SmartContractExecutionStats["finalize_shard_migration"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "finalize_shard_migration"), nil)
+	ssc.SmartContractExecutionStats["cancel_shard_migration"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "cancel_shard_migration"), nil)
+	ssc.SmartContractExecutionStats["read_sub_pool_lock"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "read_sub_pool_lock"), nil)
+	ssc.SmartContractExecutionStats["read_sub_pool_unlock"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "read_sub_pool_unlock"), nil)
+	ssc.SmartContractExecutionStats["add_blobber_price_offer"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "add_blobber_price_offer"), nil)
+	ssc.SmartContractExecutionStats["remove_blobber_price_offer"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "remove_blobber_price_offer"), nil)
+	ssc.SmartContractExecutionStats["dispute_challenge"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "dispute_challenge"), nil)
 	// challenge
 	ssc.SmartContractExecutionStats["challenge_response"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "challenge_response"), nil)
 	ssc.SmartContractExecutionStats["generate_challenge"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "generate_challenge"), nil)
@@ -106,6 +126,10 @@ func (ssc *StorageSmartContract) setSC(sc *sci.SmartContract, _ sci.BCContextI)
 	// stake pool
 	ssc.SmartContractExecutionStats["stake_pool_lock"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_lock"), nil)
 	ssc.SmartContractExecutionStats["stake_pool_unlock"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_unlock"), nil)
+	ssc.SmartContractExecutionStats["stake_pool_redelegate"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_redelegate"), nil)
+	ssc.SmartContractExecutionStats["stake_pool_auto_compound"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_auto_compound"), nil)
+	ssc.SmartContractExecutionStats["stake_pool_unbond"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_unbond"), nil)
+	ssc.SmartContractExecutionStats["stake_pool_claim_unbonded"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_claim_unbonded"), nil)
 	ssc.SmartContractExecutionStats["pay_reward"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "pay_reward (add/update/remove SC function)"), nil)
 
 }
@@ -191,6 +215,46 @@ func (sc *StorageSmartContract) Execute(t *transaction.Transaction,
 		resp, err = sc.finalizeAllocation(t, input, balances)
 	case "cancel_allocation":
 		resp, err = sc.cancelAllocationRequest(t, input, balances)
+	case "offer_allocation_transfer":
+		resp, err = sc.offerAllocationTransfer(t, input, balances)
+	case "accept_allocation_transfer":
+		resp, err = sc.acceptAllocationTransfer(t, input, balances)
+	case "cancel_allocation_transfer":
+		resp, err = sc.cancelAllocationTransfer(t, input, balances)
+	case "reject_allocation_transfer":
+		resp, err = sc.rejectAllocationTransfer(t, input, balances)
+	case "freeze_allocation":
+		resp, err = sc.freezeAllocation(t, input, balances)
+	case "set_read_acl_entry":
+		resp, err = sc.setReadACLEntry(t, input, balances)
+	case "remove_read_acl_entry":
+		resp, err = sc.removeReadACLEntry(t, input, balances)
+	case "delete_read_acl":
+		resp, err = sc.deleteReadACL(t, input, balances)
+	case "set_auto_repair_policy":
+		resp, err = sc.setAutoRepairPolicy(t, input, balances)
+	case "repair_allocation":
+		resp, err = sc.repairAllocation(t, input, balances)
+	case "top_up_metered_billing":
+		resp, err = sc.topUpMeteredBilling(t, input, balances)
+	case "settle_metered_billing":
+		resp, err = sc.settleMeteredBilling(t, input, balances)
+	case "complete_shard_migration":
+		resp, err = sc.completeShardMigration(t, input, balances)
+	case "finalize_shard_migration":
+		resp, err = sc.finalizeShardMigration(t, input, balances)
+	case "cancel_shard_migration":
+		resp, err = sc.cancelShardMigration(t, input, balances)
+	case "read_sub_pool_lock":
+		resp, err = sc.readSubPoolLock(t, input, balances)
+	case "read_sub_pool_unlock":
+		resp, err = sc.readSubPoolUnlock(t, input, balances)
+	case "add_blobber_price_offer":
+		resp, err = sc.addBlobberPriceOffer(t, input, balances)
+	case "remove_blobber_price_offer":
+		resp, err = sc.removeBlobberPriceOffer(t, input, balances)
+	case "dispute_challenge":
+		resp, err = sc.disputeChallenge(t, input, balances)
 
 	// free allocations
 
@@ -243,6 +307,14 @@ func (sc *StorageSmartContract) Execute(t *transaction.Transaction,
 		resp, err = sc.stakePoolLock(t, input, balances)
 	case "stake_pool_unlock":
 		resp, err = sc.stakePoolUnlock(t, input, balances)
+	case "stake_pool_redelegate":
+		resp, err = sc.stakePoolRedelegate(t, input, balances)
+	case "stake_pool_auto_compound":
+		resp, err = sc.stakePoolAutoCompound(t, input, balances)
+	case "stake_pool_unbond":
+		resp, err = sc.stakePoolUnbond(t, input, balances)
+	case "stake_pool_claim_unbonded":
+		resp, err = sc.stakePoolClaimUnbonded(t, input, balances)
 	case "collect_reward":
 		resp, err = sc.collectReward(t, input, balances)
 	case "generate_challenge":
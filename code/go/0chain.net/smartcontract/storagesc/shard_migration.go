@@ -0,0 +1,625 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+//msgp:ignore shardMigrationRequest shardMigrationActionRequest
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+// shardMigrationRequest moves a live allocation to another erasure coding
+// layout, it's part of update_allocation_request. The added blobbers make up
+// the difference between the number of blobbers of both layouts.
+type shardMigrationRequest struct {
+	DataShards            int      `json:"data_shards"`
+	ParityShards          int      `json:"parity_shards"`
+	AddBlobbers           []string `json:"add_blobbers"`
+	AddBlobberAuthTickets []string `json:"add_blobber_auth_tickets"`
+}
+
+func (smr *shardMigrationRequest) validate(conf *Config, alloc *storageAllocationBase) error {
+	if smr.DataShards <= 0 || smr.ParityShards <= 0 {
+		return errors.New("invalid target shards")
+	}
+	if smr.DataShards == alloc.DataShards && smr.ParityShards == alloc.ParityShards {
+		return errors.New("the allocation already has the target shards")
+	}
+
+	target := smr.DataShards + smr.ParityShards
+	if target > conf.MaxBlobbersPerAllocation {
+		return fmt.Errorf("target shards exceed max blobbers per allocation %d",
+			conf.MaxBlobbersPerAllocation)
+	}
+	if target-len(alloc.BlobberAllocs) != len(smr.AddBlobbers) {
+		return fmt.Errorf("target shards require %d more blobbers, got %d",
+			target-len(alloc.BlobberAllocs), len(smr.AddBlobbers))
+	}
+	if len(smr.AddBlobberAuthTickets) > len(smr.AddBlobbers) {
+		return errors.New("more auth tickets than added blobbers")
+	}
+	return nil
+}
+
+// shardMigrationActionRequest is the input of complete_shard_migration,
+// finalize_shard_migration and cancel_shard_migration
+type shardMigrationActionRequest struct {
+	AllocationID string `json:"allocation_id"`
+}
+
+func (smr *shardMigrationActionRequest) decode(b []byte) error {
+	return json.Unmarshal(b, smr)
+}
+
+func shardMigrationKey(sscKey, allocID string) datastore.Key {
+	return sscKey + ":shardmigration:" + allocID
+}
+
+// shardMigrationRoot is the allocation root a blobber had in the source
+// layout when the migration started
+type shardMigrationRoot struct {
+	BlobberID      string           `json:"blobber_id"`
+	AllocationRoot string           `json:"allocation_root"`
+	Timestamp      common.Timestamp `json:"timestamp"`
+}
+
+// shardMigration tracks an allocation moving to the target shards. While it
+// lasts, the blobbers of the allocation keep the size of their layout: the
+// write markers and the challenges of both layouts are accepted until the
+// blobbers report the completion and the owner finalizes the migration.
+type shardMigration struct {
+	AllocationID string `json:"allocation_id"`
+	DataShards   int    `json:"data_shards"`
+	ParityShards int    `json:"parity_shards"`
+	// Blobbers added for the target shards
+	Blobbers []string `json:"blobbers"`
+	// Completed are the blobbers storing their data in the target layout
+	Completed []string         `json:"completed"`
+	StartedAt common.Timestamp `json:"started_at"`
+	// SourceRoots of the blobbers storing the source layout
+	SourceRoots []shardMigrationRoot `json:"source_roots"`
+}
+
+func (sm *shardMigration) Encode() []byte {
+	var b, err = json.Marshal(sm)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (sm *shardMigration) Decode(p []byte) error {
+	return json.Unmarshal(p, sm)
+}
+
+func (sm *shardMigration) save(sscKey string, balances cstate.StateContextI) error {
+	_, err := balances.InsertTrieNode(shardMigrationKey(sscKey, sm.AllocationID), sm)
+	return err
+}
+
+func (sm *shardMigration) isCompleted(blobberID string) bool {
+	for _, id := range sm.Completed {
+		if id == blobberID {
+			return true
+		}
+	}
+	return false
+}
+
+// blobberSize in the target layout
+func (sm *shardMigration) blobberSize(alloc *storageAllocationBase) int64 {
+	return bSize(alloc.Size, sm.DataShards)
+}
+
+func (sm *shardMigration) isAdded(blobberID string) bool {
+	for _, id := range sm.Blobbers {
+		if id == blobberID {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceAdded swaps an added blobber replaced by the owner, it reports
+// whether the removed blobber was added for the target shards
+func (sm *shardMigration) replaceAdded(removedID, addedID string) bool {
+	for i, id := range sm.Blobbers {
+		if id == removedID {
+			sm.Blobbers[i] = addedID
+			return true
+		}
+	}
+	return false
+}
+
+// sourceRoot returns the allocation root of the blobber in the source layout,
+// nil once the blobber completed the migration
+func (sm *shardMigration) sourceRoot(blobberID string) *shardMigrationRoot {
+	if sm.isCompleted(blobberID) {
+		return nil
+	}
+	for i := range sm.SourceRoots {
+		if sm.SourceRoots[i].BlobberID == blobberID {
+			return &sm.SourceRoots[i]
+		}
+	}
+	return nil
+}
+
+// maxUsedSize of the blobber allocation while the allocation migrates. The
+// blobbers of the source layout store the data of both layouts until they
+// complete the migration, then the data of the target layout only.
+func (sm *shardMigration) maxUsedSize(alloc *storageAllocationBase, ba *BlobberAllocation) int64 {
+	size := sm.blobberSize(alloc)
+	switch {
+	case sm.isCompleted(ba.BlobberID):
+		return size
+	case sm.isAdded(ba.BlobberID):
+		return ba.Size
+	default:
+		return ba.Size + size
+	}
+}
+
+func (sc *StorageSmartContract) getShardMigration(allocID string,
+	balances cstate.CommonStateContextI) (*shardMigration, error) {
+
+	sm := new(shardMigration)
+	if err := balances.GetTrieNode(shardMigrationKey(sc.ID, allocID), sm); err != nil {
+		return nil, err
+	}
+	return sm, nil
+}
+
+// findShardMigration returns nil if the allocation isn't migrating
+func (sc *StorageSmartContract) findShardMigration(allocID string,
+	balances cstate.CommonStateContextI) (*shardMigration, error) {
+
+	sm, err := sc.getShardMigration(allocID, balances)
+	switch err {
+	case nil:
+		return sm, nil
+	case util.ErrValueNotPresent:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("can't get shard migration: %v", err)
+	}
+}
+
+// startShardMigration adds the blobbers of the target shards to the
+// allocation, sized for the target layout, and records the migration
+func (sc *StorageSmartContract) startShardMigration(
+	t *transaction.Transaction,
+	conf *Config,
+	alloc *storageAllocationBase,
+	blobbers []*StorageNode,
+	req *shardMigrationRequest,
+	isEnterprise bool,
+	balances cstate.StateContextI,
+) ([]*StorageNode, error) {
+	if err := req.validate(conf, alloc); err != nil {
+		return nil, fmt.Errorf("invalid shard migration: %v", err)
+	}
+
+	sm := &shardMigration{
+		AllocationID: alloc.ID,
+		DataShards:   req.DataShards,
+		ParityShards: req.ParityShards,
+		StartedAt:    t.CreationDate,
+	}
+	for _, ba := range alloc.BlobberAllocs {
+		root := shardMigrationRoot{
+			BlobberID:      ba.BlobberID,
+			AllocationRoot: ba.AllocationRoot,
+		}
+		if ba.LastWriteMarker != nil {
+			root.Timestamp = ba.LastWriteMarker.mustBase().Timestamp
+		}
+		sm.SourceRoots = append(sm.SourceRoots, root)
+	}
+	size := sm.blobberSize(alloc)
+	for i, id := range req.AddBlobbers {
+		var authTicket string
+		if i < len(req.AddBlobberAuthTickets) {
+			authTicket = req.AddBlobberAuthTickets[i]
+		}
+
+		b, ba, sp, err := alloc.newAllocationBlobber(conf, id, authTicket, size, t.CreationDate, balances, isEnterprise)
+		if err != nil {
+			return nil, err
+		}
+		if err := sp.addOffer(ba.Offer()); err != nil {
+			return nil, fmt.Errorf("failed to add offer: %v", err)
+		}
+		if err := sp.Save(spenum.Blobber, id, balances); err != nil {
+			return nil, err
+		}
+
+		blobbers = append(blobbers, b)
+		alloc.BlobberAllocs = append(alloc.BlobberAllocs, ba)
+		alloc.BlobberAllocsMap[id] = ba
+		sm.Blobbers = append(sm.Blobbers, id)
+	}
+
+	if err := sm.save(sc.ID, balances); err != nil {
+		return nil, fmt.Errorf("saving shard migration: %v", err)
+	}
+	return blobbers, nil
+}
+
+// completeShardMigration is sent by a blobber of a migrating allocation once
+// it stores its data in the target layout
+func (sc *StorageSmartContract) completeShardMigration(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req shardMigrationActionRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("complete_shard_migration_failed",
+			"invalid request: "+err.Error())
+	}
+
+	sa, err := sc.getTransferableAllocation(req.AllocationID, t.CreationDate, balances)
+	if err != nil {
+		return "", common.NewError("complete_shard_migration_failed", err.Error())
+	}
+	alloc := sa.mustBase()
+
+	ba, ok := alloc.BlobberAllocsMap[t.ClientID]
+	if !ok {
+		return "", common.NewError("complete_shard_migration_failed",
+			"blobber is not part of the allocation")
+	}
+
+	sm, err := sc.getShardMigration(alloc.ID, balances)
+	if err != nil {
+		return "", common.NewError("complete_shard_migration_failed",
+			"can't get shard migration: "+err.Error())
+	}
+	if sm.isCompleted(t.ClientID) {
+		return "", common.NewError("complete_shard_migration_failed",
+			"blobber already completed the migration")
+	}
+	if size := sm.blobberSize(alloc); ba.Stats != nil && ba.Stats.UsedSize > size {
+		return "", common.NewError("complete_shard_migration_failed",
+			fmt.Sprintf("used size %d exceeds the target blobber size %d", ba.Stats.UsedSize, size))
+	}
+
+	sm.Completed = append(sm.Completed, t.ClientID)
+	if err := sm.save(sc.ID, balances); err != nil {
+		return "", common.NewError("complete_shard_migration_failed",
+			"saving shard migration: "+err.Error())
+	}
+	return string(sm.Encode()), nil
+}
+
+// finalizeShardMigration switches the allocation over to the target shards
+// once all its blobbers completed the migration
+func (sc *StorageSmartContract) finalizeShardMigration(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req shardMigrationActionRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("finalize_shard_migration_failed",
+			"invalid request: "+err.Error())
+	}
+
+	sa, err := sc.getTransferableAllocation(req.AllocationID, t.CreationDate, balances)
+	if err != nil {
+		return "", common.NewError("finalize_shard_migration_failed", err.Error())
+	}
+	alloc := sa.mustBase()
+	if alloc.Owner != t.ClientID {
+		return "", common.NewError("finalize_shard_migration_failed",
+			"only owner can finalize the shard migration")
+	}
+
+	sm, err := sc.getShardMigration(alloc.ID, balances)
+	if err != nil {
+		return "", common.NewError("finalize_shard_migration_failed",
+			"can't get shard migration: "+err.Error())
+	}
+	if len(alloc.BlobberAllocs) != sm.DataShards+sm.ParityShards {
+		return "", common.NewError("finalize_shard_migration_failed",
+			"allocation blobbers don't match the target shards")
+	}
+	for _, ba := range alloc.BlobberAllocs {
+		if !sm.isCompleted(ba.BlobberID) {
+			return "", common.NewError("finalize_shard_migration_failed",
+				"blobber "+ba.BlobberID+" didn't complete the migration")
+		}
+	}
+
+	blobbers, err := sc.getAllocationBlobbers(alloc, balances)
+	if err != nil {
+		return "", common.NewError("finalize_shard_migration_failed", err.Error())
+	}
+
+	size := sm.blobberSize(alloc)
+	for i, ba := range alloc.BlobberAllocs {
+		if ba.Size == size {
+			continue
+		}
+		if err := resizeBlobberAllocation(ba, blobbers[i], size, balances); err != nil {
+			return "", common.NewError("finalize_shard_migration_failed", err.Error())
+		}
+	}
+
+	alloc.DataShards = sm.DataShards
+	alloc.ParityShards = sm.ParityShards
+	alloc.Tx = t.Hash
+
+	isEnterprise := false
+	if actErr := cstate.WithActivation(balances, "electra", func() error {
+		return nil
+	}, func() error {
+		if v2, ok := sa.Entity().(*storageAllocationV2); ok && v2.IsEnterprise != nil {
+			isEnterprise = *v2.IsEnterprise
+		}
+		return nil
+	}); actErr != nil {
+		return "", common.NewError("finalize_shard_migration_failed", actErr.Error())
+	}
+
+	if err := sc.repriceShardMigration(t, alloc, isEnterprise, balances); err != nil {
+		return "", common.NewError("finalize_shard_migration_failed", err.Error())
+	}
+	_ = sa.mustUpdateBase(func(base *storageAllocationBase) error {
+		alloc.deepCopy(base)
+		return nil
+	})
+
+	if err := sa.saveUpdatedAllocation(blobbers, balances); err != nil {
+		return "", common.NewError("finalize_shard_migration_failed", err.Error())
+	}
+	emitAddOrOverwriteAllocationBlobberTerms(alloc, balances, t)
+
+	if _, err := balances.DeleteTrieNode(shardMigrationKey(sc.ID, alloc.ID)); err != nil {
+		return "", common.NewError("finalize_shard_migration_failed",
+			"deleting shard migration: "+err.Error())
+	}
+	return string(sa.Encode()), nil
+}
+
+// cancelShardMigration is sent by the owner to give up a migration, the
+// blobbers added for the target shards are removed from the allocation and
+// their offers released, the allocation keeps the source shards
+func (sc *StorageSmartContract) cancelShardMigration(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req shardMigrationActionRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("cancel_shard_migration_failed",
+			"invalid request: "+err.Error())
+	}
+
+	sa, err := sc.getTransferableAllocation(req.AllocationID, t.CreationDate, balances)
+	if err != nil {
+		return "", common.NewError("cancel_shard_migration_failed", err.Error())
+	}
+	alloc := sa.mustBase()
+	if alloc.Owner != t.ClientID {
+		return "", common.NewError("cancel_shard_migration_failed",
+			"only owner can cancel the shard migration")
+	}
+
+	sm, err := sc.getShardMigration(alloc.ID, balances)
+	if err != nil {
+		return "", common.NewError("cancel_shard_migration_failed",
+			"can't get shard migration: "+err.Error())
+	}
+
+	isEnterprise := false
+	if actErr := cstate.WithActivation(balances, "electra", func() error {
+		return nil
+	}, func() error {
+		if v2, ok := sa.Entity().(*storageAllocationV2); ok && v2.IsEnterprise != nil {
+			isEnterprise = *v2.IsEnterprise
+		}
+		return nil
+	}); actErr != nil {
+		return "", common.NewError("cancel_shard_migration_failed", actErr.Error())
+	}
+
+	for _, id := range sm.Blobbers {
+		if _, ok := alloc.BlobberAllocsMap[id]; !ok {
+			continue
+		}
+		if err := sc.settleRemovedMeteredBlobber(alloc, id, t.CreationDate, balances); err != nil {
+			return "", common.NewError("cancel_shard_migration_failed",
+				fmt.Sprintf("settling metered blobber %s: %v", id, err))
+		}
+		if err := alloc.replaceBlobber(id, sc, balances, t, nil, t.CreationDate, isEnterprise); err != nil {
+			return "", common.NewError("cancel_shard_migration_failed", err.Error())
+		}
+		balances.EmitEvent(event.TypeStats, event.TagDeleteAllocationBlobberTerm, t.Hash, []event.AllocationBlobberTerm{
+			{
+				AllocationIdHash: alloc.ID,
+				BlobberID:        id,
+			},
+		})
+	}
+	if len(alloc.BlobberAllocs) != alloc.DataShards+alloc.ParityShards {
+		return "", common.NewError("cancel_shard_migration_failed",
+			"allocation blobbers don't match the source shards")
+	}
+
+	blobbers, err := sc.getAllocationBlobbers(alloc, balances)
+	if err != nil {
+		return "", common.NewError("cancel_shard_migration_failed", err.Error())
+	}
+
+	alloc.Tx = t.Hash
+	_ = sa.mustUpdateBase(func(base *storageAllocationBase) error {
+		alloc.deepCopy(base)
+		return nil
+	})
+
+	if err := sa.saveUpdatedAllocation(blobbers, balances); err != nil {
+		return "", common.NewError("cancel_shard_migration_failed", err.Error())
+	}
+
+	if _, err := balances.DeleteTrieNode(shardMigrationKey(sc.ID, alloc.ID)); err != nil {
+		return "", common.NewError("cancel_shard_migration_failed",
+			"deleting shard migration: "+err.Error())
+	}
+	return string(sa.Encode()), nil
+}
+
+// resizeBlobberAllocation changes the size of the blobber allocation along
+// with the blobber allocated size and the offer of its stake pool
+func resizeBlobberAllocation(ba *BlobberAllocation, b *StorageNode, size int64,
+	balances cstate.StateContextI) error {
+
+	sp, err := getStakePool(spenum.Blobber, ba.BlobberID, balances)
+	if err != nil {
+		return fmt.Errorf("can't get stake pool of %s: %v", ba.BlobberID, err)
+	}
+
+	oldOffer := ba.Offer()
+	diff := size - ba.Size
+	ba.Size = size
+	//nolint:errcheck
+	b.mustUpdateBase(func(snb *storageNodeBase) error {
+		snb.Allocated += diff
+		return nil
+	})
+
+	newOffer := ba.Offer()
+	if newOffer > oldOffer {
+		coin, err := currency.MinusCoin(newOffer, oldOffer)
+		if err != nil {
+			return err
+		}
+		if err := sp.addOffer(coin); err != nil {
+			return fmt.Errorf("adding offer: %v", err)
+		}
+	} else {
+		coin, err := currency.MinusCoin(oldOffer, newOffer)
+		if err != nil {
+			return err
+		}
+		if err := sp.reduceOffer(coin); err != nil {
+			return fmt.Errorf("reduce offer: %v", err)
+		}
+	}
+	return sp.Save(spenum.Blobber, ba.BlobberID, balances)
+}
+
+// repriceShardMigration moves the challenge pool of the migrated allocation
+// to the cost of the data stored in the target layout for the rest of the
+// allocation, and checks the write pool, topped up with the transaction
+// value, covers the cost of the resized blobbers. Metered allocations pay for
+// the used storage only and are not re-priced.
+func (sc *StorageSmartContract) repriceShardMigration(
+	t *transaction.Transaction,
+	alloc *storageAllocationBase,
+	isEnterprise bool,
+	balances cstate.StateContextI,
+) error {
+	if t.Value > 0 {
+		if err := alloc.addToWritePool(t, balances,
+			NewTokenTransfer(t.Value, t.ClientID, t.ToClientID, false)); err != nil {
+			return fmt.Errorf("adding to write pool: %v", err)
+		}
+	}
+
+	mb, err := sc.findMeteredBilling(alloc.ID, balances)
+	if err != nil {
+		return err
+	}
+	if mb != nil {
+		return nil
+	}
+
+	var cpBalance currency.Coin
+	if !isEnterprise {
+		cp, err := sc.getChallengePool(alloc.ID, balances)
+		if err != nil {
+			return fmt.Errorf("can't get challenge pool: %v", err)
+		}
+
+		rdtu, err := alloc.restDurationInTimeUnits(t.CreationDate, alloc.TimeUnit)
+		if err != nil {
+			return fmt.Errorf("can't get rest duration: %v", err)
+		}
+		var stored currency.Coin
+		for _, ba := range alloc.BlobberAllocs {
+			if ba.Stats == nil {
+				continue
+			}
+			c, err := currency.MultFloat64(ba.Terms.WritePrice, sizeInGB(ba.Stats.UsedSize)*rdtu)
+			if err != nil {
+				return err
+			}
+			if stored, err = currency.AddCoin(stored, c); err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case cp.Balance > stored:
+			move, err := currency.MinusCoin(cp.Balance, stored)
+			if err != nil {
+				return err
+			}
+			if err := alloc.moveFromChallengePool(cp, move); err != nil {
+				return fmt.Errorf("can't move tokens to write pool: %v", err)
+			}
+			if alloc.MovedBack, err = currency.AddCoin(alloc.MovedBack, move); err != nil {
+				return err
+			}
+			emitChallengePoolLock(event.TagFromChallengePool, alloc, cp, move, balances)
+		case cp.Balance < stored:
+			move, err := currency.MinusCoin(stored, cp.Balance)
+			if err != nil {
+				return err
+			}
+			if err := alloc.moveToChallengePool(cp, move); err != nil {
+				return fmt.Errorf("can't move tokens to challenge pool: %v", err)
+			}
+			if alloc.MovedToChallenge, err = currency.AddCoin(alloc.MovedToChallenge, move); err != nil {
+				return err
+			}
+			emitChallengePoolLock(event.TagToChallengePool, alloc, cp, move, balances)
+		}
+		if err := cp.save(sc.ID, alloc, balances); err != nil {
+			return fmt.Errorf("saving challenge pool: %v", err)
+		}
+		cpBalance = cp.Balance
+	}
+
+	required, err := alloc.requiredTokensForUpdateAllocation(cpBalance, false, isEnterprise, t.CreationDate)
+	if err != nil {
+		return err
+	}
+	if required > 0 {
+		return fmt.Errorf("not enough tokens to honor the target shards, lock %v more tokens", required)
+	}
+	return nil
+}
+
+func emitChallengePoolLock(tag event.EventTag, alloc *storageAllocationBase, cp *challengePool,
+	amount currency.Coin, balances cstate.StateContextI) {
+	coin, _ := amount.Int64()
+	balances.EmitEvent(event.TypeStats, tag, cp.ID, event.ChallengePoolLock{
+		Client:       alloc.Owner,
+		AllocationId: alloc.ID,
+		Amount:       coin,
+	})
+}
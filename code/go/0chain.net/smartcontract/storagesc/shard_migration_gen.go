@@ -0,0 +1,298 @@
+package storagesc
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *shardMigration) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 7
+	// string "AllocationID"
+	o = append(o, 0x87, 0xac, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44)
+	o = msgp.AppendString(o, z.AllocationID)
+	// string "DataShards"
+	o = append(o, 0xaa, 0x44, 0x61, 0x74, 0x61, 0x53, 0x68, 0x61, 0x72, 0x64, 0x73)
+	o = msgp.AppendInt(o, z.DataShards)
+	// string "ParityShards"
+	o = append(o, 0xac, 0x50, 0x61, 0x72, 0x69, 0x74, 0x79, 0x53, 0x68, 0x61, 0x72, 0x64, 0x73)
+	o = msgp.AppendInt(o, z.ParityShards)
+	// string "Blobbers"
+	o = append(o, 0xa8, 0x42, 0x6c, 0x6f, 0x62, 0x62, 0x65, 0x72, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Blobbers)))
+	for za0001 := range z.Blobbers {
+		o = msgp.AppendString(o, z.Blobbers[za0001])
+	}
+	// string "Completed"
+	o = append(o, 0xa9, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Completed)))
+	for za0002 := range z.Completed {
+		o = msgp.AppendString(o, z.Completed[za0002])
+	}
+	// string "StartedAt"
+	o = append(o, 0xa9, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o, err = z.StartedAt.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "StartedAt")
+		return
+	}
+	// string "SourceRoots"
+	o = append(o, 0xab, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.SourceRoots)))
+	for za0003 := range z.SourceRoots {
+		// map header, size 3
+		// string "BlobberID"
+		o = append(o, 0x83, 0xa9, 0x42, 0x6c, 0x6f, 0x62, 0x62, 0x65, 0x72, 0x49, 0x44)
+		o = msgp.AppendString(o, z.SourceRoots[za0003].BlobberID)
+		// string "AllocationRoot"
+		o = append(o, 0xae, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x6f, 0x6f, 0x74)
+		o = msgp.AppendString(o, z.SourceRoots[za0003].AllocationRoot)
+		// string "Timestamp"
+		o = append(o, 0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+		o, err = z.SourceRoots[za0003].Timestamp.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "SourceRoots", za0003, "Timestamp")
+			return
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *shardMigration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "AllocationID":
+			z.AllocationID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AllocationID")
+				return
+			}
+		case "DataShards":
+			z.DataShards, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DataShards")
+				return
+			}
+		case "ParityShards":
+			z.ParityShards, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ParityShards")
+				return
+			}
+		case "Blobbers":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Blobbers")
+				return
+			}
+			if cap(z.Blobbers) >= int(zb0002) {
+				z.Blobbers = (z.Blobbers)[:zb0002]
+			} else {
+				z.Blobbers = make([]string, zb0002)
+			}
+			for za0001 := range z.Blobbers {
+				z.Blobbers[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Blobbers", za0001)
+					return
+				}
+			}
+		case "Completed":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Completed")
+				return
+			}
+			if cap(z.Completed) >= int(zb0003) {
+				z.Completed = (z.Completed)[:zb0003]
+			} else {
+				z.Completed = make([]string, zb0003)
+			}
+			for za0002 := range z.Completed {
+				z.Completed[za0002], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Completed", za0002)
+					return
+				}
+			}
+		case "StartedAt":
+			bts, err = z.StartedAt.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "StartedAt")
+				return
+			}
+		case "SourceRoots":
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SourceRoots")
+				return
+			}
+			if cap(z.SourceRoots) >= int(zb0004) {
+				z.SourceRoots = (z.SourceRoots)[:zb0004]
+			} else {
+				z.SourceRoots = make([]shardMigrationRoot, zb0004)
+			}
+			for za0003 := range z.SourceRoots {
+				var zb0005 uint32
+				zb0005, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "SourceRoots", za0003)
+					return
+				}
+				for zb0005 > 0 {
+					zb0005--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "SourceRoots", za0003)
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "BlobberID":
+						z.SourceRoots[za0003].BlobberID, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "SourceRoots", za0003, "BlobberID")
+							return
+						}
+					case "AllocationRoot":
+						z.SourceRoots[za0003].AllocationRoot, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "SourceRoots", za0003, "AllocationRoot")
+							return
+						}
+					case "Timestamp":
+						bts, err = z.SourceRoots[za0003].Timestamp.UnmarshalMsg(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "SourceRoots", za0003, "Timestamp")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "SourceRoots", za0003)
+							return
+						}
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *shardMigration) Msgsize() (s int) {
+	s = 1 + 13 + msgp.StringPrefixSize + len(z.AllocationID) + 11 + msgp.IntSize + 13 + msgp.IntSize + 9 + msgp.ArrayHeaderSize
+	for za0001 := range z.Blobbers {
+		s += msgp.StringPrefixSize + len(z.Blobbers[za0001])
+	}
+	s += 10 + msgp.ArrayHeaderSize
+	for za0002 := range z.Completed {
+		s += msgp.StringPrefixSize + len(z.Completed[za0002])
+	}
+	s += 10 + z.StartedAt.Msgsize() + 12 + msgp.ArrayHeaderSize
+	for za0003 := range z.SourceRoots {
+		s += 1 + 10 + msgp.StringPrefixSize + len(z.SourceRoots[za0003].BlobberID) + 15 + msgp.StringPrefixSize + len(z.SourceRoots[za0003].AllocationRoot) + 10 + z.SourceRoots[za0003].Timestamp.Msgsize()
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *shardMigrationRoot) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "BlobberID"
+	o = append(o, 0x83, 0xa9, 0x42, 0x6c, 0x6f, 0x62, 0x62, 0x65, 0x72, 0x49, 0x44)
+	o = msgp.AppendString(o, z.BlobberID)
+	// string "AllocationRoot"
+	o = append(o, 0xae, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x6f, 0x6f, 0x74)
+	o = msgp.AppendString(o, z.AllocationRoot)
+	// string "Timestamp"
+	o = append(o, 0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	o, err = z.Timestamp.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Timestamp")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *shardMigrationRoot) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "BlobberID":
+			z.BlobberID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BlobberID")
+				return
+			}
+		case "AllocationRoot":
+			z.AllocationRoot, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AllocationRoot")
+				return
+			}
+		case "Timestamp":
+			bts, err = z.Timestamp.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Timestamp")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *shardMigrationRoot) Msgsize() (s int) {
+	s = 1 + 10 + msgp.StringPrefixSize + len(z.BlobberID) + 15 + msgp.StringPrefixSize + len(z.AllocationRoot) + 10 + z.Timestamp.Msgsize()
+	return
+}
@@ -0,0 +1,316 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"testing"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardMigration(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		owner    = newClient(2000*x10, balances)
+		tp       = int64(100)
+	)
+
+	setConfig(t, balances)
+	allocID, blobs := addAllocation(t, ssc, owner, tp, 0, 0, 0, 0, 0, balances, false, false, false)
+
+	getAlloc := func() *storageAllocationBase {
+		sa, err := ssc.getAllocation(allocID, balances)
+		require.NoError(t, err)
+		return sa.mustBase()
+	}
+	alloc := getAlloc()
+	oldSize := alloc.BlobberAllocs[0].Size
+
+	var spares []string
+	for _, b := range blobs {
+		if _, ok := alloc.BlobberAllocsMap[b.id]; !ok {
+			spares = append(spares, b.id)
+		}
+	}
+	require.True(t, len(spares) >= 2)
+
+	complete := func(clientID string) error {
+		input, err := json.Marshal(&shardMigrationActionRequest{AllocationID: allocID})
+		require.NoError(t, err)
+		tx := newTransaction(clientID, ADDRESS, 0, tp)
+		balances.setTransaction(t, tx)
+		_, err = ssc.completeShardMigration(tx, input, balances)
+		return err
+	}
+	finalize := func(clientID string) error {
+		input, err := json.Marshal(&shardMigrationActionRequest{AllocationID: allocID})
+		require.NoError(t, err)
+		tx := newTransaction(clientID, ADDRESS, 0, tp)
+		balances.setTransaction(t, tx)
+		_, err = ssc.finalizeShardMigration(tx, input, balances)
+		return err
+	}
+	update := func(uar *updateAllocationRequest) error {
+		tp += 100
+		uar.ID = allocID
+		_, err := uar.callUpdateAllocReq(t, owner.id, 0, tp, ssc, balances)
+		return err
+	}
+
+	require.ErrorContains(t, update(&updateAllocationRequest{ShardMigration: &shardMigrationRequest{
+		DataShards: 12, ParityShards: 10, AddBlobbers: spares[:1],
+	}}), "more blobbers")
+	require.ErrorContains(t, update(&updateAllocationRequest{ShardMigration: &shardMigrationRequest{
+		DataShards: 10, ParityShards: 10,
+	}}), "already has the target shards")
+	require.Error(t, complete(alloc.BlobberAllocs[0].BlobberID), "not migrating")
+
+	require.NoError(t, update(&updateAllocationRequest{ShardMigration: &shardMigrationRequest{
+		DataShards: 12, ParityShards: 10, AddBlobbers: spares[:2],
+	}}))
+	alloc = getAlloc()
+	require.Len(t, alloc.BlobberAllocs, 22)
+	require.Equal(t, 10, alloc.DataShards, "the layout changes on finalization")
+	newSize := bSize(alloc.Size, 12)
+	require.Equal(t, oldSize, alloc.BlobberAllocs[0].Size)
+	require.Equal(t, newSize, alloc.BlobberAllocsMap[spares[0]].Size)
+
+	require.ErrorContains(t, update(&updateAllocationRequest{Extend: true}), "migrating")
+
+	for i, ba := range alloc.BlobberAllocs {
+		if i == 0 {
+			continue
+		}
+		require.NoError(t, complete(ba.BlobberID))
+	}
+	require.ErrorContains(t, complete(alloc.BlobberAllocs[1].BlobberID), "already completed")
+	require.ErrorContains(t, complete(owner.id), "not part of the allocation")
+	require.ErrorContains(t, finalize(owner.id), "didn't complete")
+
+	require.NoError(t, complete(alloc.BlobberAllocs[0].BlobberID))
+	require.ErrorContains(t, finalize(blobs[0].id), "only owner")
+	require.NoError(t, finalize(owner.id))
+
+	alloc = getAlloc()
+	require.Equal(t, 12, alloc.DataShards)
+	require.Equal(t, 10, alloc.ParityShards)
+	for _, ba := range alloc.BlobberAllocs {
+		require.Equal(t, newSize, ba.Size)
+	}
+	sm, err := ssc.findShardMigration(allocID, balances)
+	require.NoError(t, err)
+	require.Nil(t, sm)
+}
+
+type shardMigrationTestEnv struct {
+	t        *testing.T
+	ssc      *StorageSmartContract
+	balances *testBalances
+	owner    *Client
+	allocID  string
+	added    []string
+	spares   []string
+	tp       int64
+}
+
+// newShardMigrationTestEnv migrates a 10+10 allocation to 12+10 shards
+func newShardMigrationTestEnv(t *testing.T) *shardMigrationTestEnv {
+	env := &shardMigrationTestEnv{
+		t:        t,
+		ssc:      newTestStorageSC(),
+		balances: newTestBalances(t, false),
+		tp:       100,
+	}
+	env.owner = newClient(2000*x10, env.balances)
+	setConfig(t, env.balances)
+
+	var blobs []*Client
+	env.allocID, blobs = addAllocation(t, env.ssc, env.owner, env.tp, 0, 0, 0, 0, 0,
+		env.balances, false, false, false)
+	alloc := env.alloc()
+	for _, b := range blobs {
+		if _, ok := alloc.BlobberAllocsMap[b.id]; ok {
+			continue
+		}
+		if len(env.added) < 2 {
+			env.added = append(env.added, b.id)
+		} else {
+			env.spares = append(env.spares, b.id)
+		}
+	}
+	require.Len(t, env.added, 2)
+
+	env.tp += 100
+	uar := &updateAllocationRequest{ID: env.allocID, ShardMigration: &shardMigrationRequest{
+		DataShards: 12, ParityShards: 10, AddBlobbers: env.added,
+	}}
+	_, err := uar.callUpdateAllocReq(t, env.owner.id, 0, env.tp, env.ssc, env.balances)
+	require.NoError(t, err)
+	return env
+}
+
+func (env *shardMigrationTestEnv) alloc() *storageAllocationBase {
+	sa, err := env.ssc.getAllocation(env.allocID, env.balances)
+	require.NoError(env.t, err)
+	return sa.mustBase()
+}
+
+func (env *shardMigrationTestEnv) migration() *shardMigration {
+	sm, err := env.ssc.getShardMigration(env.allocID, env.balances)
+	require.NoError(env.t, err)
+	return sm
+}
+
+func (env *shardMigrationTestEnv) call(clientID string, value currency.Coin,
+	f func(*transaction.Transaction, []byte, cstate.StateContextI) (string, error)) error {
+	env.tp += 100
+	tx := newTransaction(clientID, ADDRESS, value, env.tp)
+	env.balances.setTransaction(env.t, tx)
+	_, err := f(tx, mustEncode(env.t, &shardMigrationActionRequest{AllocationID: env.allocID}), env.balances)
+	return err
+}
+
+func (env *shardMigrationTestEnv) completeAll() {
+	for _, ba := range env.alloc().BlobberAllocs {
+		require.NoError(env.t, env.call(ba.BlobberID, 0, env.ssc.completeShardMigration))
+	}
+}
+
+// requireReleased checks the allocation is back to its source shards without
+// the given blobbers, holding no size or offer for it
+func (env *shardMigrationTestEnv) requireReleased(ids ...string) {
+	alloc := env.alloc()
+	require.Len(env.t, alloc.BlobberAllocs, 20)
+	require.Equal(env.t, 10, alloc.DataShards)
+	for _, id := range ids {
+		require.NotContains(env.t, alloc.BlobberAllocsMap, id)
+		b, err := env.ssc.getBlobber(id, env.balances)
+		require.NoError(env.t, err)
+		require.Zero(env.t, b.mustBase().Allocated)
+		sp, err := env.ssc.getStakePool(spenum.Blobber, id, env.balances)
+		require.NoError(env.t, err)
+		require.Zero(env.t, sp.TotalOffers)
+	}
+	sm, err := env.ssc.findShardMigration(env.allocID, env.balances)
+	require.NoError(env.t, err)
+	require.Nil(env.t, sm)
+}
+
+func TestShardMigrationLayouts(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(env *shardMigrationTestEnv)
+	}{
+		{
+			name: "source blobber accepts both layouts",
+			run: func(env *shardMigrationTestEnv) {
+				alloc := env.alloc()
+				ba := alloc.BlobberAllocs[0]
+				sm := env.migration()
+				require.Equal(t, ba.Size+sm.blobberSize(alloc), sm.maxUsedSize(alloc, ba))
+				root := sm.sourceRoot(ba.BlobberID)
+				require.NotNil(t, root)
+				require.Equal(t, ba.AllocationRoot, root.AllocationRoot)
+			},
+		},
+		{
+			name: "added blobber stores the target layout",
+			run: func(env *shardMigrationTestEnv) {
+				alloc := env.alloc()
+				ba := alloc.BlobberAllocsMap[env.added[0]]
+				sm := env.migration()
+				require.Equal(t, sm.blobberSize(alloc), sm.maxUsedSize(alloc, ba))
+				require.Nil(t, sm.sourceRoot(ba.BlobberID))
+			},
+		},
+		{
+			name: "completed blobber stores the target layout only",
+			run: func(env *shardMigrationTestEnv) {
+				ba := env.alloc().BlobberAllocs[0]
+				require.NoError(t, env.call(ba.BlobberID, 0, env.ssc.completeShardMigration))
+
+				alloc := env.alloc()
+				sm := env.migration()
+				require.Equal(t, sm.blobberSize(alloc), sm.maxUsedSize(alloc, alloc.BlobberAllocs[0]))
+				require.Nil(t, sm.sourceRoot(ba.BlobberID))
+			},
+		},
+		{
+			name: "freeze rejected while migrating",
+			run: func(env *shardMigrationTestEnv) {
+				sig, err := env.owner.scheme.Sign(newAllocationSnapshot(env.alloc()).Hash)
+				require.NoError(t, err)
+				env.tp += 100
+				tx := newTransaction(env.owner.id, ADDRESS, 0, env.tp)
+				env.balances.setTransaction(t, tx)
+				_, err = env.ssc.freezeAllocation(tx, mustEncode(t, &freezeAllocationRequest{
+					AllocationID: env.allocID,
+					Signature:    sig,
+				}), env.balances)
+				require.ErrorContains(t, err, "migrating its shards")
+			},
+		},
+		{
+			name: "finalize requires the write pool to cover the target shards",
+			run: func(env *shardMigrationTestEnv) {
+				env.completeAll()
+
+				sa, err := env.ssc.getAllocation(env.allocID, env.balances)
+				require.NoError(t, err)
+				_ = sa.mustUpdateBase(func(base *storageAllocationBase) error {
+					base.WritePool = 0
+					return nil
+				})
+				_, err = env.balances.InsertTrieNode(sa.GetKey(env.ssc.ID), sa)
+				require.NoError(t, err)
+
+				require.ErrorContains(t, env.call(env.owner.id, 0, env.ssc.finalizeShardMigration),
+					"more tokens")
+				require.NoError(t, env.call(env.owner.id, 1000*x10, env.ssc.finalizeShardMigration),
+					"the transaction value tops up the write pool")
+				require.Equal(t, 12, env.alloc().DataShards)
+			},
+		},
+		{
+			name: "cancel by the owner releases the added blobbers",
+			run: func(env *shardMigrationTestEnv) {
+				require.ErrorContains(t, env.call(env.added[0], 0, env.ssc.cancelShardMigration),
+					"only owner")
+				require.NoError(t, env.call(env.owner.id, 0, env.ssc.cancelShardMigration))
+				env.requireReleased(env.added...)
+
+				env.tp += 100
+				uar := &updateAllocationRequest{ID: env.allocID, Extend: true}
+				_, err := uar.callUpdateAllocReq(t, env.owner.id, 0, env.tp, env.ssc, env.balances)
+				require.NoError(t, err, "the allocation is no longer migrating")
+			},
+		},
+		{
+			name: "cancel releases an added blobber replaced during the migration",
+			run: func(env *shardMigrationTestEnv) {
+				env.tp += 100
+				uar := &updateAllocationRequest{
+					ID:              env.allocID,
+					AddBlobberId:    env.spares[0],
+					RemoveBlobberId: env.added[0],
+				}
+				_, err := uar.callUpdateAllocReq(t, env.owner.id, 0, env.tp, env.ssc, env.balances)
+				require.NoError(t, err)
+				require.True(t, env.migration().isAdded(env.spares[0]))
+
+				require.NoError(t, env.call(env.owner.id, 0, env.ssc.cancelShardMigration))
+				env.requireReleased(env.added[0], env.added[1], env.spares[0])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(newShardMigrationTestEnv(t))
+		})
+	}
+}
@@ -17,7 +17,7 @@ import (
 // shutdownBlobber
 // shuts down the blobber: It is no longer available for new allocations
 // but its existing commitments will still be upheld.
-func (_ *StorageSmartContract) shutdownBlobber(
+func (sc *StorageSmartContract) shutdownBlobber(
 	tx *transaction.Transaction,
 	input []byte,
 	balances cstate.StateContextI,
@@ -80,6 +80,15 @@ func (_ *StorageSmartContract) shutdownBlobber(
 		return "", common.NewError("shutdown_blobber_failed", err.Error())
 	}
 
+	_, err = balances.InsertTrieNode(blobber.GetKey(), blobber)
+	if err != nil {
+		return "", common.NewError("shutdown_blobber_failed", "saving blobber: "+err.Error())
+	}
+
+	if err := sc.repairBlobberAllocations(tx, blobber.Id(), balances); err != nil {
+		return "", common.NewError("shutdown_blobber_failed", err.Error())
+	}
+
 	if blobber.mustBase().SavedData <= 0 && len(sp.GetPools()) == 0 {
 		_, err = balances.DeleteTrieNode(blobber.GetKey())
 		if err != nil {
@@ -93,10 +102,6 @@ func (_ *StorageSmartContract) shutdownBlobber(
 		return "", nil
 	}
 
-	_, err = balances.InsertTrieNode(blobber.GetKey(), blobber)
-	if err != nil {
-		return "", common.NewError("shutdown_blobber_failed", "saving blobber: "+err.Error())
-	}
 	return "", nil
 }
 
@@ -23,6 +23,17 @@ import (
 //msgp:ignore unlockResponse stakePoolStat stakePoolRequest delegatePoolStat rewardsStat
 //go:generate msgp -io=false -tests=false -unexported=true -v
 
+func init() {
+	// the rewards of blobbers and validators compound up to the max stake
+	stakepool.RegisterMaxStake(func(balances cstate.CommonStateContextI) (currency.Coin, error) {
+		conf, err := getConfig(balances)
+		if err != nil {
+			return 0, err
+		}
+		return conf.MaxStake, nil
+	}, spenum.Blobber, spenum.Validator)
+}
+
 func validateStakePoolSettings(
 	sps stakepool.Settings,
 	conf *Config,
@@ -134,7 +145,47 @@ func (sp *stakePool) Empty(
 		return errors.New("trying to unlock not by delegate pool owner")
 	}
 
-	requiredBalance, err := currency.AddCoin(sp.TotalOffers, dp.Balance)
+	if err := sp.coversOffersWithout(dp.Balance); err != nil {
+		return err
+	}
+
+	transfer := state.NewTransfer(sscID, clientID, dp.Balance)
+	if err := balances.AddTransfer(transfer); err != nil {
+		return err
+	}
+
+	sp.Pools[poolID].Balance = 0
+	sp.Pools[poolID].Status = spenum.Deleted
+
+	return nil
+}
+
+// MoveOut a delegate pool for a redelegation if the rest of the stake covers the offers
+func (sp *stakePool) MoveOut(clientID string, balances chainstate.StateContextI) (*stakepool.DelegatePool, error) {
+	var dp, ok = sp.Pools[clientID]
+	if !ok {
+		return nil, fmt.Errorf("no such delegate pool: %q", clientID)
+	}
+
+	if err := sp.coversOffersWithout(dp.Balance); err != nil {
+		return nil, err
+	}
+
+	return sp.StakePool.MoveOut(clientID, balances)
+}
+
+// Unbond a part of a delegate pool if the rest of the stake covers the offers
+func (sp *stakePool) Unbond(clientID string, amount currency.Coin, maturityRound int64,
+	balances chainstate.StateContextI) error {
+	if err := sp.coversOffersWithout(amount); err != nil {
+		return err
+	}
+
+	return sp.StakePool.Unbond(clientID, amount, maturityRound, balances)
+}
+
+func (sp *stakePool) coversOffersWithout(unlock currency.Coin) error {
+	requiredBalance, err := currency.AddCoin(sp.TotalOffers, unlock)
 	if err != nil {
 		return err
 	}
@@ -146,17 +197,9 @@ func (sp *stakePool) Empty(
 
 	if staked < requiredBalance {
 		return fmt.Errorf("insufficent stake to cover offers: existing stake %d, unlock balance %d, offers %d",
-			staked, dp.Balance, sp.TotalOffers)
-	}
-
-	transfer := state.NewTransfer(sscID, clientID, dp.Balance)
-	if err := balances.AddTransfer(transfer); err != nil {
-		return err
+			staked, unlock, sp.TotalOffers)
 	}
 
-	sp.Pools[poolID].Balance = 0
-	sp.Pools[poolID].Status = spenum.Deleted
-
 	return nil
 }
 
@@ -183,20 +226,31 @@ func (sp *stakePool) reduceOffer(amount currency.Coin) error {
 }
 
 // slash represents blobber penalty; it returns number of tokens moved in
-// reality, in regard to division errors
+// reality, in regard to division errors, and the tokens moved per delegate.
+// The failed challenge is recorded as the evidence of the slash.
 func (sp *stakePool) slash(
 	blobID string,
 	offer, slash currency.Coin,
 	balances chainstate.StateContextI,
-	allocationID string,
-) (move currency.Coin, err error) {
+	allocationID, challengeID string,
+) (move currency.Coin, penalties map[string]currency.Coin, err error) {
 	if offer == 0 || slash == 0 {
 		return // nothing to move
 	}
 
 	staked, err := sp.stake()
 	if err != nil {
-		return 0, err
+		return 0, nil, err
+	}
+
+	// the unbonding tokens are slashed with the stake until they mature
+	round := balances.GetBlock().Round
+	unbonding, err := sp.UnbondingStake(round)
+	if err != nil {
+		return 0, nil, err
+	}
+	if staked, err = currency.AddCoin(staked, unbonding); err != nil {
+		return 0, nil, err
 	}
 
 	// offer ratio of entire stake; we are slashing only part of the offer
@@ -208,11 +262,7 @@ func (sp *stakePool) slash(
 	for _, dp := range sp.GetOrderedPools() {
 		dpSlash, err := currency.MultFloat64(dp.Balance, ratio)
 		if err != nil {
-			return 0, err
-		}
-
-		if dpSlash == 0 {
-			continue
+			return 0, nil, err
 		}
 
 		if dpSlash > dp.Balance {
@@ -220,22 +270,36 @@ func (sp *stakePool) slash(
 		}
 
 		if balance, err := currency.MinusCoin(dp.Balance, dpSlash); err != nil {
-			return 0, err
+			return 0, nil, err
 		} else {
 			dp.Balance = balance
 		}
+
+		unbondingSlash, err := dp.SlashUnbonding(ratio, round)
+		if err != nil {
+			return 0, nil, err
+		}
+		if dpSlash, err = currency.AddCoin(dpSlash, unbondingSlash); err != nil {
+			return 0, nil, err
+		}
+
+		if dpSlash == 0 {
+			continue
+		}
 		move, err = currency.AddCoin(move, dpSlash)
 		if err != nil {
-			return 0, err
+			return 0, nil, err
 		}
 		edbSlash.DelegatePenalties[dp.DelegateID] = dpSlash
 	}
 	//Added New Tag for StakePoolPenalty
 	if err := edbSlash.Emit(event.TagStakePoolPenalty, balances); err != nil {
-		return 0, err
+		return 0, nil, err
 	}
+	stakepool.EmitSlashRecords(blobID, spenum.Blobber, spenum.SlashChallengeFailed, challengeID,
+		ratio, edbSlash.DelegatePenalties, balances)
 
-	return
+	return move, edbSlash.DelegatePenalties, nil
 }
 
 func unallocatedCapacity(writePrice, total, offers currency.Coin) (free int64, err error) {
@@ -423,3 +487,50 @@ func (ssc *StorageSmartContract) stakePoolUnlock(
 ) (string, error) {
 	return stakepool.StakePoolUnlock(t, input, balances, ssc.getStakePoolAdapter, ssc.refreshProvider)
 }
+
+// stakePoolRedelegate moves a delegate pool between two providers at once
+func (ssc *StorageSmartContract) stakePoolRedelegate(
+	t *transaction.Transaction,
+	input []byte,
+	balances chainstate.StateContextI,
+) (string, error) {
+	gn, err := getConfig(balances)
+	if err != nil {
+		return "", err
+	}
+	return stakepool.StakePoolRedelegate(t, input, balances,
+		stakepool.ValidationSettings{MaxStake: gn.MaxStake, MinStake: gn.MinStake, MaxNumDelegates: gn.MaxDelegates},
+		gn.StakePool.MinRedelegatePeriod, ssc.getStakePoolAdapter, ssc.refreshProvider)
+}
+
+// stakePoolAutoCompound turns the auto-compounding of the rewards of a delegate pool on or off
+func (ssc *StorageSmartContract) stakePoolAutoCompound(
+	t *transaction.Transaction,
+	input []byte,
+	balances chainstate.StateContextI,
+) (string, error) {
+	return stakepool.StakePoolAutoCompound(t, input, balances, ssc.getStakePoolAdapter)
+}
+
+// stakePoolUnbond unstakes a part of a delegate pool into an unbonding entry
+func (ssc *StorageSmartContract) stakePoolUnbond(
+	t *transaction.Transaction,
+	input []byte,
+	balances chainstate.StateContextI,
+) (string, error) {
+	gn, err := getConfig(balances)
+	if err != nil {
+		return "", err
+	}
+	return stakepool.StakePoolUnbond(t, input, balances, gn.StakePool.UnbondingPeriod,
+		ssc.getStakePoolAdapter, ssc.refreshProvider)
+}
+
+// stakePoolClaimUnbonded pays the matured unbonding entries of a delegate pool out
+func (ssc *StorageSmartContract) stakePoolClaimUnbonded(
+	t *transaction.Transaction,
+	input []byte,
+	balances chainstate.StateContextI,
+) (string, error) {
+	return stakepool.StakePoolClaimUnbonded(t, input, balances, ssc.getStakePoolAdapter)
+}
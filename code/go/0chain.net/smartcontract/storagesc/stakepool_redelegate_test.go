@@ -0,0 +1,85 @@
+package storagesc
+
+import (
+	"testing"
+	"time"
+
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStakePoolRedelegate(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		owner    = newClient(1000*x10, balances)
+		delegate = newClient(100*x10, balances)
+		tp       = int64(100)
+	)
+
+	_, blobs := addAllocation(t, ssc, owner, tp, 0, 0, 0, 0, 0, balances, false, false, false)
+	from, to := blobs[0].id, blobs[1].id
+	conf := setConfig(t, balances)
+	conf.StakePool.MinRedelegatePeriod = time.Hour
+	_, err := balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
+	require.NoError(t, err)
+
+	tp += 100
+	tx := newTransaction(delegate.id, ssc.ID, 10*x10, tp)
+	balances.setTransaction(t, tx)
+	_, err = ssc.stakePoolLock(tx, mustEncode(t, &stakePoolRequest{
+		ProviderType: spenum.Blobber,
+		ProviderID:   from,
+	}), balances)
+	require.NoError(t, err)
+
+	redelegate := func(fromID, toID string) error {
+		tp += 100
+		tx := newTransaction(delegate.id, ssc.ID, 0, tp)
+		balances.setTransaction(t, tx)
+		_, err := ssc.stakePoolRedelegate(tx, mustEncode(t, &stakepool.StakePoolRedelegateRequest{
+			FromProviderType: spenum.Blobber,
+			FromProviderID:   fromID,
+			ToProviderType:   spenum.Blobber,
+			ToProviderID:     toID,
+		}), balances)
+		return err
+	}
+	delegatePool := func(blobberID string) (*stakepool.DelegatePool, bool) {
+		sp, err := ssc.getStakePool(spenum.Blobber, blobberID, balances)
+		require.NoError(t, err)
+		dp, ok := sp.Pools[delegate.id]
+		return dp, ok
+	}
+	totalStaked := func(blobberID string) currency.Coin {
+		sp, err := ssc.getStakePool(spenum.Blobber, blobberID, balances)
+		require.NoError(t, err)
+		staked, err := sp.stake()
+		require.NoError(t, err)
+		return staked
+	}
+
+	require.ErrorContains(t, redelegate(from, from), "same provider")
+	require.ErrorContains(t, redelegate(to, from), "no such delegate pool")
+
+	balance := balances.balances[delegate.id]
+	fromStaked, toStaked := totalStaked(from), totalStaked(to)
+	require.NoError(t, redelegate(from, to))
+	_, ok := delegatePool(from)
+	require.False(t, ok, "the delegate pool is moved out")
+	dp, ok := delegatePool(to)
+	require.True(t, ok)
+	require.EqualValues(t, 10*x10, dp.Balance)
+	require.Equal(t, balance, balances.balances[delegate.id], "the tokens never leave the smart contract")
+	require.Equal(t, fromStaked-10*x10, totalStaked(from))
+	require.Equal(t, toStaked+10*x10, totalStaked(to))
+
+	require.ErrorContains(t, redelegate(to, from), "next redelegation allowed")
+	tp += int64(toSeconds(time.Hour))
+	require.NoError(t, redelegate(to, from))
+	dp, ok = delegatePool(from)
+	require.True(t, ok)
+	require.EqualValues(t, 10*x10, dp.Balance)
+}
@@ -0,0 +1,113 @@
+package storagesc
+
+import (
+	"testing"
+
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStakePoolUnbond(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		owner    = newClient(1000*x10, balances)
+		delegate = newClient(100*x10, balances)
+		tp       = int64(100)
+	)
+
+	_, blobs := addAllocation(t, ssc, owner, tp, 0, 0, 0, 0, 0, balances, false, false, false)
+	blobberID := blobs[0].id
+	conf := setConfig(t, balances)
+	conf.StakePool.UnbondingPeriod = 10
+	_, err := balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
+	require.NoError(t, err)
+
+	tp += 100
+	tx := newTransaction(delegate.id, ssc.ID, 10*x10, tp)
+	balances.setTransaction(t, tx)
+	_, err = ssc.stakePoolLock(tx, mustEncode(t, &stakePoolRequest{
+		ProviderType: spenum.Blobber,
+		ProviderID:   blobberID,
+	}), balances)
+	require.NoError(t, err)
+
+	unbond := func(amount currency.Coin) error {
+		tp += 100
+		tx := newTransaction(delegate.id, ssc.ID, 0, tp)
+		balances.setTransaction(t, tx)
+		_, err := ssc.stakePoolUnbond(tx, mustEncode(t, &stakepool.StakePoolUnbondRequest{
+			ProviderType: spenum.Blobber,
+			ProviderID:   blobberID,
+			Amount:       amount,
+		}), balances)
+		return err
+	}
+	claim := func() error {
+		tp += 100
+		tx := newTransaction(delegate.id, ssc.ID, 0, tp)
+		balances.setTransaction(t, tx)
+		_, err := ssc.stakePoolClaimUnbonded(tx, mustEncode(t, &stakePoolRequest{
+			ProviderType: spenum.Blobber,
+			ProviderID:   blobberID,
+		}), balances)
+		return err
+	}
+	delegatePool := func() (*stakepool.DelegatePool, bool) {
+		sp, err := ssc.getStakePool(spenum.Blobber, blobberID, balances)
+		require.NoError(t, err)
+		dp, ok := sp.Pools[delegate.id]
+		return dp, ok
+	}
+
+	balances.block.Round = 100
+	require.ErrorContains(t, unbond(0), "can't unbond")
+	require.ErrorContains(t, unbond(11*x10), "can't unbond")
+	require.NoError(t, unbond(4*x10))
+	dp, ok := delegatePool()
+	require.True(t, ok)
+	require.EqualValues(t, 6*x10, dp.Balance)
+	require.Len(t, dp.Unbonding, 1)
+	require.EqualValues(t, 4*x10, dp.Unbonding[0].Amount)
+	require.EqualValues(t, 110, dp.Unbonding[0].MaturityRound)
+
+	// the unbonding tokens are slashed until they mature
+	sp, err := ssc.getStakePool(spenum.Blobber, blobberID, balances)
+	require.NoError(t, err)
+	staked, err := sp.stake()
+	require.NoError(t, err)
+	unbonding, err := sp.UnbondingStake(balances.block.Round)
+	require.NoError(t, err)
+	require.EqualValues(t, 4*x10, unbonding)
+	_, penalties, err := sp.slash(blobberID, 1, (staked+unbonding)/10, balances, "", "")
+	require.NoError(t, err)
+	require.EqualValues(t, 1*x10, penalties[delegate.id])
+	require.EqualValues(t, 36*x10/10, sp.Pools[delegate.id].Unbonding[0].Amount)
+	require.NoError(t, sp.Save(spenum.Blobber, blobberID, balances))
+
+	require.ErrorContains(t, claim(), "no matured unbonding entries")
+	tp += 100
+	tx = newTransaction(delegate.id, ssc.ID, 0, tp)
+	balances.setTransaction(t, tx)
+	_, err = ssc.stakePoolUnlock(tx, mustEncode(t, &stakePoolRequest{
+		ProviderType: spenum.Blobber,
+		ProviderID:   blobberID,
+	}), balances)
+	require.ErrorContains(t, err, "claim them first")
+
+	balances.block.Round = 110
+	balance := balances.balances[delegate.id]
+	require.NoError(t, claim())
+	require.Equal(t, balance+36*x10/10, balances.balances[delegate.id])
+	dp, ok = delegatePool()
+	require.True(t, ok)
+	require.Empty(t, dp.Unbonding)
+
+	require.NoError(t, unbond(dp.Balance))
+	balances.block.Round = 120
+	require.NoError(t, claim())
+	_, ok = delegatePool()
+	require.False(t, ok, "the delegate pool is deleted once claimed out")
+}